@@ -19,14 +19,13 @@ func main() {
 	} else {
 		// Set up the telemetry client and add it to the context
 		httpClient := &http.Client{
-			Timeout: time.Second * 3,
+			Timeout:   time.Second * 3,
+			Transport: stripe.HTTPTransport(),
 		}
 		telemetryClient := &stripe.AnalyticsTelemetryClient{HTTPClient: httpClient}
 		contextWithTelemetry := stripe.WithTelemetryClient(ctx, telemetryClient)
 
+		// Execute waits for all telemetry calls to finish before exiting the process
 		cmd.Execute(contextWithTelemetry)
-
-		// Wait for all telemetry calls to finish before existing the process
-		telemetryClient.Wait()
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -36,6 +37,14 @@ var DisableColors = false
 // `CLICOLOR_FORCE`. Cf. https://bixense.com/clicolors/
 var EnvironmentOverrideColors = true
 
+// ASCIIOnly replaces the Unicode glyphs and spinners of the output with
+// ASCII ones, for terminals and fonts without Unicode support.
+var ASCIIOnly = false
+
+// Quiet suppresses the human-oriented output: spinners and their messages,
+// banners and hints.
+var Quiet = false
+
 //
 // Public functions
 //
@@ -46,6 +55,16 @@ func Bold(text string) string {
 	return color.Sprintf(color.Bold(text))
 }
 
+// ClearScreen clears the terminal and moves the cursor to the top left
+// corner. It does nothing if the writer is not a terminal.
+func ClearScreen(w io.Writer) {
+	if !isTerminal(w) {
+		return
+	}
+
+	fmt.Fprint(w, "\033[H\033[2J")
+}
+
 // Color returns an aurora.Aurora instance with colors enabled or disabled
 // depending on whether the writer supports colors.
 func Color(w io.Writer) aurora.Aurora {
@@ -110,7 +129,7 @@ type charset = []string
 func getCharset() charset {
 	// See https://github.com/briandowns/spinner#available-character-sets for
 	// list of available charsets
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || ASCIIOnly {
 		// Less fancy, but uses ASCII characters so works with Windows default
 		// console.
 		return spinner.CharSets[8]
@@ -123,6 +142,10 @@ const duration = time.Duration(100) * time.Millisecond
 // StartNewSpinner starts a new spinner with the given message. If the writer is not
 // a terminal or doesn't support colors, it simply prints the message.
 func StartNewSpinner(msg string, w io.Writer) *spinner.Spinner {
+	if Quiet {
+		return nil
+	}
+
 	if !isTerminal(w) || !shouldUseColors(w) {
 		fmt.Fprintln(w, msg)
 		return nil
@@ -142,6 +165,10 @@ func StartNewSpinner(msg string, w io.Writer) *spinner.Spinner {
 
 // StartSpinner updates an existing spinner's message, and starts it if it was stopped
 func StartSpinner(s *spinner.Spinner, msg string, w io.Writer) {
+	if Quiet {
+		return
+	}
+
 	if s == nil {
 		fmt.Fprintln(w, msg)
 		return
@@ -157,6 +184,10 @@ func StartSpinner(s *spinner.Spinner, msg string, w io.Writer) {
 // StopSpinner stops a spinner with the given message. If the writer is not
 // a terminal or doesn't support colors, it simply prints the message.
 func StopSpinner(s *spinner.Spinner, msg string, w io.Writer) {
+	if Quiet {
+		return
+	}
+
 	if !isTerminal(w) || !shouldUseColors(w) {
 		fmt.Fprintln(w, msg)
 		return
@@ -175,6 +206,48 @@ func StrikeThrough(text string) string {
 	return color.Sprintf(color.StrikeThrough(text))
 }
 
+// SetTerminalStandIn makes the output written to standIn behave like the
+// output written to terminal, e.g. for the colors, spinners and pager, when
+// standIn is a pipe the output of terminal goes through. Paged text is shown
+// on terminal directly and written to record too. It returns the function
+// undoing it.
+func SetTerminalStandIn(standIn, terminal *os.File, record io.Writer) func() {
+	standInsMu.Lock()
+	defer standInsMu.Unlock()
+
+	standIns[standIn] = terminalStandIn{terminal: terminal, record: record}
+
+	return func() {
+		standInsMu.Lock()
+		defer standInsMu.Unlock()
+
+		delete(standIns, standIn)
+	}
+}
+
+// IsTerminal returns whether the writer is a terminal, or stands in for one
+func IsTerminal(w io.Writer) bool {
+	return isTerminal(w)
+}
+
+//
+// Private types
+//
+
+type terminalStandIn struct {
+	terminal *os.File
+	record   io.Writer
+}
+
+//
+// Private variables
+//
+
+var (
+	standInsMu sync.Mutex
+	standIns   = make(map[*os.File]terminalStandIn)
+)
+
 //
 // Private functions
 //
@@ -182,12 +255,24 @@ func StrikeThrough(text string) string {
 func isTerminal(w io.Writer) bool {
 	switch v := w.(type) {
 	case *os.File:
-		return term.IsTerminal(int(v.Fd()))
+		return term.IsTerminal(int(terminalOf(v).terminal.Fd()))
 	default:
 		return false
 	}
 }
 
+// terminalOf returns the terminal f stands in for, or f itself
+func terminalOf(f *os.File) terminalStandIn {
+	standInsMu.Lock()
+	defer standInsMu.Unlock()
+
+	if standIn, ok := standIns[f]; ok {
+		return standIn
+	}
+
+	return terminalStandIn{terminal: f}
+}
+
 func shouldUseColors(w io.Writer) bool {
 	useColors := ForceColors || isTerminal(w)
 
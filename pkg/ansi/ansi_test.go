@@ -0,0 +1,27 @@
+package ansi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTerminalStandIn(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	var record bytes.Buffer
+
+	restore := SetTerminalStandIn(w, os.Stdout, &record)
+	require.Equal(t, os.Stdout, terminalOf(w).terminal)
+	require.Equal(t, &record, terminalOf(w).record)
+	require.Equal(t, isTerminal(os.Stdout), IsTerminal(w))
+
+	restore()
+	require.Equal(t, w, terminalOf(w).terminal)
+	require.False(t, IsTerminal(w))
+}
@@ -0,0 +1,65 @@
+package ansi
+
+import (
+	"os"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// glyph is a status marker, with the ASCII version used when ASCIIOnly is set
+type glyph struct {
+	unicode string
+	ascii   string
+}
+
+var (
+	successGlyph = glyph{"✔", "+"}
+	warningGlyph = glyph{"⚠", "!"}
+	errorGlyph   = glyph{"✘", "x"}
+	infoGlyph    = glyph{"•", "*"}
+)
+
+// ErrorGlyph returns the red marker of an error. Its shape tells it apart
+// from the other markers without relying on colors.
+func ErrorGlyph() string {
+	return errorGlyph.render(aurora.Aurora.Red)
+}
+
+// InfoGlyph returns the faint marker of an entry that is neither a success
+// nor a failure
+func InfoGlyph() string {
+	return infoGlyph.render(aurora.Aurora.Faint)
+}
+
+// StatusGlyph returns the marker of an HTTP status code: a success, a
+// warning for 3xx and 4xx codes, or an error for 5xx codes, matching the
+// colors of ColorizeStatus
+func StatusGlyph(status int) string {
+	switch {
+	case status >= 500:
+		return ErrorGlyph()
+	case status >= 300:
+		return WarningGlyph()
+	default:
+		return SuccessGlyph()
+	}
+}
+
+// SuccessGlyph returns the green marker of a success
+func SuccessGlyph() string {
+	return successGlyph.render(aurora.Aurora.Green)
+}
+
+// WarningGlyph returns the yellow marker of a warning
+func WarningGlyph() string {
+	return warningGlyph.render(aurora.Aurora.Yellow)
+}
+
+func (g glyph) render(colorize func(aurora.Aurora, interface{}) aurora.Value) string {
+	text := g.unicode
+	if ASCIIOnly {
+		text = g.ascii
+	}
+
+	return colorize(Color(os.Stdout), text).String()
+}
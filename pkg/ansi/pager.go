@@ -0,0 +1,98 @@
+package ansi
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	exec "golang.org/x/sys/execabs"
+	"golang.org/x/term"
+)
+
+// DisablePager prints long outputs directly instead of through the pager.
+var DisablePager = false
+
+// Page writes text to the writer, through the pager of the $STRIPE_PAGER or
+// $PAGER environment variables (default: less) if the writer is a terminal
+// and text doesn't fit on the screen. It falls back to writing text directly
+// if the pager can't be started.
+func Page(text string, w io.Writer) error {
+	if DisablePager || !isTerminal(w) || fitsScreen(text, w) {
+		_, err := fmt.Fprint(w, text)
+		return err
+	}
+
+	return runPager(pagerCommand(), text, w)
+}
+
+// pagerCommand returns the command of the pager set in the environment, or
+// nil if the output shouldn't be paged.
+func pagerCommand() []string {
+	pager := os.Getenv("STRIPE_PAGER")
+	if pager == "" {
+		pager = os.Getenv("PAGER")
+	}
+
+	if pager == "" {
+		pager = "less"
+	}
+
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+
+	return args
+}
+
+// runPager writes text to the writer through the pager command args, or
+// directly without args or if the pager can't be started.
+func runPager(args []string, text string, w io.Writer) error {
+	if len(args) == 0 {
+		_, err := fmt.Fprint(w, text)
+		return err
+	}
+
+	// The pager needs the terminal itself, not a pipe standing in for it
+	pagerOut, record := w, io.Writer(nil)
+	if f, ok := w.(*os.File); ok {
+		standIn := terminalOf(f)
+		pagerOut, record = standIn.terminal, standIn.record
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = pagerOut
+	cmd.Stderr = os.Stderr
+
+	// Keep the colors, and quit if the output fits on the screen after all
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	if err := cmd.Start(); err != nil {
+		_, err := fmt.Fprint(w, text)
+		return err
+	}
+
+	if record != nil {
+		fmt.Fprint(record, text) // #nosec G104
+	}
+
+	return cmd.Wait()
+}
+
+func fitsScreen(text string, w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return true
+	}
+
+	_, height, err := term.GetSize(int(terminalOf(f).terminal.Fd()))
+	if err != nil {
+		return true
+	}
+
+	return strings.Count(text, "\n") < height
+}
@@ -0,0 +1,67 @@
+package ansi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageDisabled(t *testing.T) {
+	DisablePager = true
+	defer func() { DisablePager = false }()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+
+	t.Setenv("STRIPE_PAGER", "false")
+	require.NoError(t, Page("hello\n", w))
+	w.Close()
+
+	var out bytes.Buffer
+	_, err = out.ReadFrom(r)
+	require.NoError(t, err)
+	require.Equal(t, "hello\n", out.String())
+}
+
+func TestPageNonTerminal(t *testing.T) {
+	t.Setenv("STRIPE_PAGER", "false")
+
+	var out bytes.Buffer
+	require.NoError(t, Page("hello\n", &out))
+	require.Equal(t, "hello\n", out.String())
+}
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("STRIPE_PAGER", "")
+	t.Setenv("PAGER", "")
+	require.Equal(t, []string{"less"}, pagerCommand())
+
+	t.Setenv("PAGER", "more -d")
+	require.Equal(t, []string{"more", "-d"}, pagerCommand())
+
+	t.Setenv("STRIPE_PAGER", "most")
+	require.Equal(t, []string{"most"}, pagerCommand())
+
+	t.Setenv("STRIPE_PAGER", "")
+	t.Setenv("PAGER", "cat")
+	require.Nil(t, pagerCommand())
+}
+
+func TestRunPager(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runPager(nil, "hello\n", &out))
+	require.Equal(t, "hello\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runPager([]string{"sh", "-c", "tr a-z A-Z"}, "hello\n", &out))
+	require.Equal(t, "HELLO\n", out.String())
+}
+
+func TestRunPagerFallsBackWhenPagerCantStart(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runPager([]string{"stripe-cli-missing-pager"}, "hello\n", &out))
+	require.Equal(t, "hello\n", out.String())
+}
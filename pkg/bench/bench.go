@@ -0,0 +1,329 @@
+package bench
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"time"
+)
+
+//
+// Public types
+//
+
+// DoFunc makes a single request and returns its HTTP status code. The
+// request must be made with ctx for its phases to be timed.
+type DoFunc func(ctx context.Context) (int, error)
+
+// Config configures a benchmark run
+type Config struct {
+	// Requests is the number of requests to make
+	Requests int
+
+	// Concurrency is the number of requests made at the same time
+	Concurrency int
+
+	// Do makes a single request
+	Do DoFunc
+}
+
+// Distribution summarizes the durations of a request phase
+type Distribution struct {
+	Count int
+	Min   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// Report summarizes a benchmark run. The connection phases are only measured
+// for the requests that opened a new connection, the others reused one.
+type Report struct {
+	Duration time.Duration
+	Requests int
+	Errors   int
+
+	// Statuses counts the responses by HTTP status code
+	Statuses map[int]int
+
+	DNS       Distribution
+	Connect   Distribution
+	TLS       Distribution
+	FirstByte Distribution
+	Total     Distribution
+}
+
+//
+// Public functions
+//
+
+// Run makes the configured number of requests and returns a report of their
+// latencies and errors. It stops early if ctx is done.
+func Run(ctx context.Context, cfg *Config) (*Report, error) {
+	if cfg.Requests <= 0 {
+		return nil, fmt.Errorf("the number of requests must be positive")
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	samples := make([]sample, 0, cfg.Requests)
+	requests := make(chan struct{})
+
+	start := time.Now()
+
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range requests {
+				s := measure(ctx, cfg.Do)
+
+				mu.Lock()
+				samples = append(samples, s)
+				mu.Unlock()
+			}
+		}()
+	}
+
+loop:
+	for i := 0; i < cfg.Requests; i++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case requests <- struct{}{}:
+		}
+	}
+
+	close(requests)
+	wg.Wait()
+
+	report := &Report{
+		Duration: time.Since(start),
+		Requests: len(samples),
+		Statuses: make(map[int]int),
+	}
+
+	var dns, connect, tlsHandshake, firstByte, total []time.Duration
+
+	for _, s := range samples {
+		if s.err != nil || s.statusCode >= 400 {
+			report.Errors++
+		}
+
+		if s.statusCode != 0 {
+			report.Statuses[s.statusCode]++
+		}
+
+		if s.err != nil {
+			continue
+		}
+
+		if s.dns > 0 {
+			dns = append(dns, s.dns)
+		}
+		if s.connect > 0 {
+			connect = append(connect, s.connect)
+		}
+		if s.tls > 0 {
+			tlsHandshake = append(tlsHandshake, s.tls)
+		}
+		firstByte = append(firstByte, s.firstByte)
+		total = append(total, s.total)
+	}
+
+	report.DNS = distribution(dns)
+	report.Connect = distribution(connect)
+	report.TLS = distribution(tlsHandshake)
+	report.FirstByte = distribution(firstByte)
+	report.Total = distribution(total)
+
+	return report, nil
+}
+
+// Print writes a human readable version of the report to w.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "Made %d requests in %s: %d errors\n", r.Requests, r.Duration.Round(time.Millisecond), r.Errors)
+
+	statuses := make([]int, 0, len(r.Statuses))
+	for status := range r.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+
+	for _, status := range statuses {
+		fmt.Fprintf(w, "  [%d] %d\n", status, r.Statuses[status])
+	}
+
+	fmt.Fprintf(w, "\n%-12s %6s %10s %10s %10s %10s %10s\n", "PHASE", "COUNT", "MIN", "P50", "P95", "P99", "MAX")
+	printDistribution(w, "dns", r.DNS)
+	printDistribution(w, "connect", r.Connect)
+	printDistribution(w, "tls", r.TLS)
+	printDistribution(w, "first byte", r.FirstByte)
+	printDistribution(w, "total", r.Total)
+}
+
+// JSON returns the report as JSON, with durations in milliseconds.
+func (r *Report) JSON() ([]byte, error) {
+	type jsonDistribution struct {
+		Count int     `json:"count"`
+		Min   float64 `json:"min_ms"`
+		P50   float64 `json:"p50_ms"`
+		P95   float64 `json:"p95_ms"`
+		P99   float64 `json:"p99_ms"`
+		Max   float64 `json:"max_ms"`
+	}
+
+	toJSON := func(d Distribution) jsonDistribution {
+		return jsonDistribution{
+			Count: d.Count,
+			Min:   milliseconds(d.Min),
+			P50:   milliseconds(d.P50),
+			P95:   milliseconds(d.P95),
+			P99:   milliseconds(d.P99),
+			Max:   milliseconds(d.Max),
+		}
+	}
+
+	statuses := make(map[string]int, len(r.Statuses))
+	for status, count := range r.Statuses {
+		statuses[fmt.Sprint(status)] = count
+	}
+
+	return json.MarshalIndent(struct {
+		Duration float64                     `json:"duration_ms"`
+		Requests int                         `json:"requests"`
+		Errors   int                         `json:"errors"`
+		Statuses map[string]int              `json:"statuses"`
+		Phases   map[string]jsonDistribution `json:"phases"`
+	}{
+		Duration: milliseconds(r.Duration),
+		Requests: r.Requests,
+		Errors:   r.Errors,
+		Statuses: statuses,
+		Phases: map[string]jsonDistribution{
+			"dns":        toJSON(r.DNS),
+			"connect":    toJSON(r.Connect),
+			"tls":        toJSON(r.TLS),
+			"first_byte": toJSON(r.FirstByte),
+			"total":      toJSON(r.Total),
+		},
+	}, "", "  ")
+}
+
+//
+// Private constants
+//
+
+// displayPrecision is the precision of the printed durations. Connection
+// phases to nearby hosts often take less than a millisecond.
+const displayPrecision = 100 * time.Microsecond
+
+//
+// Private types
+//
+
+// sample is the timing of a single request
+type sample struct {
+	dns        time.Duration
+	connect    time.Duration
+	tls        time.Duration
+	firstByte  time.Duration
+	total      time.Duration
+	statusCode int
+	err        error
+}
+
+//
+// Private functions
+//
+
+// measure makes a request and times its phases with an httptrace.
+func measure(ctx context.Context, do DoFunc) sample {
+	var s sample
+	var dnsStart, connectStart, tlsStart time.Time
+
+	start := time.Now()
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:  func(httptrace.DNSDoneInfo) { s.dns = time.Since(dnsStart) },
+		ConnectStart: func(string, string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(string, string, error) {
+			s.connect = time.Since(connectStart)
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			s.tls = time.Since(tlsStart)
+		},
+		GotFirstResponseByte: func() { s.firstByte = time.Since(start) },
+	}
+
+	s.statusCode, s.err = do(httptrace.WithClientTrace(ctx, trace))
+	s.total = time.Since(start)
+
+	return s
+}
+
+func distribution(durations []time.Duration) Distribution {
+	if len(durations) == 0 {
+		return Distribution{}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	return Distribution{
+		Count: len(durations),
+		Min:   durations[0],
+		P50:   percentile(durations, 50),
+		P95:   percentile(durations, 95),
+		P99:   percentile(durations, 99),
+		Max:   durations[len(durations)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func printDistribution(w io.Writer, name string, d Distribution) {
+	if d.Count == 0 {
+		fmt.Fprintf(w, "%-12s %6d %10s %10s %10s %10s %10s\n", name, 0, "-", "-", "-", "-", "-")
+		return
+	}
+
+	fmt.Fprintf(w, "%-12s %6d %10s %10s %10s %10s %10s\n",
+		name,
+		d.Count,
+		d.Min.Round(displayPrecision),
+		d.P50.Round(displayPrecision),
+		d.P95.Round(displayPrecision),
+		d.P99.Round(displayPrecision),
+		d.Max.Round(displayPrecision),
+	)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
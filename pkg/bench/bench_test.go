@@ -0,0 +1,111 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var n int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&n, 1)%5 == 0 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &http.Transport{}}
+
+	report, err := Run(context.Background(), &Config{
+		Requests:    10,
+		Concurrency: 2,
+		Do: func(ctx context.Context) (int, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return 0, err
+			}
+			resp.Body.Close()
+
+			return resp.StatusCode, nil
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 10, report.Requests)
+	require.Equal(t, 2, report.Errors)
+	require.Equal(t, map[int]int{200: 8, 429: 2}, report.Statuses)
+	require.Equal(t, 10, report.Total.Count)
+	require.Equal(t, 10, report.FirstByte.Count)
+
+	// At most one connection per concurrent request, the others are reused
+	require.GreaterOrEqual(t, report.Connect.Count, 1)
+	require.LessOrEqual(t, report.Connect.Count, 2)
+	require.Equal(t, 0, report.TLS.Count)
+
+	var buf bytes.Buffer
+	report.Print(&buf)
+	require.Contains(t, buf.String(), "Made 10 requests")
+	require.Contains(t, buf.String(), "[429] 2")
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, float64(2), decoded["errors"])
+}
+
+func TestRunErrors(t *testing.T) {
+	report, err := Run(context.Background(), &Config{
+		Requests: 3,
+		Do: func(ctx context.Context) (int, error) {
+			return 0, errors.New("connection refused")
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 3, report.Errors)
+	require.Empty(t, report.Statuses)
+	require.Equal(t, 0, report.Total.Count)
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var n int32
+	report, err := Run(ctx, &Config{
+		Requests: 100,
+		Do: func(ctx context.Context) (int, error) {
+			if atomic.AddInt32(&n, 1) == 3 {
+				cancel()
+			}
+			time.Sleep(time.Millisecond)
+			return http.StatusOK, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Less(t, report.Requests, 100)
+
+	_, err = Run(context.Background(), &Config{})
+	require.Error(t, err)
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	require.Equal(t, time.Duration(5), percentile(durations, 50))
+	require.Equal(t, time.Duration(10), percentile(durations, 95))
+	require.Equal(t, time.Duration(1), percentile(durations, 0))
+}
@@ -0,0 +1,123 @@
+// Package clierrors categorizes the errors the CLI reports to users, so that
+// they can be printed with a hint on how to fix them. Errors keep their
+// message and wrap their cause, so errors.Is and errors.As still see it.
+package clierrors
+
+import (
+	"errors"
+)
+
+// The kinds of errors
+const (
+	KindAuth    = "auth"
+	KindConfig  = "config"
+	KindNetwork = "network"
+	KindOffline = "offline"
+)
+
+// The hints of errors created without one
+const (
+	defaultAuthHint    = "Check the API key with `stripe config --list`, or run `stripe login` to get a new one."
+	defaultConfigHint  = "Check the config file with `stripe config --edit`."
+	defaultNetworkHint = "Check your internet connection and proxy settings (HTTPS_PROXY), then try again."
+	defaultOfflineHint = "Run the command without --offline to use the network."
+)
+
+//
+// Public types
+//
+
+// AuthError is an error authenticating with Stripe, e.g. a revoked API key
+type AuthError struct {
+	Err error
+
+	// Hint tells how to fix the error, instead of the default hint
+	Hint string
+}
+
+// ConfigError is an error in the configuration of the CLI, e.g. an
+// unreadable key file
+type ConfigError struct {
+	Err  error
+	Hint string
+}
+
+// NetworkError is an error reaching Stripe, e.g. a DNS failure or a timeout
+type NetworkError struct {
+	Err  error
+	Hint string
+}
+
+// OfflineError is an operation needing the network while the CLI is
+// offline, see the offline package
+type OfflineError struct {
+	Err  error
+	Hint string
+}
+
+//
+// Public functions
+//
+
+func (e *AuthError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the cause of the error
+func (e *AuthError) Unwrap() error { return e.Err }
+
+func (e *ConfigError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the cause of the error
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+func (e *NetworkError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the cause of the error
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+func (e *OfflineError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the cause of the error
+func (e *OfflineError) Unwrap() error { return e.Err }
+
+// Kind returns the kind of the first categorized error of err's chain, or
+// an empty string when there is none.
+func Kind(err error) string {
+	kind, _ := classify(err)
+	return kind
+}
+
+// Hint returns the hint of the first categorized error of err's chain, or
+// an empty string when there is none.
+func Hint(err error) string {
+	_, hint := classify(err)
+	return hint
+}
+
+//
+// Private functions
+//
+
+func classify(err error) (string, string) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case *AuthError:
+			return KindAuth, orDefault(e.Hint, defaultAuthHint)
+		case *ConfigError:
+			return KindConfig, orDefault(e.Hint, defaultConfigHint)
+		case *NetworkError:
+			return KindNetwork, orDefault(e.Hint, defaultNetworkHint)
+		case *OfflineError:
+			return KindOffline, orDefault(e.Hint, defaultOfflineHint)
+		}
+	}
+
+	return "", ""
+}
+
+func orDefault(hint, defaultHint string) string {
+	if hint == "" {
+		return defaultHint
+	}
+
+	return hint
+}
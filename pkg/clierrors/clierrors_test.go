@@ -0,0 +1,33 @@
+package clierrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHint(t *testing.T) {
+	err := fmt.Errorf("could not list customers: %w", &NetworkError{Err: io.ErrUnexpectedEOF})
+
+	require.Equal(t, "could not list customers: unexpected EOF", err.Error())
+	require.Equal(t, KindNetwork, Kind(err))
+	require.Equal(t, defaultNetworkHint, Hint(err))
+	require.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	err = &AuthError{Err: errors.New("invalid key"), Hint: "Roll the key."}
+	require.Equal(t, KindAuth, Kind(err))
+	require.Equal(t, "Roll the key.", Hint(err))
+
+	require.Equal(t, "", Kind(errors.New("plain")))
+	require.Equal(t, "", Hint(nil))
+}
+
+func TestOfflineHint(t *testing.T) {
+	err := fmt.Errorf("could not fetch the policy: %w", &OfflineError{Err: errors.New("fetching the policy needs network access")})
+
+	require.Equal(t, KindOffline, Kind(err))
+	require.Equal(t, defaultOfflineHint, Hint(err))
+}
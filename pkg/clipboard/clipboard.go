@@ -0,0 +1,59 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+var execCommand = exec.Command
+
+var lookPath = exec.LookPath
+
+// Copy puts text on the system clipboard
+func Copy(text string) error {
+	name, args, err := copyCommand()
+	if err != nil {
+		return err
+	}
+
+	cmd := execCommand(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("could not copy to the clipboard with %s: %v %s", name, err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// copyCommand returns the command that copies its standard input to the
+// clipboard on this system
+func copyCommand() (string, []string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return "pbcopy", nil, nil
+	case "windows":
+		return "clip", nil, nil
+	}
+
+	candidates := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append([][]string{{"wl-copy"}}, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := lookPath(candidate[0]); err == nil {
+			return candidate[0], candidate[1:], nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("no clipboard tool found, install xclip, xsel or wl-copy")
+}
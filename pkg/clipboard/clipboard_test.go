@@ -0,0 +1,42 @@
+package clipboard
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyCommandLinux(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the clipboard tools only vary on Linux")
+	}
+
+	originalLookPath := lookPath
+	defer func() { lookPath = originalLookPath }()
+
+	installed := map[string]bool{"xsel": true, "wl-copy": true}
+	lookPath = func(file string) (string, error) {
+		if installed[file] {
+			return "/usr/bin/" + file, nil
+		}
+
+		return "", errors.New("not found")
+	}
+
+	t.Setenv("WAYLAND_DISPLAY", "")
+	name, args, err := copyCommand()
+	require.NoError(t, err)
+	require.Equal(t, "xsel", name)
+	require.Equal(t, []string{"--clipboard", "--input"}, args)
+
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	name, _, err = copyCommand()
+	require.NoError(t, err)
+	require.Equal(t, "wl-copy", name)
+
+	installed = map[string]bool{}
+	_, _, err = copyCommand()
+	require.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type aliasCmd struct {
+	cmd *cobra.Command
+}
+
+func newAliasCmd() *aliasCmd {
+	ac := &aliasCmd{}
+
+	ac.cmd = &cobra.Command{
+		Use:   "alias",
+		Args:  validators.NoArgs,
+		Short: "Manage shortcuts for frequent commands",
+		Long: `Manage the aliases of the [alias] section of the config file. An alias is
+replaced by its expansion when it's the first argument of a command, e.g.
+with pi = "payment_intents", stripe pi list runs stripe payment_intents list.
+
+Aliases can't shadow the commands of the CLI.`,
+		Example: `stripe alias add pi payment_intents
+  stripe alias add tailerr -- logs tail --filter-status-class 5xx
+  stripe alias list
+  stripe alias remove pi`,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name> <expansion>...",
+		Args:  validators.MinimumNArgs(2),
+		Short: "Add or replace an alias",
+		RunE:  ac.runAddCmd,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the aliases",
+		RunE:  ac.runListCmd,
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Remove an alias",
+		RunE:  ac.runRemoveCmd,
+	}
+
+	ac.cmd.AddCommand(addCmd)
+	ac.cmd.AddCommand(listCmd)
+	ac.cmd.AddCommand(removeCmd)
+
+	return ac
+}
+
+func (ac *aliasCmd) runAddCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if strings.HasPrefix(name, "-") || strings.ContainsAny(name, " .") {
+		return fmt.Errorf("invalid alias name: %s", name)
+	}
+
+	if c, _, err := cmd.Root().Find([]string{name}); err == nil && c != cmd.Root() {
+		return fmt.Errorf("%s is a stripe command and can't be an alias", name)
+	}
+
+	words := make([]string, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		if strings.ContainsAny(arg, " \t") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+
+		words = append(words, arg)
+	}
+
+	expansion := strings.Join(words, " ")
+	if err := Config.SetAlias(name, expansion); err != nil {
+		return err
+	}
+
+	fmt.Printf("stripe %s now runs stripe %s.\n", name, expansion)
+
+	return nil
+}
+
+func (ac *aliasCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	aliases := Config.GetAliases()
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ALIAS\tEXPANSION")
+
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\n", name, aliases[name])
+	}
+
+	return w.Flush()
+}
+
+func (ac *aliasCmd) runRemoveCmd(cmd *cobra.Command, args []string) error {
+	return Config.RemoveAlias(args[0])
+}
+
+// expandAliases expands the alias args starts with, if any. The commands of
+// the CLI always take precedence over the aliases.
+func expandAliases(args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+
+	if c, _, err := rootCmd.Find(args[:1]); err == nil && c != rootCmd {
+		return args
+	}
+
+	profilesFile := filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "config.toml")
+
+	aliases, err := config.ReadAliases(profilesFile)
+	if err != nil {
+		// The config is initialized later, which reports invalid files
+		return args
+	}
+
+	expanded, err := config.ExpandAlias(args, aliases)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	return expanded
+}
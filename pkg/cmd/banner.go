@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// contextBanner describes where a command runs, printed before the commands
+// changing objects when the show_context_banner config key is set
+type contextBanner struct {
+	Profile     string
+	AccountName string
+	AccountID   string
+	Livemode    bool
+	APIVersion  string
+}
+
+// printContextBanner prints the context banner of cmd on stderr, so that it
+// doesn't mix with the output of the command
+func printContextBanner(cmd *cobra.Command) {
+	if ansi.Quiet || !viper.GetBool("show_context_banner") || !isMutatingCommand(cmd) {
+		return
+	}
+
+	banner := contextBanner{
+		Profile:     Config.Profile.ProfileName,
+		AccountName: Config.Profile.GetDisplayName(),
+		APIVersion:  flagValue(cmd, "stripe-version"),
+		Livemode:    flagValue(cmd, "live") == "true",
+	}
+	banner.AccountID, _ = Config.Profile.GetAccountID()
+
+	banner.write(os.Stderr)
+}
+
+func (b contextBanner) write(w io.Writer) {
+	color := ansi.Color(w)
+
+	account := b.AccountName
+	switch {
+	case account == "":
+		account = b.AccountID
+	case b.AccountID != "":
+		account = fmt.Sprintf("%s (%s)", account, b.AccountID)
+	}
+
+	if account == "" {
+		account = "unknown"
+	}
+
+	mode := "test mode"
+	if b.Livemode {
+		mode = color.Bold(color.Red("LIVE MODE")).String()
+	}
+
+	apiVersion := b.APIVersion
+	if apiVersion == "" {
+		apiVersion = "account default"
+	}
+
+	fields := []string{
+		"Profile: " + b.Profile,
+		"Account: " + account,
+		mode,
+		"API version: " + apiVersion,
+	}
+
+	fmt.Fprintf(w, "> %s\n", strings.Join(fields, " | "))
+}
+
+// flagValue returns the value of a flag of cmd, or "" if it has no such flag
+func flagValue(cmd *cobra.Command, name string) string {
+	if flag := cmd.Flags().Lookup(name); flag != nil {
+		return flag.Value.String()
+	}
+
+	return ""
+}
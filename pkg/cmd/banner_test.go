@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextBanner(t *testing.T) {
+	var buf bytes.Buffer
+
+	contextBanner{
+		Profile:     "default",
+		AccountName: "Acme",
+		AccountID:   "acct_123",
+	}.write(&buf)
+	require.Equal(t, "> Profile: default | Account: Acme (acct_123) | test mode | API version: account default\n", buf.String())
+
+	buf.Reset()
+
+	contextBanner{
+		Profile:    "prod",
+		Livemode:   true,
+		APIVersion: "2020-08-27",
+	}.write(&buf)
+	require.Equal(t, "> Profile: prod | Account: unknown | LIVE MODE | API version: 2020-08-27\n", buf.String())
+}
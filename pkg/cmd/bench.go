@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/bench"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type benchCmd struct {
+	cmd *cobra.Command
+}
+
+type benchAPICmd struct {
+	cmd *cobra.Command
+
+	requests    int
+	concurrency int
+	path        string
+	format      string
+	apiBaseURL  string
+}
+
+func newBenchCmd() *benchCmd {
+	bc := &benchCmd{}
+
+	bc.cmd = &cobra.Command{
+		Use:   "bench",
+		Args:  validators.NoArgs,
+		Short: "Run diagnostic benchmarks from your machine",
+	}
+
+	bc.cmd.AddCommand(newBenchAPICmd().cmd)
+
+	return bc
+}
+
+func newBenchAPICmd() *benchAPICmd {
+	bac := &benchAPICmd{}
+
+	bac.cmd = &cobra.Command{
+		Use:   "api",
+		Args:  validators.NoArgs,
+		Short: "Measure the latency of test mode API requests",
+		Long: `The bench api command makes read-only test mode requests to the Stripe API
+and reports their latency distribution and error rate, broken down into DNS
+resolution, connection, TLS handshake and time to first byte.
+
+Slow connection phases point at your network or proxy, while a slow first byte
+on reused connections points at the time taken by the API itself. Requests
+go through the proxy set in the HTTPS_PROXY environment variable, if any.`,
+		Example: `stripe bench api
+  stripe bench api --requests 100 --concurrency 5 --path /v1/customers?limit=1`,
+		RunE: bac.runBenchAPICmd,
+	}
+
+	bac.cmd.Flags().IntVar(&bac.requests, "requests", 20, "Number of requests to make")
+	bac.cmd.Flags().IntVar(&bac.concurrency, "concurrency", 1, "Number of requests made at the same time")
+	bac.cmd.Flags().StringVar(&bac.path, "path", "/v1/balance", "Path of the GET request to make, with an optional query string")
+	bac.cmd.Flags().StringVar(&bac.format, "format", "", `Specifies the output format of the report
+	Acceptable values:
+		'JSON' - Output the report in JSON format`)
+	addNotifyFlag(bac.cmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	bac.cmd.Flags().StringVar(&bac.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	bac.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return bac
+}
+
+func (bac *benchAPICmd) runBenchAPICmd(cmd *cobra.Command, args []string) error {
+	if bac.format != "" && strings.ToUpper(bac.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, the only supported format is JSON", bac.format)
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(apiKey, "_live_") {
+		return fmt.Errorf("bench api can only be used with a test mode API key")
+	}
+
+	baseURL, err := url.Parse(bac.apiBaseURL)
+	if err != nil {
+		return err
+	}
+
+	path, query := splitQuery(bac.path)
+
+	client := &stripe.Client{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+	}
+
+	fmt.Fprintf(os.Stderr, "Making %d requests to GET %s%s...\n", bac.requests, bac.apiBaseURL, bac.path)
+
+	report, err := bench.Run(cmd.Context(), &bench.Config{
+		Requests:    bac.requests,
+		Concurrency: bac.concurrency,
+		Do: func(ctx context.Context) (int, error) {
+			resp, err := client.PerformRequest(ctx, http.MethodGet, path, query, nil)
+			if err != nil {
+				return 0, err
+			}
+			resp.Body.Close()
+
+			return resp.StatusCode, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(bac.format) == outputFormatJSON {
+		data, err := report.JSON()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
@@ -3,12 +3,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 
 	"runtime"
 
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
@@ -93,14 +96,14 @@ func selectShell(shell string) error {
 	switch {
 	case selected == "zsh":
 		fmt.Println("Detected `zsh`, generating zsh completion file: stripe-completion.zsh")
-		err := rootCmd.GenZshCompletionFile("stripe-completion.zsh")
+		err := writeCompletion("zsh", "stripe-completion.zsh")
 		if err == nil {
 			fmt.Printf("%s%s\n", instructionsHeader, zshCompletionInstructions)
 		}
 		return err
 	case selected == "bash":
 		fmt.Println("Detected `bash`, generating bash completion file: stripe-completion.bash")
-		err := rootCmd.GenBashCompletionFile("stripe-completion.bash")
+		err := writeCompletion("bash", "stripe-completion.bash")
 		if err == nil {
 			if runtime.GOOS == "darwin" {
 				fmt.Printf("%s%s\n", instructionsHeader, bashCompletionInstructionsMac)
@@ -114,6 +117,50 @@ func selectShell(shell string) error {
 	}
 }
 
+// installCompletion writes the completion script of shell to ~/.stripe and
+// returns the line to add to the shell profile to load it
+func installCompletion(shell string) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(home, ".stripe")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case "zsh":
+		if err := writeCompletion("zsh", filepath.Join(dir, "_stripe")); err != nil {
+			return "", err
+		}
+
+		return "fpath=(~/.stripe $fpath); autoload -Uz compinit && compinit -i", nil
+	case "bash":
+		if err := writeCompletion("bash", filepath.Join(dir, "stripe-completion.bash")); err != nil {
+			return "", err
+		}
+
+		return "source ~/.stripe/stripe-completion.bash", nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s. Supported shells are bash and zsh", shell)
+	}
+}
+
+// writeCompletion writes the completion script of shell to path. The
+// operation commands of the resources are built first, since they're
+// otherwise only built when their resource runs.
+func writeCompletion(shell, path string) error {
+	resource.BuildAllDeferredCmds(rootCmd)
+
+	if shell == "zsh" {
+		return rootCmd.GenZshCompletionFile(path)
+	}
+
+	return rootCmd.GenBashCompletionFile(path)
+}
+
 func detectShell() string {
 	shell := os.Getenv("SHELL")
 
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCompletionIncludesOperations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stripe-completion.bash")
+
+	require.NoError(t, writeCompletion("bash", path))
+
+	script, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(script), "_stripe_customers_create()")
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"os"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/heartbeat"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/rpcservice"
+	"github.com/stripe/stripe-cli/pkg/schedule"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
@@ -14,6 +20,9 @@ type daemonCmd struct {
 	cmd  *cobra.Command
 	port int
 	cfg  *config.Config
+
+	heartbeatFile     string
+	heartbeatInterval time.Duration
 }
 
 func newDaemonCmd(cfg *config.Config) *daemonCmd {
@@ -33,25 +42,47 @@ Currently, stripe daemon only supports a subset of CLI commands. Documentation i
 		Hidden: true,
 	}
 	dc.cmd.Flags().IntVar(&dc.port, "port", 0, "The TCP port the daemon will listen to (default: an available port)")
+	dc.cmd.Flags().StringVar(&dc.heartbeatFile, "heartbeat-file", "", "Periodically write the session health as JSON to this file, e.g. for liveness probes")
+	dc.cmd.Flags().DurationVar(&dc.heartbeatInterval, "heartbeat-interval", heartbeat.DefaultInterval, "Time between two heartbeats written to --heartbeat-file")
+
+	dc.cmd.AddCommand(newInstallServiceCmd(cfg).cmd)
+	dc.cmd.AddCommand(newUninstallServiceCmd().cmd)
 
 	return dc
 }
 
 func (dc *daemonCmd) runDaemonCmd(cmd *cobra.Command, args []string) {
 	telemetryClient := stripe.GetTelemetryClient(cmd.Context())
-	srv := rpcservice.New(&rpcservice.Config{
-		Port:    dc.port,
-		Log:     log.StandardLogger(),
-		UserCfg: dc.cfg,
-	}, telemetryClient)
+	monitor := heartbeat.Start(cmd.Context(), &heartbeat.Config{
+		Path:     dc.heartbeatFile,
+		Interval: dc.heartbeatInterval,
+		Log:      log.StandardLogger(),
+	})
+	monitor.MarkReady()
 
-	ctx := withSIGTERMCancel(cmd.Context(), func() {
-		log.WithFields(log.Fields{
-			"prefix": "cmd.daemonCmd.runDaemonCmd",
-		}).Debug("Ctrl+C received, cleaning up...")
+	// Requests read the config on each call, reloading it is enough to apply
+	// changes like a new API key
+	dc.cfg.WatchConfig(func() {
+		logging.WithPrefix(log.StandardLogger(), "cmd.daemonCmd.runDaemonCmd").Info("Config file changed, new requests will use the updated config")
 	})
 
-	go srv.Run(ctx)
+	// Scheduled tasks run while the daemon is running
+	if executable, err := os.Executable(); err == nil {
+		runner := &schedule.Runner{
+			Store:      scheduleStore(),
+			Executable: executable,
+			ConfigFile: dc.cfg.ProfilesFile,
+			Log:        log.StandardLogger(),
+		}
+		go runner.Run(cmd.Context())
+	}
+
+	srv := rpcservice.New(&rpcservice.Config{
+		Port:      dc.port,
+		Log:       log.StandardLogger(),
+		UserCfg:   dc.cfg,
+		Heartbeat: monitor,
+	}, telemetryClient)
 
-	<-ctx.Done()
+	srv.Run(cmd.Context())
 }
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+const serviceName = "stripe-cli"
+
+const launchdLabel = "com.stripe.cli"
+
+var systemdUnitTemplate = template.Must(template.New("unit").Parse(`[Unit]
+Description=Stripe CLI ({{ .Description }})
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+ExecStart={{ .Command }}
+Restart=always
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`))
+
+var launchdPlistTemplate = template.Must(template.New("plist").Funcs(template.FuncMap{
+	"xml": template.HTMLEscapeString,
+}).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{ .Label | xml }}</string>
+	<key>ProgramArguments</key>
+	<array>
+{{- range .Args }}
+		<string>{{ . | xml }}</string>
+{{- end }}
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>{{ .LogFile | xml }}</string>
+	<key>StandardErrorPath</key>
+	<string>{{ .LogFile | xml }}</string>
+</dict>
+</plist>
+`))
+
+type serviceCmd struct {
+	cmd *cobra.Command
+	cfg *config.Config
+
+	port       int
+	forwardURL string
+	events     []string
+	printOnly  bool
+}
+
+// serviceDefinition describes the command run by the service
+type serviceDefinition struct {
+	Description string
+	Command     string
+	Args        []string
+	Label       string
+	LogFile     string
+}
+
+func newInstallServiceCmd(cfg *config.Config) *serviceCmd {
+	sc := &serviceCmd{
+		cfg: cfg,
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:   "install-service",
+		Args:  validators.NoArgs,
+		Short: "Run the daemon as a service that starts at boot or login",
+		Long: `Install and start a systemd user unit (Linux) or a launchd agent (macOS) running
+the Stripe CLI in the background, so it survives reboots.
+
+On Linux, user units only run while you're logged in, so lingering is
+enabled for your user with ` + "`loginctl enable-linger`" + `: the service then starts at
+boot and keeps running after you log out. If that fails, e.g. because it
+requires administrator rights, the service starts when you log in until
+you run ` + "`sudo loginctl enable-linger $USER`" + `. On macOS, the agent starts when
+you log in.
+
+By default the service runs the daemon. Pass --forward-to to run ` + "`stripe listen`" + `
+instead and keep forwarding events to your local endpoint.`,
+		Example: `stripe daemon install-service --project-name my-project
+  stripe daemon install-service --forward-to localhost:3000/webhooks --events charge.succeeded`,
+		RunE: sc.runInstallServiceCmd,
+	}
+	sc.cmd.Flags().IntVar(&sc.port, "port", 0, "The TCP port the daemon will listen to (default: an available port)")
+	sc.cmd.Flags().StringVar(&sc.forwardURL, "forward-to", "", "Run `stripe listen` forwarding events to this URL instead of the daemon")
+	sc.cmd.Flags().StringSliceVarP(&sc.events, "events", "e", []string{}, "A comma-separated list of events to listen for when using --forward-to (default: all events)")
+	sc.cmd.Flags().BoolVar(&sc.printOnly, "print", false, "Print the service definition without installing it")
+
+	return sc
+}
+
+func newUninstallServiceCmd() *serviceCmd {
+	sc := &serviceCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "uninstall-service",
+		Args:  validators.NoArgs,
+		Short: "Stop and remove the service installed with install-service",
+		RunE:  sc.runUninstallServiceCmd,
+	}
+
+	return sc
+}
+
+func (sc *serviceCmd) runInstallServiceCmd(cmd *cobra.Command, args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	path, err := servicePath()
+	if err != nil {
+		return err
+	}
+
+	def := sc.definition(executable)
+
+	content, err := renderService(runtime.GOOS, def)
+	if err != nil {
+		return err
+	}
+
+	if sc.printOnly {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if err := afero.WriteFile(fs, path, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+
+	for _, command := range enableServiceCommands(runtime.GOOS, path) {
+		if err := runServiceCommand(command); err != nil {
+			return err
+		}
+	}
+
+	if command := lingerCommand(runtime.GOOS); command != nil {
+		if err := runServiceCommand(command); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not enable lingering: %v\nThe service only starts when you log in. Run `sudo loginctl enable-linger $USER` to start it at boot.\n", err)
+		}
+	}
+
+	fmt.Printf("The %s service is running (%s).\n", serviceName, def.Description)
+
+	return nil
+}
+
+func (sc *serviceCmd) runUninstallServiceCmd(cmd *cobra.Command, args []string) error {
+	path, err := servicePath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := fs.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("no service is installed at %s", path)
+	}
+
+	for _, command := range disableServiceCommands(runtime.GOOS, path) {
+		if err := runServiceCommand(command); err != nil {
+			return err
+		}
+	}
+
+	if err := fs.Remove(path); err != nil {
+		return err
+	}
+
+	if runtime.GOOS == "linux" {
+		if err := runServiceCommand([]string{"systemctl", "--user", "daemon-reload"}); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Removed the %s service.\n", serviceName)
+
+	return nil
+}
+
+// definition builds the command run by the service. The config file and
+// project are passed explicitly since services don't inherit the shell's
+// environment.
+func (sc *serviceCmd) definition(executable string) serviceDefinition {
+	args := []string{executable}
+	description := "daemon"
+
+	if sc.forwardURL != "" {
+		description = "forwarding events to " + sc.forwardURL
+		args = append(args, "listen", "--skip-update", "--forward-to", sc.forwardURL)
+
+		if len(sc.events) > 0 {
+			args = append(args, "--events", strings.Join(sc.events, ","))
+		}
+	} else {
+		args = append(args, "daemon")
+
+		if sc.port != 0 {
+			args = append(args, "--port", strconv.Itoa(sc.port))
+		}
+	}
+
+	args = append(args, "--project-name", sc.cfg.Profile.ProfileName)
+
+	if sc.cfg.ProfilesFile != "" {
+		args = append(args, "--config", sc.cfg.ProfilesFile)
+	}
+
+	return serviceDefinition{
+		Description: description,
+		Command:     quoteSystemdArgs(args),
+		Args:        args,
+		Label:       launchdLabel,
+		LogFile:     filepath.Join(sc.cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), serviceName+".log"),
+	}
+}
+
+func renderService(goos string, def serviceDefinition) (string, error) {
+	var tmpl *template.Template
+
+	switch goos {
+	case "linux":
+		tmpl = systemdUnitTemplate
+	case "darwin":
+		tmpl = launchdPlistTemplate
+	default:
+		return "", fmt.Errorf("installing a service is not supported on %s", goos)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, def); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func servicePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(home, ".config", "systemd", "user", serviceName+".service"), nil
+	case "darwin":
+		return filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist"), nil
+	default:
+		return "", fmt.Errorf("installing a service is not supported on %s", runtime.GOOS)
+	}
+}
+
+func enableServiceCommands(goos, path string) [][]string {
+	switch goos {
+	case "linux":
+		return [][]string{
+			{"systemctl", "--user", "daemon-reload"},
+			{"systemctl", "--user", "enable", "--now", serviceName + ".service"},
+		}
+	case "darwin":
+		return [][]string{
+			{"launchctl", "load", "-w", path},
+		}
+	default:
+		return nil
+	}
+}
+
+// lingerCommand returns the command starting the services of the user at
+// boot rather than at login, if they need one. Lingering isn't disabled when
+// the service is uninstalled since other services may need it.
+func lingerCommand(goos string) []string {
+	if goos == "linux" {
+		return []string{"loginctl", "enable-linger"}
+	}
+
+	return nil
+}
+
+func disableServiceCommands(goos, path string) [][]string {
+	switch goos {
+	case "linux":
+		return [][]string{
+			{"systemctl", "--user", "disable", "--now", serviceName + ".service"},
+		}
+	case "darwin":
+		return [][]string{
+			{"launchctl", "unload", "-w", path},
+		}
+	default:
+		return nil
+	}
+}
+
+func runServiceCommand(command []string) error {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", strings.Join(command, " "), err)
+	}
+
+	return nil
+}
+
+// quoteSystemdArgs joins args into an ExecStart command line, quoting the
+// arguments that contain spaces and escaping systemd specifiers.
+func quoteSystemdArgs(args []string) string {
+	quoted := make([]string, len(args))
+
+	for i, arg := range args {
+		arg = strings.ReplaceAll(arg, "%", "%%")
+		if strings.ContainsAny(arg, " \t\"'\\") {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestServiceDefinitionDaemon(t *testing.T) {
+	sc := newInstallServiceCmd(&config.Config{
+		Profile:      config.Profile{ProfileName: "default"},
+		ProfilesFile: "/home/user/.config/stripe/config.toml",
+	})
+	sc.port = 4242
+
+	def := sc.definition("/usr/local/bin/stripe")
+
+	require.Equal(t, "daemon", def.Description)
+	require.Equal(t, []string{
+		"/usr/local/bin/stripe", "daemon", "--port", "4242",
+		"--project-name", "default",
+		"--config", "/home/user/.config/stripe/config.toml",
+	}, def.Args)
+}
+
+func TestServiceDefinitionListen(t *testing.T) {
+	sc := newInstallServiceCmd(&config.Config{
+		Profile: config.Profile{ProfileName: "my project"},
+	})
+	sc.forwardURL = "localhost:3000/hooks?a=1&b=50%"
+	sc.events = []string{"charge.succeeded", "invoice.paid"}
+
+	def := sc.definition("/usr/local/bin/stripe")
+
+	require.Equal(t, []string{
+		"/usr/local/bin/stripe", "listen", "--skip-update",
+		"--forward-to", "localhost:3000/hooks?a=1&b=50%",
+		"--events", "charge.succeeded,invoice.paid",
+		"--project-name", "my project",
+	}, def.Args)
+
+	unit, err := renderService("linux", def)
+	require.NoError(t, err)
+	require.Contains(t, unit, `ExecStart=/usr/local/bin/stripe listen --skip-update --forward-to localhost:3000/hooks?a=1&b=50%% --events charge.succeeded,invoice.paid --project-name "my project"`)
+
+	plist, err := renderService("darwin", def)
+	require.NoError(t, err)
+	require.Contains(t, plist, "<string>localhost:3000/hooks?a=1&amp;b=50%</string>")
+	require.Contains(t, plist, "<string>com.stripe.cli</string>")
+}
+
+func TestRenderServiceUnsupported(t *testing.T) {
+	_, err := renderService("windows", serviceDefinition{})
+	require.Error(t, err)
+}
+
+func TestLingerCommand(t *testing.T) {
+	require.Equal(t, []string{"loginctl", "enable-linger"}, lingerCommand("linux"))
+	require.Nil(t, lingerCommand("darwin"))
+}
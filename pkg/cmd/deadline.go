@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// exitCodeTimeout is the exit code used when a command is interrupted by the
+// `--timeout` deadline. It matches the convention used by coreutils' timeout(1)
+// so wrapping scripts can tell a timeout apart from a regular failure.
+const exitCodeTimeout = 124
+
+// commandDeadline cancels the command context once the duration passed to
+// `--timeout` has elapsed. Flags are only parsed after the context has been
+// handed to cobra, so the deadline is armed from PersistentPreRun rather than
+// with context.WithTimeout.
+type commandDeadline struct {
+	timeout time.Duration
+	cancel  context.CancelFunc
+	expired int32
+}
+
+// withDeadline returns a cancelable copy of ctx along with the deadline that
+// controls it.
+func withDeadline(ctx context.Context) (context.Context, *commandDeadline) {
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, &commandDeadline{cancel: cancel}
+}
+
+// arm starts the countdown. It is a no-op when no timeout was set.
+func (d *commandDeadline) arm(timeout time.Duration) {
+	if d == nil || timeout <= 0 {
+		return
+	}
+
+	d.timeout = timeout
+
+	time.AfterFunc(d.timeout, func() {
+		atomic.StoreInt32(&d.expired, 1)
+		d.cancel()
+	})
+}
+
+// Expired returns true if the deadline was reached before the command
+// completed.
+func (d *commandDeadline) Expired() bool {
+	return d != nil && atomic.LoadInt32(&d.expired) == 1
+}
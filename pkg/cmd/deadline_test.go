@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandDeadlineExpires(t *testing.T) {
+	ctx, d := withDeadline(context.Background())
+	d.arm(10 * time.Millisecond)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context was not canceled by the deadline")
+	}
+
+	require.True(t, d.Expired())
+}
+
+func TestCommandDeadlineDisabled(t *testing.T) {
+	ctx, d := withDeadline(context.Background())
+	d.arm(0)
+
+	require.NoError(t, ctx.Err())
+	require.False(t, d.Expired())
+}
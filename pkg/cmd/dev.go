@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+)
+
+type devCmd struct {
+	cmd *cobra.Command
+
+	forwardURL string
+	events     []string
+	skipLogs   bool
+}
+
+func newDevCmd() *devCmd {
+	dc := &devCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "dev [-- command...]",
+		Short: "Run listen, logs tail and your app together",
+		Long: `Run the local development loop as one command: listen forwards webhook
+events to your app, logs tail prints the API requests it makes, and the
+command after -- starts your app. Their output is prefixed with their name.
+
+When any of them exits, or on Ctrl+C, the others are stopped too.`,
+		Example: `stripe dev --forward-to localhost:4242/webhook -- npm run dev
+  stripe dev --events payment_intent.succeeded,charge.refunded`,
+		RunE: dc.runDevCmd,
+	}
+
+	dc.cmd.Flags().StringVarP(&dc.forwardURL, "forward-to", "f", "", "The URL to forward webhook events to (default: the forward_url of the project, see stripe init)")
+	dc.cmd.Flags().StringSliceVarP(&dc.events, "events", "e", []string{}, "A comma-separated list of specific events to listen for (default: all events)")
+	dc.cmd.Flags().BoolVar(&dc.skipLogs, "skip-logs", false, "Don't run logs tail")
+
+	return dc
+}
+
+func (dc *devCmd) runDevCmd(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && cmd.ArgsLenAtDash() != 0 {
+		return fmt.Errorf("separate the command of your app with --, e.g. stripe dev -- npm run dev")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	inherited := inheritedFlags(cmd.Root())
+
+	listenArgs := []string{"listen"}
+	if dc.forwardURL != "" {
+		listenArgs = append(listenArgs, "--forward-to", dc.forwardURL)
+	}
+
+	if len(dc.events) > 0 {
+		listenArgs = append(listenArgs, "--events", strings.Join(dc.events, ","))
+	}
+
+	tasks := []task{{name: "listen", cmd: exec.Command(executable, append(listenArgs, inherited...)...)}}
+
+	if !dc.skipLogs {
+		tasks = append(tasks, task{name: "logs", cmd: exec.Command(executable, append([]string{"logs", "tail"}, inherited...)...)})
+	}
+
+	if len(args) > 0 {
+		tasks = append(tasks, task{name: filepath.Base(args[0]), cmd: exec.Command(args[0], args[1:]...)})
+	}
+
+	errs, first := runTasks(cmd.Context(), tasks, true)
+
+	if cmd.Context().Err() != nil {
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+
+	if errs[first] != nil {
+		return fmt.Errorf("%s exited: %w", tasks[first].name, errs[first])
+	}
+
+	return fmt.Errorf("%s exited", tasks[first].name)
+}
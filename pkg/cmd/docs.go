@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/open"
+	"github.com/stripe/stripe-cli/pkg/spec"
+	"github.com/stripe/stripe-cli/pkg/suggest"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+const apiDocsURL = "https://stripe.com/docs/api"
+
+type docsCmd struct {
+	cmd *cobra.Command
+
+	open bool
+}
+
+func newDocsCmd() *docsCmd {
+	dc := &docsCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "docs [resource] [operation]",
+		Args:  validators.MaximumNArgs(3),
+		Short: "Browse the API reference in the terminal",
+		Long: `Browse the API reference bundled with the CLI: the operations and fields of
+a resource, or the parameters of an operation with their types, accepted
+values and whether they're required.
+
+Without arguments, lists the documented resources. Namespaced resources are
+written with a dot or a space, e.g. terminal.readers or terminal readers.`,
+		Example: `stripe docs customers
+  stripe docs customers create
+  stripe docs terminal readers list
+  stripe docs payment_intents confirm --open`,
+		RunE: dc.runDocsCmd,
+	}
+
+	dc.cmd.Flags().BoolVar(&dc.open, "open", false, "Open the web docs of the resource or operation instead")
+
+	return dc
+}
+
+func (dc *docsCmd) runDocsCmd(cmd *cobra.Command, args []string) error {
+	docs, err := spec.LoadResourceDocs()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		if dc.open {
+			return open.Browser(apiDocsURL)
+		}
+
+		return ansi.Page(fmt.Sprintf("Documented resources (API version %s):\n\n  %s\n", docs.Version, strings.Join(docs.Names(), "\n  ")), os.Stdout)
+	}
+
+	name, resource, operation, err := findDocs(docs, args)
+	if err != nil {
+		return err
+	}
+
+	if dc.open {
+		url := fmt.Sprintf("%s/%s", apiDocsURL, strings.ReplaceAll(name, ".", "/"))
+		if operation != "" {
+			url += "/" + operation
+		}
+
+		return open.Browser(url)
+	}
+
+	var buf bytes.Buffer
+	if operation != "" {
+		writeOperationDocs(&buf, name, operation, resource.Operations[operation])
+	} else {
+		writeResourceDocs(&buf, name, resource)
+	}
+
+	return ansi.Page(buf.String(), os.Stdout)
+}
+
+// findDocs finds the resource and the optional operation args refer to,
+// where namespaced resources can take two arguments
+func findDocs(docs *spec.ResourceDocs, args []string) (string, *spec.ResourceDoc, string, error) {
+	name, resource, ok := docs.Find(args[0])
+	rest := args[1:]
+
+	if !ok && len(args) > 1 {
+		name, resource, ok = docs.Find(args[0] + "." + args[1])
+		rest = args[2:]
+	}
+
+	if !ok {
+		message := fmt.Sprintf("No docs for the resource %s.", args[0])
+		if match, found := suggest.Closest(args[0], docs.Names()); found {
+			message += fmt.Sprintf(" Did you mean %s?", match)
+		}
+
+		return "", nil, "", fmt.Errorf("%s Run `stripe docs` to list the resources", message)
+	}
+
+	switch len(rest) {
+	case 0:
+		return name, resource, "", nil
+	case 1:
+		if _, ok := resource.Operations[rest[0]]; ok {
+			return name, resource, rest[0], nil
+		}
+
+		message := fmt.Sprintf("%s has no operation %s.", name, rest[0])
+		if match, found := suggest.Closest(rest[0], resource.OperationNames()); found {
+			message += fmt.Sprintf(" Did you mean %s?", match)
+		}
+
+		return "", nil, "", fmt.Errorf("%s Run `stripe docs %s` to list its operations", message, name)
+	default:
+		return "", nil, "", fmt.Errorf("too many arguments: %s", strings.Join(rest[1:], " "))
+	}
+}
+
+func writeResourceDocs(w io.Writer, name string, resource *spec.ResourceDoc) {
+	color := ansi.Color(os.Stdout)
+
+	fmt.Fprintf(w, "%s (%s)\n", color.Bold(name), resource.Object)
+	if resource.Description != "" {
+		fmt.Fprintf(w, "%s\n", wrapText(resource.Description, 80, ""))
+	}
+
+	fmt.Fprintf(w, "\n%s\n", color.Bold("OPERATIONS"))
+	for _, operation := range resource.OperationNames() {
+		doc := resource.Operations[operation]
+		fmt.Fprintf(w, "  %s  %s %s\n", color.Cyan(operation), doc.Method, doc.Path)
+
+		if doc.Description != "" {
+			fmt.Fprintf(w, "%s\n", wrapText(doc.Description, 80, "      "))
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s\n", color.Bold("FIELDS"))
+	writeFieldDocs(w, resource.Fields)
+}
+
+func writeOperationDocs(w io.Writer, name, operation string, doc *spec.OperationDoc) {
+	color := ansi.Color(os.Stdout)
+
+	fmt.Fprintf(w, "%s %s\n", color.Bold(name), color.Bold(operation))
+	fmt.Fprintf(w, "%s %s\n", doc.Method, doc.Path)
+	if doc.Description != "" {
+		fmt.Fprintf(w, "%s\n", wrapText(doc.Description, 80, ""))
+	}
+
+	fmt.Fprintf(w, "\n%s\n", color.Bold("PARAMETERS"))
+	if len(doc.Parameters) == 0 {
+		fmt.Fprintln(w, "  None")
+		return
+	}
+
+	writeFieldDocs(w, doc.Parameters)
+}
+
+func writeFieldDocs(w io.Writer, fields []spec.FieldDoc) {
+	color := ansi.Color(os.Stdout)
+
+	for _, field := range fields {
+		details := field.Type
+		if field.Required {
+			details += ", " + color.Yellow("required").String()
+		}
+
+		fmt.Fprintf(w, "  %s (%s)\n", color.Cyan(field.Name), details)
+
+		if field.Description != "" {
+			fmt.Fprintf(w, "%s\n", wrapText(field.Description, 80, "      "))
+		}
+
+		if len(field.Enum) > 0 {
+			fmt.Fprintf(w, "%s\n", wrapText("One of: "+strings.Join(field.Enum, ", "), 80, "      "))
+		}
+	}
+}
+
+// wrapText wraps text on spaces to lines of at most width characters,
+// prefixed by indent
+func wrapText(text string, width int, indent string) string {
+	var lines []string
+	line := indent
+
+	for _, word := range strings.Fields(text) {
+		if line != indent && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = indent
+		}
+
+		if line != indent {
+			line += " "
+		}
+
+		line += word
+	}
+
+	return strings.Join(append(lines, line), "\n")
+}
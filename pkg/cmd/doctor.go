@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type doctorCmd struct {
+	cmd *cobra.Command
+
+	apiBaseURL string
+	format     string
+}
+
+// cryptoPosture describes the crypto policy in effect and the connection it
+// negotiates with the API
+type cryptoPosture struct {
+	Build           string   `json:"build"`
+	ApprovedOnly    bool     `json:"approved_only"`
+	MinTLSVersion   string   `json:"min_tls_version"`
+	MaxTLSVersion   string   `json:"max_tls_version"`
+	CipherPolicy    string   `json:"cipher_policy"`
+	CipherSuites    []string `json:"cipher_suites"`
+	APIHost         string   `json:"api_host"`
+	APITLSVersion   string   `json:"api_tls_version,omitempty"`
+	APICipherSuite  string   `json:"api_cipher_suite,omitempty"`
+	APIConnectError string   `json:"api_connect_error,omitempty"`
+}
+
+func newDoctorCmd() *doctorCmd {
+	dc := &doctorCmd{}
+
+	dc.cmd = &cobra.Command{
+		Use:   "doctor",
+		Args:  validators.NoArgs,
+		Short: "Report the crypto posture of the CLI",
+		Long: `Report the crypto policy applied to the outbound connections of the CLI and
+test it with a connection to the Stripe API.
+
+The policy is set with the top-level keys of the config file:
+
+  tls_min_version = "1.2"        # 1.0, 1.1, 1.2 or 1.3
+  tls_cipher_policy = "modern"   # default, modern or fips
+  approved_crypto_only = true    # refuse the algorithms not approved by FIPS 140
+
+Only approved algorithms are allowed in binaries built with the fips build tag,
+or when STRIPE_APPROVED_CRYPTO_ONLY=true.`,
+		RunE: dc.runDoctorCmd,
+	}
+
+	dc.cmd.Flags().StringVar(&dc.format, "format", "", `Specifies the output format of the report
+Acceptable values:
+	'JSON' - Output the report in JSON format`)
+
+	dc.cmd.Flags().StringVar(&dc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	dc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return dc
+}
+
+func (dc *doctorCmd) runDoctorCmd(cmd *cobra.Command, args []string) error {
+	if dc.format != "" && strings.ToUpper(dc.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, only JSON is supported", dc.format)
+	}
+
+	posture := dc.cryptoPosture(cmd.Context())
+
+	if strings.ToUpper(dc.format) == outputFormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(map[string]interface{}{"crypto": posture})
+	}
+
+	approved := "no"
+	if posture.ApprovedOnly {
+		approved = "yes"
+	}
+
+	connection := fmt.Sprintf("TLS %s, %s", posture.APITLSVersion, posture.APICipherSuite)
+	if posture.APIConnectError != "" {
+		connection = "failed: " + posture.APIConnectError
+	}
+
+	fmt.Println("Crypto")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Build:\t%s\n", posture.Build)
+	fmt.Fprintf(w, "  Approved algorithms only:\t%s\n", approved)
+	fmt.Fprintf(w, "  TLS versions:\t%s to %s\n", posture.MinTLSVersion, posture.MaxTLSVersion)
+	fmt.Fprintf(w, "  TLS cipher policy:\t%s\n", posture.CipherPolicy)
+	fmt.Fprintf(w, "  TLS 1.2 cipher suites:\t%s\n", strings.Join(posture.CipherSuites, ", "))
+	fmt.Fprintf(w, "  Connection to %s:\t%s\n", posture.APIHost, connection)
+
+	return w.Flush()
+}
+
+func (dc *doctorCmd) cryptoPosture(ctx context.Context) *cryptoPosture {
+	policy := cryptopolicy.Current()
+	tlsConfig := policy.TLSConfig()
+
+	posture := &cryptoPosture{
+		Build:         "standard",
+		ApprovedOnly:  policy.ApprovedOnly,
+		MinTLSVersion: cryptopolicy.VersionName(tlsConfig.MinVersion),
+		MaxTLSVersion: cryptopolicy.VersionName(tls.VersionTLS13),
+		CipherPolicy:  policy.Ciphers,
+		APIHost:       strings.TrimPrefix(strings.TrimPrefix(dc.apiBaseURL, "https://"), "http://"),
+	}
+
+	if cryptopolicy.BuildFIPS {
+		posture.Build = "fips"
+	}
+
+	if tlsConfig.MaxVersion != 0 {
+		posture.MaxTLSVersion = cryptopolicy.VersionName(tlsConfig.MaxVersion)
+	}
+
+	suites := tlsConfig.CipherSuites
+	if suites == nil {
+		for _, suite := range tls.CipherSuites() {
+			for _, version := range suite.SupportedVersions {
+				if version == tls.VersionTLS12 {
+					suites = append(suites, suite.ID)
+				}
+			}
+		}
+	}
+
+	for _, suite := range suites {
+		posture.CipherSuites = append(posture.CipherSuites, tls.CipherSuiteName(suite))
+	}
+
+	state, err := apiConnectionState(ctx, dc.apiBaseURL)
+	if err != nil {
+		posture.APIConnectError = err.Error()
+	} else {
+		posture.APITLSVersion = cryptopolicy.VersionName(state.Version)
+		posture.APICipherSuite = tls.CipherSuiteName(state.CipherSuite)
+	}
+
+	return posture
+}
+
+// apiConnectionState connects to the API with the shared transport, which
+// applies the crypto policy, and returns the negotiated TLS parameters
+func apiConnectionState(ctx context.Context, apiBaseURL string) (*tls.ConnectionState, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, apiBaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Transport: stripe.HTTPTransport()}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.TLS == nil {
+		return nil, fmt.Errorf("the connection doesn't use TLS")
+	}
+
+	return resp.TLS, nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/experiments"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type experimentsCmd struct {
+	cmd *cobra.Command
+}
+
+func newExperimentsCmd() *experimentsCmd {
+	ec := &experimentsCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "experiments",
+		Args:  validators.NoArgs,
+		Short: "Manage the experimental features of the CLI",
+		Long: `Experiments are new features disabled by default. Enable them in the
+[experiments] section of the config file with stripe experiments enable, or
+for one command with a STRIPE_EXPERIMENT_<NAME> environment variable, e.g.
+STRIPE_EXPERIMENT_TUI=true. The environment takes precedence over the config
+file.
+
+Experiments can change or go away in any version of the CLI.`,
+		Example: `stripe experiments list
+  stripe experiments enable tui
+  stripe experiments disable tui`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the experiments and whether they're enabled",
+		RunE:  ec.runListCmd,
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Enable an experiment",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ec.setExperiment(args[0], true)
+		},
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Disable an experiment",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ec.setExperiment(args[0], false)
+		},
+	}
+
+	ec.cmd.AddCommand(listCmd)
+	ec.cmd.AddCommand(enableCmd)
+	ec.cmd.AddCommand(disableCmd)
+
+	return ec
+}
+
+func (ec *experimentsCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	states := experiments.List()
+	if len(states) == 0 {
+		fmt.Println("There are no experiments in this version of the CLI.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EXPERIMENT\tSTATE\tSOURCE\tDESCRIPTION")
+
+	for _, s := range states {
+		state := "disabled"
+		if s.Enabled {
+			state = "enabled"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, state, s.Source, s.Description)
+	}
+
+	return w.Flush()
+}
+
+func (ec *experimentsCmd) setExperiment(name string, enabled bool) error {
+	if _, err := experiments.Lookup(name); err != nil {
+		return err
+	}
+
+	if err := Config.SetExperiment(name, enabled); err != nil {
+		return err
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+
+	fmt.Printf("Experiment %s %s.\n", name, state)
+
+	if value, ok := os.LookupEnv(experiments.EnvName(name)); ok {
+		fmt.Printf("%s=%s is set and takes precedence over the config file.\n", experiments.EnvName(name), value)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
+	"github.com/stripe/stripe-cli/pkg/export"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/spec"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type exportCmd struct {
+	cmd *cobra.Command
+
+	since      string
+	format     string
+	out        string
+	livemode   bool
+	apiBaseURL string
+}
+
+func newExportCmd() *exportCmd {
+	ec := &exportCmd{}
+
+	ec.cmd = &cobra.Command{
+		Use:   "export <resource>",
+		Args:  validators.ExactArgs(1),
+		Short: "Export a list of objects to a Parquet file or a SQLite database",
+		Long: `Export the objects of a resource to a local file for analysis, as a table
+with a column per field of the resource, typed from the API specification.
+Timestamps are converted to dates, expandable fields hold the ID of the
+object and nested objects their JSON.
+
+The files are written by external programs, which must be installed and in
+your PATH:
+  parquet    the DuckDB command-line client, duckdb:
+             https://duckdb.org/docs/installation
+  sqlite     the SQLite command-line shell, sqlite3:
+             https://sqlite.org/download.html
+
+An existing file is replaced, or the table of the resource in an existing
+SQLite database.`,
+		Example: `stripe export charges --since 30d --format parquet --out charges.parquet
+  stripe export customers --format sqlite --out stripe.db`,
+		RunE: ec.runExportCmd,
+	}
+
+	ec.cmd.Flags().StringVar(&ec.since, "since", "", "Only export the objects created in this period, e.g. 24h or 30d")
+	ec.cmd.Flags().StringVar(&ec.format, "format", "", `Specifies the format of the export (required)
+	Acceptable values:
+		'parquet' - Write a Parquet file
+		'sqlite' - Write a table in a SQLite database`)
+	ec.cmd.Flags().StringVar(&ec.out, "out", "", "Path of the file to write (default: <resource>.parquet or <resource>.db)")
+	ec.cmd.Flags().BoolVar(&ec.livemode, "live", false, "Export live mode objects (default: test)")
+	ec.cmd.MarkFlagRequired("format") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	ec.cmd.Flags().StringVar(&ec.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ec.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return ec
+}
+
+func (ec *exportCmd) runExportCmd(cmd *cobra.Command, args []string) error {
+	docs, err := spec.LoadResourceDocs()
+	if err != nil {
+		return err
+	}
+
+	name, doc, ok := docs.Find(args[0])
+	if !ok {
+		return fmt.Errorf("unknown resource %s, see `stripe resources`", args[0])
+	}
+
+	list, ok := doc.Operations["list"]
+	if !ok || strings.Contains(list.Path, "{") {
+		return fmt.Errorf("%s can't be exported, it has no list operation", name)
+	}
+
+	var since time.Time
+	if ec.since != "" {
+		if !hasParameter(list, "created") {
+			return fmt.Errorf("%s can't be filtered by creation date, remove --since", name)
+		}
+
+		period, err := resource.ParsePeriod(ec.since)
+		if err != nil {
+			return err
+		}
+
+		since = time.Now().Add(-period)
+	}
+
+	schemas, err := spec.LoadResourceSchemas()
+	if err != nil {
+		return err
+	}
+
+	columns, err := export.Columns(schemas, doc.Object)
+	if err != nil {
+		return err
+	}
+
+	table := strings.ReplaceAll(name, ".", "_")
+
+	var w export.Writer
+
+	switch strings.ToLower(ec.format) {
+	case "parquet":
+		if ec.out == "" {
+			ec.out = table + ".parquet"
+		}
+
+		w, err = export.NewParquetWriter(ec.out, columns)
+	case "sqlite":
+		if ec.out == "" {
+			ec.out = table + ".db"
+		}
+
+		w, err = export.NewSQLiteWriter(ec.out, table, columns)
+	default:
+		return fmt.Errorf("unsupported format %s, use parquet or sqlite", ec.format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(ec.livemode)
+	if err != nil {
+		w.Close()
+		return err
+	}
+
+	spinner := ansi.StartNewSpinner(fmt.Sprintf("Exporting %s...", name), os.Stderr)
+
+	count, err := export.Export(cmd.Context(), simulate.NewAPIClient(apiKey, ec.apiBaseURL), list.Path, since, columns, w, func(count int) {
+		if spinner != nil {
+			ansi.StartSpinner(spinner, fmt.Sprintf("Exporting %s... %d", name, count), os.Stderr)
+		}
+	})
+	if err != nil {
+		ansi.StopSpinner(spinner, "", os.Stderr)
+		w.Close()
+
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		ansi.StopSpinner(spinner, "", os.Stderr)
+		return err
+	}
+
+	ansi.StopSpinner(spinner, "", os.Stderr)
+	fmt.Fprintf(os.Stderr, "%s Exported %d %s to %s\n", ansi.SuccessGlyph(), count, name, ec.out)
+
+	return nil
+}
+
+func hasParameter(operation *spec.OperationDoc, name string) bool {
+	for _, parameter := range operation.Parameters {
+		if parameter.Name == name || strings.HasPrefix(parameter.Name, name+"[") {
+			return true
+		}
+	}
+
+	return false
+}
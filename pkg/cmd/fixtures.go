@@ -1,11 +1,19 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/gha"
+	"github.com/stripe/stripe-cli/pkg/notify"
+	"github.com/stripe/stripe-cli/pkg/spec"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
@@ -22,6 +30,7 @@ type FixturesCmd struct {
 	override      []string
 	add           []string
 	remove        []string
+	seed          int64
 }
 
 func newFixturesCmd(cfg *config.Config) *FixturesCmd {
@@ -33,8 +42,15 @@ func newFixturesCmd(cfg *config.Config) *FixturesCmd {
 		Use:   "fixtures",
 		Args:  validators.ExactArgs(1),
 		Short: "Run fixtures to populate your account with data",
-		Long:  `Run fixtures to populate your account with data`,
-		RunE:  fixturesCmd.runFixturesCmd,
+		Long: `Run fixtures to populate your account with data.
+
+String values in fixtures can contain placeholders for realistic fake data,
+such as {{fake.name}}, {{fake.email}}, {{fake.phone}}, {{fake.company}} or
+{{fake.address}}. Append a locale to a placeholder to localize it, e.g.
+{{fake.address.nl}}; the supported locales are en, de, fr and nl. All the
+placeholders of a fixture step describe the same person. Use --seed to create
+the same data on every run.`,
+		RunE: fixturesCmd.runFixturesCmd,
 	}
 
 	fixturesCmd.Cmd.Flags().StringVar(&fixturesCmd.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
@@ -42,12 +58,16 @@ func newFixturesCmd(cfg *config.Config) *FixturesCmd {
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.override, "override", []string{}, "Override parameters in the fixture")
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.add, "add", []string{}, "Add parameters in the fixture")
 	fixturesCmd.Cmd.Flags().StringArrayVar(&fixturesCmd.remove, "remove", []string{}, "Remove parameters from the fixture")
+	fixturesCmd.Cmd.Flags().Int64Var(&fixturesCmd.seed, "seed", 0, "Seed for the {{fake.*}} values, to create the same data on every run")
+	addNotifyFlag(fixturesCmd.Cmd)
+
+	fixturesCmd.Cmd.AddCommand(newFixturesLintCmd().cmd)
 
 	return fixturesCmd
 }
 
 func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
 	apiKey, err := fc.Cfg.Profile.GetAPIKey(false)
 	if err != nil {
@@ -58,6 +78,11 @@ func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	notifier, err := notify.Load()
+	if err != nil {
+		return err
+	}
+
 	fixture, err := fixtures.NewFixtureFromFile(
 		afero.NewOsFs(),
 		apiKey,
@@ -73,7 +98,19 @@ func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	_, err = fixture.Execute(cmd.Context())
+	if cmd.Flags().Changed("seed") {
+		fixture.Faker = fixtures.NewFaker(fc.seed)
+	}
+
+	if ghaOutput() {
+		gha.Group(os.Stdout, "Fixtures "+args[0])
+	}
+
+	requestNames, err := fixture.Execute(cmd.Context())
+
+	if ghaOutput() {
+		gha.EndGroup(os.Stdout)
+	}
 
 	if err != nil {
 		return err
@@ -84,5 +121,126 @@ func (fc *FixturesCmd) runFixturesCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	notifier.Notify(cmd.Context(), notify.FixturesCompleted, "Fixtures completed", fmt.Sprintf("Ran fixtures from %s", args[0]))
+
+	if ghaOutput() {
+		return gha.AppendSummary(fixtureSummary(fmt.Sprintf("Ran fixtures from `%s`", args[0]), requestNames))
+	}
+
 	return nil
 }
+
+type fixturesLintCmd struct {
+	cmd *cobra.Command
+
+	fix bool
+}
+
+func newFixturesLintCmd() *fixturesLintCmd {
+	flc := &fixturesLintCmd{}
+
+	flc.cmd = &cobra.Command{
+		Use:   "lint <file or directory>...",
+		Args:  validators.MinimumNArgs(1),
+		Short: "Check fixture files for errors before running them",
+		Long: `Check fixture files for errors before running them: unknown fields, missing
+names, paths or methods, references to steps that aren't declared before, and
+deprecated API operations or parameters. Directories are searched for .json
+files.
+
+With --fix, the files are also formatted with two-space indentation.`,
+		Example: `stripe fixtures lint ./fixtures
+  stripe fixtures lint customers.json --fix`,
+		RunE: flc.runFixturesLintCmd,
+	}
+
+	flc.cmd.Flags().BoolVar(&flc.fix, "fix", false, "Format the fixture files")
+
+	return flc
+}
+
+func (flc *fixturesLintCmd) runFixturesLintCmd(cmd *cobra.Command, args []string) error {
+	files, err := fixtureFiles(args)
+	if err != nil {
+		return err
+	}
+
+	schemas, err := spec.LoadResourceSchemas()
+	if err != nil {
+		return err
+	}
+
+	color := ansi.Color(os.Stdout)
+	errors := 0
+
+	for _, file := range files {
+		data, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return err
+		}
+
+		if flc.fix {
+			if formatted, err := fixtures.Format(data); err == nil {
+				if err := afero.WriteFile(fs, file, formatted, 0644); err != nil {
+					return err
+				}
+
+				data = formatted
+			}
+		}
+
+		for _, problem := range fixtures.Lint(data, schemas) {
+			if problem.Warning {
+				fmt.Printf("%s %s: %s\n", color.Yellow("warning"), file, problem)
+				continue
+			}
+
+			errors++
+			fmt.Printf("%s %s: %s\n", color.Red("error"), file, problem)
+		}
+	}
+
+	if errors > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d error(s) found in %d file(s)", errors, len(files))
+	}
+
+	fmt.Printf("%s %d file(s) checked\n", ansi.SuccessGlyph(), len(files))
+
+	return nil
+}
+
+// fixtureFiles returns the files in paths, with the .json files of the
+// directories.
+func fixtureFiles(paths []string) ([]string, error) {
+	files := make([]string, 0)
+
+	for _, path := range paths {
+		info, err := fs.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = afero.Walk(fs, path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.IsDir() && filepath.Ext(file) == ".json" {
+				files = append(files, file)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/gha"
+)
+
+// ghaOutput returns true when the output is meant for GitHub Actions
+func ghaOutput() bool {
+	return outputMode == gha.OutputMode
+}
+
+// maskAPIKeys prevents the configured API keys from leaking in the workflow
+// logs
+func maskAPIKeys() {
+	masked := make(map[string]bool)
+
+	for _, livemode := range []bool{false, true} {
+		if key, err := Config.Profile.GetAPIKey(livemode); err == nil && !masked[key] {
+			gha.Mask(os.Stdout, key)
+			masked[key] = true
+		}
+	}
+}
+
+// fixtureSummary renders the job summary of a trigger or fixtures run
+func fixtureSummary(title string, requestNames []string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "### %s\n\n", title)
+	b.WriteString("| Fixture step |\n| --- |\n")
+
+	for _, name := range requestNames {
+		// Skipped steps have no name
+		if name != "" {
+			fmt.Fprintf(&b, "| `%s` |\n", name)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureSummary(t *testing.T) {
+	summary := fixtureSummary("Triggered `charge.captured`", []string{"charge", "", "charge_captured"})
+
+	require.Equal(t, "### Triggered `charge.captured`\n\n| Fixture step |\n| --- |\n| `charge` |\n| `charge_captured` |\n", summary)
+}
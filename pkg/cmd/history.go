@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/history"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type historyCmd struct {
+	cmd *cobra.Command
+
+	limit int
+}
+
+func newHistoryCmd() *historyCmd {
+	hc := &historyCmd{}
+
+	hc.cmd = &cobra.Command{
+		Use:   "history [search]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "List and rerun the commands you ran",
+		Long: `List the commands you ran with the CLI, most recent last, with their exit
+code. With a search term, only lists the commands containing it.
+
+The history is off by default. Once enabled with ` + "`stripe history enable`" + `, it's
+kept in the config folder, on this machine only. API keys and webhook
+signing secrets are removed from the commands, which can't be rerun then.
+The last 1000 commands are kept.`,
+		Example: `stripe history
+  stripe history trigger
+  stripe history rerun 42`,
+		RunE: hc.runHistoryCmd,
+	}
+
+	hc.cmd.Flags().IntVar(&hc.limit, "limit", 20, "Maximum number of commands to list")
+
+	rerunCmd := &cobra.Command{
+		Use:   "rerun <id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Run a command of the history again",
+		RunE:  hc.runRerunCmd,
+	}
+
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Args:  validators.NoArgs,
+		Short: "Delete the history",
+		RunE:  hc.runClearCmd,
+	}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable",
+		Args:  validators.NoArgs,
+		Short: "Start recording the commands you run",
+		RunE:  hc.runEnableCmd,
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable",
+		Args:  validators.NoArgs,
+		Short: "Stop recording the commands you run and delete the history",
+		RunE:  hc.runDisableCmd,
+	}
+
+	hc.cmd.AddCommand(rerunCmd)
+	hc.cmd.AddCommand(clearCmd)
+	hc.cmd.AddCommand(enableCmd)
+	hc.cmd.AddCommand(disableCmd)
+
+	return hc
+}
+
+func (hc *historyCmd) runHistoryCmd(cmd *cobra.Command, args []string) error {
+	if !Config.GetHistoryEnabled() {
+		fmt.Println("The history is disabled. Run `stripe history enable` to record the commands you run.")
+		return nil
+	}
+
+	entries, err := history.Load(historyPath())
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		entries = history.Search(entries, args[0])
+	}
+
+	if hc.limit > 0 && len(entries) > hc.limit {
+		entries = entries[len(entries)-hc.limit:]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tEXIT\tCOMMAND")
+
+	for _, e := range entries {
+		exitCode := strconv.Itoa(e.ExitCode)
+		if e.ExitCode != 0 {
+			exitCode = ansi.Color(os.Stdout).Red(exitCode).String()
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04:05"), exitCode, e.Command())
+	}
+
+	return w.Flush()
+}
+
+func (hc *historyCmd) runRerunCmd(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("%s is not the ID of a command of the history", args[0])
+	}
+
+	entries, err := history.Load(historyPath())
+	if err != nil {
+		return err
+	}
+
+	e, ok := history.Find(entries, id)
+	if !ok {
+		return fmt.Errorf("no command %d in the history, run `stripe history` to list them", id)
+	}
+
+	if e.Redacted {
+		return fmt.Errorf("command %d had secrets, which aren't kept in the history: %s", id, e.Command())
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, ansi.Faint("$ "+e.Command()))
+
+	rerun := exec.CommandContext(cmd.Context(), executable, e.Args...)
+	rerun.Stdin = os.Stdin
+	rerun.Stdout = os.Stdout
+	rerun.Stderr = os.Stderr
+
+	if err := rerun.Run(); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s failed: %w", strings.Join(e.Args, " "), err)
+	}
+
+	return nil
+}
+
+func (hc *historyCmd) runClearCmd(cmd *cobra.Command, args []string) error {
+	if err := history.Clear(historyPath()); err != nil {
+		return err
+	}
+
+	fmt.Println("Deleted the history.")
+
+	return nil
+}
+
+func (hc *historyCmd) runEnableCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.SetHistoryEnabled(true); err != nil {
+		return err
+	}
+
+	fmt.Println("The commands you run are now recorded, run `stripe history` to list them.")
+
+	return nil
+}
+
+func (hc *historyCmd) runDisableCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.SetHistoryEnabled(false); err != nil {
+		return err
+	}
+
+	if err := history.Clear(historyPath()); err != nil {
+		return err
+	}
+
+	fmt.Println("The commands you run are no longer recorded, and the history was deleted.")
+
+	return nil
+}
+
+func historyPath() string {
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), history.FileName)
+}
+
+// enableHistory records the command in the history when the user opted in,
+// except for the history commands themselves and shell completions
+func enableHistory(cmd *cobra.Command) {
+	if !Config.GetHistoryEnabled() || strings.HasPrefix(cmd.Name(), "__complete") {
+		return
+	}
+
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "history" && c.Parent() == cmd.Root() {
+			return
+		}
+	}
+
+	history.Enable(historyPath())
+}
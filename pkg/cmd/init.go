@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type initCmd struct {
+	cmd         *cobra.Command
+	interactive bool
+
+	dashboardBaseURL string
+}
+
+func newInitCmd() *initCmd {
+	ic := &initCmd{}
+
+	ic.cmd = &cobra.Command{
+		Use:   "init",
+		Args:  validators.NoArgs,
+		Short: "Set up the CLI step by step",
+		Long: `Set up the CLI step by step: log in, choose the project name, opt in or
+out of telemetry, set the URL stripe listen forwards events to, and install
+shell completion.
+
+Every step can be skipped, and running init again changes the answers.`,
+		RunE: ic.runInitCmd,
+	}
+
+	ic.cmd.Flags().BoolVarP(&ic.interactive, "interactive", "i", false, "Log in by entering an API key if you cannot open a browser")
+
+	// Hidden configuration flags, useful for dev/debugging
+	ic.cmd.Flags().StringVar(&ic.dashboardBaseURL, "dashboard-base", stripe.DefaultDashboardBaseURL, "Sets the dashboard base URL")
+	ic.cmd.Flags().MarkHidden("dashboard-base") // #nosec G104
+
+	return ic
+}
+
+func (ic *initCmd) runInitCmd(cmd *cobra.Command, args []string) error {
+	input := bufio.NewReader(os.Stdin)
+	color := ansi.Color(os.Stdout)
+
+	fmt.Println(color.Bold("1. Project"))
+	fmt.Println("Projects keep separate credentials, e.g. one per Stripe account.")
+	Config.Profile.ProfileName = promptString(input, "Project name", Config.Profile.ProfileName)
+
+	fmt.Println(color.Bold("\n2. Login"))
+	if _, err := Config.Profile.GetAPIKey(false); err != nil || promptYesNo(input, "You are already logged in. Log in again?", false) {
+		var err error
+		if ic.interactive {
+			err = login.InteractiveLogin(cmd.Context(), &Config)
+		} else {
+			err = login.Login(cmd.Context(), ic.dashboardBaseURL, &Config, input)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(color.Bold("\n3. Telemetry"))
+	fmt.Println("The CLI sends anonymous usage data to help improve it.")
+	optOut := !promptYesNo(input, "Share usage data with Stripe?", !Config.GetTelemetryOptOut())
+	if err := Config.SetTelemetryOptOut(optOut); err != nil {
+		return err
+	}
+
+	fmt.Println(color.Bold("\n4. Forwarding"))
+	fmt.Println("stripe listen forwards events to this URL when --forward-to isn't set.")
+	forwardURL := promptString(input, "Forward URL, e.g. localhost:3000/webhooks (- for none)", Config.Profile.GetForwardURL())
+	if err := setForwardURL(forwardURL); err != nil {
+		return err
+	}
+
+	fmt.Println(color.Bold("\n5. Shell completion"))
+	if shell := detectShell(); shell == "" {
+		fmt.Println("Could not detect your shell, run `stripe completion --shell <shell>` to set up completion.")
+	} else if promptYesNo(input, fmt.Sprintf("Install completion for %s?", shell), true) {
+		line, err := installCompletion(shell)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Add this line to your shell profile to enable completion:\n    %s\n", line)
+	}
+
+	fmt.Println(color.Bold("\nYou're all set!"))
+	if Config.Profile.ProfileName != "default" {
+		fmt.Printf("Pass --project-name %s to use this project.\n", Config.Profile.ProfileName)
+	}
+	fmt.Println("Run `stripe listen` to receive events, or `stripe --help` to see all the commands.")
+
+	return nil
+}
+
+func setForwardURL(forwardURL string) error {
+	if forwardURL == "" || forwardURL == "-" {
+		return Config.Profile.DeleteConfigField("forward_url")
+	}
+
+	return Config.Profile.WriteConfigField("forward_url", forwardURL)
+}
+
+// promptString asks for a value, returning def when the answer is empty
+func promptString(input *bufio.Reader, label, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	answer, err := input.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+
+	if answer == "" || (err != nil && err != io.EOF) {
+		return def
+	}
+
+	return answer
+}
+
+// promptYesNo asks a yes/no question, returning def when the answer is empty
+func promptYesNo(input *bufio.Reader, label string, def bool) bool {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	for {
+		switch strings.ToLower(promptString(input, fmt.Sprintf("%s (%s)", label, choices), "")) {
+		case "":
+			return def
+		case "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+
+		if _, err := input.Peek(1); err != nil {
+			return def
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptString(t *testing.T) {
+	input := bufio.NewReader(strings.NewReader("work\n\n"))
+
+	require.Equal(t, "work", promptString(input, "Project name", "default"))
+	require.Equal(t, "default", promptString(input, "Project name", "default"))
+	require.Equal(t, "default", promptString(input, "Project name", "default"))
+}
+
+func TestPromptYesNo(t *testing.T) {
+	input := bufio.NewReader(strings.NewReader("y\nN\n\nmaybe\nno\n"))
+
+	require.True(t, promptYesNo(input, "Continue?", false))
+	require.False(t, promptYesNo(input, "Continue?", true))
+	require.True(t, promptYesNo(input, "Continue?", true))
+	require.False(t, promptYesNo(input, "Continue?", true))
+	require.False(t, promptYesNo(input, "Continue?", false))
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/bench"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/loadgen"
+)
+
+// The JSON outputs of the commands are stable across minor versions, see
+// docs/json-output.md. Only run with -update to add fields, never to remove
+// or rename them.
+var updateGolden = flag.Bool("update", false, "update the golden files of the JSON outputs")
+
+func requireGoldenJSON(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "json_output", name+".json")
+
+	if *updateGolden {
+		require.NoError(t, ioutil.WriteFile(path, append(bytes.TrimSpace(data), '\n'), 0644))
+	}
+
+	golden, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.JSONEq(t, string(golden), string(data), "the JSON output of %s changed", name)
+}
+
+func marshalGolden(t *testing.T, v interface{}) []byte {
+	data, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestJSONOutputBenchAPI(t *testing.T) {
+	report := &bench.Report{
+		Duration: 2 * time.Second,
+		Requests: 10,
+		Errors:   1,
+		Statuses: map[int]int{200: 9, 429: 1},
+		Total:    bench.Distribution{Count: 10, Min: 80 * time.Millisecond, P50: 100 * time.Millisecond, P95: 150 * time.Millisecond, P99: 180 * time.Millisecond, Max: 200 * time.Millisecond},
+	}
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+	requireGoldenJSON(t, "bench_api", data)
+}
+
+func TestJSONOutputDoctor(t *testing.T) {
+	requireGoldenJSON(t, "doctor", marshalGolden(t, map[string]interface{}{"crypto": &cryptoPosture{
+		Build:          "standard",
+		MinTLSVersion:  "TLS 1.2",
+		MaxTLSVersion:  "TLS 1.3",
+		CipherPolicy:   "default",
+		CipherSuites:   []string{"TLS_AES_128_GCM_SHA256"},
+		APIHost:        "api.stripe.com",
+		APITLSVersion:  "TLS 1.3",
+		APICipherSuite: "TLS_AES_128_GCM_SHA256",
+	}}))
+}
+
+func TestJSONOutputKeysWhoami(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	requireGoldenJSON(t, "keys_whoami", marshalGolden(t, &keyIdentity{
+		Profile:     "default",
+		Source:      "config",
+		Key:         "rk_test_******************1234",
+		Type:        "restricted",
+		Mode:        "test",
+		ExpiresAt:   &expiresAt,
+		AccountID:   "acct_123",
+		AccountName: "Example",
+		Scopes:      []keyScope{{Resource: "customers", Read: true}, {Resource: "charges", Error: "forbidden"}},
+	}))
+}
+
+func TestJSONOutputLoadgen(t *testing.T) {
+	report := &loadgen.Report{
+		Duration: time.Minute,
+		Started:  600,
+		Dropped:  2,
+		Total:    loadgen.Stats{Count: 598, Errors: 3, P50: 300 * time.Millisecond, P95: 900 * time.Millisecond, P99: 1200 * time.Millisecond},
+		Scenarios: map[string]*loadgen.Stats{
+			"payment_intent.succeeded": {Count: 598, Errors: 3, P50: 300 * time.Millisecond, P95: 900 * time.Millisecond, P99: 1200 * time.Millisecond},
+		},
+	}
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+	requireGoldenJSON(t, "loadgen", data)
+}
+
+func TestJSONOutputTail(t *testing.T) {
+	var buf bytes.Buffer
+	tp := &tailPrinter{out: &buf}
+
+	require.NoError(t, tp.printJSON("request_log", `{"status": 200}`))
+	requireGoldenJSON(t, "tail", buf.Bytes())
+}
+
+func TestJSONOutputTriggerCoverage(t *testing.T) {
+	coverage := fixtures.Coverage(
+		[]string{"customer.created", "custom.event"},
+		map[string]time.Time{"customer.created": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+	)
+
+	requireGoldenJSON(t, "trigger_coverage", marshalGolden(t, coverage))
+}
+
+func TestJSONOutputVersion(t *testing.T) {
+	requireGoldenJSON(t, "version", marshalGolden(t, &versionInfo{
+		Version:     "1.2.3",
+		Commit:      "0123456789abcdef0123456789abcdef01234567",
+		Date:        "2026-01-02T03:04:05Z",
+		GoVersion:   "go1.17.13",
+		OS:          "linux",
+		Arch:        "amd64",
+		APIVersion:  "2020-08-27",
+		Commands:    []string{"listen", "logs", "logs tail"},
+		Experiments: []string{"tui"},
+	}))
+}
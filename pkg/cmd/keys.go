@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// restrictedKeyProbes are the resources whose read access is checked for
+// restricted keys, which can't list their own permissions
+var restrictedKeyProbes = []string{
+	"balance",
+	"charges",
+	"customers",
+	"events",
+	"payment_intents",
+	"products",
+	"subscriptions",
+	"webhook_endpoints",
+}
+
+type keysCmd struct {
+	cmd *cobra.Command
+
+	livemode bool
+}
+
+type keysWhoamiCmd struct {
+	cmd *cobra.Command
+
+	apiBaseURL string
+	format     string
+	livemode   bool
+}
+
+// keyIdentity describes the key the CLI uses and the account it belongs to
+type keyIdentity struct {
+	Profile      string     `json:"profile"`
+	Source       string     `json:"source"`
+	Key          string     `json:"key"`
+	Type         string     `json:"type"`
+	Mode         string     `json:"mode"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	AccountID    string     `json:"account_id,omitempty"`
+	AccountName  string     `json:"account_name,omitempty"`
+	AccountError string     `json:"account_error,omitempty"`
+	Scopes       []keyScope `json:"scopes,omitempty"`
+}
+
+// keyScope is the read access of a restricted key to a resource
+type keyScope struct {
+	Resource string `json:"resource"`
+	Read     bool   `json:"read"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newKeysCmd() *keysCmd {
+	kc := &keysCmd{}
+
+	kc.cmd = &cobra.Command{
+		Use:   "keys",
+		Args:  validators.NoArgs,
+		Short: "Manage the API keys used by the CLI",
+		Long: `Manage the API keys of the profile. Besides the key set by stripe login, each
+mode has a slot per type of key:
+
+  secret       sk_ keys, used by the commands that restricted keys can't run
+  restricted   rk_ keys
+  publishable  pk_ keys, used client-side, e.g. by the samples
+
+The CLI picks the key for each command: the key set by stripe login or
+--api-key, then the restricted or secret key for API requests.`,
+		Example: `stripe keys set secret sk_test_123
+  stripe keys list
+  stripe keys unset restricted --live
+  stripe keys whoami`,
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set <slot> <key>",
+		Args:  validators.ExactArgs(2),
+		Short: "Set the key of a slot, in the mode of the key",
+		RunE:  kc.runSetCmd,
+	}
+
+	unsetCmd := &cobra.Command{
+		Use:   "unset <slot>",
+		Args:  validators.ExactArgs(1),
+		Short: "Remove the key of a slot",
+		RunE:  kc.runUnsetCmd,
+	}
+	unsetCmd.Flags().BoolVar(&kc.livemode, "live", false, "Remove the livemode key")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the masked keys of the profile",
+		RunE:  kc.runListCmd,
+	}
+
+	kc.cmd.AddCommand(setCmd)
+	kc.cmd.AddCommand(unsetCmd)
+	kc.cmd.AddCommand(listCmd)
+	kc.cmd.AddCommand(newKeysWhoamiCmd().cmd)
+
+	return kc
+}
+
+func (kc *keysCmd) runSetCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.Profile.SetKey(args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Set the %s %s key of the %s profile.\n", keyMode(args[1]), args[0], Config.Profile.ProfileName)
+
+	return nil
+}
+
+func (kc *keysCmd) runUnsetCmd(cmd *cobra.Command, args []string) error {
+	return Config.Profile.UnsetKey(args[0], kc.livemode)
+}
+
+func (kc *keysCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODE\tSLOT\tKEY")
+
+	for _, livemode := range []bool{false, true} {
+		mode := "test"
+		if livemode {
+			mode = "live"
+		}
+
+		for _, slot := range config.KeySlots {
+			key := Config.Profile.GetKey(slot, livemode)
+			if key == "" {
+				key = "-"
+			} else if len(key) >= 12 {
+				key = login.RedactAPIKey(key)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n", mode, slot, key)
+		}
+	}
+
+	return w.Flush()
+}
+
+func newKeysWhoamiCmd() *keysWhoamiCmd {
+	wc := &keysWhoamiCmd{}
+
+	wc.cmd = &cobra.Command{
+		Use:   "whoami",
+		Args:  validators.NoArgs,
+		Short: "Show which key and account the CLI uses",
+		Long: `Show the key the CLI would use right now: the profile and the place it's read
+from, the masked key, its type, mode and expiry when known, and the account it
+belongs to. The read access of restricted keys is checked on common resources.`,
+		Example: `stripe keys whoami
+  stripe keys whoami --live
+  stripe keys whoami --project-name acme --format JSON`,
+		RunE: wc.runWhoamiCmd,
+	}
+
+	wc.cmd.Flags().BoolVar(&wc.livemode, "live", false, "Show the livemode key")
+	wc.cmd.Flags().StringVar(&wc.format, "format", "", `Specifies the output format
+Acceptable values:
+	'JSON' - Output in JSON format`)
+
+	wc.cmd.Flags().StringVar(&wc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	wc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return wc
+}
+
+func (wc *keysWhoamiCmd) runWhoamiCmd(cmd *cobra.Command, args []string) error {
+	if wc.format != "" && strings.ToUpper(wc.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, only JSON is supported", wc.format)
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(wc.livemode)
+	if err != nil {
+		return err
+	}
+
+	identity := &keyIdentity{
+		Profile: Config.Profile.ProfileName,
+		Source:  Config.Profile.GetAPIKeySource(wc.livemode),
+		Key:     login.RedactAPIKey(apiKey),
+		Type:    keyType(apiKey),
+		Mode:    keyMode(apiKey),
+	}
+
+	if expiresAt, ok := Config.Profile.GetKeyExpiresAt(wc.livemode); ok {
+		identity.ExpiresAt = &expiresAt
+	}
+
+	baseURL, err := url.Parse(wc.apiBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := &stripe.Client{BaseURL: baseURL, APIKey: apiKey}
+
+	var account login.Account
+	if err := getJSON(cmd.Context(), client, "/v1/account", "", &account); err != nil {
+		identity.AccountError = err.Error()
+	} else {
+		identity.AccountID = account.ID
+		identity.AccountName = account.Settings.Dashboard.DisplayName
+	}
+
+	if identity.Type == "restricted" {
+		identity.Scopes = probeKeyScopes(cmd.Context(), client)
+	}
+
+	if strings.ToUpper(wc.format) == outputFormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(identity)
+	}
+
+	return printKeyIdentity(identity)
+}
+
+func printKeyIdentity(identity *keyIdentity) error {
+	expires := "unknown"
+	if identity.ExpiresAt != nil {
+		days := int(time.Until(*identity.ExpiresAt).Hours() / 24)
+		if identity.ExpiresAt.Before(time.Now()) {
+			expires = fmt.Sprintf("%s (expired)", identity.ExpiresAt.Format("2006-01-02"))
+		} else {
+			expires = fmt.Sprintf("%s (in %d days)", identity.ExpiresAt.Format("2006-01-02"), days)
+		}
+	}
+
+	account := identity.AccountID
+	if identity.AccountName != "" {
+		account = fmt.Sprintf("%s (%s)", identity.AccountName, identity.AccountID)
+	}
+
+	if identity.AccountError != "" {
+		account = "unknown: " + identity.AccountError
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Profile:\t%s\n", identity.Profile)
+	fmt.Fprintf(w, "Key source:\t%s\n", identity.Source)
+	fmt.Fprintf(w, "Key:\t%s\n", identity.Key)
+	fmt.Fprintf(w, "Type:\t%s\n", identity.Type)
+	fmt.Fprintf(w, "Mode:\t%s\n", identity.Mode)
+	fmt.Fprintf(w, "Expires:\t%s\n", expires)
+	fmt.Fprintf(w, "Account:\t%s\n", account)
+
+	if len(identity.Scopes) > 0 {
+		var readable, denied, unknown []string
+
+		for _, scope := range identity.Scopes {
+			switch {
+			case scope.Error != "":
+				unknown = append(unknown, scope.Resource)
+			case scope.Read:
+				readable = append(readable, scope.Resource)
+			default:
+				denied = append(denied, scope.Resource)
+			}
+		}
+
+		fmt.Fprintf(w, "Can read:\t%s\n", listOrDefault(readable, "none of the checked resources"))
+		fmt.Fprintf(w, "Can't read:\t%s\n", listOrDefault(denied, "none of the checked resources"))
+
+		if len(unknown) > 0 {
+			fmt.Fprintf(w, "Couldn't check:\t%s\n", strings.Join(unknown, ", "))
+		}
+	}
+
+	return w.Flush()
+}
+
+// keyType returns the type of an API key from its prefix
+func keyType(apiKey string) string {
+	switch {
+	case strings.HasPrefix(apiKey, "sk_"):
+		return "secret"
+	case strings.HasPrefix(apiKey, "rk_"):
+		return "restricted"
+	case strings.HasPrefix(apiKey, "pk_"):
+		return "publishable"
+	default:
+		return "unknown"
+	}
+}
+
+// keyMode returns the mode of an API key from its prefix
+func keyMode(apiKey string) string {
+	if strings.Contains(apiKey, "_live_") {
+		return "live"
+	}
+
+	return "test"
+}
+
+// probeKeyScopes checks the read access of a key to restrictedKeyProbes
+func probeKeyScopes(ctx context.Context, client *stripe.Client) []keyScope {
+	var wg sync.WaitGroup
+
+	scopes := make([]keyScope, len(restrictedKeyProbes))
+
+	for i, resource := range restrictedKeyProbes {
+		scopes[i].Resource = resource
+
+		wg.Add(1)
+
+		go func(scope *keyScope) {
+			defer wg.Done()
+
+			params := "limit=1"
+			if scope.Resource == "balance" {
+				params = ""
+			}
+
+			// Clients lazily create their HTTP client, so each goroutine
+			// uses its own
+			probeClient := &stripe.Client{BaseURL: client.BaseURL, APIKey: client.APIKey}
+
+			err := getJSON(ctx, probeClient, "/v1/"+scope.Resource, params, nil)
+
+			var statusErr *httpStatusError
+
+			switch {
+			case err == nil:
+				scope.Read = true
+			case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusForbidden || statusErr.StatusCode == http.StatusUnauthorized):
+				scope.Read = false
+			default:
+				scope.Error = err.Error()
+			}
+		}(&scopes[i])
+	}
+
+	wg.Wait()
+
+	return scopes
+}
+
+// httpStatusError is returned by getJSON for unsuccessful responses
+type httpStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("unexpected status %d", e.StatusCode)
+}
+
+// getJSON sends a GET request, decoding the response into v if it's not nil
+func getJSON(ctx context.Context, client *stripe.Client, path, params string, v interface{}) error {
+	resp, err := client.PerformRequest(ctx, http.MethodGet, path, params, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+
+		json.NewDecoder(resp.Body).Decode(&body)
+
+		return &httpStatusError{StatusCode: resp.StatusCode, Message: body.Error.Message}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
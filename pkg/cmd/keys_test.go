@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+func TestKeyTypeAndMode(t *testing.T) {
+	require.Equal(t, "secret", keyType("sk_test_123"))
+	require.Equal(t, "restricted", keyType("rk_live_123"))
+	require.Equal(t, "publishable", keyType("pk_test_123"))
+	require.Equal(t, "unknown", keyType("whsec_123"))
+
+	require.Equal(t, "test", keyMode("sk_test_123"))
+	require.Equal(t, "live", keyMode("rk_live_123"))
+}
+
+func TestProbeKeyScopes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/customers", "/v1/charges":
+			require.Equal(t, "1", r.URL.Query().Get("limit"))
+			w.Write([]byte(`{"object": "list", "data": []}`))
+		case "/v1/balance":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": {"message": "Something went wrong"}}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"message": "The provided key does not have the required permissions"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	scopes := probeKeyScopes(context.Background(), &stripe.Client{BaseURL: baseURL, APIKey: "rk_test_123"})
+
+	require.Len(t, scopes, len(restrictedKeyProbes))
+
+	for _, scope := range scopes {
+		switch scope.Resource {
+		case "customers", "charges":
+			require.True(t, scope.Read, scope.Resource)
+		case "balance":
+			require.Equal(t, "Something went wrong", scope.Error)
+		default:
+			require.False(t, scope.Read, scope.Resource)
+			require.Empty(t, scope.Error, scope.Resource)
+		}
+	}
+}
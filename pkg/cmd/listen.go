@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/signal"
+	"path/filepath"
 	"strings"
-	"syscall"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
+	"github.com/logrusorgru/aurora"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/clipboard"
+	"github.com/stripe/stripe-cli/pkg/correlation"
+	"github.com/stripe/stripe-cli/pkg/eventformat"
+	"github.com/stripe/stripe-cli/pkg/gha"
+	"github.com/stripe/stripe-cli/pkg/heartbeat"
+	"github.com/stripe/stripe-cli/pkg/listenui"
+	"github.com/stripe/stripe-cli/pkg/notify"
 	"github.com/stripe/stripe-cli/pkg/proxy"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
@@ -25,6 +33,8 @@ const webhooksWebSocketFeature = "webhooks"
 const timeLayout = "2006-01-02 15:04:05"
 const outputFormatJSON = "JSON"
 
+const outputFormatPretty = "PRETTY"
+
 type listenCmd struct {
 	cmd *cobra.Command
 
@@ -38,11 +48,21 @@ type listenCmd struct {
 	useConfiguredWebhooks bool
 	printJSON             bool
 	format                string
+	unfold                []string
 	skipVerify            bool
+	compress              bool
+	queueSize             int
+	queuePolicy           string
+	deadLetterFile        string
 	onlyPrintSecret       bool
+	copySecret            bool
 	skipUpdate            bool
 	apiBaseURL            string
 	noWSS                 bool
+	heartbeatFile         string
+	heartbeatInterval     time.Duration
+	uiAddr                string
+	tuning                websocket.Tuning
 }
 
 func newListenCmd() *listenCmd {
@@ -55,29 +75,61 @@ func newListenCmd() *listenCmd {
 		Long: `The listen command watches and forwards webhook events from Stripe to your
 local machine by connecting directly to Stripe's API. You can test the latest
 API version, filter events, or even load your saved webhook endpoints from your
-Stripe account.`,
+Stripe account.
+
+When running in a terminal, you can type session commands followed by Enter
+while listening:
+  p          pause or resume forwarding events
+  r          replay the last event to your endpoints
+  f [events] show or change the events listened to
+  c          clear the screen
+  s          show session statistics
+  h          show the list of commands
+
+With --ui, a local web page lists the received events, lets you inspect their
+payloads and replay them to your endpoints.
+
+When the API key of the project changes in the config file, e.g. after a new
+` + "`stripe login`" + `, listen reconnects with it. A new forward_url isn't picked up:
+restart listen to forward events to it.`,
 		Example: `stripe listen
   stripe listen --events charge.captured,charge.updated \
-    --forward-to localhost:3000/events`,
+    --forward-to localhost:3000/events
+  stripe listen --forward-to localhost:3000/events --ui :4500`,
 		RunE: lc.runListenCmd,
 	}
 
 	lc.cmd.Flags().StringSliceVar(&lc.forwardConnectHeaders, "connect-headers", []string{}, "A comma-separated list of custom headers to forward for Connect. Ex: \"Key1:Value1, Key2:Value2\"")
 	lc.cmd.Flags().StringSliceVarP(&lc.events, "events", "e", []string{"*"}, "A comma-separated list of specific events to listen for. For a list of all possible events, see: https://stripe.com/docs/api/events/types")
-	lc.cmd.Flags().StringVarP(&lc.forwardURL, "forward-to", "f", "", "The URL to forward webhook events to")
+	lc.cmd.Flags().StringVarP(&lc.forwardURL, "forward-to", "f", "", "The URL to forward webhook events to (default: the forward_url of the project, see stripe init)")
 	lc.cmd.Flags().StringSliceVarP(&lc.forwardHeaders, "headers", "H", []string{}, "A comma-separated list of custom headers to forward. Ex: \"Key1:Value1, Key2:Value2\"")
 	lc.cmd.Flags().StringVarP(&lc.forwardConnectURL, "forward-connect-to", "c", "", "The URL to forward Connect webhook events to (default: same as normal events)")
 	lc.cmd.Flags().BoolVarP(&lc.latestAPIVersion, "latest", "l", false, "Receive events formatted with the latest API version (default: your account's default API version)")
 	lc.cmd.Flags().BoolVar(&lc.livemode, "live", false, "Receive live events (default: test)")
 	lc.cmd.Flags().BoolVarP(&lc.printJSON, "print-json", "j", false, "Print full JSON objects to stdout.")
-	lc.cmd.Flags().MarkDeprecated("print-json", "Please use `--format JSON` instead and use `jq` if you need to process the JSON in the terminal.")
+	markDeprecated(lc.cmd.Flags(), "print-json", "Please use `--format JSON` instead and use `jq` if you need to process the JSON in the terminal.")
 	lc.cmd.Flags().StringVar(&lc.format, "format", "", `Specifies the output format of webhook events
 	Acceptable values:
-		'JSON' - Output webhook events in JSON format`)
+		'JSON' - Output webhook events in JSON format
+		'pretty' - Output webhook events in JSON for humans, with rarely useful sections folded`)
+	lc.cmd.Flags().StringSliceVar(&lc.unfold, "unfold", []string{}, "A comma-separated list of the sections of --format pretty to unfold, e.g. previous_attributes, or \"all\"")
 	lc.cmd.Flags().BoolVarP(&lc.useConfiguredWebhooks, "use-configured-webhooks", "a", false, "Load webhook endpoint configuration from the webhooks API/dashboard")
 	lc.cmd.Flags().BoolVarP(&lc.skipVerify, "skip-verify", "", false, "Skip certificate verification when forwarding to HTTPS endpoints")
+	lc.cmd.Flags().BoolVar(&lc.compress, "compress", false, "Compress large forwarded payloads with gzip. Your endpoint must decompress them before verifying signatures")
+	lc.cmd.Flags().IntVar(&lc.queueSize, "queue-size", proxy.DefaultQueueSize, "Maximum number of events waiting to be forwarded to slow endpoints")
+	lc.cmd.Flags().StringVar(&lc.queuePolicy, "queue-policy", proxy.QueuePolicyBlock, `What to do with new events when the queue is full
+	Acceptable values:
+		'block'       - Wait for room in the queue
+		'drop-oldest' - Drop the oldest event waiting to be forwarded
+		'dead-letter' - Write the new event to --dead-letter-file instead`)
+	lc.cmd.Flags().StringVar(&lc.deadLetterFile, "dead-letter-file", "", "File events are appended to with --queue-policy dead-letter (default: listen_dead_letters.jsonl in the config directory)")
 	lc.cmd.Flags().BoolVar(&lc.onlyPrintSecret, "print-secret", false, "Only print the webhook signing secret and exit")
+	lc.cmd.Flags().BoolVar(&lc.copySecret, "copy", false, "Copy the webhook signing secret to the clipboard")
 	lc.cmd.Flags().BoolVarP(&lc.skipUpdate, "skip-update", "s", false, "Skip checking latest version of Stripe CLI")
+	lc.cmd.Flags().StringVar(&lc.heartbeatFile, "heartbeat-file", "", "Periodically write the session health as JSON to this file, e.g. for liveness probes")
+	lc.cmd.Flags().DurationVar(&lc.heartbeatInterval, "heartbeat-interval", heartbeat.DefaultInterval, "Time between two heartbeats written to --heartbeat-file")
+	lc.cmd.Flags().StringVar(&lc.uiAddr, "ui", "", "Serve a web page listing the received events at this address, e.g. :4500")
+	lc.tuning.AddFlags(lc.cmd.Flags())
 
 	// Hidden configuration flags, useful for dev/debugging
 	lc.cmd.Flags().StringVar(&lc.apiBaseURL, "api-base", "", "Sets the API base URL")
@@ -101,7 +153,11 @@ Stripe account.`,
 // but since it's acting as the core functionality for the cmd above, I'm keeping it close.
 func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 	if !lc.printJSON && !lc.onlyPrintSecret && !lc.skipUpdate {
-		version.CheckLatestVersion()
+		version.CheckLatestVersion(cmd.Context())
+	}
+
+	if err := lc.tuning.Load(cmd.Flags()); err != nil {
+		return err
 	}
 
 	deviceName, err := Config.Profile.GetDeviceName()
@@ -114,11 +170,7 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := withSIGTERMCancel(cmd.Context(), func() {
-		log.WithFields(log.Fields{
-			"prefix": "proxy.Proxy.Run",
-		}).Debug("Ctrl+C received, cleaning up...")
-	})
+	ctx := cmd.Context()
 
 	// --print-secret option
 	if lc.onlyPrintSecret {
@@ -127,11 +179,35 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		fmt.Printf("%s\n", secret)
+
+		if lc.copySecret {
+			return copyWebhookSecret(secret)
+		}
+
 		return nil
 	}
 
+	// Have the other commands record their requests, to annotate the events
+	// they cause
+	stopLookups := correlation.StartLookups()
+	defer stopLookups()
+
+	forwardURLFromProfile := lc.forwardURL == "" && !lc.useConfiguredWebhooks
+	if forwardURLFromProfile {
+		lc.forwardURL = Config.Profile.GetForwardURL()
+	}
+
+	notifier, err := notify.Load()
+	if err != nil {
+		return err
+	}
+
 	logger := log.StandardLogger()
-	proxyVisitor := createVisitor(logger, lc.format, lc.printJSON)
+	proxyVisitor := createVisitor(logger, lc.format, lc.unfold, lc.printJSON)
+	proxyVisitor.VisitError = notifyForwardFailures(ctx, notifier, proxyVisitor.VisitError)
+	if lc.copySecret {
+		proxyVisitor.VisitStatus = copySecretWhenReady(proxyVisitor.VisitStatus)
+	}
 	proxyOutCh := make(chan websocket.IElement)
 
 	p, err := proxy.Init(ctx, &proxy.Config{
@@ -147,8 +223,13 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 		PrintJSON:             lc.printJSON,
 		UseLatestAPIVersion:   lc.latestAPIVersion,
 		SkipVerify:            lc.skipVerify,
+		Compress:              lc.compress,
+		QueueSize:             lc.queueSize,
+		QueuePolicy:           lc.queuePolicy,
+		DeadLetterFile:        lc.deadLetterFilePath(),
 		Log:                   logger,
 		NoWSS:                 lc.noWSS,
+		Tuning:                lc.tuning,
 		Events:                lc.events,
 		OutCh:                 proxyOutCh,
 	})
@@ -156,9 +237,41 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if lc.uiAddr != "" {
+		url, err := listenui.New(p).Listen(ctx, lc.uiAddr)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Event viewer available at %s\n", url)
+	}
+
+	onKeyChange := reauthorizeOnKeyChange(p, key, lc.livemode)
+	onForwardURLChange := func() {}
+	if forwardURLFromProfile {
+		onForwardURLChange = warnOnForwardURLChange(lc.forwardURL)
+	}
+
+	Config.WatchConfig(func() {
+		onKeyChange()
+		onForwardURLChange()
+	})
+
 	go p.Run(ctx)
 
+	if shouldReadListenCommands(lc.format, lc.printJSON) {
+		go readListenCommands(ctx, os.Stdin, os.Stdout, p)
+	}
+
+	monitor := heartbeat.Start(ctx, &heartbeat.Config{
+		Path:     lc.heartbeatFile,
+		Interval: lc.heartbeatInterval,
+		Log:      logger,
+	})
+
 	for el := range proxyOutCh {
+		monitor.Observe(el)
+
 		err := el.Accept(proxyVisitor)
 		if err != nil {
 			return err
@@ -168,29 +281,134 @@ func (lc *listenCmd) runListenCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func withSIGTERMCancel(ctx context.Context, onCancel func()) context.Context {
-	// Create a context that will be canceled when Ctrl+C is pressed
-	ctx, cancel := context.WithCancel(ctx)
+// deadLetterFilePath returns the file events are written to with the
+// dead-letter queue policy.
+func (lc *listenCmd) deadLetterFilePath() string {
+	if lc.deadLetterFile != "" {
+		return lc.deadLetterFile
+	}
+
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "listen_dead_letters.jsonl")
+}
+
+// reauthorizeOnKeyChange returns a config reload callback that reconnects
+// the session when the API key of the profile changed, e.g. after a new
+// `stripe login`.
+func reauthorizeOnKeyChange(session interface{ Reauthorize(key string) }, key string, livemode bool) func() {
+	var mu sync.Mutex
 
-	interruptCh := make(chan os.Signal, 1)
-	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
+	return func() {
+		newKey, err := Config.Profile.GetAPIKey(livemode)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err != nil || newKey == key {
+			return
+		}
 
-	go func() {
-		<-interruptCh
-		onCancel()
-		cancel()
-	}()
-	return ctx
+		key = newKey
+
+		fmt.Fprintln(os.Stderr, ansi.Color(os.Stderr).Yellow("The API key changed in your config file, reconnecting with the new key..."))
+		session.Reauthorize(newKey)
+	}
 }
 
-func createVisitor(logger *log.Logger, format string, printJSON bool) *websocket.Visitor {
+// warnOnForwardURLChange returns a config reload callback telling that the
+// forward_url of the profile changed. Unlike the API key, it isn't reloaded
+// since the endpoints are set up when listen starts.
+func warnOnForwardURLChange(forwardURL string) func() {
+	var mu sync.Mutex
+
+	return func() {
+		newURL := Config.Profile.GetForwardURL()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if newURL == forwardURL {
+			return
+		}
+
+		forwardURL = newURL
+
+		fmt.Fprintln(os.Stderr, ansi.Color(os.Stderr).Yellow("The forward_url changed in your config file, restart stripe listen to forward events to it."))
+	}
+}
+
+// requestAnnotation links an event to the API request that caused it.
+func requestAnnotation(color aurora.Aurora, requestID string) string {
+	if requestID == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(" <-- [%s]%s", requestID, commandAnnotation(color, requestID))
+}
+
+// commandAnnotation returns the CLI command that made the request, when it
+// is in the correlation index.
+func commandAnnotation(color aurora.Aurora, requestID string) string {
+	entry, ok := correlation.Lookup(requestID)
+	if !ok || entry.Command == "" {
+		return ""
+	}
+
+	return " " + color.Sprintf(color.Faint("("+entry.Command+")"))
+}
+
+// notifyForwardFailures sends a notification when an event can't be
+// forwarded, before handing the error to visitError.
+func notifyForwardFailures(ctx context.Context, notifier *notify.Notifier, visitError func(websocket.ErrorElement) error) func(websocket.ErrorElement) error {
+	return func(ee websocket.ErrorElement) error {
+		if _, ok := ee.Error.(proxy.FailedToPostError); ok {
+			go notifier.Notify(ctx, notify.ListenForwardFailed, "Webhook forwarding failed", ee.Error.Error())
+		}
+
+		return visitError(ee)
+	}
+}
+
+// copySecretWhenReady copies the webhook signing secret to the clipboard
+// once the session is ready, after handing the status to visitStatus.
+func copySecretWhenReady(visitStatus func(websocket.StateElement) error) func(websocket.StateElement) error {
+	return func(se websocket.StateElement) error {
+		if err := visitStatus(se); err != nil {
+			return err
+		}
+
+		if se.State == websocket.Ready {
+			if err := copyWebhookSecret(se.Data[1]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func copyWebhookSecret(secret string) error {
+	if err := clipboard.Copy(secret); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Copied the webhook signing secret to the clipboard.")
+
+	return nil
+}
+
+func createVisitor(logger *log.Logger, format string, unfold []string, printJSON bool) *websocket.Visitor {
 	var s *spinner.Spinner
 
 	return &websocket.Visitor{
 		VisitError: func(ee websocket.ErrorElement) error {
-			ansi.StopSpinner(s, "", logger.Out)
+			ansi.StopSpinner(s, "", os.Stderr)
 			switch ee.Error.(type) {
 			case proxy.FailedToPostError:
+				if ghaOutput() {
+					gha.Error(os.Stdout, fmt.Sprintf("Failed to POST: %v", ee.Error))
+					return nil
+				}
+
 				color := ansi.Color(os.Stdout)
 				localTime := time.Now().Format(timeLayout)
 
@@ -224,22 +442,36 @@ func createVisitor(logger *log.Logger, format string, printJSON bool) *websocket
 		VisitStatus: func(se websocket.StateElement) error {
 			switch se.State {
 			case websocket.Loading:
-				s = ansi.StartNewSpinner("Getting ready...", logger.Out)
+				s = ansi.StartNewSpinner("Getting ready...", os.Stderr)
 			case websocket.Reconnecting:
-				ansi.StartSpinner(s, "Session expired, reconnecting...", logger.Out)
+				ansi.StartSpinner(s, "Session expired, reconnecting...", os.Stderr)
 			case websocket.Ready:
-				ansi.StopSpinner(s, fmt.Sprintf("Ready! %sYour webhook signing secret is %s (^C to quit)", se.Data[0], ansi.Bold(se.Data[1])), logger.Out)
+				if ghaOutput() {
+					gha.Mask(os.Stderr, se.Data[1])
+				}
+				ansi.StopSpinner(s, fmt.Sprintf("Ready! %sYour webhook signing secret is %s (^C to quit)", se.Data[0], ansi.Bold(se.Data[1])), os.Stderr)
 			case websocket.Done:
-				ansi.StopSpinner(s, "", logger.Out)
+				ansi.StopSpinner(s, "", os.Stderr)
 			}
 			return nil
 		},
 		VisitData: func(de websocket.DataElement) error {
 			switch data := de.Data.(type) {
 			case proxy.StripeEvent:
-				if strings.ToUpper(format) == outputFormatJSON || printJSON {
+				switch {
+				case strings.ToUpper(format) == outputFormatJSON || printJSON:
 					fmt.Println(de.Marshaled)
-				} else {
+				case strings.ToUpper(format) == outputFormatPretty:
+					formatted, err := eventformat.Format([]byte(data.Payload), eventformat.Options{
+						Unfold: unfold,
+						Color:  ansi.Color(os.Stdout),
+					})
+					if err != nil {
+						return err
+					}
+
+					fmt.Print(formatted)
+				default:
 					maybeConnect := ""
 					if data.IsConnect() {
 						maybeConnect = "connect "
@@ -251,10 +483,10 @@ func createVisitor(logger *log.Logger, format string, printJSON bool) *websocket
 					outputStr := fmt.Sprintf("%s   --> %s%s [%s]",
 						color.Faint(localTime),
 						maybeConnect,
-						ansi.Linkify(ansi.Bold(data.Type), data.URLForEventType(), logger.Out),
-						ansi.Linkify(data.ID, data.URLForEventID(), logger.Out),
+						ansi.Linkify(ansi.Bold(data.Type), data.URLForEventType(), os.Stdout),
+						ansi.Linkify(data.ID, data.URLForEventID(), os.Stdout),
 					)
-					fmt.Println(outputStr)
+					fmt.Println(outputStr + requestAnnotation(color, data.Request.ID))
 				}
 				return nil
 			case proxy.EndpointResponse:
@@ -262,13 +494,18 @@ func createVisitor(logger *log.Logger, format string, printJSON bool) *websocket
 				resp := data.Resp
 				localTime := time.Now().Format(timeLayout)
 
+				if ghaOutput() && resp.StatusCode >= 400 {
+					gha.Warning(os.Stdout, fmt.Sprintf("%s %s returned %d for %s [%s]", resp.Request.Method, resp.Request.URL, resp.StatusCode, event.Type, event.ID))
+				}
+
 				color := ansi.Color(os.Stdout)
-				outputStr := fmt.Sprintf("%s  <--  [%d] %s %s [%s]",
+				outputStr := fmt.Sprintf("%s  <--  %s [%d] %s %s [%s]",
 					color.Faint(localTime),
+					ansi.StatusGlyph(resp.StatusCode),
 					ansi.ColorizeStatus(resp.StatusCode),
 					resp.Request.Method,
 					resp.Request.URL,
-					ansi.Linkify(event.ID, event.URLForEventID(), logger.Out),
+					ansi.Linkify(event.ID, event.URLForEventID(), os.Stdout),
 				)
 				fmt.Println(outputStr)
 				return nil
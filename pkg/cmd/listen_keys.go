@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+const listenCommandsHelp = `Session commands (type a letter and press Enter):
+  p          pause or resume forwarding events
+  r          replay the last event to your endpoints
+  f [events] show or change the events listened to, e.g. "f charge.succeeded,invoice.paid"
+  c          clear the screen
+  s          show session statistics
+  h          show this help`
+
+// listenSession is the subset of the proxy driven by interactive commands.
+type listenSession interface {
+	TogglePause() bool
+	Replay() (*proxy.StripeEvent, error)
+	SetEvents(events []string) error
+	Stats() proxy.SessionStats
+}
+
+// readListenCommands reads session commands line by line from in until it is
+// closed or ctx is canceled. Stdin is read in cooked mode so the event output
+// printed in the meantime is not garbled.
+func readListenCommands(ctx context.Context, in io.Reader, out io.Writer, session listenSession) {
+	lines := make(chan string)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+
+			runListenCommand(line, out, session)
+		}
+	}
+}
+
+func runListenCommand(line string, out io.Writer, session listenSession) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+
+	command, arg := line, ""
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		command, arg = line[:i], strings.TrimSpace(line[i+1:])
+	}
+
+	color := ansi.Color(out)
+
+	switch strings.ToLower(command) {
+	case "p":
+		if session.TogglePause() {
+			fmt.Fprintln(out, color.Yellow("Forwarding paused, events will be displayed but not forwarded. Type p to resume."))
+		} else {
+			fmt.Fprintln(out, color.Green("Forwarding resumed."))
+		}
+	case "r":
+		evt, err := session.Replay()
+		if err != nil {
+			fmt.Fprintf(out, "Cannot replay: %v\n", err)
+			return
+		}
+
+		fmt.Fprintf(out, "Replaying %s [%s]\n", ansi.Bold(evt.Type), evt.ID)
+	case "f":
+		if arg != "" {
+			if err := session.SetEvents(splitEventTypes(arg)); err != nil {
+				fmt.Fprintf(out, "Cannot change the events: %v\n", err)
+				return
+			}
+		}
+
+		fmt.Fprintf(out, "Listening for: %s\n", strings.Join(session.Stats().Events, ", "))
+	case "c":
+		ansi.ClearScreen(out)
+	case "s":
+		printSessionStats(out, session.Stats())
+	case "h", "?":
+		fmt.Fprintln(out, listenCommandsHelp)
+	default:
+		fmt.Fprintf(out, "Unknown command %q. Type h for help.\n", command)
+	}
+}
+
+func splitEventTypes(arg string) []string {
+	return strings.FieldsFunc(arg, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+func printSessionStats(out io.Writer, stats proxy.SessionStats) {
+	fmt.Fprintf(out, "Session started %s ago\n", time.Since(stats.StartedAt).Round(time.Second))
+
+	if stats.LastEventAt.IsZero() {
+		fmt.Fprintln(out, "Last event:       none")
+	} else {
+		fmt.Fprintf(out, "Last event:       %s\n", stats.LastEventAt.Format(timeLayout))
+	}
+
+	fmt.Fprintf(out, "Events received:  %d\n", stats.EventsReceived)
+	fmt.Fprintf(out, "Events skipped:   %d\n", stats.EventsSkipped)
+	fmt.Fprintf(out, "Events replayed:  %d\n", stats.Replays)
+	fmt.Fprintf(out, "Requests sent:    %d (%d failed)\n", stats.EventsForwarded, stats.ForwardErrors)
+	fmt.Fprintf(out, "Queued events:    %d/%d (%d dropped, %d dead-lettered)\n", stats.QueueDepth, stats.QueueCapacity, stats.EventsDropped, stats.EventsDeadLettered)
+	fmt.Fprintf(out, "Reconnects:       %d (%s down)\n", stats.Reconnects, stats.Downtime.Round(time.Millisecond))
+
+	statuses := make([]int, 0, len(stats.ResponseStatuses))
+	for status := range stats.ResponseStatuses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+
+	for _, status := range statuses {
+		fmt.Fprintf(out, "  [%d] %d\n", ansi.ColorizeStatus(status), stats.ResponseStatuses[status])
+	}
+
+	forwarding := "active"
+	if stats.Paused {
+		forwarding = "paused"
+	}
+
+	fmt.Fprintf(out, "Forwarding:       %s\n", forwarding)
+	fmt.Fprintf(out, "Listening for:    %s\n", strings.Join(stats.Events, ", "))
+}
+
+// shouldReadListenCommands returns true when stdin is an interactive terminal
+// and the output is meant for humans.
+func shouldReadListenCommands(format string, printJSON bool) bool {
+	if strings.ToUpper(format) == outputFormatJSON || printJSON {
+		return false
+	}
+
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
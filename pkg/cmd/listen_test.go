@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReauthorizer struct {
+	keys []string
+}
+
+func (r *fakeReauthorizer) Reauthorize(key string) {
+	r.keys = append(r.keys, key)
+}
+
+func TestReauthorizeOnKeyChange(t *testing.T) {
+	previousKey := Config.Profile.APIKey
+	defer func() { Config.Profile.APIKey = previousKey }()
+
+	session := &fakeReauthorizer{}
+	Config.Profile.APIKey = "sk_test_1234567890"
+	reload := reauthorizeOnKeyChange(session, "sk_test_1234567890", false)
+
+	reload()
+	require.Empty(t, session.keys)
+
+	Config.Profile.APIKey = "sk_test_0987654321"
+	reload()
+	reload()
+	require.Equal(t, []string{"sk_test_0987654321"}, session.keys)
+}
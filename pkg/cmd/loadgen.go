@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/loadgen"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type loadgenCmd struct {
+	cmd *cobra.Command
+
+	scenarios     []string
+	rate          float64
+	duration      time.Duration
+	rampUp        time.Duration
+	concurrency   int
+	format        string
+	stripeAccount string
+	apiBaseURL    string
+}
+
+func newLoadgenCmd() *loadgenCmd {
+	lc := &loadgenCmd{}
+
+	lc.cmd = &cobra.Command{
+		Use:   "loadgen",
+		Args:  validators.NoArgs,
+		Short: "Generate test mode load from a weighted mix of triggers and fixtures",
+		Long: `The loadgen command runs a weighted mix of triggers and fixtures at a target
+rate, to capacity-test your webhook consumers and services with realistic
+events. Scenarios are picked at random according to their weight, the rate
+ramps up linearly during --ramp-up, and scenarios that would exceed
+--concurrency are dropped. A latency and error report is printed at the end.
+
+Scenarios are trigger event names or paths to fixture files, optionally
+followed by =<weight>. Load can only be generated in test mode.`,
+		Example: `stripe loadgen --scenario payment_intent.succeeded=3 --scenario customer.created \
+    --rate 2 --duration 5m --ramp-up 30s
+  stripe loadgen --scenario ./checkout.json --rate 0.5 --duration 1m --format JSON`,
+		RunE: lc.runLoadgenCmd,
+	}
+
+	lc.cmd.Flags().StringArrayVar(&lc.scenarios, "scenario", []string{}, "A trigger event or fixture file to run, with an optional weight. Ex: \"charge.succeeded=3\"")
+	lc.cmd.Flags().Float64Var(&lc.rate, "rate", 1, "Number of scenarios to start per second")
+	lc.cmd.Flags().DurationVar(&lc.duration, "duration", time.Minute, "How long to generate load for")
+	lc.cmd.Flags().DurationVar(&lc.rampUp, "ramp-up", 0, "Time over which the rate increases up to --rate")
+	lc.cmd.Flags().IntVar(&lc.concurrency, "concurrency", loadgen.DefaultConcurrency, "Maximum number of scenarios running at the same time")
+	lc.cmd.Flags().StringVar(&lc.format, "format", "", `Specifies the output format of the report
+	Acceptable values:
+		'JSON' - Output the report in JSON format`)
+	lc.cmd.Flags().StringVar(&lc.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
+	addNotifyFlag(lc.cmd)
+
+	// Hidden configuration flags, useful for dev/debugging
+	lc.cmd.Flags().StringVar(&lc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	lc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return lc
+}
+
+func (lc *loadgenCmd) runLoadgenCmd(cmd *cobra.Command, args []string) error {
+	if len(lc.scenarios) == 0 {
+		return fmt.Errorf("at least one --scenario is required")
+	}
+
+	if lc.format != "" && strings.ToUpper(lc.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, the only supported format is JSON", lc.format)
+	}
+
+	scenarios := make([]loadgen.Scenario, 0, len(lc.scenarios))
+
+	for _, value := range lc.scenarios {
+		scenario, err := loadgen.ParseScenario(value)
+		if err != nil {
+			return err
+		}
+
+		scenarios = append(scenarios, scenario)
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(apiKey, "_live_") {
+		return fmt.Errorf("loadgen can only be used with a test mode API key")
+	}
+
+	fs := afero.NewOsFs()
+
+	// Build every scenario once up front so that typos fail fast
+	for _, scenario := range scenarios {
+		if _, err := fixtures.BuildFromEvent(fs, apiKey, lc.stripeAccount, lc.apiBaseURL, scenario.Name, nil, nil, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Generating load at %g scenarios/s for %s (^C to stop early)...\n", lc.rate, lc.duration)
+
+	report, err := loadgen.Run(cmd.Context(), &loadgen.Config{
+		Scenarios:   scenarios,
+		Rate:        lc.rate,
+		Duration:    lc.duration,
+		RampUp:      lc.rampUp,
+		Concurrency: lc.concurrency,
+		Run: func(ctx context.Context, name string) error {
+			fixture, err := fixtures.BuildFromEvent(fs, apiKey, lc.stripeAccount, lc.apiBaseURL, name, nil, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+
+			fixture.SuppressOutput = true
+
+			_, err = fixture.Execute(ctx)
+
+			return err
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Scenarios are trigger events, count them in `stripe trigger coverage`
+	for name, stats := range report.Scenarios {
+		if stats.Count > stats.Errors {
+			fixtures.RecordTrigger(triggerHistoryPath(), name) // #nosec G104
+		}
+	}
+
+	if strings.ToUpper(lc.format) == outputFormatJSON {
+		data, err := report.JSON()
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
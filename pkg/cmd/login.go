@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +18,9 @@ type loginCmd struct {
 	cmd              *cobra.Command
 	interactive      bool
 	dashboardBaseURL string
+
+	session           time.Duration
+	sessionBlockReads bool
 }
 
 func newLoginCmd() *loginCmd {
@@ -23,10 +30,18 @@ func newLoginCmd() *loginCmd {
 		Use:   "login",
 		Args:  validators.NoArgs,
 		Short: "Login to your Stripe account",
-		Long:  `Login to your Stripe account to setup the CLI`,
-		RunE:  lc.runLoginCmd,
+		Long: `Login to your Stripe account to setup the CLI.
+
+On shared or demo machines, --session time-boxes the login: once it expires,
+the commands changing objects require logging in again, and with
+--session-block-reads all the other commands too.`,
+		Example: `stripe login
+  stripe login --session 8h`,
+		RunE: lc.runLoginCmd,
 	}
 	lc.cmd.Flags().BoolVarP(&lc.interactive, "interactive", "i", false, "Run interactive configuration mode if you cannot open a browser")
+	lc.cmd.Flags().DurationVar(&lc.session, "session", 0, "Expire the login after this duration, e.g. 8h")
+	lc.cmd.Flags().BoolVar(&lc.sessionBlockReads, "session-block-reads", false, "Block all the commands after the session expires, not only the ones changing objects")
 
 	// Hidden configuration flags, useful for dev/debugging
 	lc.cmd.Flags().StringVar(&lc.dashboardBaseURL, "dashboard-base", stripe.DefaultDashboardBaseURL, "Sets the dashboard base URL")
@@ -36,9 +51,81 @@ func newLoginCmd() *loginCmd {
 }
 
 func (lc *loginCmd) runLoginCmd(cmd *cobra.Command, args []string) error {
+	if lc.session < 0 || (lc.session == 0 && lc.sessionBlockReads) {
+		return fmt.Errorf("--session must be a positive duration, e.g. 8h")
+	}
+
+	if lc.session > 0 {
+		Config.Profile.SessionExpiresAt = time.Now().Add(lc.session)
+		Config.Profile.SessionBlockReads = lc.sessionBlockReads
+	}
+
+	var err error
 	if lc.interactive {
-		return login.InteractiveLogin(cmd.Context(), &Config)
+		err = login.InteractiveLogin(cmd.Context(), &Config)
+	} else {
+		err = login.Login(cmd.Context(), lc.dashboardBaseURL, &Config, os.Stdin)
+	}
+
+	if err == nil && lc.session > 0 {
+		fmt.Printf("This login session expires at %s.\n", Config.Profile.SessionExpiresAt.Format(time.RFC1123))
+	}
+
+	return err
+}
+
+// loginSessionExemptCommands can run after the login session expires, so
+// that users can log in again. stripe config can't change the fields of the
+// session.
+var loginSessionExemptCommands = []string{"alias", "experiments", "init", "login", "logout", "help", "version", "completion", "config", "__complete", "__completeNoDesc"}
+
+// checkLoginSession deletes the keys of the profile once its login session
+// expires, and returns an error if the session blocks cmd. The requests cmd
+// sends that aren't GET requests are failed too, whichever command sends
+// them.
+func checkLoginSession(cmd *cobra.Command) error {
+	command := commandName(cmd)
+
+	// Let logout revoke the keys before deleting them
+	if command != "logout" {
+		if deleted, err := Config.Profile.EndExpiredSession(); err != nil {
+			return fmt.Errorf("could not delete the keys of the expired login session: %w", err)
+		} else if deleted {
+			fmt.Fprintln(os.Stderr, "Your login session has expired, its keys were deleted from this machine.")
+		}
+	}
+
+	for _, exempt := range loginSessionExemptCommands {
+		if command == exempt || strings.HasPrefix(command, exempt+" ") {
+			return nil
+		}
+	}
+
+	stripe.AddRequestCheck(func(req *http.Request) error {
+		if req.Method == http.MethodGet {
+			return nil
+		}
+
+		return Config.Profile.CheckSession(true)
+	})
+
+	return Config.Profile.CheckSession(isMutatingCommand(cmd))
+}
+
+// mutatingCommands create objects, besides post, delete and the resource
+// commands. Expired login sessions fail them before they start, and check
+// the requests of the other commands when they're sent.
+var mutatingCommands = []string{"fixtures", "loadgen", "simulate billing", "terminal quickstart", "trigger"}
+
+// isMutatingCommand returns whether cmd can create or change objects
+func isMutatingCommand(cmd *cobra.Command) bool {
+	if cmd.Parent() == cmd.Root() && cmd.Root().Annotations[cmd.Name()] == "http" {
+		return cmd.Name() != "get"
+	}
+
+	if method, ok := cmd.Annotations["method"]; ok {
+		return method != http.MethodGet
 	}
 
-	return login.Login(cmd.Context(), lc.dashboardBaseURL, &Config, os.Stdin)
+	return containsString(mutatingCommands, commandName(cmd))
 }
@@ -4,12 +4,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/logout"
+	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
 type logoutCmd struct {
-	cmd *cobra.Command
-	all bool
+	cmd    *cobra.Command
+	all    bool
+	revoke bool
+
+	apiBaseURL string
 }
 
 func newLogoutCmd() *logoutCmd {
@@ -19,19 +23,31 @@ func newLogoutCmd() *logoutCmd {
 		Use:   "logout",
 		Args:  validators.NoArgs,
 		Short: "Logout of your Stripe account",
-		Long:  `Logout of your Stripe account from the CLI`,
-		RunE:  lc.runLogoutCmd,
+		Long: `Logout of your Stripe account from the CLI.
+
+The keys created by stripe login are revoked, so that they stop working even
+if they were copied from this machine. When the API doesn't support revoking
+them, they're only deleted from this machine, with a warning. Keys you
+configured yourself are only deleted from this machine.`,
+		RunE: lc.runLogoutCmd,
 	}
 
+	lc.cmd.Flags().BoolVar(&lc.all, "all-profiles", false, "Clear credentials for all projects you are currently logged into.")
 	lc.cmd.Flags().BoolVarP(&lc.all, "all", "a", false, "Clear credentials for all projects you are currently logged into.")
+	lc.cmd.Flags().MarkHidden("all") // #nosec G104
+	lc.cmd.Flags().BoolVar(&lc.revoke, "revoke", true, "Revoke the keys created by stripe login before deleting them")
+
+	// Hidden configuration flags, useful for dev/debugging
+	lc.cmd.Flags().StringVar(&lc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	lc.cmd.Flags().MarkHidden("api-base") // #nosec G104
 
 	return lc
 }
 
 func (lc *logoutCmd) runLogoutCmd(cmd *cobra.Command, args []string) error {
 	if lc.all {
-		return logout.All(&Config)
+		return logout.All(cmd.Context(), &Config, lc.apiBaseURL, lc.revoke)
 	}
 
-	return logout.Logout(&Config)
+	return logout.Logout(cmd.Context(), &Config, lc.apiBaseURL, lc.revoke)
 }
@@ -1,24 +1,23 @@
 package logs
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/signal"
 	"reflect"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/briandowns/spinner"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
-	"context"
-
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/heartbeat"
 	"github.com/stripe/stripe-cli/pkg/logtailing"
 	logTailing "github.com/stripe/stripe-cli/pkg/logtailing"
+	"github.com/stripe/stripe-cli/pkg/notify"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
 	"github.com/stripe/stripe-cli/pkg/websocket"
@@ -34,6 +33,10 @@ type TailCmd struct {
 	format     string
 	LogFilters *logTailing.LogFilters
 	noWSS      bool
+	tuning     websocket.Tuning
+
+	heartbeatFile     string
+	heartbeatInterval time.Duration
 }
 
 // NewTailCmd creates and initializes the tail command for the logs package
@@ -118,6 +121,10 @@ Acceptable values:
 	'5XX' - All 5XX status codes`,
 	)
 
+	tailCmd.Cmd.Flags().StringVar(&tailCmd.heartbeatFile, "heartbeat-file", "", "Periodically write the session health as JSON to this file, e.g. for liveness probes")
+	tailCmd.Cmd.Flags().DurationVar(&tailCmd.heartbeatInterval, "heartbeat-interval", heartbeat.DefaultInterval, "Time between two heartbeats written to --heartbeat-file")
+	tailCmd.tuning.AddFlags(tailCmd.Cmd.Flags())
+
 	// Hidden configuration flags, useful for dev/debugging
 	tailCmd.Cmd.Flags().StringVar(&tailCmd.apiBaseURL, "api-base", "", "Sets the API base URL")
 	tailCmd.Cmd.Flags().MarkHidden("api-base") // #nosec G104
@@ -128,21 +135,6 @@ Acceptable values:
 	return tailCmd
 }
 
-func withSIGTERMCancel(ctx context.Context, onCancel func()) context.Context {
-	// Create a context that will be canceled when Ctrl+C is pressed
-	ctx, cancel := context.WithCancel(ctx)
-
-	interruptCh := make(chan os.Signal, 1)
-	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-interruptCh
-		onCancel()
-		cancel()
-	}()
-	return ctx
-}
-
 func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 	err := tailCmd.validateArgs()
 	if err != nil {
@@ -154,6 +146,11 @@ func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = tailCmd.tuning.Load(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
 	deviceName, err := tailCmd.cfg.Profile.GetDeviceName()
 	if err != nil {
 		return err
@@ -164,11 +161,17 @@ func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
 	logger := log.StandardLogger()
 
+	notifier, err := notify.Load()
+	if err != nil {
+		return err
+	}
+
 	logtailingVisitor := createVisitor(logger, tailCmd.format)
+	logtailingVisitor.VisitData = notifyServerErrorSpikes(cmd.Context(), notifier, logtailingVisitor.VisitData)
 
 	logtailingOutCh := make(chan websocket.IElement)
 
@@ -179,18 +182,21 @@ func (tailCmd *TailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
 		Key:        key,
 		Log:        logger,
 		NoWSS:      tailCmd.noWSS,
+		Tuning:     tailCmd.tuning,
 		OutCh:      logtailingOutCh,
 	})
 
-	ctx := withSIGTERMCancel(cmd.Context(), func() {
-		log.WithFields(log.Fields{
-			"prefix": "logtailing.Tailer.Run",
-		}).Debug("Ctrl+C received, cleaning up...")
-	})
+	go tailer.Run(cmd.Context())
 
-	go tailer.Run(ctx)
+	monitor := heartbeat.Start(cmd.Context(), &heartbeat.Config{
+		Path:     tailCmd.heartbeatFile,
+		Interval: tailCmd.heartbeatInterval,
+		Log:      logger,
+	})
 
 	for el := range logtailingOutCh {
+		monitor.Observe(el)
+
 		err := el.Accept(logtailingVisitor)
 		if err != nil {
 			return err
@@ -245,12 +251,28 @@ func (tailCmd *TailCmd) convertArgs() error {
 	return nil
 }
 
+// notifyServerErrorSpikes sends a notification when many requests fail with
+// a 5xx status in a short time, before handing the log to visitData.
+func notifyServerErrorSpikes(ctx context.Context, notifier *notify.Notifier, visitData func(websocket.DataElement) error) func(websocket.DataElement) error {
+	detector := notifier.NewSpikeDetector()
+
+	return func(de websocket.DataElement) error {
+		if payload, ok := de.Data.(logtailing.EventPayload); ok && payload.Status >= 500 {
+			if detector.Add(time.Now()) {
+				go notifier.Notify(ctx, notify.LogsServerErrorSpike, "Spike of server errors", fmt.Sprintf("Last failing request: %s %s [%d] %s", payload.Method, payload.URL, payload.Status, payload.RequestID))
+			}
+		}
+
+		return visitData(de)
+	}
+}
+
 func createVisitor(logger *log.Logger, format string) *websocket.Visitor {
 	var s *spinner.Spinner
 
 	return &websocket.Visitor{
 		VisitError: func(ee websocket.ErrorElement) error {
-			ansi.StopSpinner(s, "", logger.Out)
+			ansi.StopSpinner(s, "", os.Stderr)
 			return ee.Error
 		},
 		VisitWarning: func(we websocket.WarningElement) error {
@@ -261,13 +283,13 @@ func createVisitor(logger *log.Logger, format string) *websocket.Visitor {
 		VisitStatus: func(se websocket.StateElement) error {
 			switch se.State {
 			case websocket.Loading:
-				s = ansi.StartNewSpinner("Getting ready...", logger.Out)
+				s = ansi.StartNewSpinner("Getting ready...", os.Stderr)
 			case websocket.Reconnecting:
-				ansi.StartSpinner(s, "Session expired, reconnecting...", logger.Out)
+				ansi.StartSpinner(s, "Session expired, reconnecting...", os.Stderr)
 			case websocket.Ready:
-				ansi.StopSpinner(s, "Ready! You're now waiting to receive API request logs (^C to quit)", logger.Out)
+				ansi.StopSpinner(s, "Ready! You're now waiting to receive API request logs (^C to quit)", os.Stderr)
 			case websocket.Done:
-				ansi.StopSpinner(s, "", logger.Out)
+				ansi.StopSpinner(s, "", os.Stderr)
 			}
 			return nil
 		},
@@ -295,7 +317,7 @@ func createVisitor(logger *log.Logger, format string) *websocket.Visitor {
 			localTime := time.Unix(int64(log.CreatedAt), 0).Format(exampleLayout)
 
 			color := ansi.Color(os.Stdout)
-			outputStr := fmt.Sprintf("%s [%d] %s %s [%s]", color.Faint(localTime), coloredStatus, log.Method, log.URL, requestLink)
+			outputStr := fmt.Sprintf("%s %s [%d] %s %s [%s]", color.Faint(localTime), ansi.StatusGlyph(log.Status), coloredStatus, log.Method, log.URL, requestLink)
 			fmt.Println(outputStr)
 
 			errorValues := reflect.ValueOf(&log.Error).Elem()
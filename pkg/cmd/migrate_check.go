@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
+	"github.com/stripe/stripe-cli/pkg/deprecation"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/spec"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// deprecatedAnnotation is the flag annotation holding the replacement of a
+// deprecated flag
+const deprecatedAnnotation = "deprecated"
+
+// scriptExtensions are the extensions of the files that may run the CLI
+var scriptExtensions = map[string]bool{
+	".bash": true,
+	".bat":  true,
+	".cmd":  true,
+	".ps1":  true,
+	".sh":   true,
+	".yaml": true,
+	".yml":  true,
+	".zsh":  true,
+}
+
+// skippedDirs are never scanned for deprecated usages
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+type migrateCheckCmd struct {
+	cmd *cobra.Command
+}
+
+func newMigrateCheckCmd() *migrateCheckCmd {
+	mc := &migrateCheckCmd{}
+
+	mc.cmd = &cobra.Command{
+		Use:   "migrate-check [dir]",
+		Args:  validators.MaximumNArgs(1),
+		Short: "Find deprecated usages of the CLI and the API in fixtures and scripts",
+		Long: `Scan the fixtures and scripts of a directory, the current one by default,
+for deprecated flags of the CLI and deprecated operations and parameters of
+the API, according to the bundled API specification.
+
+Fixtures are the JSON files with a fixtures array. Scripts are shell, batch,
+PowerShell and YAML files, Makefiles and Dockerfiles, where every stripe
+command is checked.
+
+Exits with an error when deprecated usages are found, to run it in CI.`,
+		Example: `stripe migrate-check
+  stripe migrate-check ./scripts`,
+		RunE: mc.runMigrateCheckCmd,
+	}
+
+	return mc
+}
+
+func (mc *migrateCheckCmd) runMigrateCheckCmd(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	schemas, err := spec.LoadResourceSchemas()
+	if err != nil {
+		return err
+	}
+
+	warnings, err := migrateCheck(cmd.Root(), schemas, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		deprecation.Write(os.Stdout, warning)
+	}
+
+	if len(warnings) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d deprecated usages", len(warnings))
+	}
+
+	fmt.Println("No deprecated usages found.")
+
+	return nil
+}
+
+// migrateCheck returns the deprecated usages in the fixtures and scripts
+// of dir
+func migrateCheck(root *cobra.Command, schemas *spec.ResourceSchemas, dir string) ([]deprecation.Warning, error) {
+	var warnings []deprecation.Warning
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && skippedDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		isScript := scriptExtensions[ext] || info.Name() == "Makefile" || info.Name() == "Dockerfile"
+
+		if ext != ".json" && !isScript {
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if isScript {
+			warnings = append(warnings, scriptDeprecations(root, schemas, path, data)...)
+		} else if bytes.Contains(data, []byte(`"fixtures"`)) {
+			warnings = append(warnings, fixtureDeprecations(schemas, path, data)...)
+		}
+
+		return nil
+	})
+
+	return warnings, err
+}
+
+func fixtureDeprecations(schemas *spec.ResourceSchemas, path string, data []byte) []deprecation.Warning {
+	var warnings []deprecation.Warning
+
+	for _, problem := range fixtures.Lint(data, schemas) {
+		if !problem.Deprecated {
+			continue
+		}
+
+		warning := deprecation.Warning{
+			Kind:        deprecation.KindOperation,
+			Name:        strings.TrimSuffix(problem.Message, " is deprecated"),
+			Location:    fmt.Sprintf("%s:%s", path, problem.Path),
+			Replacement: fmt.Sprintf("See %s for its replacement", deprecation.APIUpgradesURL),
+		}
+
+		if i := strings.Index(problem.Path, ".params."); i >= 0 {
+			warning.Kind = deprecation.KindParameter
+			warning.Name = problem.Path[i+len(".params."):]
+			warning.Location = fmt.Sprintf("%s:%s", path, problem.Path[:i])
+		}
+
+		warnings = append(warnings, warning)
+	}
+
+	return warnings
+}
+
+func scriptDeprecations(root *cobra.Command, schemas *spec.ResourceSchemas, path string, data []byte) []deprecation.Warning {
+	var warnings []deprecation.Warning
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		for _, words := range stripeInvocations(scanner.Text()) {
+			for _, warning := range invocationDeprecations(root, schemas, words) {
+				warning.Location = fmt.Sprintf("%s:%d", path, line)
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+
+	return warnings
+}
+
+// stripeInvocations returns the arguments of the stripe commands of a line
+// of a script, up to the next shell operator
+func stripeInvocations(line string) [][]string {
+	var invocations [][]string
+	var words []string
+
+	inInvocation := false
+
+	for _, word := range strings.Fields(line) {
+		switch {
+		case word == "stripe" || strings.HasSuffix(word, "/stripe") || word == "stripe.exe":
+			if inInvocation && len(words) > 0 {
+				invocations = append(invocations, words)
+			}
+
+			inInvocation = true
+			words = nil
+		case strings.ContainsAny(word[:1], "|&;><#") || word == "\\":
+			if inInvocation && len(words) > 0 {
+				invocations = append(invocations, words)
+			}
+
+			inInvocation = false
+		case inInvocation:
+			words = append(words, strings.Trim(word, `"'`))
+		}
+	}
+
+	if inInvocation && len(words) > 0 {
+		invocations = append(invocations, words)
+	}
+
+	return invocations
+}
+
+// invocationDeprecations returns the deprecated flags, operation and
+// parameters the arguments of a stripe command use
+func invocationDeprecations(root *cobra.Command, schemas *spec.ResourceSchemas, args []string) []deprecation.Warning {
+	resource.BuildDeferredCmds(root, args)
+
+	cmd, rest, err := root.Find(args)
+	if err != nil || cmd == root {
+		return nil
+	}
+
+	var warnings []deprecation.Warning
+	var params, positional []string
+
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)
+		name, value, hasValue := parts[0], "", len(parts) == 2
+		if hasValue {
+			value = parts[1]
+		}
+
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = cmd.Flags().Lookup(name)
+		} else if len(name) == 1 {
+			flag = cmd.Flags().ShorthandLookup(name)
+		}
+
+		if flag == nil {
+			continue
+		}
+
+		if replacement, ok := flag.Annotations[deprecatedAnnotation]; ok {
+			warnings = append(warnings, deprecation.Flag(flag.Name, replacement[0]))
+		}
+
+		if !hasValue && flag.Value.Type() != "bool" && i+1 < len(rest) {
+			i++
+			value = rest[i]
+		}
+
+		switch {
+		case flag.Name == "data":
+			params = append(params, strings.SplitN(value, "=", 2)[0])
+		case len(flag.Annotations["request"]) > 0:
+			params = append(params, strings.ReplaceAll(flag.Name, "-", "_"))
+		}
+	}
+
+	method, path := cmd.Annotations["method"], cmd.Annotations["path"]
+	if cmd.Parent() == root && root.Annotations[cmd.Name()] == "http" && len(positional) > 0 {
+		method, path = cmd.Name(), strings.SplitN(positional[0], "?", 2)[0]
+	}
+
+	if method == "" || path == "" {
+		return warnings
+	}
+
+	if schemas.IsDeprecated(method, path, "") {
+		return append(warnings, deprecation.Operation(method, path))
+	}
+
+	seen := make(map[string]bool)
+	for _, param := range params {
+		param = strings.SplitN(param, "[", 2)[0]
+		if !seen[param] && schemas.IsDeprecated(method, path, param) {
+			warnings = append(warnings, deprecation.Parameter(method, path, param))
+		}
+
+		seen[param] = true
+	}
+
+	return warnings
+}
+
+// markDeprecated hides a deprecated flag and warns when it's used, telling
+// what replaces it
+func markDeprecated(flags *pflag.FlagSet, name, replacement string) {
+	flags.MarkHidden(name)                                                 // #nosec G104
+	flags.SetAnnotation(name, deprecatedAnnotation, []string{replacement}) // #nosec G104
+}
+
+// warnDeprecatedFlags warns about the deprecated flags of a command that are
+// set
+func warnDeprecatedFlags(cmd *cobra.Command) {
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if replacement, ok := flag.Annotations[deprecatedAnnotation]; ok {
+			deprecation.Warn(deprecation.Flag(flag.Name, replacement[0]))
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+func TestStripeInvocations(t *testing.T) {
+	require.Equal(t, [][]string{
+		{"listen", "--forward-to", "localhost:3000"},
+		{"trigger", "customer.created"},
+	}, stripeInvocations(`stripe listen --forward-to localhost:3000 & ./bin/stripe trigger "customer.created" # seed`))
+
+	require.Empty(t, stripeInvocations("echo hello"))
+}
+
+func TestMigrateCheck(t *testing.T) {
+	schemas, err := spec.LoadResourceSchemas()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "seed.sh"), []byte(`#!/bin/sh
+stripe listen --print-json
+stripe get /v1/recipients/rp_123
+stripe post /v1/checkout/sessions -d "shipping_rates[]=shr_123" -d mode=payment
+stripe customers list
+`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "fixture.json"), []byte(`{
+  "fixtures": [{"name": "session", "path": "/v1/checkout/sessions", "method": "post", "params": {"shipping_rates": ["shr_123"]}}]
+}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.json"), []byte(`{"recipients": true}`), 0644))
+
+	warnings, err := migrateCheck(rootCmd, schemas, dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, warning := range warnings {
+		names = append(names, warning.Location[len(dir)+1:]+" "+warning.Kind+" "+warning.Name)
+	}
+
+	require.Equal(t, []string{
+		"fixture.json:fixtures[0] parameter shipping_rates",
+		"seed.sh:2 flag --print-json",
+		"seed.sh:3 operation GET /v1/recipients/rp_123",
+		"seed.sh:4 parameter shipping_rates of POST /v1/checkout/sessions",
+	}, names)
+}
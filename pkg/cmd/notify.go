@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/notify"
+)
+
+// addNotifyFlag adds --notify to a slow command, to send a desktop
+// notification when it completes or fails
+func addNotifyFlag(cmd *cobra.Command) {
+	var enabled bool
+
+	cmd.Flags().BoolVar(&enabled, "notify", false, "Send a desktop notification when the command completes or fails")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		start := time.Now()
+		err := run(cmd, args)
+
+		if enabled {
+			title, message := completionNotification(cmd.CommandPath(), time.Since(start), err)
+			if notifyErr := notify.Desktop(cmd.Context(), title, message); notifyErr != nil {
+				logging.WithPrefix(log.StandardLogger(), "cmd.addNotifyFlag").Warnf("Could not send desktop notification: %v", notifyErr)
+			}
+		}
+
+		return err
+	}
+}
+
+func completionNotification(commandPath string, duration time.Duration, err error) (string, string) {
+	if err != nil {
+		return fmt.Sprintf("%s failed", commandPath), err.Error()
+	}
+
+	return fmt.Sprintf("%s completed", commandPath), fmt.Sprintf("Took %s", duration.Round(time.Second))
+}
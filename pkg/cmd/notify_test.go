@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionNotification(t *testing.T) {
+	title, message := completionNotification("stripe fixtures", 83*time.Second+400*time.Millisecond, nil)
+	require.Equal(t, "stripe fixtures completed", title)
+	require.Equal(t, "Took 1m23s", message)
+
+	title, message = completionNotification("stripe trigger", time.Second, errors.New("invalid API key"))
+	require.Equal(t, "stripe trigger failed", title)
+	require.Equal(t, "invalid API key", message)
+}
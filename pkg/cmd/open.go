@@ -154,7 +154,7 @@ func (oc *openCmd) runOpenCmd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
 	if url, ok := nameURLmap[args[0]]; ok {
 		livemode, err := cmd.Flags().GetBool("live")
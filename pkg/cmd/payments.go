@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/diagnose"
+	"github.com/stripe/stripe-cli/pkg/open"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type paymentsCmd struct {
+	cmd *cobra.Command
+}
+
+type paymentsTest3DSCmd struct {
+	cmd *cobra.Command
+
+	params     simulate.ThreeDSecureParams
+	outcome    string
+	complete   bool
+	apiBaseURL string
+}
+
+type paymentsWhyCmd struct {
+	cmd *cobra.Command
+
+	format     string
+	livemode   bool
+	apiBaseURL string
+}
+
+func newPaymentsCmd() *paymentsCmd {
+	pc := &paymentsCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:   "payments",
+		Args:  validators.NoArgs,
+		Short: "Debug payments",
+		Long:  `Understand the state of your payments.`,
+	}
+
+	pc.cmd.AddCommand(newPaymentsTest3DSCmd().cmd)
+	pc.cmd.AddCommand(newPaymentsWhyCmd().cmd)
+
+	return pc
+}
+
+func newPaymentsTest3DSCmd() *paymentsTest3DSCmd {
+	ptc := &paymentsTest3DSCmd{}
+
+	ptc.cmd = &cobra.Command{
+		Use:   "test-3ds",
+		Args:  validators.NoArgs,
+		Short: "Create a test payment requiring 3D Secure authentication",
+		Long: `Create and confirm a test mode PaymentIntent for a price with a card that
+requires a 3D Secure challenge, and print the URL of the challenge page.
+
+With --complete, the challenge page is opened in your browser: complete or
+fail the authentication there, and the final state of the payment is
+checked against the expected --outcome.`,
+		Example: `stripe payments test-3ds --price price_123
+  stripe payments test-3ds --price price_123 --outcome fail --complete`,
+		RunE: ptc.runPaymentsTest3DSCmd,
+	}
+
+	ptc.cmd.Flags().StringVar(&ptc.params.Price, "price", "", "ID of the price to pay (required)")
+	ptc.cmd.Flags().Int64Var(&ptc.params.Quantity, "quantity", 1, "Quantity of the price to pay")
+	ptc.cmd.Flags().StringVar(&ptc.outcome, "outcome", simulate.ThreeDSecureAuthenticate, "Expected outcome of the authentication: authenticate or fail")
+	ptc.cmd.Flags().BoolVar(&ptc.complete, "complete", false, "Open the challenge page and wait for the authentication to be completed or failed")
+	ptc.cmd.MarkFlagRequired("price") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	ptc.cmd.Flags().StringVar(&ptc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ptc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return ptc
+}
+
+func (ptc *paymentsTest3DSCmd) runPaymentsTest3DSCmd(cmd *cobra.Command, args []string) error {
+	if ptc.outcome != simulate.ThreeDSecureAuthenticate && ptc.outcome != simulate.ThreeDSecureFail {
+		return fmt.Errorf("unsupported outcome %s, use authenticate or fail", ptc.outcome)
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	client := simulate.NewAPIClient(apiKey, ptc.apiBaseURL)
+
+	intent, err := simulate.ThreeDSecurePayment(cmd.Context(), client, ptc.params)
+	if err != nil {
+		return err
+	}
+
+	id := intent.Get("id").String()
+	challengeURL := simulate.ThreeDSecureURL(intent)
+
+	fmt.Printf("%s PaymentIntent created, it requires authentication [%s]\n", ansi.SuccessGlyph(), id)
+	fmt.Printf("Challenge page: %s\n", challengeURL)
+
+	button := "Complete"
+	if ptc.outcome == simulate.ThreeDSecureFail {
+		button = "Fail"
+	}
+
+	if !ptc.complete {
+		fmt.Printf("\nOpen it and click %q, then run `stripe payments why %s`\n", button+" authentication", id)
+		return nil
+	}
+
+	if open.CanOpenBrowser() {
+		if err := open.Browser(challengeURL); err != nil {
+			return err
+		}
+	}
+
+	spinner := ansi.StartNewSpinner(fmt.Sprintf("Waiting for you to click %q on the challenge page...", button+" authentication"), os.Stdout)
+
+	intent, err = simulate.WaitForAuthentication(cmd.Context(), client, id, 0)
+	ansi.StopSpinner(spinner, "", os.Stdout)
+
+	if err != nil {
+		return err
+	}
+
+	outcome := simulate.ThreeDSecureOutcome(intent)
+	status := intent.Get("status").String()
+
+	if outcome != ptc.outcome {
+		return fmt.Errorf("expected the authentication to %s, but the payment is %s. Run `stripe payments why %s`", ptc.outcome, status, id)
+	}
+
+	fmt.Printf("%s Authentication %s as expected, the payment is %s\n", ansi.SuccessGlyph(), map[string]string{
+		simulate.ThreeDSecureAuthenticate: "completed",
+		simulate.ThreeDSecureFail:         "failed",
+	}[outcome], status)
+
+	return nil
+}
+
+func newPaymentsWhyCmd() *paymentsWhyCmd {
+	pwc := &paymentsWhyCmd{}
+
+	pwc.cmd = &cobra.Command{
+		Use:   "why <payment_intent>",
+		Args:  validators.ExactArgs(1),
+		Short: "Explain why a payment is in its current state",
+		Long: `Inspect a PaymentIntent, its latest charge, its Radar outcome and its events,
+and explain why it's in its current state: a decline, an authentication the
+customer must complete, a missing capture... along with the next steps.`,
+		Example: `stripe payments why pi_123`,
+		RunE:    pwc.runPaymentsWhyCmd,
+	}
+
+	pwc.cmd.Flags().StringVar(&pwc.format, "format", "", `Specifies the output format of the diagnosis
+	Acceptable values:
+		'JSON' - Output the diagnosis in JSON format`)
+	pwc.cmd.Flags().BoolVar(&pwc.livemode, "live", false, "Diagnose a live mode payment (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	pwc.cmd.Flags().StringVar(&pwc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	pwc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return pwc
+}
+
+func (pwc *paymentsWhyCmd) runPaymentsWhyCmd(cmd *cobra.Command, args []string) error {
+	if !strings.HasPrefix(args[0], "pi_") {
+		return fmt.Errorf("%s isn't the ID of a PaymentIntent, they start with pi_", args[0])
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(pwc.livemode)
+	if err != nil {
+		return err
+	}
+
+	diagnosis, err := diagnose.Payment(cmd.Context(), simulate.NewAPIClient(apiKey, pwc.apiBaseURL), args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(pwc.format) == outputFormatJSON {
+		out, err := json.MarshalIndent(diagnosis, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	diagnose.RenderPayment(os.Stdout, diagnosis)
+
+	return nil
+}
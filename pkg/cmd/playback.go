@@ -216,7 +216,10 @@ func (pc *playbackCmd) runPlaybackCmd(cmd *cobra.Command, args []string) error {
 
 	wg.Wait()
 	fmt.Println("Playback setup completed!")
-	select {}
+
+	<-cmd.Context().Done()
+
+	return nil
 }
 
 func waitUntilConnected(p *proxy.Proxy, wg *sync.WaitGroup) {
@@ -225,7 +228,7 @@ func waitUntilConnected(p *proxy.Proxy, wg *sync.WaitGroup) {
 }
 
 func runListen(cmd *cobra.Command, address string, wg *sync.WaitGroup) error {
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
 	deviceName, err := Config.Profile.GetDeviceName()
 	if err != nil {
@@ -238,14 +241,10 @@ func runListen(cmd *cobra.Command, address string, wg *sync.WaitGroup) error {
 		return err
 	}
 
-	ctx := withSIGTERMCancel(cmd.Context(), func() {
-		log.WithFields(log.Fields{
-			"prefix": "playback.proxy.Run",
-		}).Debug("Ctrl+C received, cleaning up...")
-	})
+	ctx := cmd.Context()
 
 	logger := log.StandardLogger()
-	proxyVisitor := createVisitor(logger, "", false)
+	proxyVisitor := createVisitor(logger, "", nil, false)
 	proxyOutCh := make(chan websocket.IElement)
 
 	p, err := proxy.Init(ctx, &proxy.Config{
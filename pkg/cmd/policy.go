@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/policy"
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// policyExemptCommands can always run, so that users can see why other
+// commands are blocked
+var policyExemptCommands = []string{"help", "version", "completion", "policy show", "__complete", "__completeNoDesc"}
+
+type policyCmd struct {
+	cmd *cobra.Command
+
+	keyFile string
+}
+
+func newPolicyCmd() *policyCmd {
+	pc := &policyCmd{}
+
+	pc.cmd = &cobra.Command{
+		Use:   "policy",
+		Args:  validators.NoArgs,
+		Short: "Show the policy restricting the commands the CLI can run",
+		Long: `Administrators can restrict the commands the CLI can run, e.g. on shared
+machines, with a policy signed with their private key. The policy is set with
+the policy (a path or an https URL) and policy_public_key keys of the system
+policy config, ` + "`" + `/etc/stripe-cli/policy.toml` + "`" + ` on Linux,
+` + "`" + `/Library/Application Support/Stripe/stripe-cli/policy.toml` + "`" + ` on macOS and
+` + "`" + `%ProgramData%\Stripe\stripe-cli\policy.toml` + "`" + ` on Windows:
+
+  policy = "https://example.com/stripe-cli-policy.json"
+  policy_public_key = "<base64 Ed25519 public key>"
+
+Unlike the config file of the user, it can't be changed with --config,
+--config-dir or STRIPE_CONFIG_DIR, so it should only be writable by
+administrators.
+
+A policy file holds the policy JSON and its signature. The policy can allow
+only some commands, block API resources and deny livemode:
+
+  {
+    "name": "Shared machines",
+    "allowed_commands": ["get", "listen", "logs tail", "trigger"],
+    "blocked_resources": ["payouts", "transfers"],
+    "livemode": "deny",
+    "livemode_allowed_commands": ["get"]
+  }
+
+Commands match the rules naming them or one of their parents. Blocked
+resources are checked for every API request, whichever command sends it.
+Commands are blocked when the policy can't be loaded or its signature is
+invalid.`,
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Args:  validators.NoArgs,
+		Short: "Show the policy in effect",
+		RunE:  pc.runShowCmd,
+	}
+
+	keygenCmd := &cobra.Command{
+		Use:   "keygen",
+		Args:  validators.NoArgs,
+		Short: "Create a key pair to sign policies with",
+		RunE:  pc.runKeygenCmd,
+	}
+
+	signCmd := &cobra.Command{
+		Use:     "sign <policy JSON file>",
+		Args:    validators.ExactArgs(1),
+		Short:   "Sign a policy, printing the policy file",
+		Example: `stripe policy sign policy.json --key policy.key > stripe-cli-policy.json`,
+		RunE:    pc.runSignCmd,
+	}
+	signCmd.Flags().StringVar(&pc.keyFile, "key", "", "File with the base64 encoded private key made by stripe policy keygen")
+	signCmd.MarkFlagRequired("key") // #nosec G104
+
+	pc.cmd.AddCommand(showCmd)
+	pc.cmd.AddCommand(keygenCmd)
+	pc.cmd.AddCommand(signCmd)
+
+	return pc
+}
+
+func (pc *policyCmd) runShowCmd(cmd *cobra.Command, args []string) error {
+	source, publicKey, err := policy.ReadSystemConfig(fs, policy.SystemConfigFile())
+	if err == nil && source == "" {
+		fmt.Println("No policy is set, all commands are allowed.")
+		return nil
+	}
+
+	var p *policy.Policy
+	if err == nil {
+		p, err = loadPolicy(cmd, source, publicKey)
+	}
+
+	fmt.Printf("Source: %s\n", source)
+
+	if err != nil {
+		fmt.Printf("Status: invalid, all commands except policy show are blocked (%s)\n", err)
+		return nil
+	}
+
+	fmt.Println("Status: signature verified")
+
+	if p.Name != "" {
+		fmt.Printf("Name: %s\n", p.Name)
+	}
+
+	fmt.Printf("Allowed commands: %s\n", listOrDefault(p.AllowedCommands, "all"))
+	fmt.Printf("Blocked resources: %s\n", listOrDefault(p.BlockedResources, "none"))
+
+	if p.Livemode == policy.LivemodeDeny {
+		fmt.Printf("Livemode: denied, except for %s\n", listOrDefault(p.LivemodeAllowedCommands, "no commands"))
+	} else {
+		fmt.Println("Livemode: allowed")
+	}
+
+	return nil
+}
+
+func (pc *policyCmd) runKeygenCmd(cmd *cobra.Command, args []string) error {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Private key, to sign policies with stripe policy sign --key:\n%s\n\n", base64.StdEncoding.EncodeToString(privateKey))
+	fmt.Printf("Public key, to set as policy_public_key:\n%s\n", base64.StdEncoding.EncodeToString(publicKey))
+
+	return nil
+}
+
+func (pc *policyCmd) runSignCmd(cmd *cobra.Command, args []string) error {
+	keyData, err := afero.ReadFile(fs, pc.keyFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(keyData)))
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%s doesn't hold a base64 encoded Ed25519 private key", pc.keyFile)
+	}
+
+	policyJSON, err := afero.ReadFile(fs, args[0])
+	if err != nil {
+		return err
+	}
+
+	signed, err := policy.Sign(policyJSON, ed25519.PrivateKey(key))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(signed))
+
+	return nil
+}
+
+// enforcePolicy returns an error if the policy set in the system policy
+// config doesn't allow running cmd with args, and makes the API client check
+// the resources of the requests cmd sends.
+func enforcePolicy(cmd *cobra.Command, args []string) error {
+	source, publicKey, err := policy.ReadSystemConfig(fs, policy.SystemConfigFile())
+	if err == nil && source == "" {
+		return nil
+	}
+
+	command := commandName(cmd)
+	for _, exempt := range policyExemptCommands {
+		if command == exempt || strings.HasPrefix(command, exempt+" ") {
+			return nil
+		}
+	}
+
+	var p *policy.Policy
+	if err == nil {
+		p, err = loadPolicy(cmd, source, publicKey)
+	}
+
+	if err != nil {
+		return fmt.Errorf("commands are blocked because the policy couldn't be loaded: %w", err)
+	}
+
+	stripe.AddRequestCheck(func(req *http.Request) error {
+		return p.CheckRequest(req.URL.Path)
+	})
+
+	return p.Check(policy.Invocation{
+		Command:  command,
+		Resource: policyResource(cmd, args),
+		Livemode: isLivemode(cmd),
+	})
+}
+
+// commandName returns the path of cmd without the binary name, e.g.
+// "logs tail"
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+func loadPolicy(cmd *cobra.Command, source, publicKey string) (*policy.Policy, error) {
+	cacheFile := filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "policy_cache.json")
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return policy.Load(ctx, fs, source, publicKey, cacheFile)
+}
+
+// policyResource returns the API resource a command uses, from the path of
+// the request of get, post and delete, or from the path of the operation of
+// resource commands.
+func policyResource(cmd *cobra.Command, args []string) string {
+	var path string
+
+	switch {
+	case cmd.Parent() == cmd.Root() && cmd.Root().Annotations[cmd.Name()] == "http":
+		if len(args) == 0 {
+			return ""
+		}
+
+		p, err := requests.ObjectPath(args[0])
+		if err != nil {
+			return ""
+		}
+
+		path = p
+	case cmd.Annotations["path"] != "":
+		// Keep the path up to the first URL parameter, e.g. customers for
+		// /v1/customers/{customer}/sources
+		path = cmd.Annotations["path"]
+		if i := strings.Index(path, "{"); i >= 0 {
+			path = path[:i]
+		}
+	default:
+		return ""
+	}
+
+	return policy.ResourceOfPath(path)
+}
+
+// isLivemode returns whether the command runs in livemode, with --live or a
+// live API key
+func isLivemode(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("live"); flag != nil && flag.Value.String() == "true" {
+		return true
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+
+	return err == nil && strings.Contains(apiKey, "_live_")
+}
+
+func listOrDefault(values []string, empty string) string {
+	if len(values) == 0 {
+		return empty
+	}
+
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+)
+
+// amountFlag is an --amount flag in the smallest currency unit, e.g. 1999,
+// or in the main unit, e.g. $19.99, unless --strict is set
+type amountFlag struct {
+	input  string
+	strict bool
+}
+
+func addAmountFlag(cmd *cobra.Command, amount *amountFlag, usage string) {
+	cmd.Flags().StringVar(&amount.input, "amount", "", usage)
+	addStrictFlag(cmd, &amount.strict)
+}
+
+func addStrictFlag(cmd *cobra.Command, strict *bool) {
+	cmd.Flags().BoolVar(strict, "strict", false, "Only accept amounts in the smallest currency unit and Unix timestamps, e.g. in scripts")
+}
+
+// resolve returns the amount in the smallest unit of currency, and the
+// currency. When currency is empty, it's the currency of the input, if any,
+// or else defaultCurrency.
+func (f *amountFlag) resolve(currency, defaultCurrency string) (int64, string, error) {
+	amount, err := humaninput.ParseAmount(f.input, f.strict)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if currency == "" {
+		currency = amount.Currency()
+	}
+
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	minor, err := amount.In(currency)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return minor, currency, nil
+}
@@ -0,0 +1,30 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmountFlagResolve(t *testing.T) {
+	amount, currency, err := (&amountFlag{input: "€5"}).resolve("", "usd")
+	require.NoError(t, err)
+	require.Equal(t, int64(500), amount)
+	require.Equal(t, "eur", currency)
+
+	amount, currency, err = (&amountFlag{input: "10.50"}).resolve("", "usd")
+	require.NoError(t, err)
+	require.Equal(t, int64(1050), amount)
+	require.Equal(t, "usd", currency)
+
+	amount, currency, err = (&amountFlag{input: "2500"}).resolve("", "")
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), amount)
+	require.Equal(t, "", currency)
+
+	_, _, err = (&amountFlag{input: "€5"}).resolve("usd", "usd")
+	require.EqualError(t, err, "the amount is in EUR, but the currency is USD")
+
+	_, _, err = (&amountFlag{input: "10.50", strict: true}).resolve("", "usd")
+	require.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package resource
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+	"github.com/stripe/stripe-cli/pkg/payments"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// ChargesRefundCmd refunds a charge, in full or in part
+type ChargesRefundCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params      payments.RefundParams
+	amount      string
+	strict      bool
+	format      string
+	autoConfirm bool
+	livemode    bool
+	apiBaseURL  string
+}
+
+// AddChargesSubCmds adds custom subcommands to the `charges` command created
+// automatically as a resource command.
+func AddChargesSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "charges" {
+			NewChargesRefundCmd(cmd, cfg)
+			return nil
+		}
+	}
+
+	return errors.New("Could not find charges command")
+}
+
+// NewChargesRefundCmd returns a new `charges refund` command
+func NewChargesRefundCmd(parentCmd *cobra.Command, cfg *config.Config) *ChargesRefundCmd {
+	rc := &ChargesRefundCmd{
+		cfg: cfg,
+	}
+
+	rc.cmd = &cobra.Command{
+		Use:   "refund <charge>",
+		Args:  validators.ExactArgs(1),
+		Short: "Refund a percentage or an amount of a charge",
+		Long: `Refund a percentage of the captured amount of a charge, or an amount of it.
+Percentages are rounded down to the smallest currency unit, and refunds of
+more than what's left to refund are rejected before anything is sent.
+
+Amounts are in the smallest currency unit, e.g. 500, or in the currency of
+the charge with a decimal separator or a currency symbol, e.g. $5 or 4,99 EUR.
+
+Live mode refunds are confirmed first, unless --confirm is set.`,
+		Example: `stripe charges refund ch_123 --percent 50
+  stripe charges refund ch_123 --amount 500 --reason requested_by_customer
+  stripe charges refund ch_123 --amount "$5.00"`,
+		RunE: rc.runChargesRefundCmd,
+	}
+
+	rc.cmd.Flags().Float64Var(&rc.params.Percent, "percent", 0, "Percentage of the captured amount to refund")
+	rc.cmd.Flags().StringVar(&rc.amount, "amount", "", "Amount to refund, in the smallest currency unit or with a decimal separator, e.g. 500 or $5.00")
+	addStrictFlag(rc.cmd, &rc.strict)
+	rc.cmd.Flags().StringVar(&rc.params.Reason, "reason", "", "Reason of the refund: duplicate, fraudulent or requested_by_customer")
+	rc.cmd.Flags().StringVar(&rc.format, "format", "", `Specifies the output format of the refund
+	Acceptable values:
+		'JSON' - Output the refund in JSON format`)
+	rc.cmd.Flags().BoolVarP(&rc.autoConfirm, "confirm", "c", false, "Skip the confirmation prompt of live mode refunds")
+	rc.cmd.Flags().BoolVar(&rc.livemode, "live", false, "Refund a live mode charge (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	rc.cmd.Flags().StringVar(&rc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	rc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(rc.cmd)
+	parentCmd.Annotations["refund"] = "operation"
+
+	return rc
+}
+
+func (rc *ChargesRefundCmd) runChargesRefundCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := rc.cfg.Profile.GetAPIKey(rc.livemode)
+	if err != nil {
+		return err
+	}
+
+	client := simulate.NewAPIClient(apiKey, rc.apiBaseURL)
+
+	rc.params.Charge = args[0]
+
+	if rc.amount != "" {
+		rc.params.Amount, err = humaninput.ParseAmount(rc.amount, rc.strict)
+		if err != nil {
+			return err
+		}
+	}
+
+	plan, err := payments.PlanRefund(cmd.Context(), client, rc.params)
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := confirmPlan(payments.Describe("Refund", plan), plan.Livemode && !rc.autoConfirm)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	refund, err := payments.Refund(cmd.Context(), client, plan, rc.params.Reason)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(rc.format) == "JSON" {
+		fmt.Println(ansi.ColorizeJSON(refund.Raw, false, os.Stdout))
+		return nil
+	}
+
+	fmt.Printf("%s Refunded %s of %s [%s]\n", ansi.SuccessGlyph(),
+		preview.FormatAmount(refund.Get("amount").Int(), plan.Currency), plan.ID, refund.Get("id").String())
+	fmt.Printf("  %s left to refund\n\n", preview.FormatAmount(plan.Available-refund.Get("amount").Int(), plan.Currency))
+
+	payments.RenderBalanceTransaction(os.Stdout, refund.Get("balance_transaction"))
+
+	return nil
+}
+
+// confirmPlan prints a planned refund or capture and, when prompt is set,
+// asks to confirm it. It returns whether to proceed. The plan is printed on
+// stderr to keep the output of the request parseable.
+func confirmPlan(description string, prompt bool) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s.\n", description)
+
+	if !prompt {
+		return true, nil
+	}
+
+	fmt.Fprint(os.Stderr, "Enter 'yes' to confirm: ")
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+
+	if strings.ToLower(strings.TrimSpace(answer)) != "yes" {
+		fmt.Println("Exiting without execution. User did not confirm the command.")
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/timeline"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// CustomersTimelineCmd shows what happened to a customer
+type CustomersTimelineCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	expand     []string
+	format     string
+	livemode   bool
+	apiBaseURL string
+}
+
+// AddCustomersSubCmds adds custom subcommands to the `customers` command
+// created automatically as a resource command.
+func AddCustomersSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "customers" {
+			NewCustomersTimelineCmd(cmd, cfg)
+			return nil
+		}
+	}
+
+	return errors.New("Could not find customers command")
+}
+
+// NewCustomersTimelineCmd returns a new `customers timeline` command
+func NewCustomersTimelineCmd(parentCmd *cobra.Command, cfg *config.Config) *CustomersTimelineCmd {
+	tc := &CustomersTimelineCmd{
+		cfg: cfg,
+	}
+
+	tc.cmd = &cobra.Command{
+		Use:   "timeline <customer>",
+		Args:  validators.ExactArgs(1),
+		Short: "Show what happened to a customer, in chronological order",
+		Long: `Show the charges, invoices and subscriptions of a customer, along with its
+other events like subscription changes, in a single chronological timeline.
+
+The API keeps events for 30 days, so older subscription changes don't
+appear.`,
+		Example: `stripe customers timeline cus_123
+  stripe customers timeline cus_123 --expand all`,
+		RunE: tc.runTimelineCmd,
+	}
+
+	tc.cmd.Flags().StringSliceVar(&tc.expand, "expand", []string{}, `IDs of the entries to show the details of, or "all"`)
+	tc.cmd.Flags().StringVar(&tc.format, "format", "", `Specifies the output format of the timeline
+	Acceptable values:
+		'JSON' - Output the timeline in JSON format`)
+	tc.cmd.Flags().BoolVar(&tc.livemode, "live", false, "Show a live mode customer (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	tc.cmd.Flags().StringVar(&tc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	tc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(tc.cmd)
+	parentCmd.Annotations["timeline"] = "operation"
+
+	return tc
+}
+
+func (tc *CustomersTimelineCmd) runTimelineCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := tc.cfg.Profile.GetAPIKey(tc.livemode)
+	if err != nil {
+		return err
+	}
+
+	t, err := timeline.Build(cmd.Context(), simulate.NewAPIClient(apiKey, tc.apiBaseURL), args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(tc.format) == "JSON" {
+		out, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	timeline.Render(os.Stdout, t, tc.expand)
+
+	return nil
+}
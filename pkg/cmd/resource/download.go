@@ -0,0 +1,326 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// artifact is a document of an object that can be downloaded from one of
+// its hosted URLs
+type artifact struct {
+	name      string
+	path      string
+	field     string
+	expand    string
+	extension string
+}
+
+var (
+	invoicePDF     = artifact{name: "invoice PDF", path: "/v1/invoices", field: "invoice_pdf", extension: "pdf"}
+	invoiceReceipt = artifact{name: "receipt", path: "/v1/invoices", field: "charge.receipt_url", expand: "charge", extension: "html"}
+	creditNotePDF  = artifact{name: "credit note PDF", path: "/v1/credit_notes", field: "pdf", extension: "pdf"}
+	chargeReceipt  = artifact{name: "receipt", path: "/v1/charges", field: "receipt_url", extension: "html"}
+)
+
+// DownloadCmd downloads the documents of objects, e.g. invoice PDFs
+type DownloadCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	artifacts []artifact
+	artifact  *artifact
+
+	pdf        bool
+	receipt    bool
+	out        string
+	filters    []string
+	since      string
+	overwrite  bool
+	livemode   bool
+	apiBaseURL string
+}
+
+// AddDownloadSubCmds adds a `download` subcommand to the commands of the
+// resources with documents: invoices, credit notes and charges.
+func AddDownloadSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	resources := map[string][]artifact{
+		"charges":      {chargeReceipt},
+		"credit_notes": {creditNotePDF},
+		"invoices":     {invoicePDF, invoiceReceipt},
+	}
+
+	for _, cmd := range rootCmd.Commands() {
+		if artifacts, ok := resources[cmd.Use]; ok {
+			newDownloadCmd(cmd, cfg, artifacts)
+			delete(resources, cmd.Use)
+		}
+	}
+
+	if len(resources) > 0 {
+		return fmt.Errorf("Could not find the commands of %d resources with documents", len(resources))
+	}
+
+	return nil
+}
+
+// newDownloadCmd returns a new `download` command for the documents of the
+// resource of parentCmd.
+func newDownloadCmd(parentCmd *cobra.Command, cfg *config.Config, artifacts []artifact) *DownloadCmd {
+	dc := &DownloadCmd{
+		cfg:       cfg,
+		artifacts: artifacts,
+	}
+
+	resource := parentCmd.Use
+	prefix := map[string]string{"charges": "ch", "credit_notes": "cn", "invoices": "in"}[resource]
+
+	dc.cmd = &cobra.Command{
+		Use:   "download [id...]",
+		Short: fmt.Sprintf("Download the %s of %s", artifacts[0].name, strings.ReplaceAll(resource, "_", " ")),
+		Long: fmt.Sprintf(`Download the %s of %s to files named after their IDs.
+
+Without IDs, download the documents of all the %s matching the --filter
+and --since flags. Existing files are skipped, so an interrupted download
+can be run again.`, artifacts[0].name, strings.ReplaceAll(resource, "_", " "), strings.ReplaceAll(resource, "_", " ")),
+		Example: fmt.Sprintf(`stripe %s download %s_123 --out ./%s/
+  stripe %s download --filter customer=cus_123 --since 30d --out ./%s/`, resource, prefix, resource, resource, resource),
+		RunE: dc.runDownloadCmd,
+	}
+
+	if len(artifacts) > 1 {
+		dc.cmd.Flags().BoolVar(&dc.pdf, "pdf", false, "Download the invoice PDF (default)")
+		dc.cmd.Flags().BoolVar(&dc.receipt, "receipt", false, "Download the receipt of the payment of the invoice")
+	}
+
+	dc.cmd.Flags().StringVar(&dc.out, "out", ".", "Directory to download the documents to")
+	dc.cmd.Flags().StringArrayVar(&dc.filters, "filter", []string{}, "Parameter of the list request selecting the objects to download, e.g. status=paid")
+	dc.cmd.Flags().StringVar(&dc.since, "since", "", "Only download the documents of the objects created in this period, e.g. 24h or 30d")
+	dc.cmd.Flags().BoolVar(&dc.overwrite, "overwrite", false, "Replace the files that were already downloaded")
+	dc.cmd.Flags().BoolVar(&dc.livemode, "live", false, "Download live mode documents (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	dc.cmd.Flags().StringVar(&dc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	dc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(dc.cmd)
+	parentCmd.Annotations["download"] = "operation"
+
+	return dc
+}
+
+func (dc *DownloadCmd) runDownloadCmd(cmd *cobra.Command, args []string) error {
+	if dc.pdf && dc.receipt {
+		return errors.New("--pdf and --receipt can't be used together")
+	}
+
+	dc.artifact = &dc.artifacts[0]
+	if dc.receipt {
+		dc.artifact = &dc.artifacts[1]
+	}
+
+	if len(args) > 0 && (len(dc.filters) > 0 || dc.since != "") {
+		return errors.New("--filter and --since select the objects to download, they can't be used with IDs")
+	}
+
+	if len(args) == 0 && len(dc.filters) == 0 && dc.since == "" {
+		return errors.New("pass the IDs of the objects, or use --filter or --since to download the documents of many of them")
+	}
+
+	apiKey, err := dc.cfg.Profile.GetAPIKey(dc.livemode)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dc.out, os.ModePerm); err != nil {
+		return err
+	}
+
+	client := simulate.NewAPIClient(apiKey, dc.apiBaseURL)
+	failed := 0
+
+	download := func(object gjson.Result) error {
+		id := object.Get("id").String()
+
+		if err := dc.download(cmd.Context(), apiKey, object); err != nil {
+			if ctxErr := cmd.Context().Err(); ctxErr != nil {
+				return ctxErr
+			}
+
+			failed++
+			fmt.Printf("%s %s: %v\n", ansi.ErrorGlyph(), id, err)
+		}
+
+		return nil
+	}
+
+	if len(args) > 0 {
+		for _, id := range args {
+			object, err := client.Request(cmd.Context(), http.MethodGet, dc.artifact.path+"/"+id, dc.expandParams(""))
+			if err != nil {
+				failed++
+				fmt.Printf("%s %s: %v\n", ansi.ErrorGlyph(), id, err)
+
+				continue
+			}
+
+			if err := download(object); err != nil {
+				return err
+			}
+		}
+	} else if err := dc.downloadList(cmd.Context(), client, download); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d document(s) couldn't be downloaded", failed)
+	}
+
+	return nil
+}
+
+// downloadList calls download with each object of the list selected by the
+// filters
+func (dc *DownloadCmd) downloadList(ctx context.Context, client simulate.APIClient, download func(gjson.Result) error) error {
+	params := append([]string{"limit=100"}, dc.filters...)
+	params = append(params, dc.expandParams("data.")...)
+
+	if dc.since != "" {
+		period, err := ParsePeriod(dc.since)
+		if err != nil {
+			return err
+		}
+
+		params = append(params, "created[gte]="+strconv.FormatInt(time.Now().Add(-period).Unix(), 10))
+	}
+
+	startingAfter := ""
+
+	for {
+		pageParams := params
+		if startingAfter != "" {
+			pageParams = append(pageParams, "starting_after="+startingAfter)
+		}
+
+		page, err := client.Request(ctx, http.MethodGet, dc.artifact.path, pageParams)
+		if err != nil {
+			return err
+		}
+
+		data := page.Get("data").Array()
+		for _, object := range data {
+			if err := download(object); err != nil {
+				return err
+			}
+		}
+
+		if !page.Get("has_more").Bool() || len(data) == 0 {
+			return nil
+		}
+
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+}
+
+func (dc *DownloadCmd) expandParams(prefix string) []string {
+	if dc.artifact.expand == "" {
+		return nil
+	}
+
+	return []string{"expand[]=" + prefix + dc.artifact.expand}
+}
+
+// download saves the document of object to the output directory
+func (dc *DownloadCmd) download(ctx context.Context, apiKey string, object gjson.Result) error {
+	id := object.Get("id").String()
+	dest := filepath.Join(dc.out, id+"."+dc.artifact.extension)
+
+	if _, err := os.Stat(dest); err == nil && !dc.overwrite {
+		fmt.Printf("%s %s already downloaded to %s\n", ansi.Faint("-"), id, dest)
+		return nil
+	}
+
+	documentURL := object.Get(dc.artifact.field).String()
+	if documentURL == "" {
+		return fmt.Errorf("no %s", dc.artifact.name)
+	}
+
+	if err := downloadDocument(ctx, apiKey, dc.apiBaseURL, documentURL, dest); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s downloaded to %s\n", ansi.SuccessGlyph(), id, dest)
+
+	return nil
+}
+
+// downloadDocument downloads the document at documentURL to dest. The API
+// key is only sent to the hosts of Stripe, or of the API when overridden.
+func downloadDocument(ctx context.Context, apiKey, apiBaseURL, documentURL, dest string) error {
+	u, err := url.Parse(documentURL)
+	if err != nil {
+		return err
+	}
+
+	apiBase, err := url.Parse(apiBaseURL)
+	if err != nil {
+		return err
+	}
+
+	stripeHost := u.Scheme == "https" && (u.Host == "stripe.com" || strings.HasSuffix(u.Host, ".stripe.com"))
+	if !stripeHost && u.Host != apiBase.Host {
+		return fmt.Errorf("unexpected document URL %s", documentURL)
+	}
+
+	client := &stripe.Client{
+		BaseURL: &url.URL{Scheme: u.Scheme, Host: u.Host},
+		APIKey:  apiKey,
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodGet, u.Path, u.RawQuery, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading %s failed with status %d", documentURL, resp.StatusCode)
+	}
+
+	tmp := dest + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(tmp)
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, dest)
+}
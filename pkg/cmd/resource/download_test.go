@@ -0,0 +1,54 @@
+package resource
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestDownloadDocument(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/invoice/acct_123/pdf", r.URL.Path)
+		require.Equal(t, "s=ap", r.URL.RawQuery)
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer ts.Close()
+
+	dest := filepath.Join(t.TempDir(), "in_123.pdf")
+
+	err := downloadDocument(context.Background(), "sk_test_123", ts.URL, ts.URL+"/invoice/acct_123/pdf?s=ap", dest)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(dest)
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4", string(data))
+
+	_, err = os.Stat(dest + ".tmp")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadDocumentUnexpectedHost(t *testing.T) {
+	err := downloadDocument(context.Background(), "sk_test_123", "https://api.stripe.com", "https://example.com/invoice.pdf", filepath.Join(t.TempDir(), "in_123.pdf"))
+	require.EqualError(t, err, "unexpected document URL https://example.com/invoice.pdf")
+}
+
+func TestDownloadSkipsExistingFiles(t *testing.T) {
+	out := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(out, "in_123.pdf"), []byte("%PDF-1.4"), 0600))
+
+	dc := &DownloadCmd{artifact: &invoicePDF, out: out}
+
+	// The URL would fail to download
+	err := dc.download(context.Background(), "sk_test_123", gjson.Parse(`{"id":"in_123","invoice_pdf":"https://example.com/invoice.pdf"}`))
+	require.NoError(t, err)
+
+	err = dc.download(context.Background(), "sk_test_123", gjson.Parse(`{"id":"in_456"}`))
+	require.EqualError(t, err, "no invoice PDF")
+}
@@ -18,6 +18,7 @@ func AddEventsSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
 			found = true
 
 			NewEventsResendCmd(cmd, cfg)
+			NewEventsValidateCmd(cmd)
 
 			break
 		}
@@ -0,0 +1,36 @@
+package resource
+
+import (
+	"os"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/eventformat"
+)
+
+// eventOperations are the paths of the operations returning an event, which
+// can pretty-print it
+var eventOperations = map[string]bool{
+	"/v1/events/{id}":          true,
+	"/v1/events/{event}/retry": true,
+}
+
+// addEventFormatFlags adds the flags pretty-printing the event returned by
+// an operation
+func addEventFormatFlags(oc *OperationCmd) {
+	var pretty bool
+	var unfold []string
+
+	oc.Cmd.Flags().BoolVar(&pretty, "pretty", false, "Print the event for humans, with the request and previous_attributes sections folded")
+	oc.Cmd.Flags().StringSliceVar(&unfold, "unfold", []string{}, "A comma-separated list of the sections of --pretty to unfold, or \"all\". Implies --pretty")
+
+	oc.FormatOutput = func(body []byte) (string, error) {
+		if !pretty && len(unfold) == 0 {
+			return ansi.ColorizeJSON(string(body), oc.DarkStyle, os.Stdout), nil
+		}
+
+		return eventformat.Format(body, eventformat.Options{
+			Unfold: unfold,
+			Color:  ansi.Color(os.Stdout),
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/spec"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// EventsValidateCmd validates an event payload against the schemas of the
+// bundled API specification
+type EventsValidateCmd struct {
+	cmd *cobra.Command
+
+	format string
+}
+
+// NewEventsValidateCmd returns a new EventsValidateCmd.
+func NewEventsValidateCmd(parentCmd *cobra.Command) *EventsValidateCmd {
+	evc := &EventsValidateCmd{}
+
+	evc.cmd = &cobra.Command{
+		Use:   "validate <payload file>",
+		Args:  validators.ExactArgs(1),
+		Short: "Validate an event payload against the API specification",
+		Long: `Validate an event payload, e.g. a hand-crafted test fixture or mock, against
+the schema of its type from the API specification bundled with the CLI.
+Unknown fields and type mismatches are reported. Pass - to read the payload
+from stdin.`,
+		Example: `stripe events validate payload.json
+  cat payload.json | stripe events validate -`,
+		RunE: evc.runEventsValidateCmd,
+	}
+
+	evc.cmd.Flags().StringVar(&evc.format, "format", "", `Specifies the output format of the problems found
+	Acceptable values:
+		'JSON' - Output the problems in JSON format`)
+
+	parentCmd.AddCommand(evc.cmd)
+	parentCmd.Annotations["validate"] = "operation"
+
+	return evc
+}
+
+func (evc *EventsValidateCmd) runEventsValidateCmd(cmd *cobra.Command, args []string) error {
+	var payload []byte
+	var err error
+
+	if args[0] == "-" {
+		payload, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		payload, err = ioutil.ReadFile(args[0])
+	}
+
+	if err != nil {
+		return err
+	}
+
+	schemas, err := spec.LoadResourceSchemas()
+	if err != nil {
+		return err
+	}
+
+	problems, err := schemas.ValidateEvent(payload)
+	if err != nil {
+		return err
+	}
+
+	var event struct {
+		Type       string `json:"type"`
+		APIVersion string `json:"api_version"`
+	}
+	json.Unmarshal(payload, &event) // #nosec G104, the payload was already parsed
+
+	if event.Type != "" && !proxy.IsValidEventType(event.Type) {
+		problems = append(problems, spec.Problem{Path: "type", Message: fmt.Sprintf("unknown event type %q", event.Type)})
+	}
+
+	if strings.ToUpper(evc.format) == "JSON" {
+		out, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+	} else {
+		printValidation(args[0], event.Type, event.APIVersion, schemas.Version, problems)
+	}
+
+	if len(problems) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d problem(s) found", len(problems))
+	}
+
+	return nil
+}
+
+func printValidation(file, eventType, apiVersion, specVersion string, problems []spec.Problem) {
+	color := ansi.Color(os.Stdout)
+
+	if apiVersion != "" && apiVersion != specVersion {
+		fmt.Println(color.Yellow(fmt.Sprintf("The payload uses API version %s, it was validated against the bundled specification for %s.", apiVersion, specVersion)))
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("%s %s is a valid %s event\n", ansi.SuccessGlyph(), file, ansi.Bold(eventType))
+		return
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%s %s: %s\n", ansi.ErrorGlyph(), ansi.Bold(problem.Path), problem.Message)
+	}
+}
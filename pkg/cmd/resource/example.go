@@ -0,0 +1,176 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+//
+// Private variables
+//
+
+// idPrefixes are the prefixes of the IDs of the objects of a collection, to
+// put plausible IDs in the examples
+var idPrefixes = map[string]string{
+	"accounts":               "acct",
+	"application_fees":       "fee",
+	"authorizations":         "iauth",
+	"cardholders":            "ich",
+	"cards":                  "card",
+	"charges":                "ch",
+	"credit_notes":           "cn",
+	"customers":              "cus",
+	"disputes":               "dp",
+	"early_fraud_warnings":   "issfr",
+	"files":                  "file",
+	"invoiceitems":           "ii",
+	"invoices":               "in",
+	"locations":              "tml",
+	"mandates":               "mandate",
+	"payment_intents":        "pi",
+	"payment_links":          "plink",
+	"payment_methods":        "pm",
+	"payouts":                "po",
+	"persons":                "person",
+	"prices":                 "price",
+	"products":               "prod",
+	"promotion_codes":        "promo",
+	"quotes":                 "qt",
+	"readers":                "tmr",
+	"refunds":                "re",
+	"reviews":                "prv",
+	"sessions":               "cs_test",
+	"setup_intents":          "seti",
+	"shipping_rates":         "shr",
+	"sources":                "src",
+	"subscription_items":     "si",
+	"subscription_schedules": "sub_sched",
+	"subscriptions":          "sub",
+	"tax_ids":                "txi",
+	"tax_rates":              "txr",
+	"tokens":                 "tok",
+	"topups":                 "tu",
+	"transactions":           "ipi",
+	"transfers":              "tr",
+	"value_lists":            "rsl",
+	"webhook_endpoints":      "we",
+}
+
+// exampleValues are plausible values of common parameters, in the order
+// they're added to the examples
+var exampleValues = []struct {
+	name  string
+	value string
+}{
+	{"amount", "2000"},
+	{"unit_amount", "2000"},
+	{"currency", "usd"},
+	{"email", "jenny.rosen@example.com"},
+	{"name", "\"Jenny Rosen\""},
+	{"description", "\"Created with the Stripe CLI\""},
+	{"limit", "3"},
+	{"success_url", "https://example.com/success"},
+	{"cancel_url", "https://example.com/cancel"},
+	{"return_url", "https://example.com/return"},
+	{"metadata[order_id]", "6735"},
+}
+
+//
+// Private functions
+//
+
+// examples returns invocations of the operation: one with the required
+// parameters only, and one with a few common optional parameters too
+func (oc *OperationCmd) examples(doc *spec.OperationDoc) []string {
+	base := oc.Cmd.CommandPath()
+	for _, param := range oc.URLParams {
+		base += " " + exampleID(oc.Path, param)
+	}
+
+	var required []string
+	byName := make(map[string]spec.FieldDoc)
+
+	for _, param := range doc.Parameters {
+		byName[param.Name] = param
+
+		// Nested parameters are only required with their parent
+		if param.Required && !strings.Contains(param.Name, "[") {
+			required = append(required, oc.exampleParam(param.Name, exampleValue(param)))
+		}
+	}
+
+	minimal := strings.Join(append([]string{base}, required...), " ")
+
+	optional := required
+	for _, example := range exampleValues {
+		param, ok := byName[exampleParamName(example.name)]
+		if !ok || param.Required {
+			continue
+		}
+
+		optional = append(optional, oc.exampleParam(example.name, example.value))
+	}
+
+	full := strings.Join(append([]string{base}, optional...), " ")
+	if full == minimal {
+		return []string{minimal}
+	}
+
+	return []string{minimal, full}
+}
+
+// exampleParam formats a parameter as a flag, or as data for nested
+// parameters, which don't have flags
+func (oc *OperationCmd) exampleParam(name, value string) string {
+	flagName := strings.ReplaceAll(name, "_", "-")
+	if _, ok := oc.stringFlags[flagName]; ok {
+		return fmt.Sprintf("--%s=%s", flagName, value)
+	}
+
+	return fmt.Sprintf("-d \"%s=%s\"", name, strings.Trim(value, "\""))
+}
+
+// exampleID returns a plausible ID for a URL parameter, based on the
+// collection preceding it in the path
+func exampleID(path, param string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != param || i == 0 {
+			continue
+		}
+
+		if prefix, ok := idPrefixes[segments[i-1]]; ok {
+			return prefix + "_123"
+		}
+	}
+
+	return "<" + strings.Trim(param, "{}") + ">"
+}
+
+func exampleParamName(name string) string {
+	return strings.SplitN(name, "[", 2)[0]
+}
+
+func exampleValue(param spec.FieldDoc) string {
+	for _, example := range exampleValues {
+		if example.name == param.Name {
+			return example.value
+		}
+	}
+
+	switch {
+	case len(param.Enum) > 0:
+		return param.Enum[0]
+	case param.Type == "string" && idPrefixes[param.Name+"s"] != "":
+		// Parameters named after a resource take its IDs
+		return idPrefixes[param.Name+"s"] + "_123"
+	case param.Type == "boolean":
+		return "true"
+	case param.Type == "integer":
+		return "1"
+	default:
+		return "<" + param.Name + ">"
+	}
+}
@@ -0,0 +1,129 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// InvoicesPreviewCmd previews the upcoming invoice of a subscription change
+type InvoicesPreviewCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params          preview.InvoiceParams
+	prorationDate   string
+	strict          bool
+	format          string
+	displayCurrency string
+	livemode        bool
+	apiBaseURL      string
+}
+
+// AddInvoicesSubCmds adds custom subcommands to the `invoices` command created
+// automatically as a resource command.
+func AddInvoicesSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "invoices" {
+			NewInvoicesPreviewCmd(cmd, cfg)
+			return nil
+		}
+	}
+
+	return errors.New("Could not find invoices command")
+}
+
+// NewInvoicesPreviewCmd returns a new `invoices preview` command
+func NewInvoicesPreviewCmd(parentCmd *cobra.Command, cfg *config.Config) *InvoicesPreviewCmd {
+	ipc := &InvoicesPreviewCmd{
+		cfg: cfg,
+	}
+
+	ipc.cmd = &cobra.Command{
+		Use:   "preview",
+		Args:  validators.NoArgs,
+		Short: "Preview the upcoming invoice of a subscription change",
+		Long: `Preview the lines and totals of the upcoming invoice of a subscription, after
+switching its first item to another price. Prorations are included.`,
+		Example: `stripe invoices preview --subscription sub_123
+  stripe invoices preview --subscription sub_123 --price price_456 --quantity 2
+  stripe invoices preview --subscription sub_123 --price price_456 --proration-date "next friday"`,
+		RunE: ipc.runInvoicesPreviewCmd,
+	}
+
+	ipc.cmd.Flags().StringVar(&ipc.params.Subscription, "subscription", "", "ID of the subscription to preview (required)")
+	ipc.cmd.Flags().StringVar(&ipc.params.Price, "price", "", "ID of the new price of the subscription's first item")
+	ipc.cmd.Flags().Int64Var(&ipc.params.Quantity, "quantity", 0, "New quantity of the subscription's first item")
+	ipc.cmd.Flags().StringVar(&ipc.prorationDate, "proration-date", "", "Date to compute the prorations at, e.g. 2026-11-01, next friday or a Unix timestamp (default: now)")
+	addStrictFlag(ipc.cmd, &ipc.strict)
+	ipc.cmd.Flags().StringVar(&ipc.format, "format", "", `Specifies the output format of the invoice
+	Acceptable values:
+		'JSON' - Output the raw upcoming invoice in JSON format`)
+	ipc.cmd.Flags().StringVar(&ipc.displayCurrency, "display-currency", "", "Also show the totals converted to this currency, at approximate exchange rates")
+	ipc.cmd.Flags().BoolVar(&ipc.livemode, "live", false, "Make a live request (default: test)")
+	ipc.cmd.MarkFlagRequired("subscription") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	ipc.cmd.Flags().StringVar(&ipc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	ipc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(ipc.cmd)
+	parentCmd.Annotations["preview"] = "operation"
+
+	return ipc
+}
+
+func (ipc *InvoicesPreviewCmd) runInvoicesPreviewCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := ipc.cfg.Profile.GetAPIKey(ipc.livemode)
+	if err != nil {
+		return err
+	}
+
+	if ipc.prorationDate != "" {
+		prorationDate, err := humaninput.ParseTime(ipc.prorationDate, time.Now(), ipc.strict)
+		if err != nil {
+			return err
+		}
+
+		ipc.params.ProrationDate = prorationDate.Unix()
+	}
+
+	client := simulate.NewAPIClient(apiKey, ipc.apiBaseURL)
+
+	invoice, err := preview.UpcomingInvoice(cmd.Context(), client, ipc.params)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(ipc.format) == "JSON" {
+		fmt.Println(ansi.ColorizeJSON(invoice.Raw, false, os.Stdout))
+		return nil
+	}
+
+	var rates *preview.ExchangeRates
+	if ipc.displayCurrency != "" {
+		cachePath := filepath.Join(ipc.cfg.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), preview.ExchangeRatesFileName)
+
+		rates, err = preview.LoadExchangeRates(cmd.Context(), client, cachePath, ipc.displayCurrency)
+		if err != nil {
+			return err
+		}
+	}
+
+	preview.RenderInvoice(os.Stdout, invoice, rates)
+
+	return nil
+}
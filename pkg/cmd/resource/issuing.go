@@ -0,0 +1,190 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// IssuingSimulateCmd groups the commands simulating Issuing activity through
+// the test helpers
+type IssuingSimulateCmd struct {
+	cfg *config.Config
+	Cmd *cobra.Command
+}
+
+// IssuingSimulateAuthorizationCmd creates a test mode Issuing authorization
+type IssuingSimulateAuthorizationCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params     simulate.IssuingAuthorizationParams
+	amount     amountFlag
+	apiBaseURL string
+}
+
+// IssuingSimulateScenarioCmd runs a multi-step Issuing flow
+type IssuingSimulateScenarioCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params     simulate.IssuingAuthorizationParams
+	amount     amountFlag
+	apiBaseURL string
+}
+
+// AddIssuingSubCmds adds custom subcommands to the `issuing` command created
+// automatically as a namespace command.
+func AddIssuingSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "issuing" {
+			NewIssuingSimulateCmd(cmd, cfg)
+			return nil
+		}
+	}
+
+	return errors.New("Could not find issuing command")
+}
+
+// NewIssuingSimulateCmd returns a new `issuing simulate` command
+func NewIssuingSimulateCmd(parentCmd *cobra.Command, cfg *config.Config) *IssuingSimulateCmd {
+	simulateCmd := &IssuingSimulateCmd{
+		cfg: cfg,
+	}
+
+	simulateCmd.Cmd = &cobra.Command{
+		Use:         "simulate",
+		Args:        validators.NoArgs,
+		Short:       "Simulate card activity with the Issuing test helpers",
+		Annotations: make(map[string]string),
+	}
+	simulateCmd.Cmd.SetUsageTemplate(resourceUsageTemplate())
+
+	newIssuingSimulateAuthorizationCmd(simulateCmd.Cmd, cfg)
+	newIssuingSimulateScenarioCmd(simulateCmd.Cmd, cfg)
+
+	authorizationPath := "/v1/test_helpers/issuing/authorizations/{authorization}"
+	NewOperationCmd(simulateCmd.Cmd, "capture", authorizationPath+"/capture", http.MethodPost, map[string]string{
+		"capture_amount":      "integer",
+		"close_authorization": "boolean",
+	}, cfg).Cmd.Short = "Capture an authorization"
+	NewOperationCmd(simulateCmd.Cmd, "expire", authorizationPath+"/expire", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Expire an authorization"
+	NewOperationCmd(simulateCmd.Cmd, "increment", authorizationPath+"/increment", http.MethodPost, map[string]string{
+		"increment_amount":       "integer",
+		"is_amount_controllable": "boolean",
+	}, cfg).Cmd.Short = "Increment the amount of an authorization"
+	NewOperationCmd(simulateCmd.Cmd, "reverse", authorizationPath+"/reverse", http.MethodPost, map[string]string{
+		"reverse_amount": "integer",
+	}, cfg).Cmd.Short = "Reverse all or part of an authorization"
+
+	parentCmd.AddCommand(simulateCmd.Cmd)
+	parentCmd.Annotations["simulate"] = "resource"
+
+	return simulateCmd
+}
+
+func newIssuingSimulateAuthorizationCmd(parentCmd *cobra.Command, cfg *config.Config) {
+	ac := &IssuingSimulateAuthorizationCmd{
+		cfg: cfg,
+	}
+
+	ac.cmd = &cobra.Command{
+		Use:   "authorization",
+		Args:  validators.NoArgs,
+		Short: "Create a test mode authorization on a card",
+		Example: `stripe issuing simulate authorization --card ic_123 --amount 2500
+  stripe issuing simulate authorization --card ic_123 --amount 2500 --capture`,
+		RunE: ac.runAuthorizationCmd,
+	}
+
+	addIssuingAuthorizationFlags(ac.cmd, &ac.params, &ac.amount, &ac.apiBaseURL)
+	ac.cmd.Flags().BoolVar(&ac.params.Capture, "capture", false, "Capture the authorization after creating it")
+
+	parentCmd.AddCommand(ac.cmd)
+	parentCmd.Annotations["authorization"] = "operation"
+}
+
+func newIssuingSimulateScenarioCmd(parentCmd *cobra.Command, cfg *config.Config) {
+	sc := &IssuingSimulateScenarioCmd{
+		cfg: cfg,
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:       "scenario <name>",
+		Args:      validators.ExactArgs(1),
+		ValidArgs: []string{"auth-capture-dispute"},
+		Short:     "Run a multi-step Issuing flow",
+		Long: `Run a multi-step Issuing flow on a card. Supported scenarios:
+  auth-capture-dispute  authorize, capture, then dispute and submit the dispute`,
+		Example: `stripe issuing simulate scenario auth-capture-dispute --card ic_123 --amount 2500`,
+		RunE:    sc.runScenarioCmd,
+	}
+
+	addIssuingAuthorizationFlags(sc.cmd, &sc.params, &sc.amount, &sc.apiBaseURL)
+
+	parentCmd.AddCommand(sc.cmd)
+	parentCmd.Annotations["scenario"] = "operation"
+}
+
+func addIssuingAuthorizationFlags(cmd *cobra.Command, params *simulate.IssuingAuthorizationParams, amount *amountFlag, apiBaseURL *string) {
+	cmd.Flags().StringVar(&params.Card, "card", "", "ID of the card to authorize (required)")
+	addAmountFlag(cmd, amount, "Amount to authorize, in the smallest currency unit or with a decimal separator, e.g. 2500 or $25.00 (required)")
+	cmd.Flags().StringVar(&params.Currency, "currency", "", "Currency of the authorization (default: the currency of the amount, or the card's currency)")
+	cmd.Flags().StringVar(&params.MerchantCategory, "merchant-category", "", "Merchant category of the authorization, e.g. ac_refrigeration_repair")
+	cmd.MarkFlagRequired("card")   // #nosec G104
+	cmd.MarkFlagRequired("amount") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	cmd.Flags().StringVar(apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	cmd.Flags().MarkHidden("api-base") // #nosec G104
+}
+
+func (ac *IssuingSimulateAuthorizationCmd) runAuthorizationCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := ac.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	ac.params.Amount, ac.params.Currency, err = ac.amount.resolve(ac.params.Currency, "")
+	if err != nil {
+		return err
+	}
+
+	authorization, err := simulate.IssuingAuthorization(cmd.Context(), simulate.NewAPIClient(apiKey, ac.apiBaseURL), ac.params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ansi.ColorizeJSON(authorization.Raw, false, os.Stdout))
+
+	return nil
+}
+
+func (sc *IssuingSimulateScenarioCmd) runScenarioCmd(cmd *cobra.Command, args []string) error {
+	if args[0] != "auth-capture-dispute" {
+		return fmt.Errorf("unsupported scenario %q. Supported scenarios: auth-capture-dispute", args[0])
+	}
+
+	apiKey, err := sc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	sc.params.Amount, sc.params.Currency, err = sc.amount.resolve(sc.params.Currency, "")
+	if err != nil {
+		return err
+	}
+
+	return simulate.IssuingAuthCaptureDispute(cmd.Context(), simulate.NewAPIClient(apiKey, sc.apiBaseURL), sc.params, func(step simulate.ScenarioStep) {
+		fmt.Printf("%s %s [%s]\n", ansi.SuccessGlyph(), step.Name, step.ObjectID)
+	})
+}
@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"github.com/spf13/cobra"
+)
+
+//
+// Public functions
+//
+
+// DeferOperationCmds registers build to add the operation commands of a
+// resource command the first time they're needed, instead of when the CLI
+// starts. There are hundreds of generated operation commands and building
+// them all eagerly noticeably slows down every invocation, including shell
+// completions.
+//
+// Until it is built, the resource command is made runnable with a
+// placeholder so that cobra keeps listing it in help and completions.
+func DeferOperationCmds(resourceCmd *cobra.Command, build func()) {
+	lazyBuilders[resourceCmd] = build
+	resourceCmd.Run = func(cmd *cobra.Command, args []string) {}
+}
+
+// BuildDeferredCmds builds the deferred operation commands of the command the
+// arguments resolve to, so that it can be executed, completed or described
+// like an eagerly built command. The arguments are the ones passed to the
+// root command, excluding the binary name.
+func BuildDeferredCmds(rootCmd *cobra.Command, args []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "help":
+			args = args[1:]
+		}
+	}
+
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		return
+	}
+
+	buildDeferred(cmd)
+}
+
+// BuildAllDeferredCmds builds all the deferred operation commands under
+// rootCmd, e.g. to walk the whole command tree.
+func BuildAllDeferredCmds(rootCmd *cobra.Command) {
+	buildDeferred(rootCmd)
+
+	for _, cmd := range rootCmd.Commands() {
+		BuildAllDeferredCmds(cmd)
+	}
+}
+
+//
+// Private variables
+//
+
+// lazyBuilders holds the builders of the resource commands whose operation
+// commands haven't been built yet
+var lazyBuilders = make(map[*cobra.Command]func())
+
+//
+// Private functions
+//
+
+func buildDeferred(cmd *cobra.Command) {
+	build, ok := lazyBuilders[cmd]
+	if !ok {
+		return
+	}
+
+	delete(lazyBuilders, cmd)
+	cmd.Run = nil
+	build()
+}
@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+func TestDeferOperationCmds(t *testing.T) {
+	rootCmd := &cobra.Command{Use: "stripe", Annotations: make(map[string]string)}
+	fooCmd := NewResourceCmd(rootCmd, "foos")
+	barCmd := NewResourceCmd(rootCmd, "bars")
+
+	builds := 0
+	DeferOperationCmds(fooCmd.Cmd, func() {
+		builds++
+		NewOperationCmd(fooCmd.Cmd, "create", "/v1/foos", "post", map[string]string{}, &config.Config{})
+	})
+	DeferOperationCmds(barCmd.Cmd, func() {
+		NewOperationCmd(barCmd.Cmd, "list", "/v1/bars", "get", map[string]string{}, &config.Config{})
+	})
+
+	// Unbuilt resource commands are still listed
+	require.True(t, fooCmd.Cmd.IsAvailableCommand())
+	require.False(t, fooCmd.Cmd.HasSubCommands())
+
+	BuildDeferredCmds(rootCmd, []string{"__complete", "foos", "cr"})
+	require.Equal(t, 1, builds)
+	require.Nil(t, fooCmd.Cmd.Run)
+	require.True(t, fooCmd.Cmd.HasSubCommands())
+	require.False(t, barCmd.Cmd.HasSubCommands())
+
+	BuildDeferredCmds(rootCmd, []string{"foos", "create"})
+	require.Equal(t, 1, builds)
+
+	BuildAllDeferredCmds(rootCmd)
+	require.True(t, barCmd.Cmd.HasSubCommands())
+}
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -11,6 +12,7 @@ import (
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/spec"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
@@ -34,10 +36,21 @@ type OperationCmd struct {
 
 	stringFlags map[string]*string
 
-	data []string
+	data    []string
+	example bool
+
+	// beforeRequest and afterRequest extend operations with convenience
+	// flags, e.g. capturing what's left of a payment intent. beforeRequest
+	// returns false to cancel the request.
+	beforeRequest func(ctx context.Context, apiKey string, args []string) (bool, error)
+	afterRequest  func(ctx context.Context, apiKey string, body []byte) error
 }
 
 func (oc *OperationCmd) runOperationCmd(cmd *cobra.Command, args []string) error {
+	if oc.example {
+		return oc.printExamples()
+	}
+
 	apiKey, err := oc.Profile.GetAPIKey(oc.Livemode)
 	if err != nil {
 		return err
@@ -70,6 +83,13 @@ func (oc *OperationCmd) runOperationCmd(cmd *cobra.Command, args []string) error
 
 	oc.Parameters.AppendData(flagParams)
 
+	if oc.beforeRequest != nil {
+		proceed, err := oc.beforeRequest(cmd.Context(), apiKey, args)
+		if err != nil || !proceed {
+			return err
+		}
+	}
+
 	if oc.HTTPVerb == http.MethodDelete {
 		// display account information and confirm whether user wants to proceed
 		var mode = "Test"
@@ -101,8 +121,30 @@ func (oc *OperationCmd) runOperationCmd(cmd *cobra.Command, args []string) error
 		return err
 	}
 	// else
-	_, err = oc.MakeRequest(cmd.Context(), apiKey, path, &oc.Parameters, false)
-	return err
+	body, err := oc.MakeRequest(cmd.Context(), apiKey, path, &oc.Parameters, false)
+	if err != nil || oc.afterRequest == nil || len(body) == 0 {
+		return err
+	}
+
+	return oc.afterRequest(cmd.Context(), apiKey, body)
+}
+
+func (oc *OperationCmd) printExamples() error {
+	docs, err := spec.LoadResourceDocs()
+	if err != nil {
+		return err
+	}
+
+	doc, ok := docs.FindOperation(oc.HTTPVerb, oc.Path)
+	if !ok {
+		return fmt.Errorf("no examples for %s", oc.Cmd.CommandPath())
+	}
+
+	for _, example := range oc.examples(doc) {
+		fmt.Println(example)
+	}
+
+	return nil
 }
 
 //
@@ -129,7 +171,14 @@ func NewOperationCmd(parentCmd *cobra.Command, name, path, httpVerb string, prop
 		Use:         name,
 		Annotations: make(map[string]string),
 		RunE:        operationCmd.runOperationCmd,
-		Args:        validators.ExactArgs(len(urlParams)),
+		Args: func(cmd *cobra.Command, args []string) error {
+			// Examples fill in the URL parameters
+			if operationCmd.example {
+				return nil
+			}
+
+			return validators.ExactArgs(len(urlParams))(cmd, args)
+		},
 	}
 
 	for prop := range propFlags {
@@ -140,11 +189,26 @@ func NewOperationCmd(parentCmd *cobra.Command, name, path, httpVerb string, prop
 		cmd.Flags().SetAnnotation(flagName, "request", []string{"true"})
 	}
 
+	cmd.Flags().BoolVar(&operationCmd.example, "example", false, "Print example invocations of the command instead of running it")
+
 	cmd.SetUsageTemplate(operationUsageTemplate(urlParams))
 	cmd.DisableFlagsInUseLine = true
 	operationCmd.Cmd = cmd
 	operationCmd.InitFlags()
 
+	if eventOperations[path] {
+		addEventFormatFlags(operationCmd)
+	}
+
+	if path == paymentIntentCapturePath {
+		addCaptureRemainingFlag(operationCmd)
+	}
+
+	// The policy checks which resource the operation uses, and expired login
+	// sessions which operations change objects
+	cmd.Annotations["path"] = path
+	cmd.Annotations["method"] = httpVerb
+
 	parentCmd.AddCommand(cmd)
 	parentCmd.Annotations[name] = "operation"
 
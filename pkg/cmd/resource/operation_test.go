@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/spec"
 )
 
 func TestNewOperationCmd(t *testing.T) {
@@ -127,3 +128,27 @@ func TestRunOperationCmd_NoAPIKey(t *testing.T) {
 
 	require.Error(t, err, "your API key has not been configured. Use `stripe login` to set your API key")
 }
+
+func TestOperationCmdExamples(t *testing.T) {
+	parentCmd := &cobra.Command{Use: "customers", Annotations: make(map[string]string)}
+	oc := NewOperationCmd(parentCmd, "update", "/v1/customers/{customer}", http.MethodPost, map[string]string{
+		"email":    "string",
+		"currency": "string",
+	}, &config.Config{})
+
+	doc := &spec.OperationDoc{
+		Method: http.MethodPost,
+		Path:   "/v1/customers/{customer}",
+		Parameters: []spec.FieldDoc{
+			{Name: "currency", Type: "string", Required: true},
+			{Name: "email", Type: "string"},
+			{Name: "metadata", Type: "object"},
+			{Name: "tax_exempt", Type: "string", Enum: []string{"exempt", "none"}},
+		},
+	}
+
+	require.Equal(t, []string{
+		"customers update cus_123 --currency=usd",
+		`customers update cus_123 --currency=usd --email=jenny.rosen@example.com -d "metadata[order_id]=6735"`,
+	}, oc.examples(doc))
+}
@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/payments"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+)
+
+// paymentIntentCapturePath is the path of the operation capturing a payment
+// intent, which can capture whatever is left to capture
+const paymentIntentCapturePath = "/v1/payment_intents/{intent}/capture"
+
+// addCaptureRemainingFlag adds the --amount-remaining flag to the operation
+// capturing a payment intent. The amount left to capture is retrieved
+// instead of being worked out by hand, live captures are confirmed, and the
+// balance transaction of the capture is printed after it.
+func addCaptureRemainingFlag(oc *OperationCmd) {
+	var remaining bool
+
+	oc.Cmd.Flags().BoolVar(&remaining, "amount-remaining", false, "Capture the whole amount left to capture, confirming first in live mode")
+
+	oc.beforeRequest = func(ctx context.Context, apiKey string, args []string) (bool, error) {
+		if !remaining {
+			return true, nil
+		}
+
+		if oc.Cmd.Flags().Changed("amount-to-capture") {
+			return false, fmt.Errorf("--amount-remaining can't be used with --amount-to-capture")
+		}
+
+		client := simulate.NewAPIClient(apiKey, oc.APIBaseURL)
+
+		plan, err := payments.PlanCaptureRemaining(ctx, client, args[0])
+		if err != nil {
+			return false, err
+		}
+
+		autoConfirm, _ := oc.Cmd.Flags().GetBool("confirm")
+
+		confirmed, err := confirmPlan(payments.Describe("Capture", plan), plan.Livemode && !autoConfirm)
+		if err != nil || !confirmed {
+			return false, err
+		}
+
+		oc.Parameters.AppendData([]string{
+			"amount_to_capture=" + strconv.FormatInt(plan.Amount, 10),
+			"expand[]=latest_charge.balance_transaction",
+		})
+
+		return true, nil
+	}
+
+	oc.afterRequest = func(ctx context.Context, apiKey string, body []byte) error {
+		intent := gjson.ParseBytes(body)
+		if !remaining || intent.Get("object").String() != "payment_intent" {
+			return nil
+		}
+
+		txn, err := payments.BalanceTransaction(ctx, simulate.NewAPIClient(apiKey, oc.APIBaseURL), intent)
+		if err != nil {
+			return err
+		}
+
+		// Like the plan, the balance transaction goes to stderr so that
+		// the payment intent printed on stdout stays parseable
+		fmt.Fprintln(os.Stderr)
+		payments.RenderBalanceTransaction(os.Stderr, txn)
+
+		return nil
+	}
+}
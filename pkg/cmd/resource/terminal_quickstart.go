@@ -35,15 +35,9 @@ func NewQuickstartCmd(parentCmd *cobra.Command, config *config.Config) {
 }
 
 func (cc *QuickstartCmd) runQuickstartCmd(cmd *cobra.Command, args []string) error {
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
-	key, err := cc.cfg.Profile.GetAPIKey(false)
-
-	if err != nil {
-		return fmt.Errorf(err.Error())
-	}
-
-	err = validators.APIKeyNotRestricted(key)
+	_, err := cc.cfg.Profile.GetSecretKey(false)
 
 	if err != nil {
 		return fmt.Errorf(err.Error())
@@ -0,0 +1,274 @@
+package resource
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// TreasurySimulateCmd groups the commands simulating money movement on
+// Treasury financial accounts through the test helpers
+type TreasurySimulateCmd struct {
+	cfg *config.Config
+	Cmd *cobra.Command
+}
+
+// TreasurySimulateReceivedCmd creates a test mode received credit or debit
+type TreasurySimulateReceivedCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	debit      bool
+	params     simulate.TreasuryParams
+	amount     amountFlag
+	apiBaseURL string
+}
+
+// TreasurySimulateScenarioCmd runs a multi-step money movement flow
+type TreasurySimulateScenarioCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params     simulate.TreasuryParams
+	amount     amountFlag
+	apiBaseURL string
+}
+
+// FinancialConnectionsSessionCmd creates a test mode Financial Connections
+// session
+type FinancialConnectionsSessionCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	params     simulate.FinancialConnectionsSessionParams
+	apiBaseURL string
+}
+
+// AddTreasurySubCmds adds custom subcommands to the `treasury` and
+// `financial_connections` namespace commands. The namespaces are created if
+// the resource commands don't include them.
+func AddTreasurySubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	NewTreasurySimulateCmd(namespaceCmd(rootCmd, "treasury"), cfg)
+	NewFinancialConnectionsSessionCmd(namespaceCmd(rootCmd, "financial_connections"), cfg)
+
+	return nil
+}
+
+// NewTreasurySimulateCmd returns a new `treasury simulate` command
+func NewTreasurySimulateCmd(parentCmd *cobra.Command, cfg *config.Config) *TreasurySimulateCmd {
+	simulateCmd := &TreasurySimulateCmd{
+		cfg: cfg,
+	}
+
+	simulateCmd.Cmd = &cobra.Command{
+		Use:         "simulate",
+		Args:        validators.NoArgs,
+		Short:       "Simulate money movement with the Treasury test helpers",
+		Annotations: make(map[string]string),
+	}
+	simulateCmd.Cmd.SetUsageTemplate(resourceUsageTemplate())
+
+	newTreasurySimulateReceivedCmd(simulateCmd.Cmd, cfg, false)
+	newTreasurySimulateReceivedCmd(simulateCmd.Cmd, cfg, true)
+	newTreasurySimulateScenarioCmd(simulateCmd.Cmd, cfg)
+
+	inboundPath := "/v1/test_helpers/treasury/inbound_transfers/{id}"
+	NewOperationCmd(simulateCmd.Cmd, "succeed-inbound-transfer", inboundPath+"/succeed", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Mark an inbound transfer as succeeded"
+	NewOperationCmd(simulateCmd.Cmd, "fail-inbound-transfer", inboundPath+"/fail", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Mark an inbound transfer as failed"
+	NewOperationCmd(simulateCmd.Cmd, "return-inbound-transfer", inboundPath+"/return", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Return a succeeded inbound transfer"
+
+	outboundPath := "/v1/test_helpers/treasury/outbound_transfers/{outbound_transfer}"
+	NewOperationCmd(simulateCmd.Cmd, "post-outbound-transfer", outboundPath+"/post", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Mark an outbound transfer as posted"
+	NewOperationCmd(simulateCmd.Cmd, "fail-outbound-transfer", outboundPath+"/fail", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Mark an outbound transfer as failed"
+	NewOperationCmd(simulateCmd.Cmd, "return-outbound-transfer", outboundPath+"/return", http.MethodPost, map[string]string{}, cfg).Cmd.Short = "Return a posted outbound transfer"
+
+	parentCmd.AddCommand(simulateCmd.Cmd)
+	parentCmd.Annotations["simulate"] = "resource"
+
+	return simulateCmd
+}
+
+// NewFinancialConnectionsSessionCmd returns a new
+// `financial_connections session` command
+func NewFinancialConnectionsSessionCmd(parentCmd *cobra.Command, cfg *config.Config) *FinancialConnectionsSessionCmd {
+	sc := &FinancialConnectionsSessionCmd{
+		cfg: cfg,
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:   "session",
+		Args:  validators.NoArgs,
+		Short: "Create a test mode Financial Connections session for a customer",
+		Long: `Create a test mode Financial Connections session for a customer. Pass the
+session's client secret to Stripe.js to link accounts from the test
+institutions.`,
+		Example: `stripe financial_connections session --customer cus_123
+  stripe financial_connections session --customer cus_123 --permissions balances,ownership`,
+		RunE: sc.runSessionCmd,
+	}
+
+	sc.cmd.Flags().StringVar(&sc.params.Customer, "customer", "", "ID of the customer owning the linked accounts (required)")
+	sc.cmd.Flags().StringSliceVar(&sc.params.Permissions, "permissions", []string{"payment_method"}, "Data to request access to: balances, ownership, payment_method, transactions")
+	sc.cmd.Flags().StringVar(&sc.params.ReturnURL, "return-url", "", "URL to redirect to after the authentication flow")
+	sc.cmd.MarkFlagRequired("customer") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	sc.cmd.Flags().StringVar(&sc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	sc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(sc.cmd)
+	parentCmd.Annotations["session"] = "operation"
+
+	return sc
+}
+
+func newTreasurySimulateReceivedCmd(parentCmd *cobra.Command, cfg *config.Config, debit bool) {
+	rc := &TreasurySimulateReceivedCmd{
+		cfg:   cfg,
+		debit: debit,
+	}
+
+	name, direction := "received-credit", "sent to"
+	if debit {
+		name, direction = "received-debit", "pulled from"
+	}
+
+	rc.cmd = &cobra.Command{
+		Use:     name,
+		Args:    validators.NoArgs,
+		Short:   fmt.Sprintf("Simulate money %s a financial account by an external account", direction),
+		Example: fmt.Sprintf("stripe treasury simulate %s --financial-account fa_123 --amount 1000", name),
+		RunE:    rc.runReceivedCmd,
+	}
+
+	addTreasuryFlags(rc.cmd, &rc.params, &rc.amount, &rc.apiBaseURL)
+
+	parentCmd.AddCommand(rc.cmd)
+	parentCmd.Annotations[name] = "operation"
+}
+
+func newTreasurySimulateScenarioCmd(parentCmd *cobra.Command, cfg *config.Config) {
+	sc := &TreasurySimulateScenarioCmd{
+		cfg: cfg,
+	}
+
+	names := simulate.TreasuryScenarioNames()
+
+	long := "Run a multi-step money movement flow on a financial account. Supported scenarios:"
+	for _, name := range names {
+		long += fmt.Sprintf("\n  %-16s %s", name, simulate.TreasuryScenarios[name])
+	}
+
+	sc.cmd = &cobra.Command{
+		Use:       "scenario <name>",
+		Args:      validators.ExactArgs(1),
+		ValidArgs: names,
+		Short:     "Run a multi-step money movement flow",
+		Long:      long,
+		Example:   `stripe treasury simulate scenario credit-reversal --financial-account fa_123 --amount 1000`,
+		RunE:      sc.runScenarioCmd,
+	}
+
+	addTreasuryFlags(sc.cmd, &sc.params, &sc.amount, &sc.apiBaseURL)
+
+	parentCmd.AddCommand(sc.cmd)
+	parentCmd.Annotations["scenario"] = "operation"
+}
+
+func addTreasuryFlags(cmd *cobra.Command, params *simulate.TreasuryParams, amount *amountFlag, apiBaseURL *string) {
+	cmd.Flags().StringVar(&params.FinancialAccount, "financial-account", "", "ID of the financial account (required)")
+	addAmountFlag(cmd, amount, "Amount of money, in the smallest currency unit or with a decimal separator, e.g. 1000 or $10.00 (required)")
+	cmd.Flags().StringVar(&params.Currency, "currency", "", "Currency of the money movement (default: the currency of the amount, or usd)")
+	cmd.Flags().StringVar(&params.Network, "network", "ach", "Network of the money movement: ach or us_domestic_wire")
+	cmd.MarkFlagRequired("financial-account") // #nosec G104
+	cmd.MarkFlagRequired("amount")            // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	cmd.Flags().StringVar(apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	cmd.Flags().MarkHidden("api-base") // #nosec G104
+}
+
+// namespaceCmd returns the namespace command called name, creating it if
+// it doesn't exist.
+func namespaceCmd(rootCmd *cobra.Command, name string) *cobra.Command {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == name {
+			return cmd
+		}
+	}
+
+	return NewNamespaceCmd(rootCmd, name).Cmd
+}
+
+func (rc *TreasurySimulateReceivedCmd) runReceivedCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := rc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	client := simulate.NewAPIClient(apiKey, rc.apiBaseURL)
+
+	simulateReceived := simulate.ReceivedCredit
+	if rc.debit {
+		simulateReceived = simulate.ReceivedDebit
+	}
+
+	rc.params.Amount, rc.params.Currency, err = rc.amount.resolve(rc.params.Currency, "usd")
+	if err != nil {
+		return err
+	}
+
+	received, err := simulateReceived(cmd.Context(), client, rc.params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ansi.ColorizeJSON(received.Raw, false, os.Stdout))
+
+	return nil
+}
+
+func (sc *TreasurySimulateScenarioCmd) runScenarioCmd(cmd *cobra.Command, args []string) error {
+	if _, ok := simulate.TreasuryScenarios[args[0]]; !ok {
+		return fmt.Errorf("unsupported scenario %q. Supported scenarios: %s", args[0], strings.Join(simulate.TreasuryScenarioNames(), ", "))
+	}
+
+	apiKey, err := sc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	sc.params.Amount, sc.params.Currency, err = sc.amount.resolve(sc.params.Currency, "usd")
+	if err != nil {
+		return err
+	}
+
+	return simulate.RunTreasuryScenario(cmd.Context(), simulate.NewAPIClient(apiKey, sc.apiBaseURL), args[0], sc.params, func(step simulate.ScenarioStep) {
+		fmt.Printf("%s %s [%s]\n", ansi.SuccessGlyph(), step.Name, step.ObjectID)
+	})
+}
+
+func (sc *FinancialConnectionsSessionCmd) runSessionCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := sc.cfg.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	session, err := simulate.FinancialConnectionsSession(cmd.Context(), simulate.NewAPIClient(apiKey, sc.apiBaseURL), sc.params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ansi.ColorizeJSON(session.Raw, false, os.Stdout))
+
+	return nil
+}
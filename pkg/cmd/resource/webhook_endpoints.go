@@ -0,0 +1,25 @@
+package resource
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// AddWebhookEndpointsSubCmds adds custom subcommands to the
+// `webhook_endpoints` command created automatically as a resource command.
+func AddWebhookEndpointsSubCmds(rootCmd *cobra.Command, cfg *config.Config) error {
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Use == "webhook_endpoints" {
+			cmd.Aliases = append(cmd.Aliases, "webhook-endpoints")
+
+			NewWebhookEndpointsHealthCmd(cmd, cfg)
+
+			return nil
+		}
+	}
+
+	return errors.New("Could not find webhook_endpoints command")
+}
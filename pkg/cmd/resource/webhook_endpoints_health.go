@@ -0,0 +1,342 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// maxHealthEvents is the number of events past which the health report
+// stops listing them
+const maxHealthEvents = 1000
+
+// maxRecentFailures is the number of failed events listed in the report
+const maxRecentFailures = 10
+
+// WebhookEndpointsHealthCmd summarizes the deliveries of the events of a
+// webhook endpoint
+type WebhookEndpointsHealthCmd struct {
+	cfg *config.Config
+	cmd *cobra.Command
+
+	since      string
+	livemode   bool
+	format     string
+	apiBaseURL string
+}
+
+// EndpointHealth is the delivery health of a webhook endpoint
+type EndpointHealth struct {
+	Endpoint string    `json:"endpoint"`
+	URL      string    `json:"url"`
+	Status   string    `json:"status"`
+	Since    time.Time `json:"since"`
+
+	// Events is the number of events the endpoint is subscribed to since
+	Events int `json:"events"`
+
+	// Failed is the number of these events with a failed delivery, to this
+	// endpoint or another one subscribed to them
+	Failed int `json:"failed"`
+
+	// Pending is the number of these events with deliveries still pending
+	Pending int `json:"pending"`
+
+	RecentFailures []FailedEvent `json:"recent_failures"`
+
+	// SharedWith is the number of other enabled endpoints subscribed to
+	// some of the same events
+	SharedWith int `json:"shared_with"`
+
+	// Truncated tells whether there were more events than the report counts
+	Truncated bool `json:"truncated"`
+}
+
+// FailedEvent is an event with a failed delivery
+type FailedEvent struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	Created         time.Time `json:"created"`
+	PendingWebhooks int64     `json:"pending_webhooks"`
+}
+
+// NewWebhookEndpointsHealthCmd returns a new WebhookEndpointsHealthCmd.
+func NewWebhookEndpointsHealthCmd(parentCmd *cobra.Command, cfg *config.Config) *WebhookEndpointsHealthCmd {
+	hc := &WebhookEndpointsHealthCmd{
+		cfg: cfg,
+	}
+
+	hc.cmd = &cobra.Command{
+		Use:   "health <webhook_endpoint>",
+		Args:  validators.ExactArgs(1),
+		Short: "Summarize the deliveries of the events of a webhook endpoint",
+		Long: `Summarize the deliveries of the events a webhook endpoint is subscribed to:
+their success rate, the most recent failures, and the events with retries
+pending.
+
+The API tells whether all the deliveries of an event succeeded, but not to
+which endpoint they failed nor their response codes. When other endpoints are
+subscribed to the same events, some failures may come from them: the
+Dashboard has the delivery attempts of each endpoint.`,
+		Example: `stripe webhook_endpoints health we_123
+  stripe webhook_endpoints health we_123 --since 24h --live`,
+		RunE: hc.runHealthCmd,
+	}
+
+	hc.cmd.Flags().StringVar(&hc.since, "since", "7d", "Only count the events created in this period, e.g. 24h or 30d")
+	hc.cmd.Flags().BoolVar(&hc.livemode, "live", false, "Report on a live mode endpoint (default: test)")
+	hc.cmd.Flags().StringVar(&hc.format, "format", "", `Specifies the output format of the report
+	Acceptable values:
+		'JSON' - Output the report in JSON format`)
+
+	// Hidden configuration flags, useful for dev/debugging
+	hc.cmd.Flags().StringVar(&hc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	hc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	parentCmd.AddCommand(hc.cmd)
+	parentCmd.Annotations["health"] = "operation"
+
+	return hc
+}
+
+func (hc *WebhookEndpointsHealthCmd) runHealthCmd(cmd *cobra.Command, args []string) error {
+	period, err := ParsePeriod(hc.since)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := hc.cfg.Profile.GetAPIKey(hc.livemode)
+	if err != nil {
+		return err
+	}
+
+	health, err := endpointHealth(cmd.Context(), simulate.NewAPIClient(apiKey, hc.apiBaseURL), args[0], time.Now().Add(-period))
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(hc.format) == "JSON" {
+		out, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	printEndpointHealth(health, hc.livemode)
+
+	return nil
+}
+
+// endpointHealth computes the delivery health of an endpoint from the
+// events created since
+func endpointHealth(ctx context.Context, client simulate.APIClient, id string, since time.Time) (*EndpointHealth, error) {
+	endpoint, err := client.Request(ctx, http.MethodGet, "/v1/webhook_endpoints/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool)
+	for _, event := range endpoint.Get("enabled_events").Array() {
+		enabled[event.String()] = true
+	}
+
+	subscribed := func(eventType string) bool {
+		return enabled["*"] || enabled[eventType]
+	}
+
+	health := &EndpointHealth{
+		Endpoint: id,
+		URL:      endpoint.Get("url").String(),
+		Status:   endpoint.Get("status").String(),
+		Since:    since.UTC().Truncate(time.Second),
+	}
+
+	events, truncated, err := listEvents(ctx, client, since, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	failedEvents, failedTruncated, err := listEvents(ctx, client, since, []string{"delivery_success=false"})
+	if err != nil {
+		return nil, err
+	}
+
+	health.Truncated = truncated || failedTruncated
+
+	for _, event := range events {
+		if !subscribed(event.Get("type").String()) {
+			continue
+		}
+
+		health.Events++
+
+		if event.Get("pending_webhooks").Int() > 0 {
+			health.Pending++
+		}
+	}
+
+	for _, event := range failedEvents {
+		if !subscribed(event.Get("type").String()) {
+			continue
+		}
+
+		health.Failed++
+
+		if len(health.RecentFailures) < maxRecentFailures {
+			health.RecentFailures = append(health.RecentFailures, FailedEvent{
+				ID:              event.Get("id").String(),
+				Type:            event.Get("type").String(),
+				Created:         time.Unix(event.Get("created").Int(), 0).UTC(),
+				PendingWebhooks: event.Get("pending_webhooks").Int(),
+			})
+		}
+	}
+
+	endpoints, err := client.Request(ctx, http.MethodGet, "/v1/webhook_endpoints", []string{"limit=100"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, other := range endpoints.Get("data").Array() {
+		if other.Get("id").String() == id || other.Get("status").String() != "enabled" {
+			continue
+		}
+
+		for _, event := range other.Get("enabled_events").Array() {
+			if event.String() == "*" || enabled["*"] || enabled[event.String()] {
+				health.SharedWith++
+				break
+			}
+		}
+	}
+
+	return health, nil
+}
+
+// listEvents lists the events created since, most recent first, up to
+// maxHealthEvents
+func listEvents(ctx context.Context, client simulate.APIClient, since time.Time, params []string) ([]gjson.Result, bool, error) {
+	var events []gjson.Result
+
+	startingAfter := ""
+
+	for {
+		pageParams := append([]string{"limit=100", "created[gte]=" + strconv.FormatInt(since.Unix(), 10)}, params...)
+		if startingAfter != "" {
+			pageParams = append(pageParams, "starting_after="+startingAfter)
+		}
+
+		page, err := client.Request(ctx, http.MethodGet, "/v1/events", pageParams)
+		if err != nil {
+			return nil, false, err
+		}
+
+		data := page.Get("data").Array()
+		events = append(events, data...)
+
+		if len(events) >= maxHealthEvents {
+			return events[:maxHealthEvents], true, nil
+		}
+
+		if !page.Get("has_more").Bool() || len(data) == 0 {
+			return events, false, nil
+		}
+
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+}
+
+func printEndpointHealth(health *EndpointHealth, livemode bool) {
+	color := ansi.Color(os.Stdout)
+
+	fmt.Printf("%s %s (%s)\n", ansi.Bold("Webhook endpoint"), health.Endpoint, health.Status)
+	fmt.Printf("  %s\n\n", health.URL)
+
+	fmt.Println(ansi.Bold("Deliveries since " + health.Since.Local().Format("2006-01-02 15:04:05")))
+
+	if health.Events == 0 {
+		fmt.Println("  No events the endpoint is subscribed to.")
+	} else {
+		delivered := health.Events - health.Failed
+		rate := float64(delivered) * 100 / float64(health.Events)
+
+		glyph := ansi.SuccessGlyph()
+		if health.Failed > 0 {
+			glyph = ansi.WarningGlyph()
+		}
+
+		fmt.Printf("  Events:     %d\n", health.Events)
+		fmt.Printf("  Delivered:  %d (%.1f%%) %s\n", delivered, rate, glyph)
+		fmt.Printf("  Failed:     %d\n", health.Failed)
+		fmt.Printf("  Pending:    %d\n", health.Pending)
+	}
+
+	if health.Truncated {
+		fmt.Printf("  %s Only the last %d events were counted, use a shorter --since\n", ansi.WarningGlyph(), maxHealthEvents)
+	}
+
+	if len(health.RecentFailures) > 0 {
+		fmt.Println()
+		fmt.Println(ansi.Bold("Recent failures"))
+
+		for _, event := range health.RecentFailures {
+			pending := ""
+			if event.PendingWebhooks > 0 {
+				pending = color.Faint(fmt.Sprintf(" %d pending", event.PendingWebhooks)).String()
+			}
+
+			fmt.Printf("  %s %s  %s  %s%s\n", ansi.ErrorGlyph(), event.Created.Local().Format("2006-01-02 15:04:05"), event.Type, event.ID, pending)
+		}
+	}
+
+	fmt.Println()
+
+	if health.SharedWith > 0 {
+		fmt.Printf("%s %d other endpoint(s) receive some of these events, failures may come from them.\n", ansi.WarningGlyph(), health.SharedWith)
+	}
+
+	dashboardURL := "https://dashboard.stripe.com/test/webhooks/" + health.Endpoint
+	if livemode {
+		dashboardURL = "https://dashboard.stripe.com/webhooks/" + health.Endpoint
+	}
+
+	fmt.Println("Response codes aren't available through the API, see the delivery attempts in the Dashboard:")
+	fmt.Println("  " + dashboardURL)
+}
+
+// ParsePeriod parses a period like 30d or 12h
+func ParsePeriod(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days <= 0 {
+			return 0, fmt.Errorf("invalid period %s, e.g. 7d or 24h", value)
+		}
+
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid period %s, e.g. 7d or 24h", value)
+	}
+
+	return period, nil
+}
@@ -0,0 +1,76 @@
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type webhookEndpointsClient struct{}
+
+func (c *webhookEndpointsClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	query := strings.Join(params, "&")
+
+	switch {
+	case path == "/v1/webhook_endpoints/we_123":
+		return gjson.Parse(`{"id":"we_123","url":"https://example.com/webhooks","status":"enabled","enabled_events":["charge.succeeded","charge.failed"]}`), nil
+	case path == "/v1/webhook_endpoints":
+		return gjson.Parse(`{"data":[
+			{"id":"we_123","status":"enabled","enabled_events":["charge.succeeded","charge.failed"]},
+			{"id":"we_456","status":"enabled","enabled_events":["charge.failed"]},
+			{"id":"we_789","status":"disabled","enabled_events":["*"]}
+		]}`), nil
+	case path == "/v1/events" && strings.Contains(query, "delivery_success=false"):
+		return gjson.Parse(`{"has_more":false,"data":[
+			{"id":"evt_3","type":"charge.failed","created":1700000300,"pending_webhooks":1},
+			{"id":"evt_4","type":"customer.created","created":1700000200,"pending_webhooks":1}
+		]}`), nil
+	case path == "/v1/events" && !strings.Contains(query, "starting_after"):
+		return gjson.Parse(`{"has_more":true,"data":[
+			{"id":"evt_3","type":"charge.failed","created":1700000300,"pending_webhooks":1},
+			{"id":"evt_4","type":"customer.created","created":1700000200,"pending_webhooks":1}
+		]}`), nil
+	case path == "/v1/events" && strings.Contains(query, "starting_after=evt_4"):
+		return gjson.Parse(`{"has_more":false,"data":[
+			{"id":"evt_2","type":"charge.succeeded","created":1700000100,"pending_webhooks":0},
+			{"id":"evt_1","type":"charge.succeeded","created":1700000000,"pending_webhooks":0}
+		]}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestEndpointHealth(t *testing.T) {
+	health, err := endpointHealth(context.Background(), &webhookEndpointsClient{}, "we_123", time.Unix(1699990000, 0))
+	require.NoError(t, err)
+
+	require.Equal(t, "https://example.com/webhooks", health.URL)
+	require.Equal(t, 3, health.Events)
+	require.Equal(t, 1, health.Failed)
+	require.Equal(t, 1, health.Pending)
+	require.Equal(t, 1, health.SharedWith)
+	require.False(t, health.Truncated)
+	require.Len(t, health.RecentFailures, 1)
+	require.Equal(t, "evt_3", health.RecentFailures[0].ID)
+	require.Equal(t, int64(1), health.RecentFailures[0].PendingWebhooks)
+}
+
+func TestParsePeriod(t *testing.T) {
+	period, err := ParsePeriod("7d")
+	require.NoError(t, err)
+	require.Equal(t, 7*24*time.Hour, period)
+
+	period, err = ParsePeriod("12h")
+	require.NoError(t, err)
+	require.Equal(t, 12*time.Hour, period)
+
+	_, err = ParsePeriod("0d")
+	require.Error(t, err)
+
+	_, err = ParsePeriod("week")
+	require.Error(t, err)
+}
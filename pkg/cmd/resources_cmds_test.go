@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// BenchmarkAddAllResourcesCmds measures the cost of registering the generated
+// resource commands, which is paid by every invocation of the CLI, including
+// shell completions.
+func BenchmarkAddAllResourcesCmds(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		addAllResourcesCmds(&cobra.Command{Use: "stripe", Annotations: make(map[string]string)})
+	}
+}
@@ -1,5 +1,7 @@
 //go:generate go run ../gen/gen_resources_cmds.go
 //go:generate go run ../gen/gen_events_list.go
+//go:generate go run ../gen/gen_resource_schemas.go
+//go:generate go run ../gen/gen_resource_docs.go
 
 package cmd
 
@@ -7,19 +9,35 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 
+	"github.com/stripe/stripe-cli/pkg/clierrors"
 	"github.com/stripe/stripe-cli/pkg/cmd/resource"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/correlation"
+	"github.com/stripe/stripe-cli/pkg/cursors"
+	"github.com/stripe/stripe-cli/pkg/deprecation"
+	"github.com/stripe/stripe-cli/pkg/experiments"
+	"github.com/stripe/stripe-cli/pkg/gha"
+	"github.com/stripe/stripe-cli/pkg/history"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/offline"
+	"github.com/stripe/stripe-cli/pkg/progress"
+	"github.com/stripe/stripe-cli/pkg/redact"
 	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/shutdown"
 	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/undo"
 	"github.com/stripe/stripe-cli/pkg/useragent"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
@@ -30,6 +48,23 @@ var Config config.Config
 
 var fs = afero.NewOsFs()
 
+// deadline enforces the global `--timeout` flag
+var deadline *commandDeadline
+
+var timeout time.Duration
+
+var transcriptPath string
+
+// progressFD is the file descriptor progress events are written to, if set
+var progressFD int
+
+// outputMode is set by `--output` to adapt the output to CI environments
+var outputMode string
+
+// offlineMode is set by `--offline` to fail fast instead of reaching the
+// network
+var offlineMode bool
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:           "stripe",
@@ -51,16 +86,97 @@ var rootCmd = &cobra.Command{
 %s`,
 		getLogin(&fs, &Config),
 	),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if outputMode != "" && outputMode != gha.OutputMode {
+			return fmt.Errorf("unsupported output mode %q. Expected %q", outputMode, gha.OutputMode)
+		}
+
+		if Config.LogFile != "" {
+			closeLogFile, err := logging.SetOutputFile(Config.LogFile)
+			if err != nil {
+				return fmt.Errorf("could not open the log file: %w", err)
+			}
+
+			shutdown.OnShutdown(cmd.Context(), "log file", func(ctx context.Context) {
+				closeLogFile() // #nosec G104
+			})
+		}
+
+		// Start recording before anything is printed, e.g. the errors of the
+		// policy or the login session
+		if transcriptPath != "" {
+			transcript, err := startTranscript(transcriptPath, os.Args[1:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not record transcript: %v\n", err)
+			} else {
+				shutdown.OnShutdown(cmd.Context(), "transcript", func(ctx context.Context) {
+					transcript.Close()
+				})
+			}
+		}
+
+		deadline.arm(timeout)
+
+		offline.Enabled = offlineMode
+
+		if progressFD > 0 {
+			if err := progress.EnableFD(progressFD); err != nil {
+				return err
+			}
+		}
+
+		// Requests are only recorded while stripe listen or stripe tail looks
+		// them up
+		correlation.Enable(
+			filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), correlation.IndexFileName),
+			redact.MaskSecrets(strings.Join(append([]string{cmd.CommandPath()}, args...), " ")),
+		)
+
+		if Config.GetUndoEnabled() {
+			undo.Enable(undoJournalPath(), strings.Join(append([]string{cmd.CommandPath()}, args...), " "))
+		}
+
+		cursors.Enable(filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), cursors.FileName))
+
+		enableHistory(cmd)
+
+		if err := enforcePolicy(cmd, args); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if err := checkLoginSession(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		printContextBanner(cmd)
+
+		deprecation.GitHubActions = ghaOutput()
+		warnDeprecatedFlags(cmd)
+
+		if Config.GetTelemetryOptOut() || offline.Enabled {
+			if telemetryClient, ok := stripe.GetTelemetryClient(cmd.Context()).(*stripe.AnalyticsTelemetryClient); ok {
+				telemetryClient.Disable()
+			}
+		}
+
 		// if getting the config errors, don't fail running the command
 		merchant, _ := Config.Profile.GetAccountID()
 		telemetryMetadata := stripe.GetEventMetadata(cmd.Context())
 		telemetryMetadata.SetCobraCommandContext(cmd)
 		telemetryMetadata.SetMerchant(merchant)
 		telemetryMetadata.SetUserAgent(useragent.GetEncodedUserAgent())
+		telemetryMetadata.SetExperiments(experiments.EnabledNames())
+
+		if ghaOutput() {
+			maskAPIKeys()
+		}
 
 		// record command invocation
 		sendCommandInvocationEvent(cmd.Context())
+
+		return nil
 	},
 }
 
@@ -71,15 +187,64 @@ func sendCommandInvocationEvent(ctx context.Context) {
 	}
 }
 
+// errorMessage returns the message of an error, followed by the hint on how
+// to fix it for the errors categorized by clierrors
+func errorMessage(err error) string {
+	if hint := clierrors.Hint(err); hint != "" {
+		return err.Error() + "\n" + hint
+	}
+
+	return err.Error()
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(ctx context.Context) {
 	telemetryMetadata := stripe.NewEventMetadata()
 	updatedCtx := stripe.WithEventMetadata(ctx, telemetryMetadata)
+	updatedCtx, deadline = withDeadline(updatedCtx)
+
+	coordinator := shutdown.New(&shutdown.Config{Log: log.StandardLogger()})
+	updatedCtx = coordinator.Context(updatedCtx)
+	coordinator.HandleSignals()
+
+	// Wait for in-flight telemetry calls before exiting the process
+	if telemetryClient, ok := stripe.GetTelemetryClient(ctx).(interface{ Wait() }); ok {
+		coordinator.OnShutdown("telemetry", func(ctx context.Context) {
+			telemetryClient.Wait()
+		})
+	}
 
 	rootCmd.SetUsageTemplate(getUsageTemplate())
 	rootCmd.SetVersionTemplate(version.Template)
-	if err := rootCmd.ExecuteContext(updatedCtx); err != nil {
+	args := expandAliases(os.Args[1:])
+	rootCmd.SetArgs(args)
+	resource.BuildDeferredCmds(rootCmd, args)
+	suggestSubcommands(rootCmd, args)
+	rootCmd.SetFlagErrorFunc(suggestFlag)
+	err := rootCmd.ExecuteContext(updatedCtx)
+
+	if deadline.Expired() {
+		fmt.Fprintf(os.Stderr, "Command timed out after %s.\n", deadline.timeout)
+		history.Record(os.Args[1:], exitCodeTimeout) // #nosec G104
+		coordinator.Shutdown()
+		os.Exit(exitCodeTimeout)
+	}
+
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+
+	history.Record(os.Args[1:], exitCode) // #nosec G104
+
+	if err != nil && ghaOutput() {
+		gha.Error(os.Stdout, errorMessage(err))
+		coordinator.Shutdown()
+		os.Exit(1)
+	}
+
+	if err != nil {
 		errString := err.Error()
 		isLoginRequiredError := errString == validators.ErrAPIKeyNotConfigured.Error() || errString == validators.ErrDeviceNameNotConfigured.Error()
 
@@ -91,6 +256,13 @@ func Execute(ctx context.Context) {
 			errRunes := []rune(errString)
 			errRunes[0] = unicode.ToUpper(errRunes[0])
 
+			// Logging in requires a browser or a prompt, neither of which is
+			// available in containers and other non-interactive environments
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				fmt.Printf("%s. Set the STRIPE_API_KEY or STRIPE_API_KEY_FILE environment variable, or run `stripe login`.\n", string(errRunes))
+				break
+			}
+
 			fmt.Printf("%s. Running `stripe login`...\n", string(errRunes))
 
 			err = login.Login(updatedCtx, stripe.DefaultDashboardBaseURL, &Config, os.Stdin)
@@ -112,9 +284,10 @@ func Execute(ctx context.Context) {
 				os.Args[1], rootCmd.CommandPath(), suggStr))
 
 		default:
-			fmt.Println(err)
+			fmt.Println(errorMessage(err))
 		}
 
+		coordinator.Shutdown()
 		os.Exit(1)
 	} else {
 		userInput := os.Args[1:]
@@ -123,39 +296,75 @@ func Execute(ctx context.Context) {
 			fmt.Println("You provided the \"--color\" flag but did not specify any command. The \"--color\" flag configures the color output of a specified command.")
 		}
 	}
+
+	coordinator.Shutdown()
 }
 
 func init() {
-	cobra.OnInitialize(Config.InitConfig)
+	cobra.OnInitialize(Config.InitConfig, useActiveProfile)
 
 	rootCmd.PersistentFlags().StringVar(&Config.Profile.APIKey, "api-key", "", "Your API key to use for the command")
 	rootCmd.PersistentFlags().StringVar(&Config.Color, "color", "", "turn on/off color output (on, off, auto)")
 	rootCmd.PersistentFlags().StringVar(&Config.ProfilesFile, "config", "", "config file (default is $HOME/.config/stripe/config.toml)")
+	rootCmd.PersistentFlags().StringVar(&Config.ConfigDir, "config-dir", "", "directory holding the config file and other CLI data (default is $STRIPE_CONFIG_DIR or $HOME/.config/stripe)")
 	rootCmd.PersistentFlags().StringVar(&Config.Profile.DeviceName, "device-name", "", "device name")
+	rootCmd.PersistentFlags().StringVar(&Config.LogFile, "log-file", "", "write logs to a file instead of stderr")
+	rootCmd.PersistentFlags().StringVar(&Config.LogFormat, "log-format", "text", "log format (text, json)")
 	rootCmd.PersistentFlags().StringVar(&Config.LogLevel, "log-level", "info", "log level (debug, info, trace, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&Config.DebugComponents, "debug", "", fmt.Sprintf("comma-separated components to log at the debug level (all, %s)", strings.Join(logging.Components(), ", ")))
+	rootCmd.PersistentFlags().BoolVar(&Config.NoPager, "no-pager", false, "print long outputs directly instead of through $PAGER")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "fail right away instead of reaching the network, and use cached data where available, e.g. for tests and demos")
+	rootCmd.PersistentFlags().StringVar(&outputMode, "output", "", "output mode for CI environments (gha: GitHub Actions workflow commands)")
+	rootCmd.PersistentFlags().IntVar(&progressFD, "progress-fd", 0, "write machine-readable progress events of long operations as JSON lines to this file descriptor, e.g. 3")
 	rootCmd.PersistentFlags().StringVarP(&Config.Profile.ProfileName, "project-name", "p", "default", "the project name to read from for config")
+	rootCmd.PersistentFlags().BoolVar(&Config.Quiet, "quiet", false, "suppress spinners, banners and hints, e.g. in scripts")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "maximum time the command is allowed to run, e.g. 30s or 5m (default: no limit)")
+	rootCmd.PersistentFlags().StringVar(&transcriptPath, "transcript", "", "record the session output to a file, with secrets redacted, e.g. to attach to a support ticket")
 	rootCmd.Flags().BoolP("version", "v", false, "Get the version of the Stripe CLI")
 
 	viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
 
+	rootCmd.AddCommand(newAliasCmd().cmd)
+	rootCmd.AddCommand(newBenchCmd().cmd)
 	rootCmd.AddCommand(newCompletionCmd().cmd)
 	rootCmd.AddCommand(newConfigCmd().cmd)
 	rootCmd.AddCommand(newDaemonCmd(&Config).cmd)
 	rootCmd.AddCommand(newDeleteCmd().reqs.Cmd)
+	rootCmd.AddCommand(newDevCmd().cmd)
+	rootCmd.AddCommand(newDocsCmd().cmd)
+	rootCmd.AddCommand(newDoctorCmd().cmd)
+	rootCmd.AddCommand(newExperimentsCmd().cmd)
+	rootCmd.AddCommand(newExportCmd().cmd)
 	rootCmd.AddCommand(newFeedbackdCmd().cmd)
 	rootCmd.AddCommand(newFixturesCmd(&Config).Cmd)
 	rootCmd.AddCommand(newGetCmd().reqs.Cmd)
+	rootCmd.AddCommand(newHistoryCmd().cmd)
+	rootCmd.AddCommand(newInitCmd().cmd)
+	rootCmd.AddCommand(newKeysCmd().cmd)
 	rootCmd.AddCommand(newListenCmd().cmd)
+	rootCmd.AddCommand(newLoadgenCmd().cmd)
 	rootCmd.AddCommand(newLoginCmd().cmd)
 	rootCmd.AddCommand(newLogoutCmd().cmd)
 	rootCmd.AddCommand(newLogsCmd(&Config).Cmd)
+	rootCmd.AddCommand(newMigrateCheckCmd().cmd)
 	rootCmd.AddCommand(newOpenCmd().cmd)
+	rootCmd.AddCommand(newPaymentsCmd().cmd)
+	rootCmd.AddCommand(newPolicyCmd().cmd)
 	rootCmd.AddCommand(newPostCmd().reqs.Cmd)
 	rootCmd.AddCommand(newResourcesCmd().cmd)
+	rootCmd.AddCommand(newRunCmd().cmd)
+	rootCmd.AddCommand(newSandboxesCmd().cmd)
 	rootCmd.AddCommand(newSamplesCmd().cmd)
+	rootCmd.AddCommand(newScheduleCmd().cmd)
 	rootCmd.AddCommand(newServeCmd().cmd)
+	rootCmd.AddCommand(newSessionsCmd().cmd)
+	rootCmd.AddCommand(newSimulateCmd().cmd)
+	rootCmd.AddCommand(newSnapshotCmd().cmd)
 	rootCmd.AddCommand(newStatusCmd().cmd)
+	rootCmd.AddCommand(newTailCmd().cmd)
+	rootCmd.AddCommand(newTaxCmd().cmd)
 	rootCmd.AddCommand(newTriggerCmd().cmd)
+	rootCmd.AddCommand(newUndoCmd().cmd)
 	rootCmd.AddCommand(newVersionCmd().cmd)
 	rootCmd.AddCommand(newPlaybackCmd().cmd)
 	rootCmd.AddCommand(newPostinstallCmd(&Config).cmd)
@@ -172,4 +381,39 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	err = resource.AddIssuingSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddInvoicesSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddTreasurySubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddWebhookEndpointsSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddDownloadSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddCustomersSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = resource.AddChargesSubCmds(rootCmd, &Config)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
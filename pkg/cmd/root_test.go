@@ -3,12 +3,15 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/clierrors"
 )
 
 func executeCommand(root *cobra.Command, args ...string) (output string, err error) {
@@ -42,6 +45,15 @@ func TestGetPathXDG(t *testing.T) {
 	require.Equal(t, actual, expected)
 }
 
+func TestGetPathConfigDir(t *testing.T) {
+	t.Setenv("STRIPE_CONFIG_DIR", "/some/env/path")
+	require.Equal(t, "/some/env/path", Config.GetConfigFolder("/some/xdg/path"))
+
+	c := Config
+	c.ConfigDir = "/some/flag/path"
+	require.Equal(t, "/some/flag/path", c.GetConfigFolder("/some/xdg/path"))
+}
+
 func TestHelpFlag(t *testing.T) {
 	Execute(context.Background())
 
@@ -69,3 +81,11 @@ func TestExampleCommands(t *testing.T) {
 		require.Equal(t, err.Error(), "`stripe samples create` accepts at maximum 2 positional arguments. See `stripe samples create --help` for supported flags and usage")
 	}
 }
+
+func TestErrorMessage(t *testing.T) {
+	require.Equal(t, "boom", errorMessage(errors.New("boom")))
+	require.Equal(t, "unable to read key\nCheck the key file.", errorMessage(&clierrors.ConfigError{
+		Err:  errors.New("unable to read key"),
+		Hint: "Check the key file.",
+	}))
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/suggest"
+)
+
+// taskColors are the colors of the prefixes of the tasks run in parallel
+var taskColors = []func(aurora.Aurora, interface{}) aurora.Value{
+	aurora.Aurora.Cyan,
+	aurora.Aurora.Magenta,
+	aurora.Aurora.Yellow,
+	aurora.Aurora.Green,
+	aurora.Aurora.Blue,
+}
+
+// taskStopTimeout is the time given to interrupted tasks to exit before
+// they're killed, shorter than the shutdown deadline of the CLI
+const taskStopTimeout = 3 * time.Second
+
+type runCmd struct {
+	cmd *cobra.Command
+
+	parallel bool
+}
+
+func newRunCmd() *runCmd {
+	rc := &runCmd{}
+
+	rc.cmd = &cobra.Command{
+		Use:   "run [task]...",
+		Short: "Run the tasks of the project config",
+		Long: `Run tasks defined in the [tasks] section of the project config, a
+` + config.ProjectConfigFileName + ` file in the current directory or one of its parents. Each task
+is a CLI command, run from the directory of the project config:
+
+  [tasks]
+  seed = "fixtures seed.json"
+  dev = "listen --forward-to localhost:4242/webhook"
+
+Tasks run one after the other, stopping at the first failure, unless
+--parallel is set, in which case their output is prefixed with their name.
+Without arguments, lists the tasks.`,
+		Example: `stripe run
+  stripe run seed
+  stripe run --parallel dev seed`,
+		RunE: rc.runRunCmd,
+	}
+
+	rc.cmd.Flags().BoolVarP(&rc.parallel, "parallel", "P", false, "Run the tasks concurrently, prefixing their output with their name")
+
+	return rc
+}
+
+func (rc *runCmd) runRunCmd(cmd *cobra.Command, args []string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	path, ok := config.FindProjectConfig(wd)
+	if !ok {
+		return fmt.Errorf("no %s found in %s or its parents", config.ProjectConfigFileName, wd)
+	}
+
+	tasks, err := config.ReadTasks(path)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		printTasks(os.Stdout, tasks)
+		return nil
+	}
+
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+
+	commands := make([][]string, len(args))
+
+	for i, name := range args {
+		command, ok := tasks[name]
+		if !ok {
+			message := fmt.Sprintf("%s has no task %s.", path, name)
+			if match, found := suggest.Closest(name, names); found {
+				message += fmt.Sprintf(" Did you mean %s?", match)
+			}
+
+			return fmt.Errorf("%s Run `stripe run` to list the tasks", message)
+		}
+
+		taskArgs, err := config.TaskArgs(command)
+		if err != nil {
+			return fmt.Errorf("task %s: %w", name, err)
+		}
+
+		commands[i] = append(taskArgs, inheritedFlags(cmd.Root())...)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+
+	if !rc.parallel {
+		for i, name := range args {
+			process := exec.CommandContext(cmd.Context(), executable, commands[i]...)
+			process.Dir = dir
+			process.Stdin = os.Stdin
+			process.Stdout = os.Stdout
+			process.Stderr = os.Stderr
+
+			if err := process.Run(); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("task %s failed: %w", name, err)
+			}
+		}
+
+		return nil
+	}
+
+	parallel := make([]task, len(args))
+	for i, name := range args {
+		parallel[i] = task{name: name, cmd: exec.Command(executable, commands[i]...)}
+		parallel[i].cmd.Dir = dir
+	}
+
+	errs, _ := runTasks(cmd.Context(), parallel, false)
+
+	var failed []string
+
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, args[i])
+		}
+	}
+
+	if len(failed) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("tasks failed: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// task is a process run alongside others, with its output prefixed with
+// its name
+type task struct {
+	name string
+	cmd  *exec.Cmd
+}
+
+// runTasks runs tasks concurrently and returns their errors, along with the
+// index of the first to exit. When stopAll is set, the first task to exit
+// interrupts the others. Tasks that haven't exited taskStopTimeout after
+// being interrupted, or after ctx is canceled, are killed.
+func runTasks(ctx context.Context, tasks []task, stopAll bool) ([]error, int) {
+	color := ansi.Color(os.Stdout)
+
+	width := 0
+	for _, t := range tasks {
+		if len(t.name) > width {
+			width = len(t.name)
+		}
+	}
+
+	var mu sync.Mutex
+
+	errs := make([]error, len(tasks))
+	exited := make(chan int, len(tasks))
+
+	for i, t := range tasks {
+		prefix := taskColors[i%len(taskColors)](color, fmt.Sprintf("%-*s |", width, t.name)).String() + " "
+		stdout := newPrefixWriter(os.Stdout, &mu, prefix)
+		stderr := newPrefixWriter(os.Stderr, &mu, prefix)
+
+		t.cmd.Stdout = stdout
+		t.cmd.Stderr = stderr
+
+		if err := t.cmd.Start(); err != nil {
+			errs[i] = err
+			exited <- i
+
+			continue
+		}
+
+		go func(i int, t task) {
+			errs[i] = t.cmd.Wait()
+
+			stdout.Flush()
+			stderr.Flush()
+
+			exited <- i
+		}(i, t)
+	}
+
+	first := -1
+	done := ctx.Done()
+
+	var kill <-chan time.Time
+
+	for running := len(tasks); running > 0; {
+		select {
+		case i := <-exited:
+			running--
+
+			if first >= 0 {
+				continue
+			}
+
+			first = i
+
+			if stopAll && running > 0 {
+				for _, t := range tasks {
+					interruptProcess(t.cmd.Process)
+				}
+
+				kill = time.After(taskStopTimeout)
+			}
+		case <-done:
+			// Interrupting the terminal already interrupted the tasks, which
+			// are in the same process group
+			done = nil
+			if kill == nil {
+				kill = time.After(taskStopTimeout)
+			}
+		case <-kill:
+			for _, t := range tasks {
+				if t.cmd.Process != nil {
+					t.cmd.Process.Kill() // #nosec G104
+				}
+			}
+		}
+	}
+
+	return errs, first
+}
+
+// interruptProcess asks a process to exit, killing it where interrupts
+// aren't supported, e.g. on Windows
+func interruptProcess(p *os.Process) {
+	if p == nil {
+		return
+	}
+
+	if err := p.Signal(os.Interrupt); err != nil {
+		p.Kill() // #nosec G104
+	}
+}
+
+// inheritedFlags returns the global flags set on the run command, so that
+// tasks use the same project, config and API key
+func inheritedFlags(root *cobra.Command) []string {
+	var flags []string
+
+	root.PersistentFlags().Visit(func(flag *pflag.Flag) {
+		flags = append(flags, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+
+	return flags
+}
+
+func printTasks(w io.Writer, tasks map[string]string) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(w, "No tasks defined, add them to the [tasks] section of the project config.")
+		return
+	}
+
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%s\n", name, tasks[name])
+	}
+
+	tw.Flush()
+}
+
+// prefixWriter prefixes each line written to it, writing whole lines to the
+// underlying writer so that the output of concurrent tasks isn't interleaved
+type prefixWriter struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    bytes.Buffer
+}
+
+func newPrefixWriter(w io.Writer, mu *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{w: w, mu: mu, prefix: prefix}
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	pw.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(pw.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		line := pw.buf.Next(i + 1)
+		if err := pw.writeLine(line); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush writes the last line when it doesn't end with a newline
+func (pw *prefixWriter) Flush() {
+	if pw.buf.Len() > 0 {
+		pw.writeLine(append(pw.buf.Bytes(), '\n')) // #nosec G104
+		pw.buf.Reset()
+	}
+}
+
+func (pw *prefixWriter) writeLine(line []byte) error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	_, err := fmt.Fprintf(pw.w, "%s%s", pw.prefix, line)
+
+	return err
+}
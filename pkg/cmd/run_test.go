@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	exec "golang.org/x/sys/execabs"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+
+	pw := newPrefixWriter(&out, &mu, "dev | ")
+
+	pw.Write([]byte("Ready! Your webhook "))
+	pw.Write([]byte("signing secret is whsec_123\nGetting ready"))
+	require.Equal(t, "dev | Ready! Your webhook signing secret is whsec_123\n", out.String())
+
+	pw.Flush()
+	require.Equal(t, "dev | Ready! Your webhook signing secret is whsec_123\ndev | Getting ready\n", out.String())
+}
+
+func TestPrintTasks(t *testing.T) {
+	var out bytes.Buffer
+
+	printTasks(&out, map[string]string{
+		"seed": "fixtures seed.json",
+		"dev":  "listen --forward-to localhost:4242/webhook",
+	})
+
+	require.Equal(t, "dev   listen --forward-to localhost:4242/webhook\nseed  fixtures seed.json\n", out.String())
+}
+
+func TestRunTasksStopAll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	tasks := []task{
+		{name: "app", cmd: exec.Command("sh", "-c", "exit 3")},
+		{name: "listen", cmd: exec.Command("sleep", "30")},
+	}
+
+	start := time.Now()
+	errs, first := runTasks(context.Background(), tasks, true)
+
+	require.Equal(t, 0, first)
+	require.EqualError(t, errs[0], "exit status 3")
+	require.Error(t, errs[1])
+	require.Less(t, int64(time.Since(start)), int64(taskStopTimeout))
+}
@@ -19,8 +19,11 @@ func newSamplesCmd() *samplesCmd {
 		},
 	}
 
-	samplesCmd.cmd.AddCommand(samples.NewCreateCmd(&Config).Cmd)
-	samplesCmd.cmd.AddCommand(samples.NewListCmd().Cmd)
+	createCmd := samples.NewCreateCmd(&Config).Cmd
+	addNotifyFlag(createCmd)
+
+	samplesCmd.cmd.AddCommand(createCmd)
+	samplesCmd.cmd.AddCommand(samples.NewListCmd(&Config).Cmd)
 
 	return samplesCmd
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/progress"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
@@ -48,7 +49,7 @@ local configuration to let you get started faster.`,
 }
 
 func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
 
 	if len(args) == 0 {
 		cmd.Help()
@@ -61,16 +62,16 @@ func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
 		destination = args[1]
 	}
 
-	color := ansi.Color(os.Stdout)
 	spinner := ansi.StartNewSpinner(fmt.Sprintf("Downloading %s", selectedSample), os.Stdout)
+	progress.Report("samples.create", "download", 0, "Downloading "+selectedSample)
 
-	sampleConfig, err := samples.GetSampleConfig(selectedSample, cc.forceRefresh)
+	sampleConfig, err := samples.GetSampleConfig(cc.cfg, selectedSample, cc.forceRefresh)
 	if err != nil {
 		ansi.StopSpinner(spinner, "", os.Stdout)
 		return err
 	}
 	ansi.StopSpinner(spinner, "", os.Stdout)
-	fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Finished downloading"))
+	fmt.Printf("%s %s\n", ansi.SuccessGlyph(), ansi.Faint("Finished downloading"))
 
 	// Once we've initialized the sample in the local cache
 	// directory, the user needs to select which integration they
@@ -101,18 +102,22 @@ func (cc *CreateCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
 
 		switch res.State {
 		case samples.WillInitialize:
+			progress.Report("samples.create", "initialize", 20, "Initializing "+selectedSample)
 		case samples.DidInitialize:
 		case samples.WillCopy:
 			spinner = ansi.StartNewSpinner(fmt.Sprintf("Copying files over... %s", destination), os.Stdout)
+			progress.Report("samples.create", "copy", 40, "Copying files to "+destination)
 		case samples.DidCopy:
 			ansi.StopSpinner(spinner, "", os.Stdout)
-			fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Files copied"))
+			fmt.Printf("%s %s\n", ansi.SuccessGlyph(), ansi.Faint("Files copied"))
 		case samples.WillConfigure:
 			spinner = ansi.StartNewSpinner(fmt.Sprintf("Configuring your code... %s", selectedSample), os.Stdout)
+			progress.Report("samples.create", "configure", 70, "Configuring "+selectedSample)
 		case samples.DidConfigure:
 			ansi.StopSpinner(spinner, "", os.Stdout)
-			fmt.Printf("%s %s\n", color.Green("✔"), ansi.Faint("Project configured"))
+			fmt.Printf("%s %s\n", ansi.SuccessGlyph(), ansi.Faint("Project configured"))
 		case samples.Done:
+			progress.Report("samples.create", "done", 100, "")
 			fmt.Println("You're all set. To get started: cd", destination)
 			if res.PostInstall != "" {
 				fmt.Println(res.PostInstall)
@@ -164,10 +169,8 @@ func promptSampleConfig(sampleConfig *samples.SampleConfig) (*samples.SelectedCo
 }
 
 func selectOptions(template, label string, options []string) (string, error) {
-	color := ansi.Color(os.Stdout)
-
 	templates := &promptui.SelectTemplates{
-		Selected: color.Green("✔").String() + ansi.Faint(fmt.Sprintf(" Selected %s: {{ . | bold }} ", template)),
+		Selected: ansi.SuccessGlyph() + ansi.Faint(fmt.Sprintf(" Selected %s: {{ . | bold }} ", template)),
 	}
 	prompt := promptui.Select{
 		Label:     label,
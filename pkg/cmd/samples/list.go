@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
@@ -16,11 +17,15 @@ import (
 // generate
 type ListCmd struct {
 	Cmd *cobra.Command
+
+	cfg *config.Config
 }
 
 // NewListCmd creates and returns a list command for samples
-func NewListCmd() *ListCmd {
-	listCmd := &ListCmd{}
+func NewListCmd(config *config.Config) *ListCmd {
+	listCmd := &ListCmd{
+		cfg: config,
+	}
 	listCmd.Cmd = &cobra.Command{
 		Use:   "list",
 		Args:  validators.NoArgs,
@@ -39,7 +44,7 @@ func (lc *ListCmd) runListCmd(cmd *cobra.Command, args []string) error {
 
 	spinner := ansi.StartNewSpinner("Loading...", os.Stdout)
 
-	list, err := samples.GetSamples("list")
+	list, err := samples.GetSamples(lc.cfg, "list")
 	if err != nil {
 		ansi.StopSpinner(spinner, "Error: please check your internet connection and try again!", os.Stdout)
 		return err
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/logout"
+	"github.com/stripe/stripe-cli/pkg/open"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// sandboxNamePattern matches the names that can be used as profile names
+var sandboxNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type sandboxesCmd struct {
+	cmd *cobra.Command
+
+	switchTo    bool
+	autoConfirm bool
+	revoke      bool
+
+	apiBaseURL       string
+	dashboardBaseURL string
+}
+
+func newSandboxesCmd() *sandboxesCmd {
+	sc := &sandboxesCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "sandboxes",
+		Args:  validators.NoArgs,
+		Short: "Manage the sandboxes you use from the CLI",
+		Long: `Manage the sandboxes you use from the CLI. Each sandbox has a profile of the
+same name holding its keys, so any command can run in a sandbox with
+--project-name, or in the sandbox selected with stripe sandboxes switch.
+
+The API can't create or delete sandboxes, so they are created and deleted in
+the Dashboard: stripe sandboxes create opens it, then logs in to the new
+sandbox to create its profile.`,
+		Example: `stripe sandboxes create checkout-tests
+  stripe sandboxes list
+  stripe sandboxes switch checkout-tests
+  stripe sandboxes delete checkout-tests`,
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Create a sandbox in the Dashboard and a profile with its keys",
+		RunE:  sc.runCreateCmd,
+	}
+	createCmd.Flags().BoolVar(&sc.switchTo, "switch", false, "Use the sandbox for the next commands, like stripe sandboxes switch")
+	createCmd.Flags().StringVar(&sc.dashboardBaseURL, "dashboard-base", stripe.DefaultDashboardBaseURL, "Sets the dashboard base URL")
+	createCmd.Flags().MarkHidden("dashboard-base") // #nosec G104
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the sandboxes with a profile",
+		RunE:  sc.runListCmd,
+	}
+
+	switchCmd := &cobra.Command{
+		Use:   "switch <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Use a sandbox, or any other profile, when --project-name isn't set",
+		Example: `stripe sandboxes switch checkout-tests
+  stripe sandboxes switch default`,
+		RunE: sc.runSwitchCmd,
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <name>",
+		Args:  validators.ExactArgs(1),
+		Short: "Delete the profile of a sandbox and revoke its keys",
+		RunE:  sc.runDeleteCmd,
+	}
+	deleteCmd.Flags().BoolVarP(&sc.autoConfirm, "confirm", "c", false, "Skip the confirmation prompt")
+	deleteCmd.Flags().BoolVar(&sc.revoke, "revoke", true, "Revoke the keys created by stripe login before deleting them")
+	deleteCmd.Flags().StringVar(&sc.dashboardBaseURL, "dashboard-base", stripe.DefaultDashboardBaseURL, "Sets the dashboard base URL")
+	deleteCmd.Flags().MarkHidden("dashboard-base") // #nosec G104
+	deleteCmd.Flags().StringVar(&sc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	deleteCmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	sc.cmd.AddCommand(createCmd)
+	sc.cmd.AddCommand(listCmd)
+	sc.cmd.AddCommand(switchCmd)
+	sc.cmd.AddCommand(deleteCmd)
+
+	return sc
+}
+
+func (sc *sandboxesCmd) runCreateCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if !sandboxNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid sandbox name %q, use lowercase letters, digits, - and _", name)
+	}
+
+	if containsString(Config.ListProfiles(), name) {
+		return fmt.Errorf("a profile named %s already exists", name)
+	}
+
+	sandboxesURL := sc.dashboardBaseURL + "/sandboxes"
+
+	fmt.Printf("Create a sandbox named %s in the Dashboard: %s\n", ansi.Bold(name), sandboxesURL)
+	if open.CanOpenBrowser() {
+		if err := open.Browser(sandboxesURL); err != nil {
+			fmt.Printf("Failed to open browser, please go to %s manually.\n", sandboxesURL)
+		}
+	}
+
+	fmt.Println("Then log in and select the new sandbox to create its profile.")
+	fmt.Println()
+
+	Config.Profile.ProfileName = name
+	if err := login.Login(cmd.Context(), sc.dashboardBaseURL, &Config, os.Stdin); err != nil {
+		return err
+	}
+
+	if err := Config.Profile.WriteConfigField("sandbox", "true"); err != nil {
+		return err
+	}
+
+	if sc.switchTo {
+		return switchProfile(name)
+	}
+
+	fmt.Printf("Run commands in the sandbox with --project-name %s, or select it with `stripe sandboxes switch %s`.\n", name, name)
+
+	return nil
+}
+
+func (sc *sandboxesCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	active := Config.GetActiveProfile()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tNAME\tACCOUNT\tDISPLAY NAME")
+
+	for _, name := range Config.ListProfiles() {
+		profile := &config.Profile{ProfileName: name}
+		if !profile.IsSandbox() {
+			continue
+		}
+
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+
+		accountID, _ := profile.GetAccountID()
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, name, accountID, profile.GetDisplayName())
+	}
+
+	return w.Flush()
+}
+
+func (sc *sandboxesCmd) runSwitchCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name != "default" && !containsString(Config.ListProfiles(), name) {
+		return fmt.Errorf("no profile named %s, create it with `stripe sandboxes create %s`", name, name)
+	}
+
+	return switchProfile(name)
+}
+
+func (sc *sandboxesCmd) runDeleteCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	profile := &config.Profile{ProfileName: name}
+	if !containsString(Config.ListProfiles(), name) || !profile.IsSandbox() {
+		return fmt.Errorf("no sandbox named %s, see `stripe sandboxes list`", name)
+	}
+
+	if !sc.autoConfirm {
+		if sc.revoke {
+			fmt.Printf("The profile of the %s sandbox will be deleted and its keys revoked.\n", name)
+		} else {
+			fmt.Printf("The profile of the %s sandbox will be deleted.\n", name)
+		}
+		fmt.Print("Enter 'yes' to confirm: ")
+
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "yes" {
+			fmt.Println("Exiting without deleting anything.")
+			return nil
+		}
+	}
+
+	// Switch first, as writing the config file afterwards would restore
+	// the deleted profile
+	if Config.GetActiveProfile() == name {
+		if err := switchProfile("default"); err != nil {
+			return err
+		}
+	}
+
+	Config.Profile.ProfileName = name
+	if err := logout.Logout(cmd.Context(), &Config, sc.apiBaseURL, sc.revoke); err != nil {
+		return err
+	}
+
+	fmt.Printf("The API can't delete sandboxes, delete %s in the Dashboard: %s\n", name, sc.dashboardBaseURL+"/sandboxes")
+
+	return nil
+}
+
+// switchProfile makes name the profile of the commands run without
+// --project-name
+func switchProfile(name string) error {
+	if err := Config.SetActiveProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Println(ansi.SuccessGlyph(), fmt.Sprintf("Commands now use the %s profile unless --project-name is set.", name))
+
+	return nil
+}
+
+// useActiveProfile selects the profile set with `stripe sandboxes switch`
+// when --project-name isn't set
+func useActiveProfile() {
+	if !rootCmd.PersistentFlags().Changed("project-name") {
+		Config.Profile.ProfileName = Config.GetActiveProfile()
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/schedule"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type scheduleCmd struct {
+	cmd *cobra.Command
+}
+
+func newScheduleCmd() *scheduleCmd {
+	sc := &scheduleCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "schedule",
+		Args:  validators.NoArgs,
+		Short: "Run CLI commands on a recurring schedule",
+		Long: `The schedule command manages CLI commands run on a cron-like schedule, e.g. to
+seed a sandbox account every week. Scheduled commands are run by the daemon,
+with the keys of the project they were scheduled from, while it is running.
+Run ` + "`stripe daemon install-service`" + ` to keep the daemon running in the background.`,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <schedule> -- <command>...",
+		Args:  validators.MinimumNArgs(2),
+		Short: "Schedule a CLI command",
+		Long: `Schedule a CLI command. The schedule is a cron expression with five fields:
+minute, hour, day of month, month and day of week. The @hourly, @daily,
+@weekly and @monthly shorthands are supported too.`,
+		Example: `stripe schedule add "0 9 * * 1" -- stripe fixtures weekly_seed.json
+  stripe schedule add @daily -- trigger payment_intent.succeeded`,
+		RunE: sc.runAddCmd,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Args:  validators.NoArgs,
+		Short: "List the scheduled commands",
+		RunE:  sc.runListCmd,
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove <id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Remove a scheduled command",
+		RunE:  sc.runRemoveCmd,
+	}
+
+	logsCmd := &cobra.Command{
+		Use:   "logs <id>",
+		Args:  validators.ExactArgs(1),
+		Short: "Print the output of the runs of a scheduled command",
+		RunE:  sc.runLogsCmd,
+	}
+
+	sc.cmd.AddCommand(addCmd)
+	sc.cmd.AddCommand(listCmd)
+	sc.cmd.AddCommand(removeCmd)
+	sc.cmd.AddCommand(logsCmd)
+
+	return sc
+}
+
+func (sc *scheduleCmd) runAddCmd(cmd *cobra.Command, args []string) error {
+	if cmd.ArgsLenAtDash() != 1 {
+		return fmt.Errorf("separate the schedule from the command with --, e.g. stripe schedule add \"0 9 * * 1\" -- fixtures seed.json")
+	}
+
+	command := args[1:]
+	if command[0] == "stripe" {
+		command = command[1:]
+	}
+
+	if found, _, err := rootCmd.Find(command); err != nil || found == rootCmd {
+		return fmt.Errorf("`stripe %s` is not a CLI command, see `stripe --help` for the available commands", strings.Join(command, " "))
+	}
+
+	task, err := scheduleStore().Add(schedule.Task{
+		Schedule:    args[0],
+		Args:        command,
+		ProjectName: Config.Profile.ProfileName,
+	})
+	if err != nil {
+		return err
+	}
+
+	spec, _ := schedule.ParseSpec(task.Schedule)
+
+	fmt.Printf("Scheduled `stripe %s` as task %s, next run at %s.\n", strings.Join(task.Args, " "), task.ID, spec.Next(time.Now()).Format(time.RFC1123))
+	fmt.Println("Scheduled commands run while the daemon is running, see `stripe daemon install-service`.")
+
+	return nil
+}
+
+func (sc *scheduleCmd) runListCmd(cmd *cobra.Command, args []string) error {
+	tasks, err := scheduleStore().List()
+	if err != nil {
+		return err
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No scheduled commands, add one with `stripe schedule add`.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tSCHEDULE\tNEXT RUN\tPROJECT\tCOMMAND")
+
+	for _, task := range tasks {
+		next := "-"
+		if spec, err := schedule.ParseSpec(task.Schedule); err == nil {
+			next = spec.Next(time.Now()).Format("2006-01-02 15:04")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\tstripe %s\n", task.ID, task.Schedule, next, task.ProjectName, strings.Join(task.Args, " "))
+	}
+
+	return w.Flush()
+}
+
+func (sc *scheduleCmd) runRemoveCmd(cmd *cobra.Command, args []string) error {
+	if err := scheduleStore().Remove(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed task %s.\n", args[0])
+
+	return nil
+}
+
+func (sc *scheduleCmd) runLogsCmd(cmd *cobra.Command, args []string) error {
+	store := scheduleStore()
+
+	data, err := afero.ReadFile(store.Fs, store.LogFile(args[0]))
+	if os.IsNotExist(err) {
+		fmt.Printf("Task %s hasn't run yet.\n", args[0])
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Print(string(data))
+
+	return nil
+}
+
+func scheduleStore() *schedule.Store {
+	return &schedule.Store{
+		Fs:  fs,
+		Dir: Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")),
+	}
+}
@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+	"github.com/stripe/stripe-cli/pkg/redact"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// defaultSessionRedactFields are the fields holding personal data in API
+// objects
+var defaultSessionRedactFields = []string{"email", "receipt_email", "name", "phone", "address", "line1", "line2", "shipping", "ip_address"}
+
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+const redactedValue = "[redacted]"
+
+type sessionsCmd struct {
+	cmd *cobra.Command
+
+	recipients []string
+	redact     []string
+	identity   string
+	output     string
+}
+
+func newSessionsCmd() *sessionsCmd {
+	sc := &sessionsCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "sessions",
+		Args:  validators.NoArgs,
+		Short: "Share recorded sessions safely with your teammates",
+		Long: `Recorded sessions, such as transcripts or dead-letter files of stripe listen,
+can contain personal data. The sessions commands redact them and encrypt them
+with age (https://age-encryption.org) for the teammates you share them with.
+
+Each teammate runs ` + "`stripe sessions keygen`" + ` once and sends you their public key.
+Keys made with age-keygen work too.`,
+		Example: `stripe sessions keygen
+  stripe sessions share transcript.log --encrypt-for teammate.pub
+  stripe sessions receive transcript.log.age`,
+	}
+
+	keygenCmd := &cobra.Command{
+		Use:   "keygen",
+		Args:  validators.NoArgs,
+		Short: "Create the key used to receive sessions",
+		Long: `Create the private key used to decrypt the sessions shared with you, in the
+config folder, and print its public key to send to your teammates. Prints the
+public key of the existing private key if there is one.`,
+		RunE: sc.runKeygenCmd,
+	}
+
+	shareCmd := &cobra.Command{
+		Use:   "share <file>",
+		Args:  validators.ExactArgs(1),
+		Short: "Redact and encrypt a session file",
+		Long: `Redact the personal data of a session file and encrypt it for the given
+recipients. The values of the redacted fields are replaced in JSON lines, in
+JSON strings nested in them, and in JSON printed over several lines. Email
+addresses and secret keys are redacted everywhere.`,
+		RunE: sc.runShareCmd,
+	}
+	shareCmd.Flags().StringArrayVar(&sc.recipients, "encrypt-for", []string{}, "Public key (age1...) of a recipient, or a file with one per line. Can be repeated")
+	shareCmd.Flags().StringSliceVar(&sc.redact, "redact", defaultSessionRedactFields, "Names of the fields to redact, at any depth")
+	shareCmd.Flags().StringVarP(&sc.output, "output", "o", "", "Path of the encrypted file (default: <file>.age)")
+	shareCmd.MarkFlagRequired("encrypt-for") // #nosec G104
+
+	receiveCmd := &cobra.Command{
+		Use:   "receive <file>",
+		Args:  validators.ExactArgs(1),
+		Short: "Decrypt a session file shared with you",
+		RunE:  sc.runReceiveCmd,
+	}
+	receiveCmd.Flags().StringVar(&sc.identity, "identity", "", "Private key file (default: the one created by stripe sessions keygen)")
+	receiveCmd.Flags().StringVarP(&sc.output, "output", "o", "", "Path of the decrypted file (default: <file> without .age)")
+
+	sc.cmd.AddCommand(keygenCmd)
+	sc.cmd.AddCommand(shareCmd)
+	sc.cmd.AddCommand(receiveCmd)
+
+	return sc
+}
+
+func (sc *sessionsCmd) runKeygenCmd(cmd *cobra.Command, args []string) error {
+	path := sessionIdentityPath()
+
+	identity, err := loadSessionIdentity(path)
+	if os.IsNotExist(err) {
+		identity, err = age.GenerateX25519Identity()
+		if err != nil {
+			return err
+		}
+
+		if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		content := fmt.Sprintf("# public key: %s\n%s\n", identity.Recipient(), identity)
+		if err := afero.WriteFile(fs, path, []byte(content), 0600); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Created your private key in %s, keep it secret.\n", path)
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Send your public key to the teammates sharing sessions with you:")
+	fmt.Println(identity.Recipient())
+
+	return nil
+}
+
+func (sc *sessionsCmd) runShareCmd(cmd *cobra.Command, args []string) error {
+	if err := checkSessionAlgorithms(); err != nil {
+		return err
+	}
+
+	recipients, err := parseSessionRecipients(sc.recipients)
+	if err != nil {
+		return err
+	}
+
+	data, err := afero.ReadFile(fs, args[0])
+	if err != nil {
+		return err
+	}
+
+	redacted := redactSession(data, sc.redact)
+
+	output := sc.output
+	if output == "" {
+		output = args[0] + ".age"
+	}
+
+	var encrypted bytes.Buffer
+
+	w, err := age.Encrypt(&encrypted, recipients...)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(redacted); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	if err := afero.WriteFile(fs, output, encrypted.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s, encrypted for %d recipient(s).\n", output, len(recipients))
+
+	return nil
+}
+
+func (sc *sessionsCmd) runReceiveCmd(cmd *cobra.Command, args []string) error {
+	if err := checkSessionAlgorithms(); err != nil {
+		return err
+	}
+
+	path := sc.identity
+	if path == "" {
+		path = sessionIdentityPath()
+	}
+
+	identity, err := loadSessionIdentity(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no private key found at %s, create one with `stripe sessions keygen`", path)
+	} else if err != nil {
+		return err
+	}
+
+	f, err := fs.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := age.Decrypt(f, identity)
+	if err != nil {
+		return err
+	}
+
+	var decrypted bytes.Buffer
+	if _, err := decrypted.ReadFrom(r); err != nil {
+		return err
+	}
+
+	output := sc.output
+	if output == "" {
+		output = strings.TrimSuffix(args[0], ".age")
+		if output == args[0] {
+			output += ".decrypted"
+		}
+	}
+
+	if err := afero.WriteFile(fs, output, decrypted.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s.\n", output)
+
+	return nil
+}
+
+// checkSessionAlgorithms returns an error if the crypto policy doesn't allow
+// the algorithms of age
+func checkSessionAlgorithms() error {
+	for _, algorithm := range []string{"X25519", "ChaCha20-Poly1305"} {
+		if err := cryptopolicy.Current().CheckAlgorithm(algorithm); err != nil {
+			return fmt.Errorf("sessions can't be shared: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func sessionIdentityPath() string {
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), "sessions_identity.txt")
+}
+
+// loadSessionIdentity reads a private key file, in the format of age-keygen
+func loadSessionIdentity(path string) (*age.X25519Identity, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		return age.ParseX25519Identity(line)
+	}
+
+	return nil, fmt.Errorf("no private key found in %s", path)
+}
+
+// parseSessionRecipients parses public keys, or files of public keys
+func parseSessionRecipients(values []string) ([]age.Recipient, error) {
+	recipients := make([]age.Recipient, 0, len(values))
+
+	for _, value := range values {
+		if strings.HasPrefix(value, "age1") {
+			recipient, err := age.ParseX25519Recipient(value)
+			if err != nil {
+				return nil, err
+			}
+
+			recipients = append(recipients, recipient)
+
+			continue
+		}
+
+		data, err := afero.ReadFile(fs, value)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			recipient, err := age.ParseX25519Recipient(line)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", value, err)
+			}
+
+			recipients = append(recipients, recipient)
+			found = true
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no public key found in %s", value)
+		}
+	}
+
+	return recipients, nil
+}
+
+// redactSession redacts a session file line by line. JSON lines are parsed so
+// that the fields are redacted at any depth, other lines such as transcript
+// output are redacted with patterns.
+func redactSession(data []byte, fields []string) []byte {
+	var out bytes.Buffer
+
+	fieldRegex := sessionFieldRegex(fields)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var value interface{}
+		if trimmed := strings.TrimSpace(line); (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Unmarshal([]byte(trimmed), &value) == nil {
+			if redacted, err := json.Marshal(redactJSONFields(value, fields)); err == nil {
+				line = string(redacted)
+			}
+		} else if fieldRegex != nil {
+			line = fieldRegex.ReplaceAllString(line, `"$1": "`+redactedValue+`"`)
+		}
+
+		line = emailRegex.ReplaceAllString(redact.MaskSecrets(line), redactedValue)
+
+		out.WriteString(line + "\n")
+	}
+
+	return out.Bytes()
+}
+
+// sessionFieldRegex matches the string values of fields in JSON printed over
+// several lines
+func sessionFieldRegex(fields []string) *regexp.Regexp {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+
+	return regexp.MustCompile(`"(` + strings.Join(quoted, "|") + `)":\s*"(?:[^"\\]|\\.)*"`)
+}
+
+func redactJSONFields(value interface{}, fields []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if field != nil && containsString(fields, key) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = redactJSONFields(field, fields)
+		}
+
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONFields(item, fields)
+		}
+
+		return v
+	case string:
+		// Payloads are often stored as JSON strings, e.g. in dead-letter files
+		var nested interface{}
+		if strings.HasPrefix(v, "{") && json.Unmarshal([]byte(v), &nested) == nil {
+			if redacted, err := json.Marshal(redactJSONFields(nested, fields)); err == nil {
+				return string(redacted)
+			}
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
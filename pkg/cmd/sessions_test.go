@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSession(t *testing.T) {
+	session := `{"id":"evt_1","data":{"object":{"email":"jenny@example.com","shipping":{"name":"Jenny Rosen"},"amount":100}}}
+{"event_id":"evt_2","payload":"{\"customer_details\":{\"phone\":\"+15555550100\"}}"}
+2022-03-01T10:00:00Z stdout    "name": "Jenny \"J\" Rosen",
+2022-03-01T10:00:00Z stdout  Contact jenny@example.com with key sk_test_abcdefghijkl12345678
+`
+
+	redacted := string(redactSession([]byte(session), defaultSessionRedactFields))
+
+	require.Equal(t, `{"data":{"object":{"amount":100,"email":"[redacted]","shipping":"[redacted]"}},"id":"evt_1"}
+{"event_id":"evt_2","payload":"{\"customer_details\":{\"phone\":\"[redacted]\"}}"}
+2022-03-01T10:00:00Z stdout    "name": "[redacted]",
+2022-03-01T10:00:00Z stdout  Contact [redacted] with key sk_test_****************5678
+`, redacted)
+}
+
+func TestParseSessionRecipients(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	afero.WriteFile(fs, "team.pub", []byte("# alice\nage1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj\n"), 0644)
+	afero.WriteFile(fs, "empty.pub", []byte("# nobody\n"), 0644)
+
+	recipients, err := parseSessionRecipients([]string{"team.pub", "age1zvkyg2lqzraa2lnjvqej32nkuu0ues2s82hzrye869xeexvn73equnujwj"})
+	require.NoError(t, err)
+	require.Len(t, recipients, 2)
+
+	_, err = parseSessionRecipients([]string{"empty.pub"})
+	require.EqualError(t, err, "no public key found in empty.pub")
+}
+
+func TestShareAndReceiveSession(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	afero.WriteFile(fs, "me.key", []byte(identity.String()+"\n"), 0600)
+	afero.WriteFile(fs, "session.log", []byte("Contact jenny@example.com\n"), 0600)
+
+	sc := &sessionsCmd{recipients: []string{identity.Recipient().String()}, redact: defaultSessionRedactFields}
+	require.NoError(t, sc.runShareCmd(nil, []string{"session.log"}))
+
+	encrypted, err := afero.ReadFile(fs, "session.log.age")
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(encrypted, []byte("age-encryption.org/v1\n")))
+
+	sc = &sessionsCmd{identity: "me.key", output: "received.log"}
+	require.NoError(t, sc.runReceiveCmd(nil, []string{"session.log.age"}))
+
+	received, err := afero.ReadFile(fs, "received.log")
+	require.NoError(t, err)
+	require.Equal(t, "Contact [redacted]\n", string(received))
+}
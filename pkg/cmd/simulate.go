@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type simulateCmd struct {
+	cmd *cobra.Command
+}
+
+type simulateBillingCmd struct {
+	cmd *cobra.Command
+
+	price      string
+	months     int
+	failMonth  int
+	format     string
+	apiBaseURL string
+}
+
+func newSimulateCmd() *simulateCmd {
+	sc := &simulateCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "simulate",
+		Args:  validators.NoArgs,
+		Short: "Simulate Stripe flows over time in test mode",
+		Long:  `Simulate Stripe flows over time in test mode, using test clocks.`,
+	}
+
+	sc.cmd.AddCommand(newSimulateBillingCmd().cmd)
+
+	return sc
+}
+
+func newSimulateBillingCmd() *simulateBillingCmd {
+	sbc := &simulateBillingCmd{}
+
+	sbc.cmd = &cobra.Command{
+		Use:   "billing",
+		Args:  validators.NoArgs,
+		Short: "Walk a subscription through months of invoicing",
+		Long: `The billing command creates a test clock, a customer and a subscription to
+the given price, then advances the clock month by month. With --fail-month,
+the renewal payment of that month fails and is then recovered with a working
+card. A timeline of the events generated at each step is printed at the end.
+
+The test clock and its objects are kept so that you can inspect them in the
+Dashboard.`,
+		Example: `stripe simulate billing --plan price_1234 --months 6 --fail-month 3`,
+		RunE:    sbc.runSimulateBillingCmd,
+	}
+
+	sbc.cmd.Flags().StringVar(&sbc.price, "plan", "", "ID of the price to subscribe to (required)")
+	sbc.cmd.Flags().IntVar(&sbc.months, "months", 3, "Number of months to simulate")
+	sbc.cmd.Flags().IntVar(&sbc.failMonth, "fail-month", 0, "Month in which the renewal payment fails and is recovered")
+	sbc.cmd.Flags().StringVar(&sbc.format, "format", "", `Specifies the output format of the timeline
+	Acceptable values:
+		'JSON' - Output the timeline in JSON format`)
+	sbc.cmd.MarkFlagRequired("plan") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	sbc.cmd.Flags().StringVar(&sbc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	sbc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return sbc
+}
+
+func (sbc *simulateBillingCmd) runSimulateBillingCmd(cmd *cobra.Command, args []string) error {
+	if sbc.format != "" && strings.ToUpper(sbc.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, the only supported format is JSON", sbc.format)
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(apiKey, "_live_") {
+		return fmt.Errorf("billing simulations can only be run with a test mode API key")
+	}
+
+	fmt.Fprintf(os.Stderr, "Simulating %d months of billing for %s...\n", sbc.months, sbc.price)
+
+	report, err := simulate.Billing(cmd.Context(), &simulate.BillingConfig{
+		Client:       simulate.NewAPIClient(apiKey, sbc.apiBaseURL),
+		Price:        sbc.price,
+		Months:       sbc.months,
+		FailMonth:    sbc.failMonth,
+		PollInterval: simulate.DefaultPollInterval,
+	})
+
+	if report != nil && len(report.Timeline) > 0 {
+		if strings.ToUpper(sbc.format) == outputFormatJSON {
+			data, jsonErr := json.MarshalIndent(report, "", "  ")
+			if jsonErr != nil {
+				return jsonErr
+			}
+
+			fmt.Println(string(data))
+		} else {
+			report.Print(os.Stdout)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("simulation stopped: %w", err)
+	}
+
+	return nil
+}
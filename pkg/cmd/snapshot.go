@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/requests"
+	"github.com/stripe/stripe-cli/pkg/snapshot"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type snapshotCmd struct {
+	cmd *cobra.Command
+
+	dir        string
+	livemode   bool
+	redact     []string
+	redactIDs  bool
+	apiBaseURL string
+}
+
+func newSnapshotCmd() *snapshotCmd {
+	sc := &snapshotCmd{}
+
+	sc.cmd = &cobra.Command{
+		Use:   "snapshot",
+		Args:  validators.NoArgs,
+		Short: "Capture API objects to snapshot files and verify them later",
+		Long: `The snapshot command captures the normalized JSON of API objects into snapshot
+files, and verifies later that the objects still match them. This is useful
+to regression test the configuration of an account, such as its products,
+prices or webhook endpoints.
+
+Values that change every time objects are created, such as their IDs and
+creation timestamps, are redacted so that snapshots can be compared across
+accounts.`,
+		Example: `stripe snapshot capture prod_NWjs8kKbJWmuuc /v1/prices?product=prod_NWjs8kKbJWmuuc
+  stripe snapshot verify
+  stripe snapshot verify snapshots/v1_products_prod_NWjs8kKbJWmuuc.json`,
+	}
+
+	captureCmd := &cobra.Command{
+		Use:   "capture <id or path>...",
+		Args:  validators.MinimumNArgs(1),
+		Short: "Capture API objects to snapshot files",
+		Long: `Capture the objects at the given IDs or API paths to snapshot files. Paths can
+have a query string, e.g. to snapshot a list of objects.`,
+		RunE: sc.runCaptureCmd,
+	}
+	captureCmd.Flags().StringSliceVar(&sc.redact, "redact", []string{"id", "created", "updated"}, "Names of the fields to redact, at any depth")
+	captureCmd.Flags().BoolVar(&sc.redactIDs, "redact-ids", true, "Redact all the values that look like object IDs, such as the IDs of related objects")
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify [snapshot file]...",
+		Short: "Verify that API objects match their snapshot files",
+		Long: `Verify that the API objects still match their snapshot files, and report the
+differences. Verifies all the snapshot files of the snapshot directory if no
+file is given.`,
+		RunE: sc.runVerifyCmd,
+	}
+
+	sc.cmd.PersistentFlags().StringVar(&sc.dir, "dir", "snapshots", "Directory of the snapshot files")
+	sc.cmd.PersistentFlags().BoolVar(&sc.livemode, "live", false, "Make live requests (default: test)")
+
+	// Hidden configuration flags, useful for dev/debugging
+	sc.cmd.PersistentFlags().StringVar(&sc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	sc.cmd.PersistentFlags().MarkHidden("api-base") // #nosec G104
+
+	sc.cmd.AddCommand(captureCmd)
+	sc.cmd.AddCommand(verifyCmd)
+
+	return sc
+}
+
+func (sc *snapshotCmd) runCaptureCmd(cmd *cobra.Command, args []string) error {
+	apiKey, err := Config.Profile.GetAPIKey(sc.livemode)
+	if err != nil {
+		return err
+	}
+
+	if err := fs.MkdirAll(sc.dir, 0755); err != nil {
+		return err
+	}
+
+	opts := snapshot.Options{
+		RedactFields: sc.redact,
+		RedactIDs:    sc.redactIDs,
+	}
+
+	for _, arg := range args {
+		path, query := splitQuery(arg)
+
+		path, err := requests.ObjectPath(path)
+		if err != nil {
+			return err
+		}
+
+		if query != "" {
+			path += "?" + query
+		}
+
+		body, err := sc.fetch(cmd.Context(), apiKey, path)
+		if err != nil {
+			return err
+		}
+
+		s, err := snapshot.New(path, body, opts)
+		if err != nil {
+			return err
+		}
+
+		file := filepath.Join(sc.dir, snapshot.FileName(path))
+		if err := s.Save(fs, file); err != nil {
+			return err
+		}
+
+		fmt.Printf("%s Captured %s to %s\n", ansi.SuccessGlyph(), path, file)
+	}
+
+	return nil
+}
+
+func (sc *snapshotCmd) runVerifyCmd(cmd *cobra.Command, args []string) error {
+	files := args
+	if len(files) == 0 {
+		var err error
+
+		files, err = afero.Glob(fs, filepath.Join(sc.dir, "*.json"))
+		if err != nil {
+			return err
+		}
+
+		if len(files) == 0 {
+			return fmt.Errorf("no snapshot files found in %s, capture some with `stripe snapshot capture`", sc.dir)
+		}
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(sc.livemode)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, file := range files {
+		s, err := snapshot.Load(fs, file)
+		if err != nil {
+			return err
+		}
+
+		body, err := sc.fetch(cmd.Context(), apiKey, s.Path)
+		if err != nil {
+			return err
+		}
+
+		differences, err := s.Verify(body)
+		if err != nil {
+			return err
+		}
+
+		if len(differences) == 0 {
+			fmt.Printf("%s %s matches %s\n", ansi.SuccessGlyph(), s.Path, file)
+			continue
+		}
+
+		failed++
+		fmt.Printf("%s %s doesn't match %s: %d difference(s)\n", ansi.ErrorGlyph(), s.Path, file, len(differences))
+
+		for _, d := range differences {
+			fmt.Printf("    %s\n", d)
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d snapshot(s) don't match", failed, len(files))
+	}
+
+	return nil
+}
+
+// fetch makes a GET request to path, which can have a query string
+func (sc *snapshotCmd) fetch(ctx context.Context, apiKey, path string) ([]byte, error) {
+	path, query := splitQuery(path)
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var params requests.RequestParameters
+	for _, key := range keys {
+		for _, value := range values[key] {
+			params.AppendData([]string{key + "=" + value})
+		}
+	}
+
+	req := requests.Base{
+		Method:         http.MethodGet,
+		SuppressOutput: true,
+		APIBaseURL:     sc.apiBaseURL,
+	}
+
+	return req.MakeRequest(ctx, apiKey, path, &params, true)
+}
+
+func splitQuery(path string) (string, string) {
+	if i := strings.Index(path, "?"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+
+	return path, ""
+}
@@ -46,7 +46,7 @@ func newStatusCmd() *statusCmd {
 
 func (sc *statusCmd) runStatusCmd(cmd *cobra.Command, args []string) error {
 	if sc.format != "json" {
-		version.CheckLatestVersion()
+		version.CheckLatestVersion(cmd.Context())
 	}
 
 	if sc.pollRate < 5 {
@@ -58,7 +58,7 @@ func (sc *statusCmd) runStatusCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	for {
-		stripeStatus, err := status.GetStatus()
+		stripeStatus, err := status.GetStatus(cmd.Context())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/stripe/stripe-cli/pkg/suggest"
+)
+
+// suggestFlag adds the closest flag of cmd to the errors about unknown flags
+func suggestFlag(cmd *cobra.Command, err error) error {
+	name := strings.TrimPrefix(err.Error(), "unknown flag: --")
+	if name == err.Error() {
+		return err
+	}
+
+	var names []string
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Hidden {
+			names = append(names, f.Name)
+		}
+	})
+
+	if match, ok := suggest.Closest(name, names); ok {
+		return fmt.Errorf("%w. Did you mean --%s?", err, match)
+	}
+
+	return err
+}
+
+// suggestSubcommands makes the command grouping subcommands that args run
+// report unknown subcommands with the closest match, instead of printing its
+// help
+func suggestSubcommands(root *cobra.Command, args []string) {
+	cmd, rest, err := root.Find(args)
+	if err != nil || cmd == root || !cmd.HasSubCommands() || cmd.Runnable() || len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
+		return
+	}
+
+	cmd.Args = cobra.ArbitraryArgs
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+
+		var names []string
+		for _, c := range cmd.Commands() {
+			if c.IsAvailableCommand() {
+				names = append(names, c.Name())
+			}
+		}
+
+		message := fmt.Sprintf("Unknown command \"%s\" for \"%s\".", args[0], cmd.CommandPath())
+		if match, ok := suggest.Closest(args[0], names); ok {
+			message += fmt.Sprintf(" Did you mean \"%s\"?", match)
+		}
+
+		return fmt.Errorf("%s See \"%s --help\" for a list of available commands", message, cmd.CommandPath())
+	}
+}
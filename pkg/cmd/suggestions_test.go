@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "listen"}
+	cmd.Flags().String("forward-to", "", "")
+	cmd.Flags().String("api-base", "", "")
+	cmd.Flags().MarkHidden("api-base")
+
+	err := suggestFlag(cmd, errors.New("unknown flag: --forwrad-to"))
+	require.EqualError(t, err, "unknown flag: --forwrad-to. Did you mean --forward-to?")
+
+	err = suggestFlag(cmd, errors.New("unknown flag: --api-bse"))
+	require.EqualError(t, err, "unknown flag: --api-bse")
+
+	err = suggestFlag(cmd, errors.New("invalid argument"))
+	require.EqualError(t, err, "invalid argument")
+}
+
+func TestSuggestSubcommands(t *testing.T) {
+	root := &cobra.Command{Use: "stripe"}
+	logs := &cobra.Command{Use: "logs"}
+	logs.AddCommand(&cobra.Command{Use: "tail", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(logs)
+
+	suggestSubcommands(root, []string{"logs", "tial"})
+
+	root.SetArgs([]string{"logs", "tial"})
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	err := root.Execute()
+	require.EqualError(t, err, `Unknown command "tial" for "stripe logs". Did you mean "tail"? See "stripe logs --help" for a list of available commands`)
+}
@@ -0,0 +1,544 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/logrusorgru/aurora"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/correlation"
+	"github.com/stripe/stripe-cli/pkg/logtailing"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/validators"
+	"github.com/stripe/stripe-cli/pkg/version"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+const (
+	tailSourceRequest = "request"
+	tailSourceEvent   = "event"
+)
+
+// tailOrderWindow is how long the lines are held back so that a line of
+// one source created before a line of the other one, but received after it,
+// is still printed first
+const tailOrderWindow = time.Second
+
+// maxCorrelatedRequests is the number of request IDs remembered to link
+// request logs and events together
+const maxCorrelatedRequests = 1000
+
+type tailCmd struct {
+	cmd *cobra.Command
+
+	requests   bool
+	events     bool
+	eventTypes []string
+	format     string
+	livemode   bool
+	skipUpdate bool
+	apiBaseURL string
+	noWSS      bool
+	tuning     websocket.Tuning
+}
+
+func newTailCmd() *tailCmd {
+	tc := &tailCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "tail",
+		Args:  validators.NoArgs,
+		Short: "Tail API request logs and webhook events together",
+		Long: `The tail command shows API request logs and webhook events in a single
+stream, so you can see which request caused which event. Request logs are
+prefixed with "req" and events with "evt". When an event was caused by an API
+request, both lines are tagged with the same colored request ID. Lines are
+printed in the order they were created, within a second of each other.
+
+Both sources are shown when neither --requests nor --events is passed.`,
+		Example: `stripe tail
+  stripe tail --requests --events
+  stripe tail --events --event-types charge.succeeded,payment_intent.created`,
+		RunE: tc.runTailCmd,
+	}
+
+	tc.cmd.Flags().BoolVar(&tc.requests, "requests", false, "Show API request logs")
+	tc.cmd.Flags().BoolVar(&tc.events, "events", false, "Show webhook events")
+	tc.cmd.Flags().StringSliceVar(&tc.eventTypes, "event-types", []string{"*"}, "A comma-separated list of specific events to show")
+	tc.cmd.Flags().StringVar(&tc.format, "format", "", `Specifies the output format
+	Acceptable values:
+		'JSON' - Output one JSON object per line, with the source and the raw payload`)
+	tc.cmd.Flags().BoolVar(&tc.livemode, "live", false, "Receive live events (default: test)")
+	tc.cmd.Flags().BoolVarP(&tc.skipUpdate, "skip-update", "s", false, "Skip checking latest version of Stripe CLI")
+	tc.tuning.AddFlags(tc.cmd.Flags())
+
+	// Hidden configuration flags, useful for dev/debugging
+	tc.cmd.Flags().StringVar(&tc.apiBaseURL, "api-base", "", "Sets the API base URL")
+	tc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	tc.cmd.Flags().BoolVar(&tc.noWSS, "no-wss", false, "Force unencrypted ws:// protocol instead of wss://")
+	tc.cmd.Flags().MarkHidden("no-wss") // #nosec G104
+
+	return tc
+}
+
+func (tc *tailCmd) runTailCmd(cmd *cobra.Command, args []string) error {
+	if !tc.requests && !tc.events {
+		tc.requests = true
+		tc.events = true
+	}
+
+	if tc.format != "" && strings.ToUpper(tc.format) != outputFormatJSON {
+		return fmt.Errorf("invalid format %q, the only supported format is JSON", tc.format)
+	}
+
+	if err := tc.tuning.Load(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if !tc.skipUpdate {
+		version.CheckLatestVersion(cmd.Context())
+	}
+
+	deviceName, err := Config.Profile.GetDeviceName()
+	if err != nil {
+		return err
+	}
+
+	key, err := Config.Profile.GetAPIKey(tc.livemode)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	logger := log.StandardLogger()
+	sources := make(map[string]chan websocket.IElement)
+
+	if tc.events {
+		eventsOutCh := make(chan websocket.IElement)
+
+		// Have the other commands record their requests, to annotate the
+		// events they cause
+		stopLookups := correlation.StartLookups()
+		defer stopLookups()
+
+		p, err := proxy.Init(ctx, &proxy.Config{
+			DeviceName:       deviceName,
+			Key:              key,
+			APIBaseURL:       tc.apiBaseURL,
+			WebSocketFeature: webhooksWebSocketFeature,
+			Log:              logger,
+			NoWSS:            tc.noWSS,
+			Tuning:           tc.tuning,
+			Events:           tc.eventTypes,
+			OutCh:            eventsOutCh,
+		})
+		if err != nil {
+			return err
+		}
+
+		go p.Run(ctx)
+
+		sources[tailSourceEvent] = eventsOutCh
+	}
+
+	if tc.requests {
+		requestsOutCh := make(chan websocket.IElement)
+
+		tailer := logtailing.New(&logtailing.Config{
+			APIBaseURL: tc.apiBaseURL,
+			DeviceName: deviceName,
+			Filters:    &logtailing.LogFilters{},
+			Key:        key,
+			Log:        logger,
+			NoWSS:      tc.noWSS,
+			Tuning:     tc.tuning,
+			OutCh:      requestsOutCh,
+		})
+
+		go tailer.Run(ctx)
+
+		sources[tailSourceRequest] = requestsOutCh
+	}
+
+	printer := newTailPrinter(os.Stdout, tc.format, len(sources))
+
+	for el := range mergeTailSources(sources) {
+		err := el.Accept(printer.visitor(el.source))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// Private types
+//
+
+// tailElement is an element received from one of the tailed sources
+type tailElement struct {
+	websocket.IElement
+	source string
+}
+
+// pendingTailElement is a data element held back until its turn
+type pendingTailElement struct {
+	tailElement
+	created int
+	until   time.Time
+}
+
+// tailPrinter prints the elements of all tailed sources as one stream
+type tailPrinter struct {
+	out        io.Writer
+	format     string
+	color      aurora.Aurora
+	correlator *requestCorrelator
+
+	spinner *spinner.Spinner
+	sources int
+	ready   int
+}
+
+// requestCorrelator remembers which events were caused by which API
+// requests, in whichever order the request logs and events arrive
+type requestCorrelator struct {
+	order    []string
+	requests map[string]bool
+	events   map[string][]string
+}
+
+//
+// Private functions
+//
+
+func newTailPrinter(out io.Writer, format string, sources int) *tailPrinter {
+	return &tailPrinter{
+		out:        out,
+		format:     format,
+		color:      ansi.Color(out),
+		correlator: newRequestCorrelator(),
+		sources:    sources,
+	}
+}
+
+func newRequestCorrelator() *requestCorrelator {
+	return &requestCorrelator{
+		requests: make(map[string]bool),
+		events:   make(map[string][]string),
+	}
+}
+
+// mergeTailSources multiplexes the output channels of all sources into a
+// single channel ordered by creation time, which is closed once every source
+// is done.
+func mergeTailSources(sources map[string]chan websocket.IElement) <-chan tailElement {
+	merged := make(chan tailElement)
+
+	var wg sync.WaitGroup
+
+	for source, ch := range sources {
+		wg.Add(1)
+
+		go func(source string, ch chan websocket.IElement) {
+			defer wg.Done()
+
+			for el := range ch {
+				merged <- tailElement{IElement: el, source: source}
+			}
+		}(source, ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	return orderTailElements(merged, tailOrderWindow)
+}
+
+// orderTailElements holds the data elements received from in for window,
+// and sends them in the order they were created. Other elements, e.g. errors
+// and status changes, are sent right away, after the data held back.
+func orderTailElements(in <-chan tailElement, window time.Duration) <-chan tailElement {
+	out := make(chan tailElement)
+
+	go func() {
+		defer close(out)
+
+		var pending []pendingTailElement
+
+		for {
+			var wait <-chan time.Time
+			if len(pending) > 0 {
+				wait = time.After(time.Until(pending[0].until))
+			}
+
+			select {
+			case el, ok := <-in:
+				if !ok {
+					for _, p := range pending {
+						out <- p.tailElement
+					}
+
+					return
+				}
+
+				created, ok := tailElementCreated(el)
+				if !ok {
+					for _, p := range pending {
+						out <- p.tailElement
+					}
+					pending = nil
+
+					out <- el
+
+					continue
+				}
+
+				// Keep the elements created at the same time in arrival order
+				i := sort.Search(len(pending), func(i int) bool {
+					return pending[i].created > created
+				})
+
+				pending = append(pending, pendingTailElement{})
+				copy(pending[i+1:], pending[i:])
+				pending[i] = pendingTailElement{
+					tailElement: el,
+					created:     created,
+					until:       time.Now().Add(window),
+				}
+			case <-wait:
+				now := time.Now()
+
+				for len(pending) > 0 && !pending[0].until.After(now) {
+					out <- pending[0].tailElement
+					pending = pending[1:]
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// tailElementCreated returns the creation time of the request log or event
+// of a data element.
+func tailElementCreated(el tailElement) (int, bool) {
+	de, ok := el.IElement.(websocket.DataElement)
+	if !ok {
+		return 0, false
+	}
+
+	switch data := de.Data.(type) {
+	case logtailing.EventPayload:
+		return data.CreatedAt, true
+	case proxy.StripeEvent:
+		return data.Created, true
+	default:
+		return 0, false
+	}
+}
+
+// addRequest records a request log and returns the IDs of the events it
+// already caused.
+func (c *requestCorrelator) addRequest(requestID string) []string {
+	if requestID == "" {
+		return nil
+	}
+
+	c.remember(requestID)
+	c.requests[requestID] = true
+
+	return c.events[requestID]
+}
+
+// addEvent records an event caused by an API request and returns whether
+// the request log was already seen.
+func (c *requestCorrelator) addEvent(requestID, eventID string) bool {
+	if requestID == "" {
+		return false
+	}
+
+	c.remember(requestID)
+	c.events[requestID] = append(c.events[requestID], eventID)
+
+	return c.requests[requestID]
+}
+
+func (c *requestCorrelator) remember(requestID string) {
+	if c.requests[requestID] || len(c.events[requestID]) > 0 {
+		return
+	}
+
+	c.order = append(c.order, requestID)
+
+	if len(c.order) > maxCorrelatedRequests {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+
+		delete(c.requests, oldest)
+		delete(c.events, oldest)
+	}
+}
+
+func (tp *tailPrinter) visitor(source string) *websocket.Visitor {
+	return &websocket.Visitor{
+		VisitError: func(ee websocket.ErrorElement) error {
+			ansi.StopSpinner(tp.spinner, "", os.Stderr)
+			return ee.Error
+		},
+		VisitWarning: func(we websocket.WarningElement) error {
+			fmt.Fprintf(tp.out, "%s %s\n", tp.color.Yellow("Warning"), we.Warning)
+			return nil
+		},
+		VisitStatus: func(se websocket.StateElement) error {
+			switch se.State {
+			case websocket.Loading:
+				if tp.spinner == nil {
+					tp.spinner = ansi.StartNewSpinner("Getting ready...", os.Stderr)
+				}
+			case websocket.Reconnecting:
+				ansi.StartSpinner(tp.spinner, "Session expired, reconnecting...", os.Stderr)
+			case websocket.Ready:
+				tp.ready++
+				if tp.ready == tp.sources {
+					ansi.StopSpinner(tp.spinner, "Ready! You're now waiting to receive API request logs and events (^C to quit)", os.Stderr)
+				}
+			case websocket.Done:
+				ansi.StopSpinner(tp.spinner, "", os.Stderr)
+			}
+			return nil
+		},
+		VisitData: func(de websocket.DataElement) error {
+			if strings.ToUpper(tp.format) == outputFormatJSON {
+				return tp.printJSON(source, de.Marshaled)
+			}
+
+			switch data := de.Data.(type) {
+			case logtailing.EventPayload:
+				tp.printRequest(data)
+			case proxy.StripeEvent:
+				tp.printEvent(data)
+			default:
+				return fmt.Errorf("VisitData received unexpected type for DataElement, got %T", de.Data)
+			}
+			return nil
+		},
+	}
+}
+
+func (tp *tailPrinter) printJSON(source string, marshaled string) error {
+	line, err := json.Marshal(struct {
+		Source string          `json:"source"`
+		Data   json.RawMessage `json:"data"`
+	}{
+		Source: source,
+		Data:   json.RawMessage(marshaled),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(tp.out, string(line))
+
+	return nil
+}
+
+func (tp *tailPrinter) printRequest(payload logtailing.EventPayload) {
+	causedEvents := tp.correlator.addRequest(payload.RequestID)
+
+	url := payload.URL
+	if url == "" {
+		url = "[View path in dashboard]"
+	}
+
+	line := fmt.Sprintf("%s %s %s [%d] %s %s [%s]",
+		tp.color.Faint(time.Unix(int64(payload.CreatedAt), 0).Format(timeLayout)),
+		tp.color.Cyan("req"),
+		ansi.StatusGlyph(payload.Status),
+		ansi.ColorizeStatus(payload.Status),
+		payload.Method,
+		url,
+		ansi.Linkify(tp.requestID(payload.RequestID), dashboardRequestURL(payload.RequestID, payload.Livemode), tp.out),
+	)
+
+	if len(causedEvents) > 0 {
+		line += fmt.Sprintf(" --> %s", strings.Join(causedEvents, ", "))
+	}
+
+	if payload.Error.Message != "" {
+		line += fmt.Sprintf("\n%s %s", tp.color.Red("  error:"), payload.Error.Message)
+	}
+
+	fmt.Fprintln(tp.out, line)
+}
+
+func (tp *tailPrinter) printEvent(evt proxy.StripeEvent) {
+	tp.correlator.addEvent(evt.Request.ID, evt.ID)
+
+	maybeConnect := ""
+	if evt.IsConnect() {
+		maybeConnect = "connect "
+	}
+
+	line := fmt.Sprintf("%s %s %s%s [%s]",
+		tp.color.Faint(time.Unix(int64(evt.Created), 0).Format(timeLayout)),
+		tp.color.Magenta("evt"),
+		maybeConnect,
+		ansi.Linkify(tp.color.Sprintf(tp.color.Bold(evt.Type)), evt.URLForEventType(), tp.out),
+		ansi.Linkify(evt.ID, evt.URLForEventID(), tp.out),
+	)
+
+	if evt.Request.ID != "" {
+		line += fmt.Sprintf(" <-- [%s]%s", tp.requestID(evt.Request.ID), commandAnnotation(tp.color, evt.Request.ID))
+	}
+
+	fmt.Fprintln(tp.out, line)
+}
+
+// requestID colors a request ID so that all lines related to the same
+// request share the same color.
+func (tp *tailPrinter) requestID(id string) string {
+	if id == "" {
+		return id
+	}
+
+	colors := []func(interface{}) aurora.Value{
+		tp.color.Blue,
+		tp.color.Green,
+		tp.color.Yellow,
+		tp.color.Cyan,
+		tp.color.Magenta,
+		tp.color.BrightBlue,
+		tp.color.BrightGreen,
+		tp.color.BrightYellow,
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(id)) // #nosec G104
+
+	return tp.color.Sprintf(colors[h.Sum32()%uint32(len(colors))](id))
+}
+
+func dashboardRequestURL(requestID string, livemode bool) string {
+	maybeTest := ""
+	if !livemode {
+		maybeTest = "/test"
+	}
+
+	return fmt.Sprintf("https://dashboard.stripe.com%s/logs/%s", maybeTest, requestID)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/correlation"
+	"github.com/stripe/stripe-cli/pkg/logtailing"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestRequestCorrelator(t *testing.T) {
+	c := newRequestCorrelator()
+
+	require.False(t, c.addEvent("req_123", "evt_1"))
+	require.False(t, c.addEvent("req_123", "evt_2"))
+	require.Equal(t, []string{"evt_1", "evt_2"}, c.addRequest("req_123"))
+	require.True(t, c.addEvent("req_123", "evt_3"))
+
+	require.Empty(t, c.addRequest("req_456"))
+	require.False(t, c.addEvent("", "evt_4"))
+}
+
+func TestRequestCorrelatorEvictsOldestRequests(t *testing.T) {
+	c := newRequestCorrelator()
+
+	c.addRequest("req_first")
+	for i := 0; i < maxCorrelatedRequests; i++ {
+		c.addRequest(fmt.Sprintf("req_%d", i))
+	}
+
+	require.Len(t, c.order, maxCorrelatedRequests)
+	require.False(t, c.addEvent("req_first", "evt_1"))
+}
+
+func TestOrderTailElements(t *testing.T) {
+	in := make(chan tailElement)
+	out := orderTailElements(in, 50*time.Millisecond)
+
+	evt := tailElement{IElement: websocket.DataElement{Data: proxy.StripeEvent{ID: "evt_123", Created: 20}}, source: tailSourceEvent}
+	req := tailElement{IElement: websocket.DataElement{Data: logtailing.EventPayload{RequestID: "req_123", CreatedAt: 10}}, source: tailSourceRequest}
+	req2 := tailElement{IElement: websocket.DataElement{Data: logtailing.EventPayload{RequestID: "req_456", CreatedAt: 20}}, source: tailSourceRequest}
+	ready := tailElement{IElement: websocket.StateElement{State: websocket.Ready}, source: tailSourceEvent}
+
+	// Data created earlier but received later is sent first
+	in <- evt
+	in <- req
+	in <- req2
+	require.Equal(t, req, <-out)
+	require.Equal(t, evt, <-out)
+	require.Equal(t, req2, <-out)
+
+	// Other elements are sent right away, after the data held back
+	in <- evt
+	in <- ready
+	require.Equal(t, evt, <-out)
+	require.Equal(t, ready, <-out)
+
+	in <- req
+	close(in)
+	require.Equal(t, req, <-out)
+
+	_, ok := <-out
+	require.False(t, ok)
+}
+
+func TestTailPrinter(t *testing.T) {
+	var b bytes.Buffer
+	tp := newTailPrinter(&b, "", 2)
+
+	evt := websocket.DataElement{Data: proxy.StripeEvent{
+		ID:      "evt_123",
+		Type:    "customer.created",
+		Created: 1600000000,
+		Request: proxy.StripeRequest{ID: "req_123"},
+	}}
+	require.NoError(t, evt.Accept(tp.visitor(tailSourceEvent)))
+
+	req := websocket.DataElement{Data: logtailing.EventPayload{
+		CreatedAt: 1600000000,
+		Method:    "POST",
+		RequestID: "req_123",
+		Status:    200,
+		URL:       "/v1/customers",
+	}}
+	require.NoError(t, req.Accept(tp.visitor(tailSourceRequest)))
+
+	require.Contains(t, b.String(), "evt customer.created [evt_123] <-- [req_123]")
+	require.Contains(t, b.String(), "req ✔ [200] POST /v1/customers [req_123] --> evt_123")
+}
+
+func TestTailPrinterJSON(t *testing.T) {
+	var b bytes.Buffer
+	tp := newTailPrinter(&b, "json", 1)
+
+	el := websocket.DataElement{Data: proxy.StripeEvent{}, Marshaled: `{"id":"evt_123"}`}
+	require.NoError(t, el.Accept(tp.visitor(tailSourceEvent)))
+
+	require.Equal(t, "{\"source\":\"event\",\"data\":{\"id\":\"evt_123\"}}\n", b.String())
+}
+
+func TestTailPrinterCommandAnnotation(t *testing.T) {
+	correlation.Enable(filepath.Join(t.TempDir(), correlation.IndexFileName), "stripe trigger customer.created")
+	defer correlation.Enable("", "")
+	defer correlation.StartLookups()()
+	require.NoError(t, correlation.Record("req_123"))
+
+	var b bytes.Buffer
+	tp := newTailPrinter(&b, "", 1)
+
+	el := websocket.DataElement{Data: proxy.StripeEvent{
+		ID:      "evt_123",
+		Type:    "customer.created",
+		Request: proxy.StripeRequest{ID: "req_123"},
+	}}
+	require.NoError(t, el.Accept(tp.visitor(tailSourceEvent)))
+
+	require.Contains(t, b.String(), "<-- [req_123] (stripe trigger customer.created)")
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type taxCmd struct {
+	cmd *cobra.Command
+}
+
+type taxPreviewCmd struct {
+	cmd *cobra.Command
+
+	params          preview.TaxParams
+	addressFile     string
+	format          string
+	displayCurrency string
+	livemode        bool
+	apiBaseURL      string
+}
+
+func newTaxCmd() *taxCmd {
+	tc := &taxCmd{}
+
+	tc.cmd = &cobra.Command{
+		Use:   "tax",
+		Args:  validators.NoArgs,
+		Short: "Preview Stripe Tax calculations",
+		Long:  `Preview the taxes Stripe Tax calculates for your customers and prices.`,
+	}
+
+	tc.cmd.AddCommand(newTaxPreviewCmd().cmd)
+
+	return tc
+}
+
+func newTaxPreviewCmd() *taxPreviewCmd {
+	tpc := &taxPreviewCmd{}
+
+	tpc.cmd = &cobra.Command{
+		Use:   "preview",
+		Args:  validators.NoArgs,
+		Short: "Calculate the taxes due on the purchase of a price",
+		Long: `Calculate the taxes due when a customer purchases a price, and show the
+breakdown by jurisdiction. The customer's address is taken from the customer
+object, or from an address file containing a JSON object with line1, line2,
+city, state, postal_code and country.`,
+		Example: `stripe tax preview --customer cus_123 --price price_123
+  stripe tax preview --price price_123 --address-file address.json`,
+		RunE: tpc.runTaxPreviewCmd,
+	}
+
+	tpc.cmd.Flags().StringVar(&tpc.params.Customer, "customer", "", "ID of the customer to calculate taxes for")
+	tpc.cmd.Flags().StringVar(&tpc.params.Price, "price", "", "ID of the price being purchased (required)")
+	tpc.cmd.Flags().Int64Var(&tpc.params.Quantity, "quantity", 1, "Quantity of the price being purchased")
+	tpc.cmd.Flags().StringVar(&tpc.addressFile, "address-file", "", "Path to a JSON file with the customer's address")
+	tpc.cmd.Flags().StringVar(&tpc.format, "format", "", `Specifies the output format of the calculation
+	Acceptable values:
+		'JSON' - Output the raw tax calculation in JSON format`)
+	tpc.cmd.Flags().StringVar(&tpc.displayCurrency, "display-currency", "", "Also show the totals converted to this currency, at approximate exchange rates")
+	tpc.cmd.Flags().BoolVar(&tpc.livemode, "live", false, "Make a live request (default: test)")
+	tpc.cmd.MarkFlagRequired("price") // #nosec G104
+
+	// Hidden configuration flags, useful for dev/debugging
+	tpc.cmd.Flags().StringVar(&tpc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	tpc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return tpc
+}
+
+func (tpc *taxPreviewCmd) runTaxPreviewCmd(cmd *cobra.Command, args []string) error {
+	if tpc.addressFile != "" {
+		data, err := afero.ReadFile(fs, tpc.addressFile)
+		if err != nil {
+			return err
+		}
+
+		tpc.params.Address, err = preview.ParseAddress(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(tpc.livemode)
+	if err != nil {
+		return err
+	}
+
+	client := simulate.NewAPIClient(apiKey, tpc.apiBaseURL)
+
+	calculation, err := preview.Tax(cmd.Context(), client, tpc.params)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(tpc.format) == outputFormatJSON {
+		fmt.Println(ansi.ColorizeJSON(calculation.Raw, false, os.Stdout))
+		return nil
+	}
+
+	var rates *preview.ExchangeRates
+	if tpc.displayCurrency != "" {
+		rates, err = preview.LoadExchangeRates(cmd.Context(), client, exchangeRatesPath(), tpc.displayCurrency)
+		if err != nil {
+			return err
+		}
+	}
+
+	preview.RenderTax(os.Stdout, calculation, rates)
+
+	return nil
+}
+
+func exchangeRatesPath() string {
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), preview.ExchangeRatesFileName)
+}
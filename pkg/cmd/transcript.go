@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/redact"
+)
+
+var ansiEscapeRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]|\x1b\]8;;[^\x1b]*\x1b\\`)
+
+// sessionTranscript records everything the CLI prints to stdout and stderr
+// to a file, one timestamped line at a time, with secrets redacted. Output is
+// still passed through to the terminal unchanged.
+type sessionTranscript struct {
+	mu   sync.Mutex
+	file *os.File
+	wg   sync.WaitGroup
+
+	stdout, stderr *os.File
+	pipes          []*os.File
+	streams        []*transcriptStream
+
+	// restoreTerminals stops the pipes from standing in for the terminal
+	restoreTerminals []func()
+}
+
+type transcriptStream struct {
+	name string
+	t    *sessionTranscript
+	buf  bytes.Buffer
+}
+
+// startTranscript starts recording the session to the file at path. The
+// original stdout and stderr are restored when the transcript is closed.
+func startTranscript(path string, args []string) (*sessionTranscript, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &sessionTranscript{
+		file:   file,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+
+	fmt.Fprintf(file, "# stripe %s\n", redact.MaskSecrets(strings.Join(args, " ")))
+	fmt.Fprintf(file, "# started at %s\n", time.Now().Format(time.RFC3339))
+
+	os.Stdout, err = t.tee("stdout", t.stdout)
+	if err != nil {
+		t.Close()
+		return nil, err
+	}
+
+	os.Stderr, err = t.tee("stderr", t.stderr)
+	if err != nil {
+		t.Close()
+		return nil, err
+	}
+
+	if log.StandardLogger().Out == t.stderr {
+		log.SetOutput(os.Stderr)
+	}
+
+	return t, nil
+}
+
+// Close restores stdout and stderr, writes any pending output and closes the
+// transcript file. It is safe to call on a nil transcript.
+func (t *sessionTranscript) Close() {
+	if t == nil {
+		return
+	}
+
+	if log.StandardLogger().Out == os.Stderr {
+		log.SetOutput(t.stderr)
+	}
+
+	os.Stdout = t.stdout
+	os.Stderr = t.stderr
+
+	for _, restore := range t.restoreTerminals {
+		restore()
+	}
+
+	for _, pipe := range t.pipes {
+		pipe.Close()
+	}
+
+	t.wg.Wait()
+
+	for _, s := range t.streams {
+		s.flush()
+	}
+
+	fmt.Fprintf(t.file, "# ended at %s\n", time.Now().Format(time.RFC3339))
+	t.file.Close()
+}
+
+// tee returns a file to use in place of out. Everything written to it is
+// copied to out and recorded in the transcript. The file stands in for out
+// when checking whether the output is a terminal, so that colors, spinners
+// and the pager behave as without a transcript.
+func (t *sessionTranscript) tee(name string, out *os.File) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &transcriptStream{name: name, t: t}
+	paged := &transcriptStream{name: name, t: t}
+
+	t.pipes = append(t.pipes, w)
+	t.streams = append(t.streams, s, paged)
+	t.restoreTerminals = append(t.restoreTerminals, ansi.SetTerminalStandIn(w, out, paged))
+	t.wg.Add(1)
+
+	go func() {
+		defer t.wg.Done()
+		defer r.Close()
+
+		io.Copy(io.MultiWriter(out, s), r) // #nosec G104
+	}()
+
+	return w, nil
+}
+
+func (t *sessionTranscript) writeLine(stream, line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	line = ansiEscapeRegex.ReplaceAllString(line, "")
+	line = strings.TrimRight(line, "\r")
+	// Spinners and progress output rewrite the same line using carriage returns,
+	// only the final state is worth keeping.
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		line = line[i+1:]
+	}
+
+	fmt.Fprintf(t.file, "%s %s  %s\n", time.Now().Format(timeLayout), stream, redact.MaskSecrets(line))
+}
+
+// Write implements io.Writer, recording complete lines as they come in.
+func (s *transcriptStream) Write(p []byte) (int, error) {
+	s.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(s.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		line := string(s.buf.Next(i + 1))
+		s.t.writeLine(s.name, strings.TrimSuffix(line, "\n"))
+	}
+
+	return len(p), nil
+}
+
+func (s *transcriptStream) flush() {
+	if s.buf.Len() > 0 {
+		s.t.writeLine(s.name, s.buf.String())
+		s.buf.Reset()
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptRecordsOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcript.log")
+
+	stdout := os.Stdout
+	tr, err := startTranscript(path, []string{"listen", "--api-key", "sk_test_123456789"})
+	require.NoError(t, err)
+
+	fmt.Fprintln(os.Stdout, "\x1b[1mReady!\x1b[0m Your webhook signing secret is whsec_abcdefgh1234")
+	fmt.Fprint(os.Stderr, "partial line")
+	tr.Close()
+
+	require.Equal(t, stdout, os.Stdout)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.Contains(t, string(content), "# stripe listen --api-key sk_test_*****6789\n")
+	require.Contains(t, string(content), "stdout  Ready! Your webhook signing secret is whsec_********1234\n")
+	require.Contains(t, string(content), "stderr  partial line\n")
+	require.NotContains(t, string(content), "abcdefgh")
+}
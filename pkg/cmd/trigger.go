@@ -2,12 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/gha"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
@@ -23,6 +29,9 @@ type triggerCmd struct {
 	add           []string
 	remove        []string
 	raw           string
+	rawEventType  string
+	fixture       string
+	timeout       time.Duration
 	apiBaseURL    string
 }
 
@@ -44,8 +53,9 @@ needed to create the triggered event as well as the corresponding API objects.
 			ansi.Bold("Supported events:"),
 			fixtures.EventList(),
 		),
-		Example: `stripe trigger payment_intent.created`,
-		RunE:    tc.runTriggerCmd,
+		Example: `stripe trigger payment_intent.created
+  stripe trigger --raw-event-type treasury.received_credit.created --fixture ./received_credit.json`,
+		RunE: tc.runTriggerCmd,
 	}
 
 	tc.cmd.Flags().StringVar(&tc.stripeAccount, "stripe-account", "", "Set a header identifying the connected account")
@@ -54,16 +64,35 @@ needed to create the triggered event as well as the corresponding API objects.
 	tc.cmd.Flags().StringArrayVar(&tc.add, "add", []string{}, "Add params to the trigger")
 	tc.cmd.Flags().StringArrayVar(&tc.remove, "remove", []string{}, "Remove params from the trigger")
 	tc.cmd.Flags().StringVar(&tc.raw, "raw", "", "Raw fixture in string format to replace all default fixtures")
+	tc.cmd.Flags().StringVar(&tc.rawEventType, "raw-event-type", "", "Trigger an event type without a built-in fixture, e.g. of a beta product, and wait for it to be received. Requires --fixture")
+	tc.cmd.Flags().StringVar(&tc.fixture, "fixture", "", "Path to the fixture file creating the event of --raw-event-type")
+	tc.cmd.Flags().DurationVar(&tc.timeout, "timeout", 30*time.Second, "How long to wait for the event of --raw-event-type")
+	addNotifyFlag(tc.cmd)
 
 	// Hidden configuration flags, useful for dev/debugging
 	tc.cmd.Flags().StringVar(&tc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
 	tc.cmd.Flags().MarkHidden("api-base") // #nosec G104
 
+	tc.cmd.AddCommand(newTriggerCoverageCmd().cmd)
+
 	return tc
 }
 
 func (tc *triggerCmd) runTriggerCmd(cmd *cobra.Command, args []string) error {
-	version.CheckLatestVersion()
+	version.CheckLatestVersion(cmd.Context())
+
+	if err := tc.validateRawEventFlags(args); err != nil {
+		return err
+	}
+
+	if tc.rawEventType != "" {
+		apiKey, err := Config.Profile.GetAPIKey(false)
+		if err != nil {
+			return err
+		}
+
+		return tc.runRawEventTrigger(cmd, apiKey)
+	}
 
 	if len(args) == 0 {
 		cmd.Help()
@@ -78,11 +107,37 @@ func (tc *triggerCmd) runTriggerCmd(cmd *cobra.Command, args []string) error {
 
 	event := args[0]
 
-	_, err = fixtures.Trigger(cmd.Context(), event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+	if ghaOutput() {
+		gha.Group(os.Stdout, "Trigger "+event)
+	}
+
+	requestNames, err := fixtures.Trigger(cmd.Context(), event, tc.stripeAccount, tc.apiBaseURL, apiKey, tc.skip, tc.override, tc.add, tc.remove, tc.raw)
+
+	if ghaOutput() {
+		gha.EndGroup(os.Stdout)
+	}
+
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("Trigger succeeded! Check dashboard for event details.")
+	if !ansi.Quiet {
+		fmt.Println(ansi.SuccessGlyph(), "Trigger succeeded! Check dashboard for event details.")
+	}
+
+	if err := fixtures.RecordTrigger(triggerHistoryPath(), event); err != nil {
+		logging.WithPrefix(log.StandardLogger(), "cmd.triggerCmd.runTriggerCmd").Debugf("Could not record the trigger: %v", err)
+	}
+
+	if ghaOutput() {
+		return gha.AppendSummary(fixtureSummary(fmt.Sprintf("Triggered `%s`", event), requestNames))
+	}
+
 	return nil
 }
+
+// triggerHistoryPath returns the path of the history of the events
+// triggered locally.
+func triggerHistoryPath() string {
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), fixtures.TriggerHistoryFileName)
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type triggerCoverageCmd struct {
+	cmd *cobra.Command
+
+	handlerEvents   string
+	webhookEndpoint string
+	since           time.Duration
+	format          string
+	apiBaseURL      string
+}
+
+func newTriggerCoverageCmd() *triggerCoverageCmd {
+	tcc := &triggerCoverageCmd{}
+
+	tcc.cmd = &cobra.Command{
+		Use:   "coverage",
+		Args:  validators.NoArgs,
+		Short: "Report the events handled by your webhook handler that weren't triggered recently",
+		Long: `Compare the events your webhook handler processes with the events you
+triggered recently with ` + "`stripe trigger`" + `, and report the untested ones.
+
+The handled events are read from a file listing one event type per line, or
+from the enabled events of a webhook endpoint.`,
+		Example: `stripe trigger coverage --handler-events events.txt
+  stripe trigger coverage --webhook-endpoint we_123 --since 168h`,
+		RunE: tcc.runTriggerCoverageCmd,
+	}
+
+	tcc.cmd.Flags().StringVar(&tcc.handlerEvents, "handler-events", "", "Path to a file listing the event types handled by your webhook handler")
+	tcc.cmd.Flags().StringVar(&tcc.webhookEndpoint, "webhook-endpoint", "", "ID of a webhook endpoint whose enabled events are handled by your webhook handler")
+	tcc.cmd.Flags().DurationVar(&tcc.since, "since", 30*24*time.Hour, "Only count the events triggered in this period")
+	tcc.cmd.Flags().StringVar(&tcc.format, "format", "", `Specifies the output format of the report
+	Acceptable values:
+		'JSON' - Output the report in JSON format`)
+
+	// Hidden configuration flags, useful for dev/debugging
+	tcc.cmd.Flags().StringVar(&tcc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	tcc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	return tcc
+}
+
+func (tcc *triggerCoverageCmd) runTriggerCoverageCmd(cmd *cobra.Command, args []string) error {
+	if (tcc.handlerEvents == "") == (tcc.webhookEndpoint == "") {
+		return fmt.Errorf("exactly one of --handler-events or --webhook-endpoint is required")
+	}
+
+	handlerEvents, err := tcc.loadHandlerEvents(cmd)
+	if err != nil {
+		return err
+	}
+
+	lastTriggers, err := fixtures.LastTriggers(triggerHistoryPath(), time.Now().Add(-tcc.since))
+	if err != nil {
+		return err
+	}
+
+	coverage := fixtures.Coverage(handlerEvents, lastTriggers)
+
+	if strings.ToUpper(tcc.format) == outputFormatJSON {
+		out, err := json.MarshalIndent(coverage, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	printTriggerCoverage(coverage)
+
+	return nil
+}
+
+func (tcc *triggerCoverageCmd) loadHandlerEvents(cmd *cobra.Command) ([]string, error) {
+	if tcc.handlerEvents != "" {
+		data, err := afero.ReadFile(fs, tcc.handlerEvents)
+		if err != nil {
+			return nil, err
+		}
+
+		return fixtures.ParseHandlerEvents(data), nil
+	}
+
+	apiKey, err := Config.Profile.GetAPIKey(false)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := simulate.NewAPIClient(apiKey, tcc.apiBaseURL).Request(cmd.Context(), http.MethodGet, "/v1/webhook_endpoints/"+tcc.webhookEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]string, 0)
+	for _, event := range endpoint.Get("enabled_events").Array() {
+		events = append(events, event.String())
+	}
+
+	return events, nil
+}
+
+func printTriggerCoverage(coverage []fixtures.EventCoverage) {
+	color := ansi.Color(os.Stdout)
+	tested := 0
+
+	for _, event := range coverage {
+		switch {
+		case event.Tested():
+			tested++
+			fmt.Printf("%s %s %s\n", ansi.SuccessGlyph(), event.Event, color.Faint("last triggered "+event.LastTriggered.Format(timeLayout)))
+		case event.Triggerable:
+			fmt.Printf("%s %s %s\n", ansi.ErrorGlyph(), event.Event, color.Faint("run `stripe trigger "+event.Event+"`"))
+		default:
+			fmt.Printf("%s %s %s\n", color.Yellow("?"), event.Event, color.Faint("not supported by `stripe trigger`"))
+		}
+	}
+
+	fmt.Printf("\n%d of %d handled event types triggered recently\n", tested, len(coverage))
+}
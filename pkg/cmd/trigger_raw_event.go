@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/fixtures"
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// runRawEventTrigger runs the fixture file of an event type the CLI doesn't
+// have a fixture for, e.g. of a beta product, and checks that Stripe sent an
+// event of that type. A listener is connected before the fixture runs, so
+// that the event can't be missed.
+func (tc *triggerCmd) runRawEventTrigger(cmd *cobra.Command, apiKey string) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	// Fail before connecting when the fixture can't run
+	if _, err := tc.fs.Stat(tc.fixture); err != nil {
+		return err
+	}
+
+	deviceName, err := Config.Profile.GetDeviceName()
+	if err != nil {
+		return err
+	}
+
+	observer := newEventObserver(tc.rawEventType)
+
+	listener, err := proxy.NewListener(ctx, proxy.ListenerOptions{
+		APIKey:     apiKey,
+		APIBaseURL: tc.apiBaseURL,
+		DeviceName: deviceName,
+		Visitor:    observer.visitor(),
+		Log:        log.StandardLogger(),
+	})
+	if err != nil {
+		return err
+	}
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- listener.Listen(ctx)
+	}()
+
+	connected := make(chan struct{})
+	go func() {
+		<-listener.Connected()
+		close(connected)
+	}()
+
+	select {
+	case <-connected:
+	case err := <-listenErr:
+		return fmt.Errorf("could not listen for %s events: %w", tc.rawEventType, err)
+	case <-time.After(tc.timeout):
+		return fmt.Errorf("could not listen for %s events: timed out connecting to Stripe", tc.rawEventType)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	runner, err := fixtures.NewRunner(fixtures.RunnerOptions{
+		APIKey:        apiKey,
+		APIBaseURL:    tc.apiBaseURL,
+		StripeAccount: tc.stripeAccount,
+		Skip:          tc.skip,
+		Override:      tc.override,
+		Add:           tc.add,
+		Remove:        tc.remove,
+		Fs:            tc.fs,
+		Out:           os.Stdout,
+		Log:           log.StandardLogger(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := runner.RunFile(ctx, tc.fixture); err != nil {
+		return err
+	}
+
+	spinner := ansi.StartNewSpinner(fmt.Sprintf("Waiting for a %s event...", tc.rawEventType), os.Stdout)
+
+	select {
+	case id := <-observer.observed:
+		ansi.StopSpinner(spinner, "", os.Stdout)
+
+		if !ansi.Quiet {
+			fmt.Println(ansi.SuccessGlyph(), fmt.Sprintf("Trigger succeeded! Received %s event %s.", tc.rawEventType, id))
+		}
+
+		if err := fixtures.RecordTrigger(triggerHistoryPath(), tc.rawEventType); err != nil {
+			logging.WithPrefix(log.StandardLogger(), "cmd.triggerCmd.runRawEventTrigger").Debugf("Could not record the trigger: %v", err)
+		}
+
+		return nil
+	case err := <-listenErr:
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return fmt.Errorf("stopped listening for %s events: %w", tc.rawEventType, err)
+	case <-time.After(tc.timeout):
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return fmt.Errorf("the fixture ran, but no %s event was received within %s. Check that the fixture creates this event, and that the event type is enabled for your account", tc.rawEventType, tc.timeout)
+	case <-ctx.Done():
+		ansi.StopSpinner(spinner, "", os.Stdout)
+		return ctx.Err()
+	}
+}
+
+// eventObserver reports the IDs of the received events of a type
+type eventObserver struct {
+	eventType string
+	observed  chan string
+}
+
+func newEventObserver(eventType string) *eventObserver {
+	return &eventObserver{
+		eventType: eventType,
+		observed:  make(chan string, 1),
+	}
+}
+
+func (o *eventObserver) visitor() *websocket.Visitor {
+	return &websocket.Visitor{
+		VisitData: func(de websocket.DataElement) error {
+			evt, ok := de.Data.(proxy.StripeEvent)
+			if !ok || evt.Type != o.eventType {
+				return nil
+			}
+
+			// Only the first event is waited for
+			select {
+			case o.observed <- evt.ID:
+			default:
+			}
+
+			return nil
+		},
+	}
+}
+
+// validateRawEventFlags checks the flags of raw event triggers
+func (tc *triggerCmd) validateRawEventFlags(args []string) error {
+	switch {
+	case tc.rawEventType == "" && tc.fixture == "":
+		return nil
+	case tc.rawEventType == "":
+		return errors.New("--fixture can only be used with --raw-event-type; use `stripe fixtures` to run a fixture file")
+	case tc.fixture == "":
+		return errors.New("--raw-event-type requires a fixture creating the event with --fixture")
+	case len(args) > 0:
+		return fmt.Errorf("can't trigger both %s and --raw-event-type", args[0])
+	case tc.raw != "":
+		return errors.New("--raw can't be used with --raw-event-type, pass the fixture with --fixture")
+	}
+
+	return nil
+}
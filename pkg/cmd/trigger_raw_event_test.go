@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestValidateRawEventFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tc      triggerCmd
+		args    []string
+		wantErr string
+	}{
+		{"event", triggerCmd{}, []string{"charge.succeeded"}, ""},
+		{"raw event", triggerCmd{rawEventType: "treasury.received_credit.created", fixture: "credit.json"}, nil, ""},
+		{"fixture only", triggerCmd{fixture: "credit.json"}, nil, "--fixture can only be used with --raw-event-type; use `stripe fixtures` to run a fixture file"},
+		{"no fixture", triggerCmd{rawEventType: "treasury.received_credit.created"}, nil, "--raw-event-type requires a fixture creating the event with --fixture"},
+		{"event and raw event", triggerCmd{rawEventType: "treasury.received_credit.created", fixture: "credit.json"}, []string{"charge.succeeded"}, "can't trigger both charge.succeeded and --raw-event-type"},
+		{"raw fixture", triggerCmd{rawEventType: "treasury.received_credit.created", fixture: "credit.json", raw: "{}"}, nil, "--raw can't be used with --raw-event-type, pass the fixture with --fixture"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tc.validateRawEventFlags(tt.args)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventObserver(t *testing.T) {
+	observer := newEventObserver("treasury.received_credit.created")
+	visitor := observer.visitor()
+
+	for _, evt := range []proxy.StripeEvent{
+		{ID: "evt_1", Type: "treasury.financial_account.created"},
+		{ID: "evt_2", Type: "treasury.received_credit.created"},
+		{ID: "evt_3", Type: "treasury.received_credit.created"},
+	} {
+		require.NoError(t, visitor.VisitData(websocket.DataElement{Data: evt}))
+	}
+
+	require.Equal(t, "evt_2", <-observer.observed)
+	require.Empty(t, observer.observed)
+}
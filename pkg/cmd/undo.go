@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/undo"
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+type undoCmd struct {
+	cmd *cobra.Command
+
+	steps       int
+	list        bool
+	autoConfirm bool
+	apiBaseURL  string
+}
+
+func newUndoCmd() *undoCmd {
+	uc := &undoCmd{}
+
+	uc.cmd = &cobra.Command{
+		Use:   "undo",
+		Args:  validators.NoArgs,
+		Short: "Revert the most recent API mutations made with the CLI",
+		Long: `Revert the most recent reversible mutations made with the API commands of
+the CLI: get, post, delete, the resource commands, fixtures and trigger.
+Objects that were created are deleted, and objects that were updated get
+back the values the updated parameters had before.
+
+These mutations can't be reverted, and are skipped:
+  - deletions
+  - actions, e.g. confirming, capturing or canceling a payment intent
+  - creating objects the API can't delete, e.g. charges, refunds and
+    payment intents
+  - updating lists, nested objects more than one level deep, or parameters
+    that aren't returned, e.g. the source of a customer
+  - requests to paths that aren't in the API reference
+
+Reverting an update overwrites the changes made to the same parameters
+since, e.g. in the Dashboard.
+
+The undo journal is off by default. Once enabled with ` + "`stripe undo enable`" + `,
+the last 100 mutations are kept in the config folder, on this machine only.
+For each of them, it stores the command, the method and path of the request,
+the ID of the object and the request reverting it. To revert updates, the
+object is retrieved before each update, which is one more API request, and
+the previous values of the updated parameters are stored in plain text. They
+can hold personal data, e.g. the email or address of a customer. An object
+changed between the retrieval and the update is restored to the values it
+had at the retrieval.`,
+		Example: `stripe undo
+  stripe undo --steps 3
+  stripe undo --list`,
+		RunE: uc.runUndoCmd,
+	}
+
+	uc.cmd.Flags().IntVar(&uc.steps, "steps", 1, "Number of reversible mutations to revert")
+	uc.cmd.Flags().BoolVar(&uc.list, "list", false, "List the recent mutations and how they're reverted instead")
+	uc.cmd.Flags().BoolVarP(&uc.autoConfirm, "confirm", "c", false, "Skip the confirmation prompt")
+
+	// Hidden configuration flags, useful for dev/debugging
+	uc.cmd.Flags().StringVar(&uc.apiBaseURL, "api-base", stripe.DefaultAPIBaseURL, "Sets the API base URL")
+	uc.cmd.Flags().MarkHidden("api-base") // #nosec G104
+
+	enableCmd := &cobra.Command{
+		Use:   "enable",
+		Args:  validators.NoArgs,
+		Short: "Start recording the mutations made with the CLI in the undo journal",
+		RunE:  uc.runEnableCmd,
+	}
+
+	disableCmd := &cobra.Command{
+		Use:   "disable",
+		Args:  validators.NoArgs,
+		Short: "Stop recording the mutations made with the CLI and delete the undo journal",
+		RunE:  uc.runDisableCmd,
+	}
+
+	uc.cmd.AddCommand(enableCmd)
+	uc.cmd.AddCommand(disableCmd)
+
+	return uc
+}
+
+func (uc *undoCmd) runUndoCmd(cmd *cobra.Command, args []string) error {
+	if uc.steps < 1 {
+		return fmt.Errorf("--steps must be at least 1")
+	}
+
+	journalPath := undoJournalPath()
+
+	mutations, err := undo.Load(journalPath)
+	if err != nil {
+		return err
+	}
+
+	if len(mutations) == 0 && !Config.GetUndoEnabled() {
+		fmt.Println("The undo journal is disabled. Run `stripe undo enable` to record the mutations made with the CLI.")
+		return nil
+	}
+
+	if uc.list {
+		return printMutations(mutations)
+	}
+
+	reversible, skipped := undo.Select(mutations, uc.steps)
+
+	if len(skipped) > 0 {
+		fmt.Println("These mutations can't be reverted and are skipped:")
+		for _, i := range skipped {
+			fmt.Printf("  %s: %s\n", mutationSummary(mutations[i]), mutations[i].Irreversible)
+		}
+	}
+
+	if len(reversible) == 0 {
+		return fmt.Errorf("there are no mutations to revert")
+	}
+
+	fmt.Printf("These mutations will be reverted, most recent first:\n")
+	for _, i := range reversible {
+		fmt.Printf("  %s, with %s\n", mutationSummary(mutations[i]), mutations[i].Undo)
+	}
+
+	if !uc.autoConfirm {
+		fmt.Print("Enter 'yes' to confirm: ")
+
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "yes" {
+			fmt.Println("Exiting without reverting anything.")
+			return nil
+		}
+	}
+
+	for _, i := range reversible {
+		if err := uc.revert(cmd.Context(), mutations[i]); err != nil {
+			return fmt.Errorf("could not revert %s: %w", mutationSummary(mutations[i]), err)
+		}
+
+		fmt.Printf("Reverted %s.\n", mutationSummary(mutations[i]))
+
+		// Reverted mutations leave the journal, from the most recent so that
+		// the indices of the others don't change
+		mutations = append(mutations[:i], mutations[i+1:]...)
+		if err := undo.Save(journalPath, mutations); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *undoCmd) runEnableCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.SetUndoEnabled(true); err != nil {
+		return err
+	}
+
+	fmt.Println("The mutations made with the CLI are now recorded, run `stripe undo` to revert them.")
+
+	return nil
+}
+
+func (uc *undoCmd) runDisableCmd(cmd *cobra.Command, args []string) error {
+	if err := Config.SetUndoEnabled(false); err != nil {
+		return err
+	}
+
+	if err := undo.Clear(undoJournalPath()); err != nil {
+		return err
+	}
+
+	fmt.Println("The mutations made with the CLI are no longer recorded, and the undo journal was deleted.")
+
+	return nil
+}
+
+func undoJournalPath() string {
+	return filepath.Join(Config.GetConfigFolder(os.Getenv("XDG_CONFIG_HOME")), undo.JournalFileName)
+}
+
+func (uc *undoCmd) revert(ctx context.Context, m undo.Mutation) error {
+	apiKey, err := Config.Profile.GetAPIKey(m.Livemode)
+	if err != nil {
+		return err
+	}
+
+	baseURL, err := url.Parse(uc.apiBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := &stripe.Client{BaseURL: baseURL, APIKey: apiKey}
+
+	resp, err := client.PerformRequest(ctx, m.Undo.Method, m.Undo.Path, m.Undo.Params, func(req *http.Request) {
+		if m.StripeAccount != "" {
+			req.Header.Set("Stripe-Account", m.StripeAccount)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	var apiError struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if json.Unmarshal(body, &apiError) == nil && apiError.Error.Message != "" {
+		return fmt.Errorf("%s", apiError.Error.Message)
+	}
+
+	return fmt.Errorf("the API returned status %d", resp.StatusCode)
+}
+
+func printMutations(mutations []undo.Mutation) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tMUTATION\tREVERTED WITH")
+
+	for i := len(mutations) - 1; i >= 0; i-- {
+		m := mutations[i]
+
+		revert := ansi.Faint("irreversible: " + m.Irreversible)
+		if m.Undo != nil {
+			revert = m.Undo.String()
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", m.Time.Local().Format("2006-01-02 15:04:05"), mutationSummary(m), revert)
+	}
+
+	return w.Flush()
+}
+
+// mutationSummary describes a mutation by its command, and the object it
+// changed when the command doesn't tell
+func mutationSummary(m undo.Mutation) string {
+	summary := m.Command
+	if summary == "" {
+		summary = fmt.Sprintf("%s %s", m.Method, m.Path)
+	}
+
+	if m.ObjectID != "" && !strings.Contains(summary, m.ObjectID) {
+		summary += fmt.Sprintf(" (%s)", m.ObjectID)
+	}
+
+	if m.Livemode {
+		summary += " [live]"
+	}
+
+	return summary
+}
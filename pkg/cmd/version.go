@@ -1,29 +1,135 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"sort"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stripe/stripe-cli/pkg/experiments"
+	"github.com/stripe/stripe-cli/pkg/spec"
 	"github.com/stripe/stripe-cli/pkg/validators"
 	"github.com/stripe/stripe-cli/pkg/version"
 )
 
 type versionCmd struct {
 	cmd *cobra.Command
+
+	json bool
+}
+
+// versionInfo is the output of `stripe version --json`, which bug reports and
+// tools use to check the capabilities of the CLI.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+
+	// APIVersion is the version of the bundled OpenAPI specification
+	APIVersion string `json:"api_version"`
+
+	// Commands are the paths of the commands of the CLI, e.g. `logs tail`,
+	// without the ones generated for the API resources
+	Commands []string `json:"commands"`
+
+	// Experiments are the names of the enabled experiments
+	Experiments []string `json:"experiments"`
 }
 
 func newVersionCmd() *versionCmd {
-	return &versionCmd{
-		cmd: &cobra.Command{
-			Use:   "version",
-			Args:  validators.NoArgs,
-			Short: "Get the version of the Stripe CLI",
-			Run: func(cmd *cobra.Command, args []string) {
-				fmt.Print(version.Template)
-
-				version.CheckLatestVersion()
-			},
+	vc := &versionCmd{}
+
+	vc.cmd = &cobra.Command{
+		Use:   "version",
+		Args:  validators.NoArgs,
+		Short: "Get the version of the Stripe CLI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if vc.json {
+				return printVersionJSON(cmd)
+			}
+
+			fmt.Print(version.Template)
+
+			version.CheckLatestVersion(cmd.Context())
+
+			return nil
 		},
 	}
+
+	vc.cmd.Flags().BoolVar(&vc.json, "json", false, "Print the version, build and capabilities of the CLI as JSON")
+
+	return vc
+}
+
+func printVersionJSON(cmd *cobra.Command) error {
+	info, err := newVersionInfo(cmd.Root())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+
+	return nil
+}
+
+func newVersionInfo(root *cobra.Command) (*versionInfo, error) {
+	schemas, err := spec.LoadResourceSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	return &versionInfo{
+		Version:     version.Version,
+		Commit:      version.Commit,
+		Date:        version.Date,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		APIVersion:  schemas.Version,
+		Commands:    commandPaths(root),
+		Experiments: experiments.EnabledNames(),
+	}, nil
+}
+
+// commandPaths returns the sorted paths of the visible commands under root,
+// skipping the namespaces, resources and operations of the API.
+func commandPaths(root *cobra.Command) []string {
+	paths := make([]string, 0)
+
+	var walk func(cmd *cobra.Command, prefix string)
+	walk = func(cmd *cobra.Command, prefix string) {
+		for _, child := range cmd.Commands() {
+			if child.Hidden || child.Name() == "help" || isAPICommand(cmd, child) {
+				continue
+			}
+
+			path := prefix + child.Name()
+			paths = append(paths, path)
+			walk(child, path+" ")
+		}
+	}
+	walk(root, "")
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func isAPICommand(parent, cmd *cobra.Command) bool {
+	switch parent.Annotations[cmd.Name()] {
+	case "namespace", "resource", "operation":
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandPaths(t *testing.T) {
+	root := &cobra.Command{Use: "stripe", Annotations: map[string]string{"customers": "resource", "listen": "webhooks"}}
+	logs := &cobra.Command{Use: "logs"}
+	logs.AddCommand(&cobra.Command{Use: "tail", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(
+		&cobra.Command{Use: "listen", Run: func(*cobra.Command, []string) {}},
+		&cobra.Command{Use: "customers", Run: func(*cobra.Command, []string) {}},
+		&cobra.Command{Use: "daemon", Hidden: true, Run: func(*cobra.Command, []string) {}},
+		logs,
+	)
+
+	require.Equal(t, []string{"listen", "logs", "logs tail"}, commandPaths(root))
+}
+
+func TestNewVersionInfo(t *testing.T) {
+	info, err := newVersionInfo(rootCmd)
+	require.NoError(t, err)
+	require.NotEmpty(t, info.APIVersion)
+	require.Contains(t, info.Commands, "version")
+	require.NotContains(t, info.Commands, "customers")
+}
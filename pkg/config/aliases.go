@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/viper"
+)
+
+// AliasSection is the section of the config file defining the aliases,
+// e.g. `pi = "payment_intents"`
+const AliasSection = "alias"
+
+// GetAliases returns the aliases of the config file and their expansion
+func (c *Config) GetAliases() map[string]string {
+	return viper.GetStringMapString(AliasSection)
+}
+
+// SetAlias writes an alias to the config file
+func (c *Config) SetAlias(name, expansion string) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set(AliasSection+"."+name, expansion)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
+}
+
+// RemoveAlias removes an alias from the config file
+func (c *Config) RemoveAlias(name string) error {
+	if _, ok := c.GetAliases()[name]; !ok {
+		return fmt.Errorf("alias %s does not exist", name)
+	}
+
+	v, err := removeKey(viper.GetViper(), AliasSection+"."+name)
+	if err != nil {
+		return err
+	}
+
+	return syncConfig(v)
+}
+
+// ReadAliases reads the aliases of the config file at path. Aliases are
+// expanded before the flags are parsed and the config is initialized, so
+// they're read separately.
+func ReadAliases(path string) (map[string]string, error) {
+	var file struct {
+		Alias map[string]string `toml:"alias"`
+	}
+
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return file.Alias, nil
+}
+
+// ExpandAlias replaces the first argument with the expansion of the alias of
+// the same name, if any. Expansions are split on spaces, and single or
+// double quotes group words into one argument.
+func ExpandAlias(args []string, aliases map[string]string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, nil
+	}
+
+	expanded, err := splitArgs(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("alias %s: %w", args[0], err)
+	}
+
+	return append(expanded, args[1:]...), nil
+}
+
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in %q", s)
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
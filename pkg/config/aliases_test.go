@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAlias(t *testing.T) {
+	aliases := map[string]string{
+		"pi":      "payment_intents",
+		"tailerr": "logs tail --filter-status-class 5xx",
+		"desc":    `customers create --description "Test customer"`,
+		"broken":  `get "unterminated`,
+	}
+
+	args, err := ExpandAlias([]string{"pi", "list", "--limit", "3"}, aliases)
+	require.NoError(t, err)
+	require.Equal(t, []string{"payment_intents", "list", "--limit", "3"}, args)
+
+	args, err = ExpandAlias([]string{"tailerr"}, aliases)
+	require.NoError(t, err)
+	require.Equal(t, []string{"logs", "tail", "--filter-status-class", "5xx"}, args)
+
+	args, err = ExpandAlias([]string{"desc"}, aliases)
+	require.NoError(t, err)
+	require.Equal(t, []string{"customers", "create", "--description", "Test customer"}, args)
+
+	args, err = ExpandAlias([]string{"listen", "pi"}, aliases)
+	require.NoError(t, err)
+	require.Equal(t, []string{"listen", "pi"}, args)
+
+	_, err = ExpandAlias([]string{"broken"}, aliases)
+	require.EqualError(t, err, `alias broken: unterminated quote in "get \"unterminated"`)
+}
+
+func TestReadAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	aliases, err := ReadAliases(path)
+	require.NoError(t, err)
+	require.Empty(t, aliases)
+
+	content := "[alias]\n  pi = \"payment_intents\"\n\n[default]\n  device_name = \"st-testing\"\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	aliases, err = ReadAliases(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"pi": "payment_intents"}, aliases)
+}
+
+func TestAliasSectionIsNotAProfile(t *testing.T) {
+	require.False(t, isProfile(AliasSection, map[string]interface{}{"pi": "payment_intents"}))
+	require.True(t, isProfile("default", map[string]interface{}{"device_name": "st-testing"}))
+}
+
+func TestRendererSectionIsNotAProfile(t *testing.T) {
+	require.False(t, isProfile(RendererSection, map[string]interface{}{"customer": "acme-render"}))
+}
+
+func TestExperimentSectionIsNotAProfile(t *testing.T) {
+	require.False(t, isProfile(ExperimentSection, map[string]interface{}{"daemon": true}))
+}
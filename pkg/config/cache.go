@@ -0,0 +1,45 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// InvalidateCache forces the next read of a config value to read the
+// config file again, e.g. after it was modified by another process.
+// Writes made through this package invalidate the cache by themselves.
+func InvalidateCache() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.loaded = false
+}
+
+// cache memoizes reading the config file, which used to be read and parsed
+// again by every accessor. It's keyed on the config file path since it can be
+// changed with SetConfigFile.
+var cache struct {
+	mu     sync.Mutex
+	loaded bool
+	path   string
+	err    error
+}
+
+// readConfig reads the config file into viper unless it was already read,
+// and returns the error of the read.
+func readConfig() error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	path := viper.ConfigFileUsed()
+	if cache.loaded && cache.path == path {
+		return cache.err
+	}
+
+	cache.err = viper.ReadInConfig()
+	cache.path = path
+	cache.loaded = true
+
+	return cache.err
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfigIsCached(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(profilesFile, []byte("[default]\n  display_name = \"before\"\n"), 0600))
+
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	p := Profile{ProfileName: "default"}
+	require.Equal(t, "before", p.GetDisplayName())
+
+	// Changes made behind the CLI's back are only read after invalidation
+	require.NoError(t, ioutil.WriteFile(profilesFile, []byte("[default]\n  display_name = \"after\"\n"), 0600))
+	require.Equal(t, "before", p.GetDisplayName())
+
+	InvalidateCache()
+	require.Equal(t, "after", p.GetDisplayName())
+
+	// Changing the config file reads the new one
+	otherFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, ioutil.WriteFile(otherFile, []byte("[default]\n  display_name = \"other\"\n"), 0600))
+	viper.SetConfigFile(otherFile)
+	require.Equal(t, "other", p.GetDisplayName())
+
+	// Writes through the package invalidate the cache
+	require.NoError(t, p.WriteConfigField("display_name", "written"))
+	require.Equal(t, "written", p.GetDisplayName())
+}
+
+func BenchmarkGetAPIKey(b *testing.B) {
+	profilesFile := filepath.Join(b.TempDir(), "config.toml")
+	require.NoError(b, ioutil.WriteFile(profilesFile, []byte("[default]\n  test_mode_api_key = \"sk_test_1234567890\"\n"), 0600))
+
+	viper.SetConfigFile(profilesFile)
+	defer viper.Reset()
+
+	b.Setenv("STRIPE_API_KEY", "")
+	p := Profile{ProfileName: "default"}
+
+	for i := 0; i < b.N; i++ {
+		if _, err := p.GetAPIKey(false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,18 +7,22 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	exec "golang.org/x/sys/execabs"
 
 	"github.com/BurntSushi/toml"
+	"github.com/fsnotify/fsnotify"
 	"github.com/mitchellh/go-homedir"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+	"github.com/stripe/stripe-cli/pkg/logging"
 )
 
 // ColorOn represnets the on-state for colors
@@ -33,20 +37,37 @@ const ColorAuto = "auto"
 // Config handles all overall configuration for the CLI
 type Config struct {
 	Color        string
+	ConfigDir    string
+	LogFile      string
+	LogFormat    string
 	LogLevel     string
+	NoPager      bool
 	Profile      Profile
 	ProfilesFile string
+	Quiet        bool
+
+	// DebugComponents is a comma-separated list of the components logged at
+	// the debug level regardless of LogLevel, e.g. "listen,websocket"
+	DebugComponents string
 }
 
 // GetConfigFolder retrieves the folder where the profiles file is stored
-// It searches for the xdg environment path first and will secondarily
-// place it in the home directory
+// It uses the `--config-dir` flag or the STRIPE_CONFIG_DIR environment
+// variable when set, then searches for the xdg environment path and will
+// secondarily place it in the home directory
 func (c *Config) GetConfigFolder(xdgPath string) string {
+	if c.ConfigDir != "" {
+		return c.ConfigDir
+	}
+
+	if configDir := os.Getenv("STRIPE_CONFIG_DIR"); configDir != "" {
+		return configDir
+	}
+
 	configPath := xdgPath
 
-	log.WithFields(log.Fields{
-		"prefix": "config.Config.GetProfilesFolder",
-		"path":   configPath,
+	logging.WithPrefix(log.StandardLogger(), "config.Config.GetProfilesFolder").WithFields(log.Fields{
+		"path": configPath,
 	}).Debug("Using profiles file")
 
 	if configPath == "" {
@@ -88,10 +109,9 @@ func (c *Config) InitConfig() {
 	}
 
 	// If a profiles file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		log.WithFields(log.Fields{
-			"prefix": "config.Config.InitConfig",
-			"path":   viper.ConfigFileUsed(),
+	if err := readConfig(); err == nil {
+		logging.WithPrefix(log.StandardLogger(), "config.Config.InitConfig").WithFields(log.Fields{
+			"path": viper.ConfigFileUsed(),
 		}).Debug("Using profiles file")
 	}
 
@@ -122,7 +142,18 @@ func (c *Config) InitConfig() {
 		log.Fatalf("Unrecognized color value: %s. Expected one of on, off, auto.", c.Color)
 	}
 
-	log.SetFormatter(logFormatter)
+	ansi.DisablePager = c.NoPager || viper.GetBool("no_pager")
+	ansi.Quiet = c.Quiet
+	ansi.ASCIIOnly = viper.GetBool("ascii_only")
+
+	switch c.LogFormat {
+	case "", "text":
+		log.SetFormatter(logFormatter)
+	case "json":
+		log.SetFormatter(&log.JSONFormatter{})
+	default:
+		log.Fatalf("Unrecognized log format value: %s. Expected one of text, json.", c.LogFormat)
+	}
 
 	// Set log level
 	switch c.LogLevel {
@@ -139,6 +170,133 @@ func (c *Config) InitConfig() {
 	default:
 		log.Fatalf("Unrecognized log level value: %s. Expected one of debug, info, warn, error.", c.LogLevel)
 	}
+
+	if c.DebugComponents == "" {
+		c.DebugComponents = os.Getenv("STRIPE_DEBUG")
+	}
+
+	if c.DebugComponents != "" {
+		formatter, err := logging.NewComponentFormatter(log.StandardLogger().Formatter, log.GetLevel(), c.DebugComponents)
+		if err != nil {
+			log.Fatalf("%s", err)
+		}
+
+		log.SetFormatter(formatter)
+
+		if log.GetLevel() < log.DebugLevel {
+			log.SetLevel(log.DebugLevel)
+		}
+	}
+
+	cryptoPolicy, err := c.GetCryptoPolicy()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	cryptopolicy.Set(cryptoPolicy)
+}
+
+// WatchConfig reloads the config file whenever it changes on disk and calls
+// onChange after each reload. Long-running commands use it to pick up e.g.
+// the API key of a new `stripe login` instead of keeping stale values.
+func (c *Config) WatchConfig(onChange func()) {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		// viper reloaded the file itself, so the cache is up to date
+		logging.WithPrefix(log.StandardLogger(), "config.Config.WatchConfig").WithFields(log.Fields{
+			"path": e.Name,
+		}).Debug("Config file changed, reloaded it")
+
+		onChange()
+	})
+	viper.WatchConfig()
+}
+
+// GetCryptoPolicy returns the crypto policy set with the top-level
+// tls_min_version, tls_cipher_policy and approved_crypto_only keys of the
+// config file. STRIPE_APPROVED_CRYPTO_ONLY=true only allows the approved
+// algorithms too.
+func (c *Config) GetCryptoPolicy() (*cryptopolicy.Policy, error) {
+	approvedOnly := viper.GetBool("approved_crypto_only") || os.Getenv("STRIPE_APPROVED_CRYPTO_ONLY") == "true"
+
+	return cryptopolicy.New(viper.GetString("tls_min_version"), viper.GetString("tls_cipher_policy"), approvedOnly)
+}
+
+// GetTelemetryOptOut returns whether the user opted out of telemetry with
+// the top-level telemetry_opt_out key of the config file
+func (c *Config) GetTelemetryOptOut() bool {
+	return viper.GetBool("telemetry_opt_out")
+}
+
+// SetTelemetryOptOut writes the top-level telemetry_opt_out key of the
+// config file
+func (c *Config) SetTelemetryOptOut(optOut bool) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set("telemetry_opt_out", optOut)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
+}
+
+// GetHistoryEnabled returns whether the user opted in to the command history
+// with the top-level record_history key of the config file
+func (c *Config) GetHistoryEnabled() bool {
+	return viper.GetBool("record_history")
+}
+
+// SetHistoryEnabled writes the top-level record_history key of the config
+// file
+func (c *Config) SetHistoryEnabled(enabled bool) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set("record_history", enabled)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
+}
+
+// GetUndoEnabled returns whether the user opted in to the undo journal with
+// the top-level undo_journal key of the config file
+func (c *Config) GetUndoEnabled() bool {
+	return viper.GetBool("undo_journal")
+}
+
+// SetUndoEnabled writes the top-level undo_journal key of the config file
+func (c *Config) SetUndoEnabled(enabled bool) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set("undo_journal", enabled)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
+}
+
+// GetActiveProfile returns the profile used when --project-name isn't set,
+// from the top-level project_name key of the config file
+func (c *Config) GetActiveProfile() string {
+	if name := viper.GetString("project_name"); name != "" {
+		return name
+	}
+
+	return "default"
+}
+
+// SetActiveProfile writes the top-level project_name key of the config file
+func (c *Config) SetActiveProfile(name string) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set("project_name", name)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
 }
 
 // EditConfig opens the configuration file in the default editor.
@@ -202,7 +360,7 @@ func (c *Config) RemoveProfile(profileName string) error {
 	var err error
 
 	for field, value := range runtimeViper.AllSettings() {
-		if isProfile(value) && field == profileName {
+		if isProfile(field, value) && field == profileName {
 			runtimeViper, err = removeKey(runtimeViper, field)
 			if err != nil {
 				return err
@@ -219,7 +377,7 @@ func (c *Config) RemoveAllProfiles() error {
 	var err error
 
 	for field, value := range runtimeViper.AllSettings() {
-		if isProfile(value) {
+		if isProfile(field, value) {
 			runtimeViper, err = removeKey(runtimeViper, field)
 			if err != nil {
 				return err
@@ -230,8 +388,27 @@ func (c *Config) RemoveAllProfiles() error {
 	return syncConfig(runtimeViper)
 }
 
+// ListProfiles returns the names of the profiles of the config file
+func (c *Config) ListProfiles() []string {
+	var names []string
+
+	for field, value := range viper.AllSettings() {
+		if isProfile(field, value) {
+			names = append(names, field)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 // isProfile identifies whether a value in the config pertains to a profile.
-func isProfile(value interface{}) bool {
+func isProfile(field string, value interface{}) bool {
+	if field == AliasSection || field == RendererSection || field == ExperimentSection {
+		return false
+	}
+
 	// TODO: ianjabour - ideally find a better way to identify projects in config
 	_, ok := value.(map[string]interface{})
 	return ok
@@ -239,6 +416,8 @@ func isProfile(value interface{}) bool {
 
 // syncConfig merges a runtimeViper instance with the config file being used.
 func syncConfig(runtimeViper *viper.Viper) error {
+	defer InvalidateCache()
+
 	runtimeViper.MergeInConfig()
 	profilesFile := viper.ConfigFileUsed()
 	runtimeViper.SetConfigFile(profilesFile)
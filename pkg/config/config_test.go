@@ -1,6 +1,8 @@
 package config
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -18,3 +20,27 @@ func TestRemoveKey(t *testing.T) {
 	require.EqualValues(t, []string{"stay"}, nv.AllKeys())
 	require.ElementsMatch(t, []string{"stay", "remove"}, v.AllKeys())
 }
+
+func TestActiveProfile(t *testing.T) {
+	defer viper.Reset()
+
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	err := ioutil.WriteFile(profilesFile, []byte("[sandbox]\n  sandbox = \"true\"\n"), 0600)
+	require.NoError(t, err)
+
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+
+	require.Equal(t, "default", c.GetActiveProfile())
+	require.True(t, (&Profile{ProfileName: "sandbox"}).IsSandbox())
+	require.False(t, (&Profile{ProfileName: "default"}).IsSandbox())
+
+	require.NoError(t, c.SetActiveProfile("sandbox"))
+	require.Equal(t, "sandbox", c.GetActiveProfile())
+	require.Contains(t, string(helperLoadBytes(t, profilesFile)), `project_name = "sandbox"`)
+	require.Equal(t, []string{"sandbox"}, c.ListProfiles())
+}
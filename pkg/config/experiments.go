@@ -0,0 +1,32 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+)
+
+// ExperimentSection is the section of the config file enabling or disabling
+// the experiments, e.g. `daemon = true`
+const ExperimentSection = "experiments"
+
+// GetExperiment returns whether the config file enables an experiment. ok is
+// false when the config file doesn't set it.
+func GetExperiment(name string) (enabled bool, ok bool) {
+	key := ExperimentSection + "." + name
+	if !viper.IsSet(key) {
+		return false, false
+	}
+
+	return viper.GetBool(key), true
+}
+
+// SetExperiment enables or disables an experiment in the config file
+func (c *Config) SetExperiment(name string, enabled bool) error {
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
+	viper.Set(ExperimentSection+"."+name, enabled)
+	defer InvalidateCache()
+
+	return viper.WriteConfig()
+}
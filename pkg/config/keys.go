@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/stripe/stripe-cli/pkg/validators"
+)
+
+// Key slots of a profile. Each mode has its own slots.
+const (
+	// KeySlotSecret holds a secret key (sk_), used by the operations that
+	// restricted keys can't run
+	KeySlotSecret = "secret"
+	// KeySlotRestricted holds a restricted key (rk_)
+	KeySlotRestricted = "restricted"
+	// KeySlotPublishable holds a publishable key (pk_), used client-side,
+	// e.g. by the samples
+	KeySlotPublishable = "publishable"
+)
+
+// KeySlots lists the key slots of a profile
+var KeySlots = []string{KeySlotSecret, KeySlotRestricted, KeySlotPublishable}
+
+var keySlotPrefixes = map[string]string{
+	KeySlotSecret:      "sk",
+	KeySlotRestricted:  "rk",
+	KeySlotPublishable: "pk",
+}
+
+// ValidateKeyForSlot returns an error if key can't be stored in slot, and
+// whether it's a livemode key
+func ValidateKeyForSlot(slot, key string) (bool, error) {
+	prefix, ok := keySlotPrefixes[slot]
+	if !ok {
+		return false, fmt.Errorf("unknown key slot %q, expected one of %s", slot, strings.Join(KeySlots, ", "))
+	}
+
+	parts := strings.Split(key, "_")
+	if len(key) < 12 || len(parts) < 3 || (parts[1] != "test" && parts[1] != "live") {
+		return false, fmt.Errorf("the key provided isn't a valid API key")
+	}
+
+	if parts[0] != prefix {
+		return false, fmt.Errorf("the %s slot only holds %s_ keys, the key provided is a %s_ key", slot, prefix, parts[0])
+	}
+
+	return parts[1] == "live", nil
+}
+
+// SetKey validates key and writes it to slot, in the mode of the key
+func (p *Profile) SetKey(slot, key string) error {
+	key = strings.TrimSpace(key)
+
+	livemode, err := ValidateKeyForSlot(slot, key)
+	if err != nil {
+		return err
+	}
+
+	return p.WriteConfigField(keySlotField(slot, livemode), key)
+}
+
+// UnsetKey removes the key of slot
+func (p *Profile) UnsetKey(slot string, livemode bool) error {
+	if _, ok := keySlotPrefixes[slot]; !ok {
+		return fmt.Errorf("unknown key slot %q, expected one of %s", slot, strings.Join(KeySlots, ", "))
+	}
+
+	return p.DeleteConfigField(keySlotField(slot, livemode))
+}
+
+// GetKey returns the key of slot from the config file, or an empty string if
+// the slot is empty
+func (p *Profile) GetKey(slot string, livemode bool) string {
+	if err := readConfig(); err != nil {
+		return ""
+	}
+
+	if slot == KeySlotPublishable && !livemode && viper.IsSet(p.GetConfigField("publishable_key")) {
+		p.RegisterAlias("test_mode_publishable_key", "publishable_key")
+	}
+
+	return viper.GetString(p.GetConfigField(keySlotField(slot, livemode)))
+}
+
+// GetSecretKey returns the key for the operations that restricted keys can't
+// run: the key of the secret slot, unless the key is set with the --api-key
+// flag or the environment, then the API key if it's a secret key.
+func (p *Profile) GetSecretKey(livemode bool) (string, error) {
+	if !p.apiKeyOverridden() {
+		if key := p.GetKey(KeySlotSecret, livemode); key != "" {
+			return key, nil
+		}
+	}
+
+	apiKey, err := p.GetAPIKey(livemode)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validators.APIKeyNotRestricted(apiKey); err != nil {
+		return "", err
+	}
+
+	return apiKey, nil
+}
+
+// GetLoginKey returns the API key of the config file if it was created by
+// stripe login, which records when the keys it creates expire
+func (p *Profile) GetLoginKey(livemode bool) (string, bool) {
+	if err := readConfig(); err != nil {
+		return "", false
+	}
+
+	key := viper.GetString(p.GetConfigField(livemodeKeyField(livemode)))
+	if key == "" || !viper.IsSet(p.GetConfigField(livemodeKeyExpiresAtField(livemode))) {
+		return "", false
+	}
+
+	return key, true
+}
+
+// validateConfigField returns an error if value can't be stored in the field
+// of the profile, e.g. a key of the other mode, or a field of the login
+// session
+func validateConfigField(field, value string) error {
+	if isSessionField(field) {
+		return fmt.Errorf("%s is set by stripe login --session and can't be changed", field)
+	}
+
+	for _, livemode := range []bool{false, true} {
+		for _, slot := range KeySlots {
+			if field != keySlotField(slot, livemode) {
+				continue
+			}
+
+			keyLivemode, err := ValidateKeyForSlot(slot, value)
+			if err != nil {
+				return err
+			}
+
+			if keyLivemode != livemode {
+				return fmt.Errorf("%s can't hold a key of the other mode", field)
+			}
+		}
+
+		if field == livemodeKeyField(livemode) {
+			return validators.APIKey(value)
+		}
+	}
+
+	return nil
+}
+
+func keySlotField(slot string, livemode bool) string {
+	mode := "test_mode"
+	if livemode {
+		mode = "live_mode"
+	}
+
+	return fmt.Sprintf("%s_%s_key", mode, slot)
+}
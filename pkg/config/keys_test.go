@@ -0,0 +1,81 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKeyForSlot(t *testing.T) {
+	livemode, err := ValidateKeyForSlot(KeySlotSecret, "sk_live_1234567890")
+	require.NoError(t, err)
+	require.True(t, livemode)
+
+	livemode, err = ValidateKeyForSlot(KeySlotPublishable, "pk_test_1234567890")
+	require.NoError(t, err)
+	require.False(t, livemode)
+
+	_, err = ValidateKeyForSlot(KeySlotRestricted, "sk_test_1234567890")
+	require.EqualError(t, err, "the restricted slot only holds rk_ keys, the key provided is a sk_ key")
+
+	_, err = ValidateKeyForSlot(KeySlotSecret, "sk_1234567890")
+	require.EqualError(t, err, "the key provided isn't a valid API key")
+
+	_, err = ValidateKeyForSlot("webhook", "whsec_test_1234567890")
+	require.Error(t, err)
+}
+
+func TestValidateConfigField(t *testing.T) {
+	require.NoError(t, validateConfigField("color", "off"))
+	require.NoError(t, validateConfigField("live_mode_secret_key", "sk_live_1234567890"))
+	require.NoError(t, validateConfigField("test_mode_api_key", "rk_test_1234567890"))
+
+	require.EqualError(t, validateConfigField("test_mode_secret_key", "sk_live_1234567890"), "test_mode_secret_key can't hold a key of the other mode")
+	require.Error(t, validateConfigField("test_mode_publishable_key", "sk_test_1234567890"))
+	require.Error(t, validateConfigField("test_mode_api_key", "pk_test_1234567890"))
+}
+
+func TestKeySlots(t *testing.T) {
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      Profile{ProfileName: "tests"},
+		ProfilesFile: filepath.Join(t.TempDir(), "config.toml"),
+	}
+	c.InitConfig()
+
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_API_KEY_FILE", "")
+
+	p := &c.Profile
+
+	require.NoError(t, p.SetKey(KeySlotRestricted, "rk_test_1234567890"))
+	require.NoError(t, p.SetKey(KeySlotSecret, "sk_test_1234567890"))
+	require.NoError(t, p.SetKey(KeySlotPublishable, "pk_test_1234567890"))
+
+	require.Equal(t, "pk_test_1234567890", p.GetPublishableKey())
+	require.Equal(t, "", p.GetKey(KeySlotSecret, true))
+
+	// Profiles without a login key use the restricted key for requests, and
+	// the secret key for the operations that need it
+	key, err := p.GetAPIKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "rk_test_1234567890", key)
+
+	key, err = p.GetSecretKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890", key)
+
+	// Keys set with --api-key take precedence
+	p.APIKey = "rk_test_0987654321"
+	_, err = p.GetSecretKey(false)
+	require.Error(t, err)
+	p.APIKey = ""
+
+	require.NoError(t, p.UnsetKey(KeySlotRestricted, false))
+
+	key, err = p.GetAPIKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890", key)
+}
@@ -1,16 +1,24 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 
+	"github.com/stripe/stripe-cli/pkg/clierrors"
 	"github.com/stripe/stripe-cli/pkg/validators"
 )
 
+// ErrSessionExpired is returned for the commands blocked by an expired login
+// session
+var ErrSessionExpired = errors.New("your login session has expired, run `stripe login` to authenticate again")
+
 // Profile handles all things related to managing the project specific configurations
 type Profile struct {
 	DeviceName             string
@@ -23,6 +31,13 @@ type Profile struct {
 	TerminalPOSDeviceID    string
 	DisplayName            string
 	AccountID              string
+	KeysExpireAt           time.Time
+
+	// SessionExpiresAt is when the login session expires, if it's time-boxed
+	SessionExpiresAt time.Time
+	// SessionBlockReads blocks all the commands after the session expires,
+	// instead of only the ones changing objects
+	SessionBlockReads bool
 }
 
 // CreateProfile creates a profile when logging in
@@ -66,20 +81,30 @@ func (p *Profile) GetDeviceName() (string, error) {
 		return p.DeviceName, nil
 	}
 
-	if err := viper.ReadInConfig(); err == nil {
+	if err := readConfig(); err == nil {
 		return viper.GetString(p.GetConfigField("device_name")), nil
 	}
 
 	return "", validators.ErrDeviceNameNotConfigured
 }
 
+// GetForwardURL returns the URL `stripe listen` forwards events to when
+// --forward-to isn't set, if any
+func (p *Profile) GetForwardURL() string {
+	if err := readConfig(); err == nil {
+		return viper.GetString(p.GetConfigField("forward_url"))
+	}
+
+	return ""
+}
+
 // GetAccountID returns the accountId for the given profile.
 func (p *Profile) GetAccountID() (string, error) {
 	if p.AccountID != "" {
 		return p.AccountID, nil
 	}
 
-	if err := viper.ReadInConfig(); err == nil {
+	if err := readConfig(); err == nil {
 		return viper.GetString(p.GetConfigField("account_id")), nil
 	}
 
@@ -98,6 +123,27 @@ func (p *Profile) GetAPIKey(livemode bool) (string, error) {
 		return envKey, nil
 	}
 
+	// STRIPE_API_KEY_FILE points to a file holding the key, such as a Docker
+	// or Kubernetes secret mount
+	if keyFile := os.Getenv("STRIPE_API_KEY_FILE"); keyFile != "" {
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return "", &clierrors.ConfigError{
+				Err:  fmt.Errorf("unable to read STRIPE_API_KEY_FILE: %w", err),
+				Hint: "Check that STRIPE_API_KEY_FILE is the path of a readable file holding the API key.",
+			}
+		}
+
+		fileKey := strings.TrimSpace(string(content))
+
+		err = validators.APIKey(fileKey)
+		if err != nil {
+			return "", err
+		}
+
+		return fileKey, nil
+	}
+
 	if p.APIKey != "" {
 		err := validators.APIKey(p.APIKey)
 		if err != nil {
@@ -118,8 +164,8 @@ func (p *Profile) GetAPIKey(livemode bool) (string, error) {
 	}
 
 	// Try to fetch the API key from the configuration file
-	if err := viper.ReadInConfig(); err == nil {
-		key := viper.GetString(p.GetConfigField(livemodeKeyField(livemode)))
+	if err := readConfig(); err == nil {
+		key := viper.GetString(p.GetConfigField(p.configKeyField(livemode)))
 
 		err := validators.APIKey(key)
 		if err != nil {
@@ -132,31 +178,158 @@ func (p *Profile) GetAPIKey(livemode bool) (string, error) {
 	return "", validators.ErrAPIKeyNotConfigured
 }
 
-// GetPublishableKey returns the publishable key for the user
-func (p *Profile) GetPublishableKey() string {
-	if err := viper.ReadInConfig(); err == nil {
-		if viper.IsSet(p.GetConfigField("publishable_key")) {
-			p.RegisterAlias("test_mode_publishable_key", "publishable_key")
+// GetAPIKeySource describes where GetAPIKey reads the key from, e.g. to
+// explain which account the CLI uses
+func (p *Profile) GetAPIKeySource(livemode bool) string {
+	switch {
+	case os.Getenv("STRIPE_API_KEY") != "":
+		return "STRIPE_API_KEY environment variable"
+	case os.Getenv("STRIPE_API_KEY_FILE") != "":
+		return fmt.Sprintf("STRIPE_API_KEY_FILE environment variable (%s)", os.Getenv("STRIPE_API_KEY_FILE"))
+	case p.APIKey != "":
+		return "--api-key flag"
+	default:
+		readConfig()
+
+		return fmt.Sprintf("%s in the [%s] profile of %s", p.configKeyField(livemode), p.ProfileName, viper.ConfigFileUsed())
+	}
+}
+
+// GetKeyExpiresAt returns when the API key returned by GetAPIKey expires, if
+// known. Only the keys created by `stripe login` have a known expiry.
+func (p *Profile) GetKeyExpiresAt(livemode bool) (time.Time, bool) {
+	if p.apiKeyOverridden() {
+		return time.Time{}, false
+	}
+
+	if err := readConfig(); err != nil {
+		return time.Time{}, false
+	}
+
+	expiresAt := viper.GetInt64(p.GetConfigField(livemodeKeyExpiresAtField(livemode)))
+	if expiresAt == 0 || p.configKeyField(livemode) != livemodeKeyField(livemode) {
+		return time.Time{}, false
+	}
+
+	return time.Unix(expiresAt, 0), true
+}
+
+// GetSessionExpiresAt returns when the login session of the profile
+// expires, if it's time-boxed
+func (p *Profile) GetSessionExpiresAt() (time.Time, bool) {
+	if err := readConfig(); err != nil {
+		return time.Time{}, false
+	}
+
+	expiresAt := viper.GetInt64(p.GetConfigField("session_expires_at"))
+	if expiresAt == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(expiresAt, 0), true
+}
+
+// CheckSession returns ErrSessionExpired if the login session of the profile
+// expired and blocks the command. Expired sessions block the commands
+// changing objects, and all the commands if they were created with
+// SessionBlockReads. Keys set with the --api-key flag or the environment
+// aren't part of the session.
+func (p *Profile) CheckSession(mutating bool) error {
+	if p.apiKeyOverridden() {
+		return nil
+	}
+
+	expiresAt, ok := p.GetSessionExpiresAt()
+	if !ok || time.Now().Before(expiresAt) {
+		return nil
+	}
+
+	if mutating || viper.GetBool(p.GetConfigField("session_block_reads")) {
+		return ErrSessionExpired
+	}
+
+	return nil
+}
+
+// EndExpiredSession deletes the keys of the profile from the config file if
+// its login session expired, so that they can't be used or copied anymore.
+// The expiry of the session is kept, for CheckSession to keep telling users
+// to log in again. It returns whether keys were deleted.
+func (p *Profile) EndExpiredSession() (bool, error) {
+	expiresAt, ok := p.GetSessionExpiresAt()
+	if !ok || time.Now().Before(expiresAt) {
+		return false, nil
+	}
+
+	fields := []string{"api_key", "secret_key", "publishable_key"}
+	for _, livemode := range []bool{false, true} {
+		fields = append(fields, livemodeKeyField(livemode), livemodeKeyExpiresAtField(livemode))
+		for _, slot := range KeySlots {
+			fields = append(fields, keySlotField(slot, livemode))
+		}
+	}
+
+	v := viper.GetViper()
+	deleted := false
+
+	for _, field := range fields {
+		if !v.IsSet(p.GetConfigField(field)) {
+			continue
 		}
 
-		return viper.GetString(p.GetConfigField("test_mode_publishable_key"))
+		// Clear the value set in this process, if any, which removeKey can't
+		viper.Set(p.GetConfigField(field), "")
+
+		var err error
+		if v, err = removeKey(v, p.GetConfigField(field)); err != nil {
+			return false, err
+		}
+		deleted = true
 	}
 
-	return ""
+	if !deleted {
+		return false, nil
+	}
+
+	// Write with the name only, not the keys p may still hold
+	session := Profile{ProfileName: p.ProfileName}
+
+	return true, session.writeProfile(v)
+}
+
+// isSessionField returns whether field belongs to the login session, which
+// only stripe login --session sets
+func isSessionField(field string) bool {
+	return field == "session_expires_at" || field == "session_block_reads"
+}
+
+// GetPublishableKey returns the test mode publishable key for the user
+func (p *Profile) GetPublishableKey() string {
+	return p.GetKey(KeySlotPublishable, false)
 }
 
 // GetDisplayName returns the account display name of the user
 func (p *Profile) GetDisplayName() string {
-	if err := viper.ReadInConfig(); err == nil {
+	if err := readConfig(); err == nil {
 		return viper.GetString(p.GetConfigField("display_name"))
 	}
 
 	return ""
 }
 
+// IsSandbox returns whether the profile was created by `stripe sandboxes
+// create`
+func (p *Profile) IsSandbox() bool {
+	if err := readConfig(); err == nil {
+		return viper.GetBool(p.GetConfigField("sandbox"))
+	}
+
+	return false
+}
+
 // GetTerminalPOSDeviceID returns the device id from the config for Terminal quickstart to use
 func (p *Profile) GetTerminalPOSDeviceID() string {
-	if err := viper.ReadInConfig(); err == nil {
+	if err := readConfig(); err == nil {
 		return viper.GetString(p.GetConfigField("terminal_pos_device_id"))
 	}
 
@@ -176,12 +349,29 @@ func (p *Profile) RegisterAlias(alias, key string) {
 // WriteConfigField updates a configuration field and writes the updated
 // configuration to disk.
 func (p *Profile) WriteConfigField(field, value string) error {
+	if err := validateConfigField(field, value); err != nil {
+		return err
+	}
+
+	if err := makePath(viper.ConfigFileUsed()); err != nil {
+		return err
+	}
+
 	viper.Set(p.GetConfigField(field), value)
+	defer InvalidateCache()
+
 	return viper.WriteConfig()
 }
 
 // DeleteConfigField deletes a configuration field.
 func (p *Profile) DeleteConfigField(field string) error {
+	if isSessionField(field) {
+		return fmt.Errorf("%s is set by stripe login --session and can't be changed", field)
+	}
+
+	// Clear the value set in this process, if any, which removeKey can't
+	viper.Set(p.GetConfigField(field), "")
+
 	v, err := removeKey(viper.GetViper(), p.GetConfigField(field))
 	if err != nil {
 		return err
@@ -192,6 +382,7 @@ func (p *Profile) DeleteConfigField(field string) error {
 
 func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 	profilesFile := viper.ConfigFileUsed()
+	defer InvalidateCache()
 
 	err := makePath(profilesFile)
 	if err != nil {
@@ -218,6 +409,21 @@ func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 		runtimeViper.Set(p.GetConfigField("test_mode_publishable_key"), strings.TrimSpace(p.TestModePublishableKey))
 	}
 
+	if !p.SessionExpiresAt.IsZero() {
+		runtimeViper.Set(p.GetConfigField("session_expires_at"), p.SessionExpiresAt.Unix())
+		runtimeViper.Set(p.GetConfigField("session_block_reads"), p.SessionBlockReads)
+	}
+
+	if !p.KeysExpireAt.IsZero() {
+		if p.TestModeAPIKey != "" {
+			runtimeViper.Set(p.GetConfigField(livemodeKeyExpiresAtField(false)), p.KeysExpireAt.Unix())
+		}
+
+		if p.LiveModeAPIKey != "" {
+			runtimeViper.Set(p.GetConfigField(livemodeKeyExpiresAtField(true)), p.KeysExpireAt.Unix())
+		}
+	}
+
 	if p.DisplayName != "" {
 		runtimeViper.Set(p.GetConfigField("display_name"), strings.TrimSpace(p.DisplayName))
 	}
@@ -238,6 +444,23 @@ func (p *Profile) writeProfile(runtimeViper *viper.Viper) error {
 		runtimeViper = p.safeRemove(runtimeViper, "publishable_key")
 	}
 
+	// Logging in without a session ends the previous one
+	if p.SessionExpiresAt.IsZero() && (p.TestModeAPIKey != "" || p.LiveModeAPIKey != "") {
+		runtimeViper = p.safeRemove(runtimeViper, "session_expires_at")
+		runtimeViper = p.safeRemove(runtimeViper, "session_block_reads")
+	}
+
+	// The expiry of previous keys doesn't apply to keys set without one
+	if p.KeysExpireAt.IsZero() {
+		if p.TestModeAPIKey != "" {
+			runtimeViper = p.safeRemove(runtimeViper, livemodeKeyExpiresAtField(false))
+		}
+
+		if p.LiveModeAPIKey != "" {
+			runtimeViper = p.safeRemove(runtimeViper, livemodeKeyExpiresAtField(true))
+		}
+	}
+
 	runtimeViper.SetConfigFile(profilesFile)
 
 	// Ensure we preserve the config file type
@@ -271,3 +494,25 @@ func livemodeKeyField(livemode bool) string {
 
 	return "test_mode_api_key"
 }
+
+// apiKeyOverridden returns whether GetAPIKey returns a key set with the
+// --api-key flag or the environment rather than the one of the config file
+func (p *Profile) apiKeyOverridden() bool {
+	return os.Getenv("STRIPE_API_KEY") != "" || os.Getenv("STRIPE_API_KEY_FILE") != "" || p.APIKey != ""
+}
+
+// configKeyField returns the field of the config file holding the API key:
+// the one set by stripe login, or else the restricted or secret key slot
+func (p *Profile) configKeyField(livemode bool) string {
+	for _, field := range []string{livemodeKeyField(livemode), keySlotField(KeySlotRestricted, livemode), keySlotField(KeySlotSecret, livemode)} {
+		if viper.GetString(p.GetConfigField(field)) != "" {
+			return field
+		}
+	}
+
+	return livemodeKeyField(livemode)
+}
+
+func livemodeKeyExpiresAtField(livemode bool) string {
+	return livemodeKeyField(livemode) + "_expires_at"
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -107,3 +108,154 @@ func helperLoadBytes(t *testing.T, name string) []byte {
 func cleanUp(file string) {
 	os.Remove(file)
 }
+
+func TestGetAPIKeyFromFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "stripe_api_key")
+	err := ioutil.WriteFile(keyFile, []byte("sk_test_1234567890\n"), 0600)
+	require.NoError(t, err)
+
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_API_KEY_FILE", keyFile)
+
+	p := Profile{APIKey: "sk_test_flag_ignored"}
+
+	key, err := p.GetAPIKey(false)
+	require.NoError(t, err)
+	require.Equal(t, "sk_test_1234567890", key)
+
+	t.Setenv("STRIPE_API_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	_, err = p.GetAPIKey(false)
+	require.Error(t, err)
+}
+
+func TestKeyExpiresAt(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	expiresAt := time.Unix(1700000000, 0)
+	p := Profile{
+		ProfileName:    "tests",
+		TestModeAPIKey: "sk_test_123",
+		KeysExpireAt:   expiresAt,
+	}
+
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      p,
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_API_KEY_FILE", "")
+
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	got, ok := p.GetKeyExpiresAt(false)
+	require.True(t, ok)
+	require.Equal(t, expiresAt, got)
+
+	_, ok = p.GetKeyExpiresAt(true)
+	require.False(t, ok)
+
+	// The expiry of the config file doesn't apply to other keys
+	t.Setenv("STRIPE_API_KEY", "sk_test_from_env")
+	_, ok = p.GetKeyExpiresAt(false)
+	require.False(t, ok)
+	require.Equal(t, "STRIPE_API_KEY environment variable", p.GetAPIKeySource(false))
+	t.Setenv("STRIPE_API_KEY", "")
+
+	// Keys set without an expiry remove the previous one
+	p.KeysExpireAt = time.Time{}
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	_, ok = p.GetKeyExpiresAt(false)
+	require.False(t, ok)
+}
+
+func TestCheckSession(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	p := Profile{
+		ProfileName:      "tests",
+		TestModeAPIKey:   "sk_test_123",
+		SessionExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      p,
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_API_KEY_FILE", "")
+
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.NoError(t, p.CheckSession(true))
+
+	p.SessionExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.Equal(t, ErrSessionExpired, p.CheckSession(true))
+	require.NoError(t, p.CheckSession(false))
+
+	p.SessionBlockReads = true
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.Equal(t, ErrSessionExpired, p.CheckSession(false))
+
+	// Keys set with --api-key aren't part of the session
+	p.APIKey = "sk_test_456"
+	require.NoError(t, p.CheckSession(true))
+	p.APIKey = ""
+
+	// Logging in without a session ends the previous one
+	p.SessionExpiresAt = time.Time{}
+	require.NoError(t, p.writeProfile(viper.New()))
+	require.NoError(t, p.CheckSession(true))
+}
+
+func TestEndExpiredSession(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "config.toml")
+	p := Profile{
+		ProfileName:            "tests",
+		TestModeAPIKey:         "sk_test_123",
+		TestModePublishableKey: "pk_test_123",
+		SessionExpiresAt:       time.Now().Add(time.Hour),
+	}
+
+	c := &Config{
+		Color:        "auto",
+		LogLevel:     "info",
+		Profile:      p,
+		ProfilesFile: profilesFile,
+	}
+	c.InitConfig()
+
+	t.Setenv("STRIPE_API_KEY", "")
+	t.Setenv("STRIPE_API_KEY_FILE", "")
+
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	deleted, err := p.EndExpiredSession()
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	p.SessionExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, p.writeProfile(viper.New()))
+
+	deleted, err = p.EndExpiredSession()
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	content, err := ioutil.ReadFile(profilesFile)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "sk_test_123")
+	require.NotContains(t, string(content), "pk_test_123")
+	require.Equal(t, ErrSessionExpired, p.CheckSession(true))
+
+	// The session can only be changed by logging in again
+	require.Error(t, p.WriteConfigField("session_expires_at", "4102444800"))
+	require.Error(t, p.DeleteConfigField("session_expires_at"))
+	require.Equal(t, ErrSessionExpired, p.CheckSession(true))
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// RendererSection is the section of the config file registering the external
+// commands that render the objects of a type instead of the JSON output, e.g.
+// `customer = "acme-render --metadata"`
+const RendererSection = "renderers"
+
+// GetRenderer returns the arguments of the command rendering the objects of
+// objectType, or nil when there is none.
+func GetRenderer(objectType string) ([]string, error) {
+	command := viper.GetStringMapString(RendererSection)[objectType]
+	if command == "" {
+		return nil, nil
+	}
+
+	args, err := splitArgs(command)
+	if err != nil {
+		return nil, fmt.Errorf("renderer of %s: %w", objectType, err)
+	}
+
+	return args, nil
+}
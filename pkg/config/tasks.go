@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectConfigFileName is the name of the project-local config file, looked
+// up in the working directory and its parents
+const ProjectConfigFileName = ".stripe.toml"
+
+// FindProjectConfig returns the path of the project-local config file of
+// dir, in dir or the closest of its parents
+func FindProjectConfig(dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// ReadTasks reads the tasks of the `[tasks]` section of the project-local
+// config file at path, e.g. `seed = "fixtures seed.json"`
+func ReadTasks(path string) (map[string]string, error) {
+	var file struct {
+		Tasks map[string]string `toml:"tasks"`
+	}
+
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return file.Tasks, nil
+}
+
+// TaskArgs splits the command of a task into the arguments of the CLI, the
+// same way as aliases. The leading `stripe` is optional.
+func TaskArgs(command string) ([]string, error) {
+	args, err := splitArgs(command)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) > 0 && args[0] == "stripe" {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	return args, nil
+}
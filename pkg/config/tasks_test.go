@@ -0,0 +1,45 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindProjectConfig(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "app", "server")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	_, ok := FindProjectConfig(nested)
+	require.False(t, ok)
+
+	path := filepath.Join(root, ProjectConfigFileName)
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[tasks]
+seed = "fixtures seed.json"
+dev = "stripe listen --forward-to localhost:4242/webhook"
+`), 0644))
+
+	found, ok := FindProjectConfig(nested)
+	require.True(t, ok)
+	require.Equal(t, path, found)
+
+	tasks, err := ReadTasks(found)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"seed": "fixtures seed.json",
+		"dev":  "stripe listen --forward-to localhost:4242/webhook",
+	}, tasks)
+}
+
+func TestTaskArgs(t *testing.T) {
+	args, err := TaskArgs(`stripe trigger customer.created --add "customer:name=Jenny Rosen"`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"trigger", "customer.created", "--add", "customer:name=Jenny Rosen"}, args)
+
+	_, err = TaskArgs("stripe")
+	require.Error(t, err)
+}
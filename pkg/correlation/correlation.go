@@ -0,0 +1,335 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IndexFileName is the name of the correlation index file in the config folder
+const IndexFileName = "request_index.jsonl"
+
+// maxIndexSize is the size past which the index is compacted down to its
+// most recent half
+const maxIndexSize = 256 * 1024
+
+// activeInterval is how often the commands looking up requests mark the
+// index as active. Requests are only recorded while it's active, i.e. while
+// a `stripe listen` or `stripe tail` is running.
+const activeInterval = 30 * time.Second
+
+// lockTimeout is how long Record waits for another process to finish
+// writing the index, and staleLockAge the age past which the lock of a
+// process that died is removed.
+const (
+	lockTimeout  = time.Second
+	staleLockAge = 10 * time.Second
+)
+
+// errLocked is returned when the index stays locked by another process
+var errLocked = errors.New("the correlation index is locked by another process")
+
+//
+// Public types
+//
+
+// Entry is an API request made by the CLI
+type Entry struct {
+	RequestID string    `json:"request_id"`
+	Command   string    `json:"command"`
+	Time      time.Time `json:"time"`
+}
+
+//
+// Public functions
+//
+
+// Enable sets the index at path the API requests made by this process are
+// recorded in, attributed to command. The index is shared with other CLI
+// processes so that e.g. `stripe listen` can tell which `stripe trigger`
+// caused an event. Requests are only recorded while a process looks them up,
+// see StartLookups.
+func Enable(path, command string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	indexPath = path
+	currentCommand = command
+	entries = nil
+	indexed = nil
+	offset = 0
+}
+
+// StartLookups makes the CLI processes record their requests in the index
+// for this process to look them up, until the returned function is called.
+func StartLookups() func() {
+	mu.Lock()
+	path := activePath()
+	mu.Unlock()
+
+	if path == "" {
+		return func() {}
+	}
+
+	markActive(path)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(activeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				markActive(path)
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			os.Remove(path) // #nosec G104
+		})
+	}
+}
+
+// Record adds a request made by this process to the index. It does nothing
+// unless recording was enabled and a process looks requests up.
+func Record(requestID string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if indexPath == "" || requestID == "" || !active() {
+		return nil
+	}
+
+	data, err := json.Marshal(Entry{
+		RequestID: requestID,
+		Command:   currentCommand,
+		Time:      time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(indexPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	// Appending and compacting are done under the lock so that compactions
+	// don't drop the entries appended by other processes meanwhile
+	unlock, err := lock(indexPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(indexPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return compact(indexPath)
+}
+
+// Lookup returns the entry recorded for requestID, if the request was made
+// by the CLI.
+func Lookup(requestID string) (Entry, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if indexPath == "" || requestID == "" {
+		return Entry{}, false
+	}
+
+	if err := refresh(); err != nil {
+		return Entry{}, false
+	}
+
+	entry, ok := entries[requestID]
+
+	return entry, ok
+}
+
+//
+// Private variables
+//
+
+var (
+	mu             sync.Mutex
+	indexPath      string
+	currentCommand string
+
+	// entries are the entries of the index read so far by Lookup, from the
+	// file indexed up to offset
+	entries map[string]Entry
+	indexed os.FileInfo
+	offset  int64
+)
+
+//
+// Private functions
+//
+
+// activePath returns the path of the file marking the index as active
+func activePath() string {
+	if indexPath == "" {
+		return ""
+	}
+
+	return indexPath + ".active"
+}
+
+func markActive(path string) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return
+	}
+
+	if err := ioutil.WriteFile(path, nil, 0600); err != nil {
+		return
+	}
+
+	now := time.Now()
+	os.Chtimes(path, now, now) // #nosec G104
+}
+
+// active returns whether a process marked the index as active recently.
+// Markers left by processes that didn't exit cleanly go stale.
+func active() bool {
+	info, err := os.Stat(activePath())
+	if err != nil {
+		return false
+	}
+
+	return time.Since(info.ModTime()) < 2*activeInterval
+}
+
+// lock takes the lock of the index at path, shared with the other CLI
+// processes, and returns the function releasing it.
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil // #nosec G104
+		}
+
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(lockPath) // #nosec G104
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errLocked
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// refresh reads the entries appended to the index since the last lookup,
+// or all of them again once it was compacted.
+func refresh() error {
+	f, err := os.Open(indexPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if entries == nil || indexed == nil || !os.SameFile(indexed, info) || info.Size() < offset {
+		entries = make(map[string]Entry)
+		offset = 0
+	}
+	indexed = info
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	// Leave the last line for the next lookup until it's fully written
+	end := bytes.LastIndexByte(content, '\n') + 1
+	offset += int64(end)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content[:end]))
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.RequestID != "" {
+			entries[entry.RequestID] = entry
+		}
+	}
+
+	return nil
+}
+
+// compact keeps the index from growing forever by dropping its oldest half
+// once it gets too large. It must be called with the lock of the index.
+func compact(path string) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() <= maxIndexSize {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	kept := strings.Join(lines[len(lines)/2:], "")
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.WriteString(kept)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name()) // #nosec G104
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
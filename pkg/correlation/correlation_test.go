@@ -0,0 +1,110 @@
+package correlation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndLookup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), IndexFileName)
+	Enable(path, "stripe trigger customer.created")
+	defer Enable("", "")
+	defer StartLookups()()
+
+	require.NoError(t, Record("req_123"))
+	require.NoError(t, Record(""))
+
+	entry, ok := Lookup("req_123")
+	require.True(t, ok)
+	require.Equal(t, "req_123", entry.RequestID)
+	require.Equal(t, "stripe trigger customer.created", entry.Command)
+
+	_, ok = Lookup("req_456")
+	require.False(t, ok)
+
+	// Lookups read the entries appended since the previous one
+	require.NoError(t, Record("req_456"))
+
+	_, ok = Lookup("req_456")
+	require.True(t, ok)
+}
+
+func TestRecordDisabled(t *testing.T) {
+	require.NoError(t, Record("req_123"))
+
+	_, ok := Lookup("req_123")
+	require.False(t, ok)
+}
+
+func TestRecordWithoutLookups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), IndexFileName)
+	Enable(path, "stripe trigger customer.created")
+	defer Enable("", "")
+
+	require.NoError(t, Record("req_123"))
+
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	stopLookups := StartLookups()
+	require.NoError(t, Record("req_456"))
+	stopLookups()
+	require.NoError(t, Record("req_789"))
+
+	_, ok := Lookup("req_456")
+	require.True(t, ok)
+
+	_, ok = Lookup("req_789")
+	require.False(t, ok)
+}
+
+func TestCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), IndexFileName)
+	line := `{"request_id":"req_old","command":"stripe post"}` + "\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(strings.Repeat(line, maxIndexSize/len(line)+10)), 0600))
+
+	Enable(path, "stripe trigger")
+	defer Enable("", "")
+	defer StartLookups()()
+
+	_, ok := Lookup("req_old")
+	require.True(t, ok)
+
+	require.NoError(t, Record("req_new"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(maxIndexSize))
+
+	_, ok = Lookup("req_new")
+	require.True(t, ok)
+
+	matches, err := filepath.Glob(path + ".tmp*")
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
+
+func TestRecordLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), IndexFileName)
+	Enable(path, "stripe trigger")
+	defer Enable("", "")
+	defer StartLookups()()
+
+	// Another process is writing the index
+	require.NoError(t, ioutil.WriteFile(path+".lock", nil, 0600))
+	require.Equal(t, errLocked, Record("req_123"))
+
+	// The lock of a process that died goes stale
+	stale := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(path+".lock", stale, stale))
+	require.NoError(t, Record("req_123"))
+
+	_, err := os.Stat(path + ".lock")
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,195 @@
+package cryptopolicy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+)
+
+//
+// Public constants
+//
+
+// Cipher policies
+const (
+	// CiphersDefault uses the cipher suites of Go
+	CiphersDefault = "default"
+	// CiphersModern only uses forward secret AEAD cipher suites
+	CiphersModern = "modern"
+	// CiphersFIPS only uses the cipher suites approved by FIPS 140, with
+	// ECDHE and AES-GCM
+	CiphersFIPS = "fips"
+)
+
+//
+// Public types
+//
+
+// Policy sets the algorithms the CLI can use for its outbound connections and
+// for the data it encrypts or signs
+type Policy struct {
+	// MinTLSVersion is the minimum TLS version of outbound connections
+	MinTLSVersion uint16
+
+	// Ciphers is the cipher policy of outbound connections: CiphersDefault,
+	// CiphersModern or CiphersFIPS
+	Ciphers string
+
+	// ApprovedOnly refuses the algorithms not approved by FIPS 140. It's
+	// always on in binaries built with the fips build tag.
+	ApprovedOnly bool
+}
+
+//
+// Public functions
+//
+
+// New returns the policy for the config values. minTLSVersion is "1.0",
+// "1.1", "1.2" or "1.3" and defaults to "1.2", ciphers defaults to
+// CiphersDefault, or to CiphersFIPS when only approved algorithms are allowed.
+func New(minTLSVersion, ciphers string, approvedOnly bool) (*Policy, error) {
+	p := &Policy{
+		MinTLSVersion: tls.VersionTLS12,
+		Ciphers:       ciphers,
+		ApprovedOnly:  approvedOnly || BuildFIPS,
+	}
+
+	if minTLSVersion != "" {
+		version, ok := tlsVersions[minTLSVersion]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized minimum TLS version: %s. Expected one of 1.0, 1.1, 1.2, 1.3", minTLSVersion)
+		}
+
+		p.MinTLSVersion = version
+	}
+
+	switch p.Ciphers {
+	case "":
+		p.Ciphers = CiphersDefault
+		if p.ApprovedOnly {
+			p.Ciphers = CiphersFIPS
+		}
+	case CiphersDefault, CiphersModern, CiphersFIPS:
+	default:
+		return nil, fmt.Errorf("unrecognized TLS cipher policy: %s. Expected one of default, modern, fips", p.Ciphers)
+	}
+
+	if p.ApprovedOnly {
+		if p.Ciphers != CiphersFIPS {
+			return nil, fmt.Errorf("the %s TLS cipher policy isn't allowed when only approved algorithms are, use fips", p.Ciphers)
+		}
+
+		if p.MinTLSVersion < tls.VersionTLS12 {
+			return nil, fmt.Errorf("TLS %s isn't allowed when only approved algorithms are, use 1.2 or later", VersionName(p.MinTLSVersion))
+		}
+
+		// The cipher suites of TLS 1.3 can't be restricted in crypto/tls, so
+		// approved connections are limited to TLS 1.2
+		if p.MinTLSVersion > tls.VersionTLS12 {
+			return nil, fmt.Errorf("TLS 1.3 can't be required when only approved algorithms are allowed, since its cipher suites can't be restricted")
+		}
+	}
+
+	return p, nil
+}
+
+// Set sets the policy used by the CLI. The connections made afterwards use
+// it, including the ones of HTTP clients created before.
+func Set(p *Policy) {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	current = p
+}
+
+// Current returns the policy used by the CLI
+func Current() *Policy {
+	currentMu.Lock()
+	defer currentMu.Unlock()
+
+	return current
+}
+
+// TLSConfig returns the TLS config of outbound connections
+func (p *Policy) TLSConfig() *tls.Config {
+	config := &tls.Config{
+		MinVersion:   p.MinTLSVersion,
+		CipherSuites: p.CipherSuites(),
+	}
+
+	if p.ApprovedOnly {
+		config.MaxVersion = tls.VersionTLS12
+		config.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+	}
+
+	return config
+}
+
+// CipherSuites returns the TLS 1.2 cipher suites allowed by the policy, or
+// nil for the ones of Go
+func (p *Policy) CipherSuites() []uint16 {
+	switch p.Ciphers {
+	case CiphersModern:
+		return append(append([]uint16{}, fipsCipherSuites...),
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+		)
+	case CiphersFIPS:
+		return append([]uint16{}, fipsCipherSuites...)
+	default:
+		return nil
+	}
+}
+
+// CheckAlgorithm returns an error if the policy doesn't allow the algorithm,
+// e.g. "X25519" or "ChaCha20-Poly1305"
+func (p *Policy) CheckAlgorithm(algorithm string) error {
+	if p.ApprovedOnly && !approvedAlgorithms[algorithm] {
+		return fmt.Errorf("%s isn't allowed since only FIPS 140 approved algorithms are", algorithm)
+	}
+
+	return nil
+}
+
+// VersionName returns the name of a TLS version, e.g. "1.2"
+func VersionName(version uint16) string {
+	for name, v := range tlsVersions {
+		if v == version {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("0x%04x", version)
+}
+
+//
+// Private variables
+//
+
+// current is the default policy until the config is loaded
+var current, _ = New("", "", false)
+
+var currentMu sync.Mutex
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// approvedAlgorithms are the algorithms the CLI uses outside of TLS that are
+// approved by FIPS 140
+var approvedAlgorithms = map[string]bool{
+	"AES-GCM":     true,
+	"Ed25519":     true,
+	"HMAC-SHA256": true,
+	"SHA-256":     true,
+}
@@ -0,0 +1,78 @@
+package cryptopolicy
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaults(t *testing.T) {
+	if BuildFIPS {
+		t.Skip("only approved algorithms are allowed in fips builds")
+	}
+
+	p, err := New("", "", false)
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS12), p.MinTLSVersion)
+	require.Equal(t, CiphersDefault, p.Ciphers)
+	require.False(t, p.ApprovedOnly)
+
+	config := p.TLSConfig()
+	require.Nil(t, config.CipherSuites)
+	require.Zero(t, config.MaxVersion)
+}
+
+func TestNewInvalidValues(t *testing.T) {
+	_, err := New("1.4", "", false)
+	require.EqualError(t, err, "unrecognized minimum TLS version: 1.4. Expected one of 1.0, 1.1, 1.2, 1.3")
+
+	_, err = New("", "strong", false)
+	require.EqualError(t, err, "unrecognized TLS cipher policy: strong. Expected one of default, modern, fips")
+}
+
+func TestNewApprovedOnly(t *testing.T) {
+	p, err := New("", "", true)
+	require.NoError(t, err)
+	require.Equal(t, CiphersFIPS, p.Ciphers)
+
+	config := p.TLSConfig()
+	require.Equal(t, uint16(tls.VersionTLS12), config.MaxVersion)
+	require.Equal(t, fipsCipherSuites, config.CipherSuites)
+	require.Equal(t, []tls.CurveID{tls.CurveP256, tls.CurveP384}, config.CurvePreferences)
+
+	_, err = New("", CiphersModern, true)
+	require.Error(t, err)
+
+	_, err = New("1.1", "", true)
+	require.Error(t, err)
+
+	_, err = New("1.3", "", true)
+	require.Error(t, err)
+}
+
+func TestCipherSuites(t *testing.T) {
+	if BuildFIPS {
+		t.Skip("only approved algorithms are allowed in fips builds")
+	}
+
+	p, err := New("1.3", CiphersModern, false)
+	require.NoError(t, err)
+	require.Equal(t, uint16(tls.VersionTLS13), p.TLSConfig().MinVersion)
+	require.Contains(t, p.CipherSuites(), tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305)
+	require.NotContains(t, p.CipherSuites(), tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA)
+}
+
+func TestCheckAlgorithm(t *testing.T) {
+	p := &Policy{ApprovedOnly: true}
+	require.NoError(t, p.CheckAlgorithm("Ed25519"))
+	require.EqualError(t, p.CheckAlgorithm("X25519"), "X25519 isn't allowed since only FIPS 140 approved algorithms are")
+
+	p = &Policy{}
+	require.NoError(t, p.CheckAlgorithm("X25519"))
+}
+
+func TestVersionName(t *testing.T) {
+	require.Equal(t, "1.2", VersionName(tls.VersionTLS12))
+	require.Equal(t, "0x0300", VersionName(0x0300))
+}
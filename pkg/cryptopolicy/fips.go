@@ -0,0 +1,8 @@
+//go:build fips
+// +build fips
+
+package cryptopolicy
+
+// BuildFIPS is whether the CLI was built with the fips build tag, which only
+// allows the algorithms approved by FIPS 140
+const BuildFIPS = true
@@ -0,0 +1,144 @@
+// Package cursors keeps named pagination cursors of list requests, so that
+// exporting a long list can be resumed where it was interrupted.
+package cursors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileName is the name of the cursors file in the config folder
+const FileName = "cursors.json"
+
+//
+// Public types
+//
+
+// Cursor is the position reached in a list
+type Cursor struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Livemode bool   `json:"livemode"`
+
+	// StartingAfter is the ID of the last object retrieved
+	StartingAfter string `json:"starting_after"`
+
+	// Objects is the number of objects retrieved through the cursor
+	Objects int `json:"objects"`
+
+	// Done tells whether the end of the list was reached
+	Done bool `json:"done"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+//
+// Public functions
+//
+
+// Enable sets the cursors file at path as the one Get and Save use.
+func Enable(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cursorsPath = path
+}
+
+// Get returns the cursor saved under name.
+func Get(name string) (*Cursor, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cursors, err := Load(cursorsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cursors {
+		if c.Name == name {
+			return &c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no cursor named %s was saved", name)
+}
+
+// Save saves the cursor, replacing the one saved under the same name.
+func Save(cursor Cursor) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if cursorsPath == "" {
+		return fmt.Errorf("cursors aren't enabled")
+	}
+
+	cursors, err := Load(cursorsPath)
+	if err != nil {
+		return err
+	}
+
+	cursor.UpdatedAt = time.Now()
+
+	replaced := false
+	for i, c := range cursors {
+		if c.Name == cursor.Name {
+			cursors[i] = cursor
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		cursors = append(cursors, cursor)
+	}
+
+	sort.Slice(cursors, func(i, j int) bool { return cursors[i].Name < cursors[j].Name })
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cursorsPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp := cursorsPath + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, cursorsPath)
+}
+
+// Load reads the cursors of the file at path. A missing file has no
+// cursors.
+func Load(path string) ([]Cursor, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var cursors []Cursor
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("could not read the cursors in %s: %w", path, err)
+	}
+
+	return cursors, nil
+}
+
+//
+// Private variables
+//
+
+var (
+	mu          sync.Mutex
+	cursorsPath string
+)
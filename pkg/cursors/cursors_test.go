@@ -0,0 +1,34 @@
+package cursors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndGet(t *testing.T) {
+	Enable(filepath.Join(t.TempDir(), FileName))
+	defer Enable("")
+
+	_, err := Get("export")
+	require.Error(t, err)
+
+	require.NoError(t, Save(Cursor{Name: "export", Path: "/v1/balance_transactions", StartingAfter: "txn_1", Objects: 100}))
+	require.NoError(t, Save(Cursor{Name: "customers", Path: "/v1/customers", StartingAfter: "cus_1", Objects: 10}))
+	require.NoError(t, Save(Cursor{Name: "export", Path: "/v1/balance_transactions", StartingAfter: "txn_2", Objects: 200}))
+
+	cursor, err := Get("export")
+	require.NoError(t, err)
+	require.Equal(t, "txn_2", cursor.StartingAfter)
+	require.Equal(t, 200, cursor.Objects)
+	require.False(t, cursor.UpdatedAt.IsZero())
+
+	cursor, err = Get("customers")
+	require.NoError(t, err)
+	require.Equal(t, "cus_1", cursor.StartingAfter)
+}
+
+func TestSaveDisabled(t *testing.T) {
+	require.Error(t, Save(Cursor{Name: "export"}))
+}
@@ -0,0 +1,111 @@
+// Package deprecation reports the deprecated usages of the CLI and the API,
+// with guidance to migrate away from them.
+package deprecation
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/gha"
+)
+
+// Kinds of deprecated usages
+const (
+	KindFlag      = "flag"
+	KindOperation = "operation"
+	KindParameter = "parameter"
+)
+
+// APIUpgradesURL lists the changes of the API, with the replacements of its
+// deprecated operations and parameters
+const APIUpgradesURL = "https://stripe.com/docs/upgrades"
+
+//
+// Public variables
+//
+
+// GitHubActions reports the warnings as GitHub Actions workflow commands.
+var GitHubActions = false
+
+// Output is where the warnings are reported.
+var Output io.Writer = os.Stderr
+
+//
+// Public types
+//
+
+// Warning is a deprecated usage of the CLI or the API
+type Warning struct {
+	Kind string `json:"kind"`
+
+	// Name is the name of the flag or parameter, or the method and path of
+	// the operation, e.g. `GET /v1/recipients`
+	Name string `json:"name"`
+
+	// Location is where the usage was found, e.g. `scripts/seed.sh:12`
+	Location string `json:"location,omitempty"`
+
+	// Replacement tells how to migrate away from the usage
+	Replacement string `json:"replacement,omitempty"`
+}
+
+func (w Warning) String() string {
+	var b strings.Builder
+
+	if w.Location != "" {
+		fmt.Fprintf(&b, "%s: ", w.Location)
+	}
+
+	fmt.Fprintf(&b, "the %s %s is deprecated", w.Kind, w.Name)
+
+	if w.Replacement != "" {
+		fmt.Fprintf(&b, ". %s", w.Replacement)
+	}
+
+	return b.String()
+}
+
+//
+// Public functions
+//
+
+// Flag returns the warning for a deprecated flag of the CLI
+func Flag(name, replacement string) Warning {
+	return Warning{Kind: KindFlag, Name: "--" + name, Replacement: replacement}
+}
+
+// Operation returns the warning for a deprecated operation of the API
+func Operation(method, path string) Warning {
+	return Warning{
+		Kind:        KindOperation,
+		Name:        fmt.Sprintf("%s %s", strings.ToUpper(method), path),
+		Replacement: fmt.Sprintf("See %s for its replacement", APIUpgradesURL),
+	}
+}
+
+// Parameter returns the warning for a deprecated parameter of an operation
+// of the API
+func Parameter(method, path, param string) Warning {
+	return Warning{
+		Kind:        KindParameter,
+		Name:        fmt.Sprintf("%s of %s %s", param, strings.ToUpper(method), path),
+		Replacement: fmt.Sprintf("See %s for its replacement", APIUpgradesURL),
+	}
+}
+
+// Warn reports a warning to Output
+func Warn(w Warning) {
+	Write(Output, w)
+}
+
+// Write reports a warning to out
+func Write(out io.Writer, w Warning) {
+	if GitHubActions {
+		gha.Warning(out, w.String())
+		return
+	}
+
+	fmt.Fprintf(out, "Warning: %s\n", w)
+}
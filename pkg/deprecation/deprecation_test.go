@@ -0,0 +1,25 @@
+package deprecation
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarn(t *testing.T) {
+	var buf bytes.Buffer
+	Output = &buf
+
+	Warn(Flag("print-json", "Use --format JSON instead"))
+	require.Equal(t, "Warning: the flag --print-json is deprecated. Use --format JSON instead\n", buf.String())
+
+	buf.Reset()
+	GitHubActions = true
+	defer func() { GitHubActions = false }()
+
+	w := Operation("get", "/v1/recipients")
+	w.Location = "seed.sh:3"
+	Warn(w)
+	require.Equal(t, "::warning::seed.sh:3: the operation GET /v1/recipients is deprecated. See https://stripe.com/docs/upgrades for its replacement\n", buf.String())
+}
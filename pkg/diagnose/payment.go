@@ -0,0 +1,331 @@
+// Package diagnose explains the state of API objects, e.g. why a payment
+// failed, and suggests what to do next.
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+)
+
+// maxEventPages is the number of pages of payment intent events searched
+// for the events of the payment
+const maxEventPages = 3
+
+// captureWindow is how long an authorized card payment can be captured for
+const captureWindow = 7 * 24 * time.Hour
+
+// declineCodes explain the most common decline codes, with what to do
+// about them. See https://stripe.com/docs/declines/codes
+var declineCodes = map[string][2]string{
+	"authentication_required":         {"The card requires authentication (3D Secure).", "Confirm the payment again on-session so the customer can authenticate."},
+	"card_not_supported":              {"The card doesn't support this type of purchase.", "Ask the customer to use another card."},
+	"card_velocity_exceeded":          {"The customer exceeded the balance or credit limit of their card.", "Ask the customer to contact their bank, or to use another card."},
+	"currency_not_supported":          {"The card doesn't support the currency of the payment.", "Ask the customer to use another card."},
+	"do_not_honor":                    {"The bank declined the payment without giving a reason.", "Ask the customer to contact their bank, or to use another card."},
+	"expired_card":                    {"The card has expired.", "Ask the customer to use another card."},
+	"fraudulent":                      {"The bank suspects the payment is fraudulent.", "Don't retry the payment; don't tell the customer the exact reason."},
+	"generic_decline":                 {"The bank declined the payment without giving a reason.", "Ask the customer to contact their bank, or to use another card."},
+	"incorrect_cvc":                   {"The CVC of the card is incorrect.", "Ask the customer to enter their card details again."},
+	"incorrect_number":                {"The card number is incorrect.", "Ask the customer to enter their card details again."},
+	"insufficient_funds":              {"The card has insufficient funds.", "Ask the customer to use another payment method, or to retry later."},
+	"lost_card":                       {"The card was reported lost.", "Don't retry the payment; don't tell the customer the exact reason."},
+	"pickup_card":                     {"The card can't be used, it may have been reported lost or stolen.", "Ask the customer to contact their bank."},
+	"processing_error":                {"An error occurred while processing the card.", "Retry the payment; if it keeps failing, retry later."},
+	"stolen_card":                     {"The card was reported stolen.", "Don't retry the payment; don't tell the customer the exact reason."},
+	"try_again_later":                 {"The bank declined the payment for a temporary reason.", "Retry the payment later."},
+	"withdrawal_count_limit_exceeded": {"The customer exceeded the number of payments allowed on their card.", "Ask the customer to use another card."},
+}
+
+//
+// Public types
+//
+
+// PaymentDiagnosis explains the state of a payment intent
+type PaymentDiagnosis struct {
+	PaymentIntent string `json:"payment_intent"`
+	Status        string `json:"status"`
+	Amount        string `json:"amount"`
+	Charge        string `json:"charge,omitempty"`
+
+	// Explanation tells why the payment is in its status
+	Explanation []string `json:"explanation"`
+
+	// Actions are the suggested next actions
+	Actions []string `json:"actions"`
+
+	Radar  *Radar         `json:"radar,omitempty"`
+	Events []PaymentEvent `json:"events"`
+}
+
+// Radar is the outcome of the Radar review of the latest charge
+type Radar struct {
+	Type          string `json:"type"`
+	NetworkStatus string `json:"network_status"`
+	Reason        string `json:"reason,omitempty"`
+	RiskLevel     string `json:"risk_level,omitempty"`
+	RiskScore     int64  `json:"risk_score,omitempty"`
+	Rule          string `json:"rule,omitempty"`
+	SellerMessage string `json:"seller_message,omitempty"`
+}
+
+// PaymentEvent is an event of the payment intent
+type PaymentEvent struct {
+	ID      string    `json:"id"`
+	Type    string    `json:"type"`
+	Created time.Time `json:"created"`
+}
+
+//
+// Public functions
+//
+
+// Payment diagnoses the payment intent with ID id.
+func Payment(ctx context.Context, client simulate.APIClient, id string) (*PaymentDiagnosis, error) {
+	intent, err := client.Request(ctx, http.MethodGet, "/v1/payment_intents/"+id, []string{"expand[]=latest_charge"})
+	if err != nil {
+		return nil, err
+	}
+
+	charge := intent.Get("latest_charge")
+	if !charge.IsObject() {
+		// API versions before 2022-11-15 list the charges instead
+		charge = intent.Get("charges.data.0")
+	}
+
+	d := &PaymentDiagnosis{
+		PaymentIntent: id,
+		Status:        intent.Get("status").String(),
+		Amount:        preview.FormatAmount(intent.Get("amount").Int(), intent.Get("currency").String()),
+		Charge:        charge.Get("id").String(),
+		Explanation:   []string{},
+		Actions:       []string{},
+		Events:        []PaymentEvent{},
+	}
+
+	explain(d, intent, charge)
+
+	if outcome := charge.Get("outcome"); outcome.Exists() {
+		rule := outcome.Get("rule")
+		if rule.IsObject() {
+			rule = rule.Get("id")
+		}
+
+		d.Radar = &Radar{
+			Type:          outcome.Get("type").String(),
+			NetworkStatus: outcome.Get("network_status").String(),
+			Reason:        outcome.Get("reason").String(),
+			RiskLevel:     outcome.Get("risk_level").String(),
+			RiskScore:     outcome.Get("risk_score").Int(),
+			Rule:          rule.String(),
+			SellerMessage: outcome.Get("seller_message").String(),
+		}
+
+		switch d.Radar.Type {
+		case "blocked":
+			d.Explanation = append(d.Explanation, "Radar blocked the payment"+ruleSuffix(d.Radar)+".")
+			d.Actions = append(d.Actions, "Review your Radar rules in the Dashboard if the payment was legitimate.")
+		case "manual_review":
+			d.Explanation = append(d.Explanation, "Radar placed the payment in review"+ruleSuffix(d.Radar)+".")
+			d.Actions = append(d.Actions, "Approve or refund the payment from the review queue of the Dashboard.")
+		}
+	}
+
+	d.Events, err = paymentEvents(ctx, client, id, intent.Get("created").Int())
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// RenderPayment writes a diagnosis to w.
+func RenderPayment(w io.Writer, d *PaymentDiagnosis) {
+	color := ansi.Color(w)
+
+	fmt.Fprintf(w, "%s %s  %s  %s\n\n", ansi.Bold("Payment"), d.PaymentIntent, d.Amount, statusGlyph(d.Status)+" "+d.Status)
+
+	fmt.Fprintln(w, ansi.Bold("Why"))
+	for _, line := range d.Explanation {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+
+	if len(d.Actions) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, ansi.Bold("Next steps"))
+		for _, action := range d.Actions {
+			fmt.Fprintf(w, "  %s %s\n", ansi.InfoGlyph(), action)
+		}
+	}
+
+	if d.Radar != nil {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, ansi.Bold("Radar"))
+		fmt.Fprintf(w, "  Outcome:  %s (network: %s)\n", d.Radar.Type, d.Radar.NetworkStatus)
+		if d.Radar.RiskLevel != "" {
+			fmt.Fprintf(w, "  Risk:     %s (score %d)\n", d.Radar.RiskLevel, d.Radar.RiskScore)
+		}
+		if d.Radar.SellerMessage != "" {
+			fmt.Fprintf(w, "  Message:  %s\n", d.Radar.SellerMessage)
+		}
+	}
+
+	if len(d.Events) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, ansi.Bold("Events"))
+		for _, event := range d.Events {
+			fmt.Fprintf(w, "  %s  %s %s\n", color.Faint(event.Created.Local().Format("2006-01-02 15:04:05")), event.Type, color.Faint(event.ID))
+		}
+	}
+}
+
+//
+// Private functions
+//
+
+// explain fills the explanation and the next actions of the status of the
+// payment intent
+func explain(d *PaymentDiagnosis, intent, charge gjson.Result) {
+	lastError := intent.Get("last_payment_error")
+
+	switch d.Status {
+	case "requires_payment_method":
+		if !lastError.Exists() {
+			d.Explanation = append(d.Explanation, "No payment method was attached to the payment yet.")
+			d.Actions = append(d.Actions, "Confirm the payment with a payment method, e.g. with Stripe.js on your checkout page.")
+
+			return
+		}
+
+		d.Explanation = append(d.Explanation, "The last payment attempt failed: "+lastError.Get("message").String())
+
+		code := lastError.Get("decline_code").String()
+		if code == "" {
+			code = lastError.Get("code").String()
+		}
+
+		if explanation, ok := declineCodes[code]; ok {
+			d.Explanation = append(d.Explanation, fmt.Sprintf("%s (%s)", explanation[0], code))
+			d.Actions = append(d.Actions, explanation[1])
+		} else if code != "" {
+			d.Explanation = append(d.Explanation, fmt.Sprintf("Code: %s, see https://stripe.com/docs/declines/codes", code))
+			d.Actions = append(d.Actions, "Ask the customer to use another payment method.")
+		}
+	case "requires_confirmation":
+		d.Explanation = append(d.Explanation, "The payment has a payment method but wasn't confirmed.")
+		d.Actions = append(d.Actions, fmt.Sprintf("Confirm it: stripe payment_intents confirm %s", d.PaymentIntent))
+	case "requires_action":
+		nextAction := intent.Get("next_action.type").String()
+
+		switch nextAction {
+		case "use_stripe_sdk", "redirect_to_url":
+			d.Explanation = append(d.Explanation, "The customer must authenticate the payment (3D Secure / SCA).")
+			d.Actions = append(d.Actions, "Handle the next action with Stripe.js, e.g. stripe.handleNextAction, while the customer is on your page.")
+
+			if url := intent.Get("next_action.redirect_to_url.url").String(); url != "" {
+				d.Actions = append(d.Actions, "Or redirect the customer to "+url)
+			}
+		default:
+			d.Explanation = append(d.Explanation, fmt.Sprintf("The customer must complete an action: %s.", nextAction))
+			d.Actions = append(d.Actions, "Show the customer the instructions of the next action of the payment.")
+		}
+	case "processing":
+		d.Explanation = append(d.Explanation, "The payment method is being processed, which can take days for bank debits.")
+		d.Actions = append(d.Actions, "Wait for the payment_intent.succeeded or payment_intent.payment_failed webhook.")
+	case "requires_capture":
+		d.Explanation = append(d.Explanation, "The payment was authorized but not captured: the funds are held, not collected.")
+
+		if created := charge.Get("created").Int(); created > 0 {
+			deadline := time.Unix(created, 0).Add(captureWindow)
+			d.Explanation = append(d.Explanation, fmt.Sprintf("Card authorizations are released if they aren't captured by %s.", deadline.Local().Format("2006-01-02 15:04")))
+		}
+
+		d.Actions = append(d.Actions, fmt.Sprintf("Capture it: stripe payment_intents capture %s", d.PaymentIntent))
+	case "canceled":
+		reason := intent.Get("cancellation_reason").String()
+		if reason == "" {
+			reason = "no reason given"
+		}
+
+		d.Explanation = append(d.Explanation, fmt.Sprintf("The payment was canceled (%s).", reason))
+		d.Actions = append(d.Actions, "Create a new payment intent to collect the payment again.")
+	case "succeeded":
+		d.Explanation = append(d.Explanation, fmt.Sprintf("The payment succeeded, %s was collected.",
+			preview.FormatAmount(intent.Get("amount_received").Int(), intent.Get("currency").String())))
+
+		if refunded := charge.Get("amount_refunded").Int(); refunded > 0 {
+			d.Explanation = append(d.Explanation, fmt.Sprintf("%s was refunded.", preview.FormatAmount(refunded, charge.Get("currency").String())))
+		}
+
+		if charge.Get("disputed").Bool() {
+			d.Explanation = append(d.Explanation, "The customer disputed the payment.")
+			d.Actions = append(d.Actions, "Respond to the dispute with evidence from the Dashboard before its deadline.")
+		}
+	default:
+		d.Explanation = append(d.Explanation, fmt.Sprintf("The payment is %s.", d.Status))
+	}
+}
+
+// paymentEvents returns the events of the payment intent, oldest first
+func paymentEvents(ctx context.Context, client simulate.APIClient, id string, created int64) ([]PaymentEvent, error) {
+	events := []PaymentEvent{}
+	startingAfter := ""
+
+	for page := 0; page < maxEventPages; page++ {
+		params := []string{"limit=100", "type=payment_intent.*", fmt.Sprintf("created[gte]=%d", created)}
+		if startingAfter != "" {
+			params = append(params, "starting_after="+startingAfter)
+		}
+
+		list, err := client.Request(ctx, http.MethodGet, "/v1/events", params)
+		if err != nil {
+			return nil, err
+		}
+
+		data := list.Get("data").Array()
+		for _, event := range data {
+			if event.Get("data.object.id").String() == id {
+				events = append([]PaymentEvent{{
+					ID:      event.Get("id").String(),
+					Type:    event.Get("type").String(),
+					Created: time.Unix(event.Get("created").Int(), 0),
+				}}, events...)
+			}
+		}
+
+		if !list.Get("has_more").Bool() || len(data) == 0 {
+			break
+		}
+
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+
+	return events, nil
+}
+
+func ruleSuffix(radar *Radar) string {
+	if radar.Rule == "" {
+		return ""
+	}
+
+	return " with rule " + radar.Rule
+}
+
+func statusGlyph(status string) string {
+	switch status {
+	case "succeeded":
+		return ansi.SuccessGlyph()
+	case "requires_payment_method", "canceled":
+		return ansi.ErrorGlyph()
+	}
+
+	return ansi.WarningGlyph()
+}
@@ -0,0 +1,83 @@
+package diagnose
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type fakeClient struct {
+	intent string
+}
+
+func (c *fakeClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	if path == "/v1/events" {
+		return gjson.Parse(`{"object":"list","has_more":false,"data":[
+			{"id":"evt_2","type":"payment_intent.payment_failed","created":1700000100,"data":{"object":{"id":"pi_123"}}},
+			{"id":"evt_3","type":"payment_intent.created","created":1700000050,"data":{"object":{"id":"pi_456"}}},
+			{"id":"evt_1","type":"payment_intent.created","created":1700000000,"data":{"object":{"id":"pi_123"}}}
+		]}`), nil
+	}
+
+	return gjson.Parse(c.intent), nil
+}
+
+func TestPaymentDeclined(t *testing.T) {
+	client := &fakeClient{intent: `{
+		"id": "pi_123", "status": "requires_payment_method", "amount": 2000, "currency": "usd", "created": 1700000000,
+		"last_payment_error": {"message": "Your card has insufficient funds.", "code": "card_declined", "decline_code": "insufficient_funds"},
+		"latest_charge": {"id": "ch_123", "outcome": {"type": "issuer_declined", "network_status": "declined_by_network", "risk_level": "normal", "risk_score": 20}}
+	}`}
+
+	d, err := Payment(context.Background(), client, "pi_123")
+	require.NoError(t, err)
+
+	require.Equal(t, "USD 20.00", d.Amount)
+	require.Equal(t, "ch_123", d.Charge)
+	require.Equal(t, []string{
+		"The last payment attempt failed: Your card has insufficient funds.",
+		"The card has insufficient funds. (insufficient_funds)",
+	}, d.Explanation)
+	require.Equal(t, []string{"Ask the customer to use another payment method, or to retry later."}, d.Actions)
+	require.Equal(t, "declined_by_network", d.Radar.NetworkStatus)
+
+	require.Len(t, d.Events, 2)
+	require.Equal(t, "evt_1", d.Events[0].ID)
+	require.Equal(t, "evt_2", d.Events[1].ID)
+
+	var b bytes.Buffer
+	RenderPayment(&b, d)
+	require.Contains(t, b.String(), "The card has insufficient funds.")
+	require.Contains(t, b.String(), "payment_intent.payment_failed")
+}
+
+func TestPaymentRequiresCapture(t *testing.T) {
+	client := &fakeClient{intent: `{
+		"id": "pi_123", "status": "requires_capture", "amount": 2000, "currency": "usd",
+		"charges": {"data": [{"id": "ch_123", "created": 1700000000}]}
+	}`}
+
+	d, err := Payment(context.Background(), client, "pi_123")
+	require.NoError(t, err)
+
+	require.Equal(t, "ch_123", d.Charge)
+	require.Contains(t, d.Explanation[0], "authorized but not captured")
+	require.Equal(t, []string{"Capture it: stripe payment_intents capture pi_123"}, d.Actions)
+}
+
+func TestPaymentBlockedByRadar(t *testing.T) {
+	client := &fakeClient{intent: `{
+		"id": "pi_123", "status": "requires_payment_method", "amount": 2000, "currency": "usd",
+		"last_payment_error": {"message": "Your card was declined.", "code": "card_declined", "decline_code": "fraudulent"},
+		"latest_charge": {"id": "ch_123", "outcome": {"type": "blocked", "network_status": "not_sent_to_network", "rule": {"id": "block_if_high_risk"}}}
+	}`}
+
+	d, err := Payment(context.Background(), client, "pi_123")
+	require.NoError(t, err)
+
+	require.Equal(t, "block_if_high_risk", d.Radar.Rule)
+	require.Contains(t, d.Explanation, "Radar blocked the payment with rule block_if_high_risk.")
+}
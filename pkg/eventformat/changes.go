@@ -0,0 +1,78 @@
+package eventformat
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Change is a field changed by an update event, computed from its
+// previous_attributes
+type Change struct {
+	// Path is the path of the field in the object, e.g. `metadata.order_id`
+	Path   string      `json:"path"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes returns the fields changed by a `*.updated` event, sorted by path,
+// and nil for other events. Changes to the keys of nested hashes like
+// metadata are listed one by one.
+func Changes(event map[string]interface{}) []Change {
+	eventType, _ := event["type"].(string)
+	if !strings.HasSuffix(eventType, ".updated") {
+		return nil
+	}
+
+	data, _ := event["data"].(map[string]interface{})
+
+	previous, ok := data["previous_attributes"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	object, _ := data["object"].(map[string]interface{})
+
+	var changes []Change
+
+	diff("", previous, object, &changes)
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+// diff appends the changes between the previous values of fields and their
+// current values
+func diff(prefix string, previous, current map[string]interface{}, changes *[]Change) {
+	for key, before := range previous {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		after := current[key]
+
+		beforeHash, beforeIsHash := before.(map[string]interface{})
+		afterHash, afterIsHash := after.(map[string]interface{})
+
+		if beforeIsHash && afterIsHash && len(beforeHash) > 0 {
+			diff(path, beforeHash, afterHash, changes)
+			continue
+		}
+
+		*changes = append(*changes, Change{Path: path, Before: before, After: after})
+	}
+}
+
+// compact returns a value of a change in JSON
+func compact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "?"
+	}
+
+	return string(data)
+}
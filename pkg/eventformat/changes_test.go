@@ -0,0 +1,53 @@
+package eventformat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const updateEvent = `{
+  "id": "evt_1NG8Du2eZvKYlo2CUI79vXWy",
+  "object": "event",
+  "data": {
+    "object": {
+      "id": "cus_NzRjh3cxY1Xz8m",
+      "email": "jenny.rosen@example.com",
+      "metadata": {"order_id": "6735", "source": "cli"},
+      "address": {"city": "Paris", "country": "FR"}
+    },
+    "previous_attributes": {
+      "email": null,
+      "metadata": {"order_id": null},
+      "address": null
+    }
+  },
+  "type": "customer.updated"
+}`
+
+func TestChanges(t *testing.T) {
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(updateEvent), &event))
+
+	require.Equal(t, []Change{
+		{Path: "address", Before: nil, After: map[string]interface{}{"city": "Paris", "country": "FR"}},
+		{Path: "email", Before: nil, After: "jenny.rosen@example.com"},
+		{Path: "metadata.order_id", Before: nil, After: "6735"},
+	}, Changes(event))
+
+	event["type"] = "customer.created"
+	require.Nil(t, Changes(event))
+}
+
+func TestFormatChanges(t *testing.T) {
+	formatted, err := Format([]byte(updateEvent), Options{})
+	require.NoError(t, err)
+	require.Contains(t, formatted, `"previous_attributes": {…} // 3 fields, --unfold previous_attributes`)
+	require.Contains(t, formatted, `}
+Changes:
+  address: null → {"city":"Paris","country":"FR"}
+  email: null → "jenny.rosen@example.com"
+  metadata.order_id: null → "6735"
+`)
+}
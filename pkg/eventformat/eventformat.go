@@ -0,0 +1,347 @@
+// Package eventformat pretty-prints event payloads for humans, folding the
+// sections of events that are rarely useful and highlighting IDs and
+// statuses.
+package eventformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+)
+
+// UnfoldAll unfolds all the folded sections
+const UnfoldAll = "all"
+
+// indent is the indentation of each level of the output
+const indent = "  "
+
+// FoldedSections are the paths of the sections of events folded unless
+// they're unfolded
+var FoldedSections = []string{
+	"request",
+	"data.previous_attributes",
+}
+
+// idRegex matches the IDs of Stripe objects, e.g. `evt_1Abc`, `cs_test_a1B2`
+var idRegex = regexp.MustCompile(`^[a-z]+(?:_[a-z]+)?_[0-9A-Za-z]{8,}$`)
+
+// successStatuses and failureStatuses are the statuses highlighted in green
+// and red, the others are highlighted in yellow
+var (
+	successStatuses = map[string]bool{
+		"active":    true,
+		"available": true,
+		"complete":  true,
+		"paid":      true,
+		"succeeded": true,
+	}
+
+	failureStatuses = map[string]bool{
+		"canceled":           true,
+		"failed":             true,
+		"incomplete_expired": true,
+		"past_due":           true,
+		"unpaid":             true,
+	}
+)
+
+//
+// Public types
+//
+
+// Options configures the output of Format
+type Options struct {
+	// Unfold are the folded sections to show, by path, e.g.
+	// `data.previous_attributes`, or by name, e.g. `previous_attributes`.
+	// UnfoldAll shows them all.
+	Unfold []string
+
+	// Color colors the output
+	Color aurora.Aurora
+}
+
+//
+// Public functions
+//
+
+// Format returns the pretty-printed payload of an event, in JSON with the
+// folded sections replaced by a placeholder.
+func Format(payload []byte, opts Options) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+
+	root, err := decode(dec)
+	if err != nil {
+		return "", fmt.Errorf("invalid event payload: %w", err)
+	}
+
+	if opts.Color == nil {
+		opts.Color = aurora.NewAurora(false)
+	}
+
+	var b strings.Builder
+
+	p := &printer{w: &b, opts: opts}
+	p.print(root, "", "", 0)
+	b.WriteString("\n")
+
+	var event map[string]interface{}
+
+	dec = json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+
+	if dec.Decode(&event) == nil {
+		p.printChanges(Changes(event))
+	}
+
+	return b.String(), nil
+}
+
+//
+// Private types
+//
+
+// node is a decoded JSON value. Objects keep the order of their keys.
+type node struct {
+	keys   []string
+	fields []*node
+	items  []*node
+
+	isObject bool
+	isArray  bool
+
+	// scalar is the JSON of strings, numbers, booleans and null
+	scalar string
+	str    string
+	isStr  bool
+}
+
+type printer struct {
+	w    *strings.Builder
+	opts Options
+}
+
+//
+// Private functions
+//
+
+func decode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &node{isObject: t == '{', isArray: t == '['}
+
+		for dec.More() {
+			if n.isObject {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				n.keys = append(n.keys, key.(string))
+			}
+
+			child, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			if n.isObject {
+				n.fields = append(n.fields, child)
+			} else {
+				n.items = append(n.items, child)
+			}
+		}
+
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		return n, nil
+	case string:
+		return &node{scalar: quote(t), str: t, isStr: true}, nil
+	case json.Number:
+		return &node{scalar: t.String()}, nil
+	case bool:
+		return &node{scalar: fmt.Sprintf("%t", t)}, nil
+	default:
+		return &node{scalar: "null"}, nil
+	}
+}
+
+func (p *printer) print(n *node, path, key string, depth int) {
+	switch {
+	case n.isObject && len(n.keys) == 0:
+		io.WriteString(p.w, "{}")
+	case n.isObject:
+		io.WriteString(p.w, "{\n")
+
+		for i, k := range n.keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+
+			io.WriteString(p.w, strings.Repeat(indent, depth+1))
+			io.WriteString(p.w, p.opts.Color.Blue(quote(k)).String()+": ")
+
+			if p.isFolded(childPath, n.fields[i]) {
+				p.printFolded(n.fields[i], childPath, i < len(n.keys)-1)
+				continue
+			}
+
+			p.print(n.fields[i], childPath, k, depth+1)
+
+			if i < len(n.keys)-1 {
+				io.WriteString(p.w, ",")
+			}
+
+			io.WriteString(p.w, "\n")
+		}
+
+		io.WriteString(p.w, strings.Repeat(indent, depth)+"}")
+	case n.isArray && len(n.items) == 0:
+		io.WriteString(p.w, "[]")
+	case n.isArray:
+		io.WriteString(p.w, "[\n")
+
+		for i, item := range n.items {
+			io.WriteString(p.w, strings.Repeat(indent, depth+1))
+			p.print(item, path, key, depth+1)
+
+			if i < len(n.items)-1 {
+				io.WriteString(p.w, ",")
+			}
+
+			io.WriteString(p.w, "\n")
+		}
+
+		io.WriteString(p.w, strings.Repeat(indent, depth)+"]")
+	default:
+		io.WriteString(p.w, p.highlight(n, key))
+	}
+}
+
+// printFolded prints the placeholder of a folded section, with a hint on how
+// to unfold it
+func (p *printer) printFolded(n *node, path string, more bool) {
+	ellipsis := "…"
+	if ansi.ASCIIOnly {
+		ellipsis = "..."
+	}
+
+	placeholder := "{" + ellipsis + "}"
+	if more {
+		placeholder += ","
+	}
+
+	name := path[strings.LastIndex(path, ".")+1:]
+	hint := fmt.Sprintf("%d fields, --unfold %s", len(n.keys), name)
+
+	io.WriteString(p.w, placeholder+" "+p.opts.Color.Faint("// "+hint).String()+"\n")
+}
+
+// printChanges prints the changes of an update event as a before/after diff
+func (p *printer) printChanges(changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	arrow := "→"
+	if ansi.ASCIIOnly {
+		arrow = "->"
+	}
+
+	io.WriteString(p.w, p.opts.Color.Bold("Changes:").String()+"\n")
+
+	for _, c := range changes {
+		fmt.Fprintf(p.w, "  %s: %s %s %s\n",
+			c.Path,
+			p.opts.Color.Red(compact(c.Before)),
+			arrow,
+			p.opts.Color.Green(compact(c.After)),
+		)
+	}
+}
+
+func (p *printer) isFolded(path string, n *node) bool {
+	if !n.isObject || len(n.keys) == 0 {
+		return false
+	}
+
+	folded := false
+
+	for _, section := range FoldedSections {
+		if section == path {
+			folded = true
+		}
+	}
+
+	if !folded {
+		return false
+	}
+
+	name := path[strings.LastIndex(path, ".")+1:]
+
+	for _, unfold := range p.opts.Unfold {
+		if unfold == UnfoldAll || unfold == path || unfold == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *printer) highlight(n *node, key string) string {
+	switch {
+	case !n.isStr:
+		return n.scalar
+	case key == "status" && successStatuses[n.str]:
+		return p.opts.Color.Green(n.scalar).Bold().String()
+	case key == "status" && failureStatuses[n.str]:
+		return p.opts.Color.Red(n.scalar).Bold().String()
+	case key == "status":
+		return p.opts.Color.Yellow(n.scalar).Bold().String()
+	case isID(n.str):
+		return p.opts.Color.Cyan(n.scalar).String()
+	default:
+		return n.scalar
+	}
+}
+
+// isID returns whether a string is the ID of a Stripe object. IDs have
+// random suffixes with digits or uppercase letters, unlike enum values like
+// `requires_payment_method`.
+func isID(s string) bool {
+	if !idRegex.MatchString(s) {
+		return false
+	}
+
+	suffix := s[strings.LastIndex(s, "_")+1:]
+
+	return strings.IndexAny(suffix, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ") >= 0
+}
+
+// quote returns a string in JSON, without escaping HTML characters like
+// json.Marshal does
+func quote(s string) string {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // #nosec G104
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,76 @@
+package eventformat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const event = `{
+  "id": "evt_1NG8Du2eZvKYlo2CUI79vXWy",
+  "object": "event",
+  "data": {
+    "object": {
+      "id": "pi_3NG8Dt2eZvKYlo2C1Xxc0Ih7",
+      "status": "succeeded",
+      "description": "<Order 6735 & co>",
+      "next_action": null,
+      "payment_method_types": ["card"]
+    },
+    "previous_attributes": {"status": "requires_confirmation", "amount_received": 0}
+  },
+  "livemode": false,
+  "request": {"id": "req_Fe8yAaXwnnAuaB", "idempotency_key": null},
+  "type": "payment_intent.succeeded"
+}`
+
+func TestFormat(t *testing.T) {
+	formatted, err := Format([]byte(event), Options{})
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "id": "evt_1NG8Du2eZvKYlo2CUI79vXWy",
+  "object": "event",
+  "data": {
+    "object": {
+      "id": "pi_3NG8Dt2eZvKYlo2C1Xxc0Ih7",
+      "status": "succeeded",
+      "description": "<Order 6735 & co>",
+      "next_action": null,
+      "payment_method_types": [
+        "card"
+      ]
+    },
+    "previous_attributes": {…} // 2 fields, --unfold previous_attributes
+  },
+  "livemode": false,
+  "request": {…}, // 2 fields, --unfold request
+  "type": "payment_intent.succeeded"
+}
+`, formatted)
+}
+
+func TestFormatUnfold(t *testing.T) {
+	formatted, err := Format([]byte(event), Options{Unfold: []string{"data.previous_attributes"}})
+	require.NoError(t, err)
+	require.Contains(t, formatted, `"previous_attributes": {
+      "status": "requires_confirmation",
+      "amount_received": 0
+    }`)
+	require.Contains(t, formatted, `"request": {…},`)
+
+	formatted, err = Format([]byte(event), Options{Unfold: []string{UnfoldAll}})
+	require.NoError(t, err)
+	require.NotContains(t, formatted, "--unfold")
+}
+
+func TestFormatInvalid(t *testing.T) {
+	_, err := Format([]byte(`{"id":`), Options{})
+	require.Error(t, err)
+}
+
+func TestIsID(t *testing.T) {
+	require.True(t, isID("evt_1NG8Du2eZvKYlo2CUI79vXWy"))
+	require.True(t, isID("cs_test_a1B2c3D4e5"))
+	require.False(t, isID("requires_payment_method"))
+	require.False(t, isID("payment_intent.succeeded"))
+}
@@ -0,0 +1,122 @@
+// Package experiments lets large new subsystems ship dark: they register an
+// experiment, and only run when the user enables it in the [experiments]
+// section of the config file, or with a STRIPE_EXPERIMENT_<NAME> environment
+// variable, which takes precedence.
+package experiments
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// Sources of the state of an experiment
+const (
+	SourceDefault = "default"
+	SourceConfig  = "config"
+	SourceEnv     = "env"
+)
+
+// Experiment is a subsystem disabled by default
+type Experiment struct {
+	Name        string
+	Description string
+}
+
+// State is whether an experiment is enabled, and where that comes from
+type State struct {
+	Experiment
+
+	Enabled bool
+	Source  string
+}
+
+var registry = make(map[string]Experiment)
+
+//
+// Public functions
+//
+
+// Register adds an experiment. It's meant to be called from the init
+// functions of the packages of the subsystems.
+func Register(e Experiment) {
+	if _, ok := registry[e.Name]; ok {
+		panic(fmt.Sprintf("experiment %s is already registered", e.Name))
+	}
+
+	registry[e.Name] = e
+}
+
+// Lookup returns the registered experiment called name
+func Lookup(name string) (Experiment, error) {
+	e, ok := registry[name]
+	if !ok {
+		return Experiment{}, fmt.Errorf("unknown experiment: %s. Run `stripe experiments list` to see the experiments", name)
+	}
+
+	return e, nil
+}
+
+// Enabled returns whether the experiment called name is enabled
+func Enabled(name string) bool {
+	e, err := Lookup(name)
+	if err != nil {
+		return false
+	}
+
+	return stateOf(e).Enabled
+}
+
+// List returns the state of the registered experiments, sorted by name
+func List() []State {
+	states := make([]State, 0, len(registry))
+	for _, e := range registry {
+		states = append(states, stateOf(e))
+	}
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+
+	return states
+}
+
+// EnabledNames returns the sorted names of the enabled experiments
+func EnabledNames() []string {
+	names := make([]string, 0)
+
+	for _, s := range List() {
+		if s.Enabled {
+			names = append(names, s.Name)
+		}
+	}
+
+	return names
+}
+
+// EnvName returns the environment variable enabling or disabling the
+// experiment called name, e.g. STRIPE_EXPERIMENT_DECLARATIVE_APPLY for
+// declarative-apply
+func EnvName(name string) string {
+	return "STRIPE_EXPERIMENT_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+//
+// Private functions
+//
+
+func stateOf(e Experiment) State {
+	if value, ok := os.LookupEnv(EnvName(e.Name)); ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			return State{Experiment: e, Enabled: enabled, Source: SourceEnv}
+		}
+	}
+
+	if enabled, ok := config.GetExperiment(e.Name); ok {
+		return State{Experiment: e, Enabled: enabled, Source: SourceConfig}
+	}
+
+	return State{Experiment: e, Enabled: false, Source: SourceDefault}
+}
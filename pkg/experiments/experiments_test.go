@@ -0,0 +1,39 @@
+package experiments
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperiments(t *testing.T) {
+	defer func() { registry = make(map[string]Experiment) }()
+	defer viper.Reset()
+
+	Register(Experiment{Name: "declarative-apply", Description: "stripe apply"})
+	Register(Experiment{Name: "tui", Description: "stripe tui"})
+
+	require.Panics(t, func() { Register(Experiment{Name: "tui"}) })
+
+	require.False(t, Enabled("tui"))
+	require.False(t, Enabled("unknown"))
+	require.Empty(t, EnabledNames())
+
+	viper.Set("experiments.tui", true)
+	viper.Set("experiments.declarative-apply", true)
+	require.True(t, Enabled("tui"))
+	require.Equal(t, []string{"declarative-apply", "tui"}, EnabledNames())
+
+	// The environment takes precedence over the config file
+	t.Setenv("STRIPE_EXPERIMENT_DECLARATIVE_APPLY", "false")
+
+	states := List()
+	require.Equal(t, []State{
+		{Experiment: Experiment{Name: "declarative-apply", Description: "stripe apply"}, Enabled: false, Source: SourceEnv},
+		{Experiment: Experiment{Name: "tui", Description: "stripe tui"}, Enabled: true, Source: SourceConfig},
+	}, states)
+
+	_, err := Lookup("unknown")
+	require.EqualError(t, err, "unknown experiment: unknown. Run `stripe experiments list` to see the experiments")
+}
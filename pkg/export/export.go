@@ -0,0 +1,242 @@
+// Package export writes lists of API objects to local files, as typed
+// tables mapped from the resource schemas of the OpenAPI specification.
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/simulate"
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+// The types of the columns
+const (
+	TypeBoolean   = "boolean"
+	TypeFloat     = "float"
+	TypeInteger   = "integer"
+	TypeJSON      = "json"
+	TypeString    = "string"
+	TypeTimestamp = "timestamp"
+)
+
+// timestampFields are the integer fields holding a Unix timestamp besides
+// `created` and the `*_at` ones. The specification doesn't tell them apart
+// from the other integers.
+var timestampFields = map[string]bool{
+	"arrival_date":         true,
+	"available_on":         true,
+	"current_period_end":   true,
+	"current_period_start": true,
+	"date":                 true,
+	"due_date":             true,
+	"period_end":           true,
+	"period_start":         true,
+	"start_date":           true,
+	"trial_end":            true,
+	"trial_start":          true,
+}
+
+//
+// Public types
+//
+
+// Column is a typed column of an exported table
+type Column struct {
+	Name string
+	Type string
+}
+
+// Writer writes the rows of an exported table
+type Writer interface {
+	// Write writes a row, with a value per column: nil, a bool, float64,
+	// int64, string or time.Time depending on the type of the column
+	Write(row []interface{}) error
+
+	// Close finishes writing the table
+	Close() error
+}
+
+//
+// Public functions
+//
+
+// Columns returns the columns of the table of the objects called object,
+// e.g. `charge`: scalar fields are typed, expandable fields hold the ID of
+// the object and the other fields their JSON.
+func Columns(schemas *spec.ResourceSchemas, object string) ([]Column, error) {
+	schema, ok := schemas.Schemas[object]
+	if !ok {
+		return nil, fmt.Errorf("no schema for %s objects", object)
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if name != "id" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	columns := make([]Column, 0, len(names)+1)
+	if _, ok := schema.Properties["id"]; ok {
+		columns = append(columns, Column{Name: "id", Type: TypeString})
+	}
+
+	for _, name := range names {
+		columns = append(columns, Column{Name: name, Type: columnType(name, schema.Properties[name])})
+	}
+
+	return columns, nil
+}
+
+// Row converts an object to the values of its row.
+func Row(columns []Column, object map[string]interface{}) []interface{} {
+	row := make([]interface{}, len(columns))
+
+	for i, column := range columns {
+		value, ok := object[column.Name]
+		if !ok || value == nil {
+			continue
+		}
+
+		row[i] = convert(column.Type, value)
+	}
+
+	return row
+}
+
+// Export writes the objects of the list at path, created since the given
+// time when it's not zero, to w. It calls progress with the number of
+// objects written after each page.
+func Export(ctx context.Context, client simulate.APIClient, path string, since time.Time, columns []Column, w Writer, progress func(int)) (int, error) {
+	count := 0
+	startingAfter := ""
+
+	for {
+		params := []string{"limit=100"}
+		if !since.IsZero() {
+			params = append(params, "created[gte]="+strconv.FormatInt(since.Unix(), 10))
+		}
+
+		if startingAfter != "" {
+			params = append(params, "starting_after="+startingAfter)
+		}
+
+		page, err := client.Request(ctx, http.MethodGet, path, params)
+		if err != nil {
+			return count, err
+		}
+
+		if page.Get("object").String() != "list" {
+			return count, fmt.Errorf("%s isn't a list", path)
+		}
+
+		data := page.Get("data").Array()
+		for _, item := range data {
+			object, ok := item.Value().(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if err := w.Write(Row(columns, object)); err != nil {
+				return count, err
+			}
+
+			count++
+		}
+
+		if progress != nil {
+			progress(count)
+		}
+
+		if !page.Get("has_more").Bool() || len(data) == 0 {
+			return count, nil
+		}
+
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+}
+
+//
+// Private functions
+//
+
+func columnType(name string, schema *spec.Schema) string {
+	if schema.Ref != "" {
+		return TypeJSON
+	}
+
+	// Expandable fields are either an ID or the object
+	if len(schema.AnyOf) > 0 {
+		for _, alternative := range schema.AnyOf {
+			if alternative.Type == "string" {
+				return TypeString
+			}
+		}
+
+		return TypeJSON
+	}
+
+	switch schema.Type {
+	case "boolean":
+		return TypeBoolean
+	case "integer":
+		if name == "created" || strings.HasSuffix(name, "_at") || timestampFields[name] {
+			return TypeTimestamp
+		}
+
+		return TypeInteger
+	case "number":
+		return TypeFloat
+	case "string":
+		return TypeString
+	}
+
+	return TypeJSON
+}
+
+func convert(columnType string, value interface{}) interface{} {
+	switch columnType {
+	case TypeBoolean:
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	case TypeFloat:
+		if f, ok := value.(float64); ok {
+			return f
+		}
+	case TypeInteger:
+		if f, ok := value.(float64); ok {
+			return int64(f)
+		}
+	case TypeTimestamp:
+		if f, ok := value.(float64); ok {
+			return time.Unix(int64(f), 0).UTC()
+		}
+	case TypeString:
+		switch v := value.(type) {
+		case string:
+			return v
+		case map[string]interface{}:
+			// An expanded object
+			if id, ok := v["id"].(string); ok {
+				return id
+			}
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	return string(data)
+}
@@ -0,0 +1,176 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+type fakeClient struct{}
+
+func (c *fakeClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	if strings.Contains(strings.Join(params, "&"), "starting_after=ch_2") {
+		return gjson.Parse(`{"object":"list","has_more":false,"data":[{"id":"ch_3","amount":300}]}`), nil
+	}
+
+	return gjson.Parse(`{"object":"list","has_more":true,"data":[{"id":"ch_1","amount":100},{"id":"ch_2","amount":200}]}`), nil
+}
+
+type memoryWriter struct {
+	rows [][]interface{}
+}
+
+func (w *memoryWriter) Write(row []interface{}) error {
+	w.rows = append(w.rows, row)
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	return nil
+}
+
+func TestColumns(t *testing.T) {
+	schemas, err := spec.LoadResourceSchemas()
+	require.NoError(t, err)
+
+	columns, err := Columns(schemas, "charge")
+	require.NoError(t, err)
+
+	types := make(map[string]string)
+	for _, column := range columns {
+		types[column.Name] = column.Type
+	}
+
+	require.Equal(t, Column{Name: "id", Type: TypeString}, columns[0])
+	require.Equal(t, TypeInteger, types["amount"])
+	require.Equal(t, TypeBoolean, types["captured"])
+	require.Equal(t, TypeTimestamp, types["created"])
+	require.Equal(t, TypeString, types["customer"])
+	require.Equal(t, TypeJSON, types["billing_details"])
+	require.Equal(t, TypeJSON, types["metadata"])
+
+	_, err = Columns(schemas, "unknown")
+	require.Error(t, err)
+}
+
+func TestRow(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: TypeString},
+		{Name: "amount", Type: TypeInteger},
+		{Name: "captured", Type: TypeBoolean},
+		{Name: "created", Type: TypeTimestamp},
+		{Name: "customer", Type: TypeString},
+		{Name: "metadata", Type: TypeJSON},
+		{Name: "description", Type: TypeString},
+	}
+
+	row := Row(columns, map[string]interface{}{
+		"id":          "ch_123",
+		"amount":      float64(2000),
+		"captured":    true,
+		"created":     float64(1700000000),
+		"customer":    map[string]interface{}{"id": "cus_123", "object": "customer"},
+		"metadata":    map[string]interface{}{"order_id": "6735"},
+		"description": nil,
+	})
+
+	require.Equal(t, []interface{}{
+		"ch_123",
+		int64(2000),
+		true,
+		time.Unix(1700000000, 0).UTC(),
+		"cus_123",
+		`{"order_id":"6735"}`,
+		nil,
+	}, row)
+}
+
+func TestExport(t *testing.T) {
+	w := &memoryWriter{}
+	columns := []Column{{Name: "id", Type: TypeString}, {Name: "amount", Type: TypeInteger}}
+
+	var progress []int
+
+	count, err := Export(context.Background(), &fakeClient{}, "/v1/charges", time.Time{}, columns, w, func(n int) {
+		progress = append(progress, n)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Equal(t, []int{2, 3}, progress)
+	require.Equal(t, []interface{}{"ch_3", int64(300)}, w.rows[2])
+}
+
+func TestSQLLiteral(t *testing.T) {
+	require.Equal(t, "NULL", sqlLiteral(nil))
+	require.Equal(t, "1", sqlLiteral(true))
+	require.Equal(t, "42", sqlLiteral(int64(42)))
+	require.Equal(t, "1.5", sqlLiteral(1.5))
+	require.Equal(t, "'2023-11-14 22:13:20'", sqlLiteral(time.Unix(1700000000, 0)))
+	require.Equal(t, "'Jenny''s order'", sqlLiteral("Jenny's order"))
+}
+
+func TestParquetQuery(t *testing.T) {
+	query := parquetQuery("/tmp/rows.ndjson", "charges.parquet", []Column{
+		{Name: "id", Type: TypeString},
+		{Name: "created", Type: TypeTimestamp},
+	})
+
+	require.Equal(t, "COPY (SELECT * FROM read_json('/tmp/rows.ndjson', format = 'newline_delimited', columns = {'id': 'VARCHAR', 'created': 'TIMESTAMP'})) TO 'charges.parquet' (FORMAT parquet);", query)
+}
+
+func TestSQLiteWriter(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 isn't installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "stripe.db")
+	columns := []Column{{Name: "id", Type: TypeString}, {Name: "amount", Type: TypeInteger}}
+
+	w, err := NewSQLiteWriter(path, "charges", columns)
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{"ch_1", int64(100)}))
+	require.NoError(t, w.Write([]interface{}{"ch_2", nil}))
+	require.NoError(t, w.Close())
+
+	out, err := exec.Command("sqlite3", path, "SELECT count(*), sum(amount) FROM charges").Output()
+	require.NoError(t, err)
+	require.Equal(t, "2|100\n", string(out))
+}
+
+func TestParquetWriter(t *testing.T) {
+	if _, err := exec.LookPath("duckdb"); err != nil {
+		t.Skip("duckdb isn't installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "charges.parquet")
+	columns := []Column{{Name: "id", Type: TypeString}, {Name: "amount", Type: TypeInteger}}
+
+	w, err := NewParquetWriter(path, columns)
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{"ch_1", int64(100)}))
+	require.NoError(t, w.Write([]interface{}{"ch_2", nil}))
+	require.NoError(t, w.Close())
+
+	out, err := exec.Command("duckdb", "-csv", "-noheader", "-c", fmt.Sprintf("SELECT count(*), sum(amount) FROM %s", stringLiteral(path))).Output() // #nosec G204
+	require.NoError(t, err)
+	require.Equal(t, "2,100\n", string(out))
+}
+
+func TestWritersRequireTheirProgram(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := NewParquetWriter(filepath.Join(t.TempDir(), "charges.parquet"), nil)
+	require.EqualError(t, err, "exporting to Parquet requires the DuckDB command-line client: https://duckdb.org/docs/installation")
+
+	_, err = NewSQLiteWriter(filepath.Join(t.TempDir(), "stripe.db"), "charges", nil)
+	require.EqualError(t, err, "exporting to SQLite requires the sqlite3 command-line shell: https://sqlite.org/download.html")
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+var duckDBTypes = map[string]string{
+	TypeBoolean:   "BOOLEAN",
+	TypeFloat:     "DOUBLE",
+	TypeInteger:   "BIGINT",
+	TypeJSON:      "JSON",
+	TypeString:    "VARCHAR",
+	TypeTimestamp: "TIMESTAMP",
+}
+
+type parquetWriter struct {
+	path    string
+	columns []Column
+
+	tmp *os.File
+	w   *bufio.Writer
+}
+
+// NewParquetWriter returns a Writer writing the rows to a Parquet file at
+// path. The rows are staged in a temporary NDJSON file that the DuckDB
+// command-line client, which must be installed, converts on Close.
+func NewParquetWriter(path string, columns []Column) (Writer, error) {
+	if _, err := exec.LookPath("duckdb"); err != nil {
+		return nil, fmt.Errorf("exporting to Parquet requires the DuckDB command-line client: https://duckdb.org/docs/installation")
+	}
+
+	tmp, err := ioutil.TempFile("", "stripe-export-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetWriter{
+		path:    path,
+		columns: columns,
+		tmp:     tmp,
+		w:       bufio.NewWriter(tmp),
+	}, nil
+}
+
+func (pw *parquetWriter) Write(row []interface{}) error {
+	object := make(map[string]interface{}, len(row))
+
+	for i, value := range row {
+		switch v := value.(type) {
+		case time.Time:
+			object[pw.columns[i].Name] = v.UTC().Format("2006-01-02 15:04:05")
+		case string:
+			if pw.columns[i].Type == TypeJSON {
+				object[pw.columns[i].Name] = json.RawMessage(v)
+			} else {
+				object[pw.columns[i].Name] = v
+			}
+		default:
+			object[pw.columns[i].Name] = v
+		}
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pw.w.Write(data); err != nil {
+		return err
+	}
+
+	return pw.w.WriteByte('\n')
+}
+
+func (pw *parquetWriter) Close() error {
+	defer os.Remove(pw.tmp.Name())
+
+	if err := pw.w.Flush(); err != nil {
+		pw.tmp.Close()
+		return err
+	}
+
+	if err := pw.tmp.Close(); err != nil {
+		return err
+	}
+
+	out, err := exec.Command("duckdb", "-c", parquetQuery(pw.tmp.Name(), pw.path, pw.columns)).CombinedOutput() // #nosec G204
+	if err != nil {
+		return fmt.Errorf("duckdb: %s", strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func parquetQuery(source, destination string, columns []Column) string {
+	types := make([]string, len(columns))
+	for i, column := range columns {
+		types[i] = fmt.Sprintf("%s: '%s'", stringLiteral(column.Name), duckDBTypes[column.Type])
+	}
+
+	return fmt.Sprintf(
+		"COPY (SELECT * FROM read_json(%s, format = 'newline_delimited', columns = {%s})) TO %s (FORMAT parquet);",
+		stringLiteral(source),
+		strings.Join(types, ", "),
+		stringLiteral(destination),
+	)
+}
+
+func stringLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
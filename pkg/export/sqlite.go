@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+var sqliteTypes = map[string]string{
+	TypeBoolean:   "INTEGER",
+	TypeFloat:     "REAL",
+	TypeInteger:   "INTEGER",
+	TypeJSON:      "TEXT",
+	TypeString:    "TEXT",
+	TypeTimestamp: "TEXT",
+}
+
+type sqliteWriter struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	w      *bufio.Writer
+	stderr bytes.Buffer
+
+	table   string
+	columns []Column
+}
+
+// NewSQLiteWriter returns a Writer replacing the table in the SQLite
+// database at path with the rows written. It runs the sqlite3 command-line
+// shell, which must be installed.
+func NewSQLiteWriter(path, table string, columns []Column) (Writer, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return nil, fmt.Errorf("exporting to SQLite requires the sqlite3 command-line shell: https://sqlite.org/download.html")
+	}
+
+	sw := &sqliteWriter{
+		cmd:     exec.Command("sqlite3", "-bail", path),
+		table:   table,
+		columns: columns,
+	}
+	sw.cmd.Stderr = &sw.stderr
+
+	stdin, err := sw.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	sw.stdin = stdin
+	sw.w = bufio.NewWriter(stdin)
+
+	if err := sw.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	definitions := make([]string, len(columns))
+	for i, column := range columns {
+		definitions[i] = quoteIdentifier(column.Name) + " " + sqliteTypes[column.Type]
+	}
+
+	fmt.Fprintf(sw.w, "BEGIN;\nDROP TABLE IF EXISTS %s;\nCREATE TABLE %s (%s);\n", quoteIdentifier(table), quoteIdentifier(table), strings.Join(definitions, ", "))
+
+	return sw, nil
+}
+
+func (sw *sqliteWriter) Write(row []interface{}) error {
+	values := make([]string, len(row))
+	for i, value := range row {
+		values[i] = sqlLiteral(value)
+	}
+
+	_, err := fmt.Fprintf(sw.w, "INSERT INTO %s VALUES (%s);\n", quoteIdentifier(sw.table), strings.Join(values, ", "))
+	if err != nil {
+		return sw.failure(err)
+	}
+
+	return nil
+}
+
+func (sw *sqliteWriter) Close() error {
+	_, err := sw.w.WriteString("COMMIT;\n")
+	if err == nil {
+		err = sw.w.Flush()
+	}
+
+	if err != nil {
+		sw.stdin.Close()
+		sw.cmd.Wait() // #nosec G104
+
+		return sw.failure(err)
+	}
+
+	sw.stdin.Close()
+
+	if err := sw.cmd.Wait(); err != nil {
+		return sw.failure(err)
+	}
+
+	return nil
+}
+
+// failure returns the error of sqlite3 when it reported one
+func (sw *sqliteWriter) failure(err error) error {
+	if message := strings.TrimSpace(sw.stderr.String()); message != "" {
+		return fmt.Errorf("sqlite3: %s", message)
+	}
+
+	return err
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sqlLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+
+		return "0"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return "'" + v.UTC().Format("2006-01-02 15:04:05") + "'"
+	case string:
+		return stringLiteral(v)
+	}
+
+	return "NULL"
+}
@@ -0,0 +1,198 @@
+package fixtures
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TriggerHistoryFileName is the name of the file in the config folder
+// recording the events triggered locally
+const TriggerHistoryFileName = "trigger_history.jsonl"
+
+// maxHistorySize is the size past which the history is compacted down to
+// its most recent half
+const maxHistorySize = 256 * 1024
+
+//
+// Public types
+//
+
+// TriggerRun is an event triggered locally
+type TriggerRun struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+}
+
+// EventCoverage tells whether an event handled by a webhook handler was
+// triggered recently
+type EventCoverage struct {
+	Event string `json:"event"`
+
+	// LastTriggered is the time of the last trigger of the event, zero if it
+	// wasn't triggered recently
+	LastTriggered time.Time `json:"last_triggered,omitempty"`
+
+	// Triggerable is whether `stripe trigger` supports the event
+	Triggerable bool `json:"triggerable"`
+}
+
+// Tested returns whether the event was triggered recently.
+func (c EventCoverage) Tested() bool {
+	return !c.LastTriggered.IsZero()
+}
+
+//
+// Public functions
+//
+
+// RecordTrigger appends a triggered event to the history at path.
+func RecordTrigger(path string, event string) error {
+	data, err := json.Marshal(TriggerRun{Event: event, Time: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return compactHistory(path)
+}
+
+// LastTriggers returns the time of the last trigger of each event in the
+// history at path, for the triggers since the given time.
+func LastTriggers(path string, since time.Time) (map[string]time.Time, error) {
+	last := make(map[string]time.Time)
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return last, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var run TriggerRun
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil || run.Time.Before(since) {
+			continue
+		}
+
+		if run.Time.After(last[run.Event]) {
+			last[run.Event] = run.Time
+		}
+	}
+
+	return last, scanner.Err()
+}
+
+// ParseHandlerEvents parses a list of event types, separated by new lines
+// or commas. Lines starting with # are ignored.
+func ParseHandlerEvents(data []byte) []string {
+	events := make([]string, 0)
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		text := strings.TrimSpace(string(line))
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		for _, event := range strings.Split(text, ",") {
+			if event = strings.TrimSpace(event); event != "" {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events
+}
+
+// Coverage compares the events handled by a webhook handler with the
+// events triggered recently. A `*` handler event stands for all the events
+// supported by `stripe trigger`. The untested events are listed first.
+func Coverage(handlerEvents []string, lastTriggers map[string]time.Time) []EventCoverage {
+	events := make(map[string]bool)
+
+	for _, event := range handlerEvents {
+		if event == "*" {
+			for name := range Events {
+				events[name] = true
+			}
+
+			continue
+		}
+
+		events[event] = true
+	}
+
+	coverage := make([]EventCoverage, 0, len(events))
+	for event := range events {
+		_, triggerable := Events[event]
+
+		coverage = append(coverage, EventCoverage{
+			Event:         event,
+			LastTriggered: lastTriggers[event],
+			Triggerable:   triggerable,
+		})
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Tested() != coverage[j].Tested() {
+			return !coverage[i].Tested()
+		}
+
+		return coverage[i].Event < coverage[j].Event
+	})
+
+	return coverage
+}
+
+//
+// Private functions
+//
+
+// compactHistory keeps the history from growing forever by dropping its
+// oldest half once it gets too large.
+func compactHistory(path string) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() <= maxHistorySize {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	kept := strings.Join(lines[len(lines)/2:], "")
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(kept), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
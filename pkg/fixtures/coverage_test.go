@@ -0,0 +1,55 @@
+package fixtures
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastTriggers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), TriggerHistoryFileName)
+
+	last, err := LastTriggers(path, time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, last)
+
+	require.NoError(t, RecordTrigger(path, "charge.captured"))
+	require.NoError(t, RecordTrigger(path, "invoice.paid"))
+
+	last, err = LastTriggers(path, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, last, 2)
+	require.False(t, last["charge.captured"].IsZero())
+
+	last, err = LastTriggers(path, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, last)
+}
+
+func TestParseHandlerEvents(t *testing.T) {
+	events := ParseHandlerEvents([]byte(`# events handled by the billing service
+invoice.paid
+customer.subscription.created, customer.subscription.deleted
+
+`))
+	require.Equal(t, []string{"invoice.paid", "customer.subscription.created", "customer.subscription.deleted"}, events)
+}
+
+func TestCoverage(t *testing.T) {
+	triggered := time.Now()
+
+	coverage := Coverage([]string{"invoice.paid", "charge.captured", "account.application.authorized"}, map[string]time.Time{
+		"charge.captured":        triggered,
+		"payment_intent.created": triggered,
+	})
+
+	require.Equal(t, []EventCoverage{
+		{Event: "account.application.authorized", Triggerable: false},
+		{Event: "invoice.paid", Triggerable: true},
+		{Event: "charge.captured", LastTriggered: triggered, Triggerable: true},
+	}, coverage)
+
+	require.Len(t, Coverage([]string{"*"}, nil), len(Events))
+}
@@ -0,0 +1,287 @@
+package fixtures
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// The functions in this file generate realistic looking test data for
+// fixtures, for demos and screenshots. Fixture values can contain
+// placeholders of the form:
+// 		{{fake.<field>}}
+// 		{{fake.<field>.<locale>}}
+//
+// for example {{fake.name}}, {{fake.email}} or {{fake.address.nl}}.
+//
+// All the placeholders of a fixture step describe the same fake person, so
+// that {{fake.name}} and {{fake.email}} match. The values only depend on
+// the seed, the step name and the locale, so running the same fixture file
+// with the same seed always creates the same data.
+
+//
+// Public types
+//
+
+// Faker replaces fake data placeholders in fixture values
+type Faker struct {
+	seed int64
+}
+
+//
+// Public functions
+//
+
+// NewFaker returns a faker whose values are derived from seed
+func NewFaker(seed int64) *Faker {
+	return &Faker{seed: seed}
+}
+
+// Replace replaces the fake data placeholders in value for the fixture step
+// with the given name.
+func (f *Faker) Replace(step, value string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	var replaceErr error
+
+	replaced := fakePlaceholder.ReplaceAllStringFunc(value, func(match string) string {
+		field := fakePlaceholder.FindStringSubmatch(match)[1]
+
+		fake, err := f.fake(step, field)
+		if err != nil && replaceErr == nil {
+			replaceErr = err
+		}
+
+		return fake
+	})
+	if replaceErr != nil {
+		return "", replaceErr
+	}
+
+	return replaced, nil
+}
+
+//
+// Private types
+//
+
+type fakeLocale struct {
+	firstNames      []string
+	lastNames       []string
+	streets         []string
+	cities          []string
+	companySuffixes []string
+	country         string
+
+	// The formats of the generated values. Address lines get the street and
+	// house number, postal codes and phone numbers get random digits in place
+	// of '#' and random uppercase letters in place of '?'
+	addressFormat    string
+	postalCodeFormat string
+	phoneFormat      string
+}
+
+// fakePerson holds the values of the placeholders of a fixture step
+type fakePerson struct {
+	firstName  string
+	lastName   string
+	company    string
+	line1      string
+	city       string
+	postalCode string
+	country    string
+	phone      string
+}
+
+//
+// Private constants
+//
+
+const defaultFakeLocale = "en"
+
+//
+// Private variables
+//
+
+var fakePlaceholder = regexp.MustCompile(`{{\s*fake\.([a-z0-9_.]+)\s*}}`)
+
+var fakeLocales = map[string]fakeLocale{
+	"en": {
+		firstNames:       []string{"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael", "Linda", "David", "Elizabeth"},
+		lastNames:        []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Miller", "Davis", "Wilson", "Anderson", "Taylor"},
+		streets:          []string{"Main Street", "Oak Avenue", "Maple Drive", "Cedar Lane", "Park Road", "Elm Street", "Washington Avenue", "Lake Drive"},
+		cities:           []string{"Springfield", "Portland", "Madison", "Austin", "Denver", "Columbus", "Raleigh", "Sacramento"},
+		companySuffixes:  []string{"Inc.", "LLC", "Co."},
+		country:          "US",
+		addressFormat:    "%[2]s %[1]s",
+		postalCodeFormat: "#####",
+		phoneFormat:      "+1 555-01##",
+	},
+	"de": {
+		firstNames:       []string{"Lukas", "Anna", "Leon", "Lea", "Felix", "Laura", "Jonas", "Julia", "Paul", "Sophie"},
+		lastNames:        []string{"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker", "Schulz", "Hoffmann"},
+		streets:          []string{"Hauptstraße", "Schulstraße", "Gartenstraße", "Bahnhofstraße", "Dorfstraße", "Bergstraße", "Lindenstraße"},
+		cities:           []string{"Berlin", "Hamburg", "München", "Köln", "Frankfurt am Main", "Stuttgart", "Leipzig", "Dresden"},
+		companySuffixes:  []string{"GmbH", "AG", "KG"},
+		country:          "DE",
+		addressFormat:    "%[1]s %[2]s",
+		postalCodeFormat: "#####",
+		phoneFormat:      "+49 151 ########",
+	},
+	"fr": {
+		firstNames:       []string{"Gabriel", "Emma", "Louis", "Jade", "Raphaël", "Louise", "Jules", "Alice", "Adam", "Chloé"},
+		lastNames:        []string{"Martin", "Bernard", "Dubois", "Thomas", "Robert", "Richard", "Petit", "Durand", "Leroy", "Moreau"},
+		streets:          []string{"rue de la Paix", "rue Victor Hugo", "avenue Jean Jaurès", "rue de la République", "boulevard Pasteur", "rue du Moulin"},
+		cities:           []string{"Paris", "Lyon", "Marseille", "Toulouse", "Nantes", "Bordeaux", "Lille", "Strasbourg"},
+		companySuffixes:  []string{"SARL", "SA", "SAS"},
+		country:          "FR",
+		addressFormat:    "%[2]s %[1]s",
+		postalCodeFormat: "#####",
+		phoneFormat:      "+33 6 ## ## ## ##",
+	},
+	"nl": {
+		firstNames:       []string{"Daan", "Emma", "Sem", "Julia", "Lucas", "Sanne", "Milan", "Tess", "Bram", "Lotte"},
+		lastNames:        []string{"de Jong", "Jansen", "de Vries", "van den Berg", "Bakker", "Visser", "Smit", "Meijer", "de Boer", "Mulder"},
+		streets:          []string{"Kerkstraat", "Dorpsstraat", "Schoolstraat", "Molenweg", "Stationsweg", "Julianalaan", "Beatrixstraat"},
+		cities:           []string{"Amsterdam", "Rotterdam", "Utrecht", "Den Haag", "Eindhoven", "Groningen", "Haarlem", "Leiden"},
+		companySuffixes:  []string{"B.V.", "N.V."},
+		country:          "NL",
+		addressFormat:    "%[1]s %[2]s",
+		postalCodeFormat: "#### ??",
+		phoneFormat:      "+31 6 ## ## ## ##",
+	},
+}
+
+// emailReplacer turns names into the local part of email addresses
+var emailReplacer = strings.NewReplacer(
+	" ", "", "'", "",
+	"ä", "a", "ö", "o", "ü", "u", "ß", "ss",
+	"à", "a", "â", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e", "ï", "i", "î", "i", "ô", "o", "ù", "u", "û", "u",
+)
+
+//
+// Private functions
+//
+
+func (f *Faker) fake(step, field string) (string, error) {
+	locale := defaultFakeLocale
+
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		if _, ok := fakeLocales[field[i+1:]]; ok {
+			field, locale = field[:i], field[i+1:]
+		}
+	}
+
+	p := f.person(step, locale)
+
+	switch field {
+	case "name":
+		return p.firstName + " " + p.lastName, nil
+	case "first_name":
+		return p.firstName, nil
+	case "last_name":
+		return p.lastName, nil
+	case "email":
+		return fmt.Sprintf("%s.%s@example.com", emailReplacer.Replace(strings.ToLower(p.firstName)), emailReplacer.Replace(strings.ToLower(p.lastName))), nil
+	case "phone":
+		return p.phone, nil
+	case "company":
+		return p.company, nil
+	case "address", "address.line1":
+		return p.line1, nil
+	case "address.city":
+		return p.city, nil
+	case "address.postal_code":
+		return p.postalCode, nil
+	case "address.country":
+		return p.country, nil
+	default:
+		return "", fmt.Errorf("unknown fake data field %q, expected one of %s", field, strings.Join(fakeFields(), ", "))
+	}
+}
+
+// person generates the fake person of a fixture step. It only depends on the
+// seed, step and locale so that the values don't depend on the order the
+// fixture parameters are read in.
+func (f *Faker) person(step, locale string) fakePerson {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%s", f.seed, step, locale)
+	r := rand.New(rand.NewSource(int64(h.Sum64()))) // #nosec G404
+
+	l := fakeLocales[locale]
+	pick := func(values []string) string { return values[r.Intn(len(values))] }
+
+	p := fakePerson{
+		firstName: pick(l.firstNames),
+		lastName:  pick(l.lastNames),
+		city:      pick(l.cities),
+		country:   l.country,
+	}
+
+	p.company = fmt.Sprintf("%s %s", pick(l.lastNames), pick(l.companySuffixes))
+	p.line1 = fmt.Sprintf(l.addressFormat, pick(l.streets), fmt.Sprint(1+r.Intn(200)))
+	p.postalCode = fillFormat(r, l.postalCodeFormat)
+	p.phone = fillFormat(r, l.phoneFormat)
+
+	return p
+}
+
+// fillFormat replaces '#' with random digits and '?' with random uppercase
+// letters
+func fillFormat(r *rand.Rand, format string) string {
+	var sb strings.Builder
+
+	for _, c := range format {
+		switch c {
+		case '#':
+			sb.WriteByte(byte('0' + r.Intn(10)))
+		case '?':
+			sb.WriteByte(byte('A' + r.Intn(26)))
+		default:
+			sb.WriteRune(c)
+		}
+	}
+
+	return sb.String()
+}
+
+func fakeFields() []string {
+	return []string{"address", "address.city", "address.country", "address.line1", "address.postal_code", "company", "email", "first_name", "last_name", "name", "phone"}
+}
+
+// fakeValues returns a copy of params with the fake data placeholders of the
+// string values replaced.
+func (f *Faker) fakeValues(step string, params interface{}) (interface{}, error) {
+	switch v := params.(type) {
+	case string:
+		return f.Replace(step, v)
+	case map[string]interface{}:
+		replaced := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			r, err := f.fakeValues(step, value)
+			if err != nil {
+				return nil, err
+			}
+			replaced[key] = r
+		}
+
+		return replaced, nil
+	case []interface{}:
+		replaced := make([]interface{}, len(v))
+		for i, value := range v {
+			r, err := f.fakeValues(step, value)
+			if err != nil {
+				return nil, err
+			}
+			replaced[i] = r
+		}
+
+		return replaced, nil
+	default:
+		return params, nil
+	}
+}
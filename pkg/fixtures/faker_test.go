@@ -0,0 +1,114 @@
+package fixtures
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeFixture = `
+{
+	"_meta": {
+		"template_version": 0,
+		"exclude_metadata": true
+	},
+	"fixtures": [
+		{
+			"name": "customer",
+			"path": "/v1/customers",
+			"method": "post",
+			"params": {
+				"name": "{{fake.name}}",
+				"email": "{{fake.email}}",
+				"address": {
+					"line1": "{{fake.address.nl}}",
+					"postal_code": "{{fake.address.postal_code.nl}}",
+					"country": "{{fake.address.country.nl}}"
+				}
+			}
+		}
+	]
+}`
+
+func TestFakerIsDeterministic(t *testing.T) {
+	const template = "{{fake.name}} <{{fake.email}}>"
+
+	a, err := NewFaker(42).Replace("customer", template)
+	require.NoError(t, err)
+
+	b, err := NewFaker(42).Replace("customer", template)
+	require.NoError(t, err)
+
+	require.Equal(t, a, b)
+	require.NotContains(t, a, "{{")
+
+	// Other seeds create other people
+	values := make(map[string]bool)
+	for seed := int64(0); seed < 10; seed++ {
+		value, err := NewFaker(seed).Replace("customer", template)
+		require.NoError(t, err)
+		values[value] = true
+	}
+
+	require.Greater(t, len(values), 1)
+}
+
+func TestFakerLocales(t *testing.T) {
+	faker := NewFaker(1)
+
+	postalCode, err := faker.Replace("step", "{{fake.address.postal_code.nl}}")
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^\d{4} [A-Z]{2}$`), postalCode)
+
+	country, err := faker.Replace("step", "{{ fake.address.country.de }}")
+	require.NoError(t, err)
+	require.Equal(t, "DE", country)
+
+	country, err = faker.Replace("step", "{{fake.address.country}}")
+	require.NoError(t, err)
+	require.Equal(t, "US", country)
+
+	email, err := NewFaker(3).Replace("step", "{{fake.email.fr}}")
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^[a-z]+\.[a-z]+@example\.com$`), email)
+}
+
+func TestFakerUnknownField(t *testing.T) {
+	_, err := NewFaker(1).Replace("step", "{{fake.ssn}}")
+	require.EqualError(t, err, `unknown fake data field "ssn", expected one of address, address.city, address.country, address.line1, address.postal_code, company, email, first_name, last_name, name, phone`)
+
+	value, err := NewFaker(1).Replace("step", "{{not.fake}}")
+	require.NoError(t, err)
+	require.Equal(t, "{{not.fake}}", value)
+}
+
+func TestMakeRequestWithFakeData(t *testing.T) {
+	var bodies []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		bodies = append(bodies, req.PostForm.Encode())
+		res.Write([]byte(`{"id": "cus_12345"}`))
+	}))
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		fxt, err := NewFixtureFromRawString(afero.NewMemMapFs(), apiKey, "", ts.URL, fakeFixture)
+		require.NoError(t, err)
+		fxt.Faker = NewFaker(7)
+		fxt.SuppressOutput = true
+
+		_, err = fxt.Execute(context.Background())
+		require.NoError(t, err)
+	}
+
+	require.Len(t, bodies, 2)
+	require.Equal(t, bodies[0], bodies[1])
+	require.NotContains(t, bodies[0], "fake")
+	require.Contains(t, bodies[0], "address%5Bcountry%5D=NL")
+}
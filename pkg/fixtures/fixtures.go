@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -13,9 +14,12 @@ import (
 
 	"github.com/imdario/mergo"
 	"github.com/joho/godotenv"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"github.com/tidwall/gjson"
 
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/progress"
 	"github.com/stripe/stripe-cli/pkg/requests"
 )
 
@@ -50,16 +54,25 @@ type fixtureQuery struct {
 
 // Fixture contains a mapping of an individual fixtures responses for querying
 type Fixture struct {
-	Fs            afero.Fs
-	APIKey        string
-	StripeAccount string
-	Skip          []string
-	Overrides     map[string]interface{}
-	Additions     map[string]interface{}
-	Removals      map[string]interface{}
-	BaseURL       string
-	responses     map[string]gjson.Result
-	fixture       fixtureFile
+	Fs             afero.Fs
+	APIKey         string
+	StripeAccount  string
+	Skip           []string
+	Overrides      map[string]interface{}
+	Additions      map[string]interface{}
+	Removals       map[string]interface{}
+	BaseURL        string
+	SuppressOutput bool
+	Faker          *Faker
+
+	// Out receives the progress of the fixture (default: os.Stdout)
+	Out io.Writer
+	// Log receives the debug logs of the requests (default: the standard
+	// logger)
+	Log *log.Logger
+
+	responses map[string]gjson.Result
+	fixture   fixtureFile
 }
 
 // NewFixtureFromFile creates a to later run steps for populating test data
@@ -70,6 +83,7 @@ func NewFixtureFromFile(fs afero.Fs, apiKey, stripeAccount, baseURL, file string
 		StripeAccount: stripeAccount,
 		Skip:          skip,
 		BaseURL:       baseURL,
+		Faker:         NewFaker(time.Now().UnixNano()),
 		responses:     make(map[string]gjson.Result),
 	}
 
@@ -99,9 +113,17 @@ func NewFixtureFromFile(fs afero.Fs, apiKey, stripeAccount, baseURL, file string
 	}
 
 	// Customize fixture data
-	fxt.Override(override)
-	fxt.Add(add)
-	fxt.Remove(remove)
+	if err := fxt.Override(override); err != nil {
+		return nil, err
+	}
+
+	if err := fxt.Add(add); err != nil {
+		return nil, err
+	}
+
+	if err := fxt.Remove(remove); err != nil {
+		return nil, err
+	}
 
 	if fxt.fixture.Meta.Version > SupportedVersions {
 		return nil, fmt.Errorf("Fixture version not supported: %s", fmt.Sprint(fxt.fixture.Meta.Version))
@@ -118,6 +140,7 @@ func NewFixtureFromRawString(fs afero.Fs, apiKey, stripeAccount, baseURL, raw st
 		StripeAccount: stripeAccount,
 		Skip:          []string{},
 		BaseURL:       baseURL,
+		Faker:         NewFaker(time.Now().UnixNano()),
 		responses:     make(map[string]gjson.Result),
 	}
 
@@ -143,21 +166,27 @@ func (fxt *Fixture) GetFixtureFileContent() string {
 }
 
 // Override forcefully overrides fields with existing data on a fixture
-func (fxt *Fixture) Override(overrides []string) {
-	data := buildRewrites(overrides, false)
+func (fxt *Fixture) Override(overrides []string) error {
+	data, err := buildRewrites(overrides, false)
+	if err != nil {
+		return err
+	}
+
 	for _, f := range fxt.fixture.Fixtures {
 		if _, ok := data[f.Name]; ok {
 			if err := mergo.Merge(&f.Params, data[f.Name], mergo.WithOverride); err != nil {
-				fmt.Println(err)
+				return err
 			}
 		}
 	}
+
+	return nil
 }
 
 // Add safely only adds any missing fields that do not already exist.
 // If the field is already on the fixture, it does not get copied
 // over. For that, `Override` should be used
-func (fxt *Fixture) Add(additions []string) {
+func (fxt *Fixture) Add(additions []string) error {
 	// If the params is empty, initialize it before merging with added data
 	for i, data := range fxt.fixture.Fixtures {
 		if data.Method == "post" && data.Params == nil {
@@ -165,19 +194,29 @@ func (fxt *Fixture) Add(additions []string) {
 		}
 	}
 
-	data := buildRewrites(additions, false)
+	data, err := buildRewrites(additions, false)
+	if err != nil {
+		return err
+	}
+
 	for _, f := range fxt.fixture.Fixtures {
 		if _, ok := data[f.Name]; ok {
 			if err := mergo.Merge(&f.Params, data[f.Name]); err != nil {
-				fmt.Println(err)
+				return err
 			}
 		}
 	}
+
+	return nil
 }
 
 // Remove removes fields from the fixture
-func (fxt *Fixture) Remove(removals []string) {
-	data := buildRewrites(removals, true)
+func (fxt *Fixture) Remove(removals []string) error {
+	data, err := buildRewrites(removals, true)
+	if err != nil {
+		return err
+	}
+
 	for _, f := range fxt.fixture.Fixtures {
 		if _, ok := data[f.Name]; ok {
 			for remove := range data[f.Name].(map[string]interface{}) {
@@ -185,22 +224,27 @@ func (fxt *Fixture) Remove(removals []string) {
 			}
 		}
 	}
+
+	return nil
 }
 
 // Execute takes the parsed fixture file and runs through all the requests
 // defined to populate the user's account
 func (fxt *Fixture) Execute(ctx context.Context) ([]string, error) {
 	requestNames := make([]string, len(fxt.fixture.Fixtures))
+	total := len(fxt.fixture.Fixtures)
 	for i, data := range fxt.fixture.Fixtures {
 		if isNameIn(data.Name, fxt.Skip) {
-			fmt.Printf("Skipping fixture for: %s\n", data.Name)
+			fxt.printf("Skipping fixture for: %s\n", data.Name)
+			progress.Report("fixtures", data.Name, i*100/total, "Skipping fixture for: "+data.Name)
 			continue
 		}
 
-		fmt.Printf("Setting up fixture for: %s\n", data.Name)
+		fxt.printf("Setting up fixture for: %s\n", data.Name)
 		requestNames[i] = data.Name
 
-		fmt.Printf("Running fixture for: %s\n", data.Name)
+		fxt.printf("Running fixture for: %s\n", data.Name)
+		progress.Report("fixtures", data.Name, i*100/total, "Running fixture for: "+data.Name)
 		resp, err := fxt.makeRequest(ctx, data)
 		if err != nil && !errWasExpected(err, data.ExpectedErrorType) {
 			return nil, err
@@ -209,9 +253,24 @@ func (fxt *Fixture) Execute(ctx context.Context) ([]string, error) {
 		fxt.responses[data.Name] = gjson.ParseBytes(resp)
 	}
 
+	progress.Report("fixtures", "done", 100, "")
+
 	return requestNames, nil
 }
 
+func (fxt *Fixture) printf(format string, a ...interface{}) {
+	if fxt.SuppressOutput || ansi.Quiet {
+		return
+	}
+
+	out := fxt.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	fmt.Fprintf(out, format, a...)
+}
+
 func errWasExpected(err error, expectedErrorType string) bool {
 	if rerr, ok := err.(requests.RequestError); ok {
 		return rerr.ErrorType == expectedErrorType
@@ -242,6 +301,7 @@ func (fxt *Fixture) makeRequest(ctx context.Context, data fixture) ([]byte, erro
 		Method:         strings.ToUpper(data.Method),
 		SuppressOutput: true,
 		APIBaseURL:     fxt.BaseURL,
+		Log:            fxt.Log,
 		Parameters:     rp,
 	}
 
@@ -251,7 +311,21 @@ func (fxt *Fixture) makeRequest(ctx context.Context, data fixture) ([]byte, erro
 		return make([]byte, 0), err
 	}
 
-	params, err := fxt.createParams(data.Params)
+	var rawParams interface{} = data.Params
+
+	if fxt.Faker != nil {
+		path, err = fxt.Faker.Replace(data.Name, path)
+		if err != nil {
+			return make([]byte, 0), err
+		}
+
+		rawParams, err = fxt.Faker.fakeValues(data.Name, data.Params)
+		if err != nil {
+			return make([]byte, 0), err
+		}
+	}
+
+	params, err := fxt.createParams(rawParams)
 
 	if err != nil {
 		return make([]byte, 0), err
@@ -273,24 +347,34 @@ func (fxt *Fixture) createParams(params interface{}) (*requests.RequestParameter
 	return &requestParams, nil
 }
 
-func getEnvVar(query fixtureQuery) (string, error) {
+// getEnvVar returns the value of an env var, from the environment or else
+// from the .env file of the current directory. The .env file isn't loaded in
+// the environment of the process, which may be a program embedding the CLI.
+func (fxt *Fixture) getEnvVar(query fixtureQuery) (string, error) {
 	key := query.Query
 	// Check if env variable is present
 	envValue := os.Getenv(key)
 	if envValue == "" {
-		// Try to load from .env file
+		// Try to read it from .env file
 		dir, err := os.Getwd()
 		if err != nil {
 			dir = ""
 		}
-		err = godotenv.Load(path.Join(dir, ".env"))
+
+		file, err := fxt.Fs.Open(path.Join(dir, ".env"))
+		if err != nil {
+			return "", nil
+		}
+		defer file.Close()
+
+		dotenv, err := godotenv.Parse(file)
 		if err != nil {
 			return "", nil
 		}
-		envValue = os.Getenv(key)
+		envValue = dotenv[key]
 	}
 	if envValue == "" {
-		fmt.Printf("No value for env var: %s\n", key)
+		fxt.printf("No value for env var: %s\n", key)
 		return "", nil
 	}
 
@@ -356,7 +440,7 @@ func isNameIn(name string, skip []string) bool {
 // changes for the same fixture.
 //
 // The query supported is <fixture_name>:path.to.field=value
-func buildRewrites(changes []string, toRemove bool) map[string]interface{} {
+func buildRewrites(changes []string, toRemove bool) (map[string]interface{}, error) {
 	builtChanges := make(map[string]interface{})
 	for _, change := range changes {
 		if change == "" {
@@ -391,14 +475,14 @@ func buildRewrites(changes []string, toRemove bool) map[string]interface{} {
 		_, ok := builtChanges[name]
 		if ok {
 			if err := mergo.Merge(&keyMap, builtChanges[name]); err != nil {
-				fmt.Println(err)
+				return nil, err
 			}
 		}
 
 		builtChanges[name] = keyMap
 	}
 
-	return builtChanges
+	return builtChanges, nil
 }
 
 // pop returns the last item and the rest of the list minus the last item
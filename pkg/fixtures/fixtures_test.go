@@ -453,3 +453,13 @@ func TestExecuteReturnsRequestNames(t *testing.T) {
 	expectedResponseNames := []string{"cust_bender", "char_bender", "capt_bender"}
 	assert.Equal(t, expectedResponseNames, requestNames)
 }
+
+func TestTriggerFixturesAreValid(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	for _, event := range EventNames() {
+		fxt, err := BuildFromEvent(fs, apiKey, "", "", event, []string{}, []string{}, []string{}, []string{})
+		require.NoError(t, err, event)
+		require.NotEmpty(t, fxt.fixture.Fixtures, event)
+	}
+}
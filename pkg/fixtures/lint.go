@@ -0,0 +1,254 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+//
+// Public types
+//
+
+// LintProblem is a problem found in a fixture file by Lint
+type LintProblem struct {
+	// Path is the location of the problem in the fixture file, e.g.
+	// `fixtures[1].params.customer`
+	Path    string
+	Message string
+
+	// Warning is set for problems that don't prevent the fixture from running
+	Warning bool
+
+	// Deprecated is set for the usages of deprecated operations or parameters
+	Deprecated bool
+}
+
+func (p LintProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+//
+// Public functions
+//
+
+// Lint checks a fixture file: its structure, that the steps only reference
+// previous steps, and that no deprecated operations or parameters are used.
+// Deprecations are only checked when schemas isn't nil.
+func Lint(data []byte, schemas *spec.ResourceSchemas) []LintProblem {
+	l := &linter{schemas: schemas, declared: make(map[string]bool)}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []LintProblem{{Path: "$", Message: fmt.Sprintf("invalid JSON: %v", err)}}
+	}
+
+	l.checkKeys("", raw, "_meta", "fixtures", "env")
+
+	if meta, ok := raw["_meta"]; ok {
+		l.lintMeta(meta)
+	}
+
+	steps, ok := raw["fixtures"].([]interface{})
+	if !ok {
+		l.errorf("fixtures", "is required and must be a list of steps")
+	}
+
+	for i, step := range steps {
+		l.lintStep(fmt.Sprintf("fixtures[%d]", i), step)
+	}
+
+	if env, ok := raw["env"]; ok {
+		values, ok := env.(map[string]interface{})
+		if !ok {
+			l.errorf("env", "must be an object")
+		}
+
+		for _, key := range sortedKeys(values) {
+			l.lintReferences("env."+key, values[key])
+		}
+	}
+
+	if formatted, err := Format(data); err == nil && !bytes.Equal(formatted, data) {
+		l.warnf("$", "is not formatted, run with --fix to format it")
+	}
+
+	return l.problems
+}
+
+// Format indents a fixture file with two spaces, keeping the order of the
+// keys.
+func Format(data []byte) ([]byte, error) {
+	var formatted bytes.Buffer
+
+	if err := json.Indent(&formatted, bytes.TrimSpace(data), "", "  "); err != nil {
+		return nil, err
+	}
+
+	formatted.WriteByte('\n')
+
+	return formatted.Bytes(), nil
+}
+
+//
+// Private types
+//
+
+type linter struct {
+	schemas  *spec.ResourceSchemas
+	declared map[string]bool
+	problems []LintProblem
+}
+
+//
+// Private functions
+//
+
+func (l *linter) lintMeta(meta interface{}) {
+	values, ok := meta.(map[string]interface{})
+	if !ok {
+		l.errorf("_meta", "must be an object")
+		return
+	}
+
+	l.checkKeys("_meta", values, "template_version", "exclude_metadata")
+
+	if version, ok := values["template_version"]; ok {
+		number, ok := version.(float64)
+		if !ok {
+			l.errorf("_meta.template_version", "must be a number")
+		} else if int(number) > SupportedVersions {
+			l.errorf("_meta.template_version", "version %d is not supported, the latest supported version is %d", int(number), SupportedVersions)
+		}
+	}
+
+	if _, ok := values["exclude_metadata"]; ok {
+		if _, ok := values["exclude_metadata"].(bool); !ok {
+			l.errorf("_meta.exclude_metadata", "must be a boolean")
+		}
+	}
+}
+
+func (l *linter) lintStep(path string, step interface{}) {
+	values, ok := step.(map[string]interface{})
+	if !ok {
+		l.errorf(path, "must be an object")
+		return
+	}
+
+	l.checkKeys(path, values, "name", "expected_error_type", "path", "method", "params")
+
+	name, _ := values["name"].(string)
+	switch {
+	case name == "":
+		l.errorf(path+".name", "is required")
+	case l.declared[name]:
+		l.warnf(path+".name", "%q is already the name of a previous step, later references get the response of this step", name)
+	}
+
+	method, _ := values["method"].(string)
+	switch strings.ToLower(method) {
+	case "get", "post", "delete":
+		if method != strings.ToLower(method) {
+			l.warnf(path+".method", "should be lowercase")
+		}
+	case "":
+		l.errorf(path+".method", "is required")
+	default:
+		l.errorf(path+".method", "%q is not one of get, post or delete", method)
+	}
+
+	requestPath, _ := values["path"].(string)
+	if !strings.HasPrefix(requestPath, "/") {
+		l.errorf(path+".path", "is required and must start with /")
+	}
+
+	l.lintReferences(path+".path", values["path"])
+
+	params, ok := values["params"].(map[string]interface{})
+	if _, set := values["params"]; set && !ok {
+		l.errorf(path+".params", "must be an object")
+	}
+
+	for _, key := range sortedKeys(params) {
+		l.lintReferences(path+".params."+key, params[key])
+	}
+
+	if l.schemas != nil && requestPath != "" && method != "" {
+		if l.schemas.IsDeprecated(method, requestPath, "") {
+			l.deprecatedf(path, "%s %s is deprecated", strings.ToUpper(method), requestPath)
+		}
+
+		for _, key := range sortedKeys(params) {
+			if l.schemas.IsDeprecated(method, requestPath, key) {
+				l.deprecatedf(path+".params."+key, "is deprecated")
+			}
+		}
+	}
+
+	// Steps can only reference the steps before them
+	if name != "" {
+		l.declared[name] = true
+	}
+}
+
+// lintReferences checks that the queries of a value, like `${customer:id}`,
+// reference previous steps.
+func (l *linter) lintReferences(path string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		r, ok := matchFixtureQuery(v)
+		if !ok {
+			return
+		}
+
+		for _, match := range r.FindAllStringSubmatch(v, -1) {
+			if name := match[1]; name != ".env" && !l.declared[name] {
+				l.errorf(path, "references %q, which is not the name of a previous step", name)
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range sortedKeys(v) {
+			l.lintReferences(path+"."+key, v[key])
+		}
+	case []interface{}:
+		for i, item := range v {
+			l.lintReferences(fmt.Sprintf("%s[%d]", path, i), item)
+		}
+	}
+}
+
+func (l *linter) checkKeys(path string, values map[string]interface{}, known ...string) {
+	for _, key := range sortedKeys(values) {
+		if !isNameIn(key, known) {
+			l.errorf(strings.TrimPrefix(path+"."+key, "."), "unknown field")
+		}
+	}
+}
+
+func (l *linter) errorf(path, format string, a ...interface{}) {
+	l.problems = append(l.problems, LintProblem{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+func (l *linter) warnf(path, format string, a ...interface{}) {
+	l.problems = append(l.problems, LintProblem{Path: path, Message: fmt.Sprintf(format, a...), Warning: true})
+}
+
+func (l *linter) deprecatedf(path, format string, a ...interface{}) {
+	l.problems = append(l.problems, LintProblem{Path: path, Message: fmt.Sprintf(format, a...), Warning: true, Deprecated: true})
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,94 @@
+package fixtures
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+func TestLintTriggerFixtures(t *testing.T) {
+	schemas, err := spec.LoadResourceSchemas()
+	require.NoError(t, err)
+
+	for _, file := range Events {
+		f, err := triggers.Open(file)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+
+		for _, problem := range Lint(data, schemas) {
+			require.True(t, problem.Warning, "%s: %s", file, problem)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	schemas, err := spec.LoadResourceSchemas()
+	require.NoError(t, err)
+
+	problems := Lint([]byte(`{
+  "_meta": {"template_version": 1, "colour": "blue"},
+  "fixtures": [
+    {
+      "name": "session",
+      "path": "/v1/checkout/sessions",
+      "method": "POST",
+      "params": {"customer": "${cust:id}", "shipping_rates": ["shr_123"]}
+    },
+    {
+      "name": "cust",
+      "path": "/v1/customers",
+      "method": "put"
+    },
+    {
+      "name": "cust",
+      "path": "v1/customers/${cust:id}/sources/${card:id}",
+      "method": "get"
+    },
+    {
+      "name": "recipient",
+      "path": "/v1/recipients/${cust:id}",
+      "method": "get",
+      "params": {"description": "${.env:DESCRIPTION}"}
+    }
+  ]
+}`), schemas)
+
+	require.Equal(t, []LintProblem{
+		{Path: "_meta.colour", Message: "unknown field"},
+		{Path: "_meta.template_version", Message: "version 1 is not supported, the latest supported version is 0"},
+		{Path: "fixtures[0].method", Message: "should be lowercase", Warning: true},
+		{Path: "fixtures[0].params.customer", Message: `references "cust", which is not the name of a previous step`},
+		{Path: "fixtures[0].params.shipping_rates", Message: "is deprecated", Warning: true, Deprecated: true},
+		{Path: "fixtures[1].method", Message: `"put" is not one of get, post or delete`},
+		{Path: "fixtures[2].name", Message: `"cust" is already the name of a previous step, later references get the response of this step`, Warning: true},
+		{Path: "fixtures[2].path", Message: "is required and must start with /"},
+		{Path: "fixtures[2].path", Message: `references "card", which is not the name of a previous step`},
+		{Path: "fixtures[3]", Message: "GET /v1/recipients/${cust:id} is deprecated", Warning: true, Deprecated: true},
+		{Path: "$", Message: "is not formatted, run with --fix to format it", Warning: true},
+	}, problems)
+}
+
+func TestLintInvalidJSON(t *testing.T) {
+	problems := Lint([]byte(`{"fixtures": [`), nil)
+	require.Len(t, problems, 1)
+	require.Equal(t, "$", problems[0].Path)
+}
+
+func TestFormat(t *testing.T) {
+	formatted, err := Format([]byte(`{"fixtures":[{"name":"customer","path":"/v1/customers"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "fixtures": [
+    {
+      "name": "customer",
+      "path": "/v1/customers"
+    }
+  ]
+}
+`, string(formatted))
+}
@@ -284,7 +284,7 @@ func (fxt *Fixture) parseQuery(queryString string) (string, error) {
 		// Catch and insert .env values
 		if name == ".env" {
 			// Check if env variable is present
-			envValue, err := getEnvVar(query)
+			envValue, err := fxt.getEnvVar(query)
 			if err != nil || envValue == "" {
 				return value, nil
 			}
@@ -302,7 +302,7 @@ func (fxt *Fixture) parseQuery(queryString string) (string, error) {
 
 			referenceError := fmt.Errorf(
 				"%s - an undeclared fixture name was referenced: %s",
-				color.Red("✘ Validation error").String(),
+				ansi.ErrorGlyph() + " " + color.Red("Validation error").String(),
 				ansi.Bold(name),
 			).Error()
 
@@ -17,7 +17,7 @@ import (
 )
 
 func TestParsePathDoNothing(t *testing.T) {
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 	http := fixture{
 		Path: "/v1/charges",
 	}
@@ -160,7 +160,7 @@ func TestParseInterfaceFromRaw(t *testing.T) {
 	parsedFixtureData := make(map[string]interface{})
 	json.Unmarshal(rawFixtureData, &parsedFixtureData)
 
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 
 	output, _ := fxt.parseInterface(parsedFixtureData)
 	sort.Strings(output)
@@ -191,7 +191,7 @@ func TestParseInterface(t *testing.T) {
 	data["email"] = "bender@planex.com"
 	data["address"] = address
 	data["tax_id_data"] = taxIDData
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 
 	output, _ := fxt.parseInterface(data)
 	sort.Strings(output)
@@ -210,7 +210,7 @@ func TestParseInterface(t *testing.T) {
 func TestParseWithQueryIgnoreDefault(t *testing.T) {
 	jsonData := gjson.Parse(`{"id": "cust_bend123456789", "currency": "eur"}`)
 
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 	fxt.responses = make(map[string]gjson.Result)
 	fxt.responses["cust_bender"] = jsonData
 
@@ -233,7 +233,7 @@ func TestParseWithQueryIgnoreDefault(t *testing.T) {
 func TestParseWithQueryDefaultValue(t *testing.T) {
 	jsonData := gjson.Parse(`{"id": "cust_bend123456789"}`)
 
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 	fxt.responses = make(map[string]gjson.Result)
 	fxt.responses["cust_bender"] = jsonData
 
@@ -247,7 +247,7 @@ func TestParseWithQueryDefaultValue(t *testing.T) {
 }
 
 func TestParseNoEnv(t *testing.T) {
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 	data := make(map[string]interface{})
 	data["phone"] = "${.env:PHONE_NOT_SET|+1234567890}"
 
@@ -258,7 +258,7 @@ func TestParseNoEnv(t *testing.T) {
 }
 
 func TestParseWithLocalEnv(t *testing.T) {
-	fxt := Fixture{}
+	fxt := Fixture{Fs: afero.NewMemMapFs()}
 	data := make(map[string]interface{})
 	data["phone"] = "${.env:PHONE_LOCAL|+1234567890}"
 
@@ -286,7 +286,7 @@ func TestParseWithEnvFile(t *testing.T) {
 	envPath := path.Join(wd, ".env")
 	afero.WriteFile(fs, envPath, []byte(`PHONE_FILE="+1234"`), os.ModePerm)
 
-	fxt := Fixture{}
+	fxt := Fixture{Fs: fs}
 	data := make(map[string]interface{})
 	data["phone"] = "${.env:PHONE_FILE|+1234567890}"
 	output, _ := fxt.parseInterface(data)
@@ -294,6 +294,9 @@ func TestParseWithEnvFile(t *testing.T) {
 	require.Equal(t, len(output), 1)
 	require.Equal(t, "phone=+1234", output[0])
 
+	// The .env file isn't loaded in the environment of the process
+	require.Empty(t, os.Getenv("PHONE_FILE"))
+
 	fs.Remove(envPath)
 }
 
@@ -303,7 +306,7 @@ func TestParseWithEnvSubstring(t *testing.T) {
 	envPath := path.Join(wd, ".env")
 	afero.WriteFile(fs, envPath, []byte(`BASE_API_URL="https://myexample.com"`), os.ModePerm)
 
-	fxt := Fixture{}
+	fxt := Fixture{Fs: fs}
 	data := make(map[string]interface{})
 	data["url"] = "${.env:BASE_API_URL}/hook/stripe"
 	output, _ := (fxt.parseInterface(data))
@@ -0,0 +1,124 @@
+package fixtures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// RunnerOptions configures a Runner
+type RunnerOptions struct {
+	// APIKey authenticates the requests of the fixtures (required)
+	APIKey string
+	// APIBaseURL is the URL of the API (default: stripe.DefaultAPIBaseURL)
+	APIBaseURL string
+	// StripeAccount is the connected account to run the fixtures on, if any
+	StripeAccount string
+
+	// Skip are the names of the fixture steps to skip
+	Skip []string
+	// Override, Add and Remove change the parameters of fixture steps, as
+	// `step:param=value` for Override and Add, and `step:param` for Remove
+	Override []string
+	Add      []string
+	Remove   []string
+
+	// Fs is the file system fixture files are read from (default: the OS
+	// file system)
+	Fs afero.Fs
+
+	// Out receives the progress of the fixtures (default: discarded)
+	Out io.Writer
+	// Log receives the debug logs of the requests (default: discarded)
+	Log *log.Logger
+	// TelemetryClient receives the telemetry events of the requests
+	// (default: a NoOpTelemetryClient)
+	TelemetryClient stripe.TelemetryClient
+}
+
+// A Runner runs fixtures, like `stripe trigger` and `stripe fixtures` do.
+// It's the entry point for Go programs embedding the CLI's trigger
+// machinery.
+//
+// The steps of a fixture are run in order, each once: a step failing with
+// an error other than the one it expects stops the fixture, and the steps
+// already run aren't rolled back.
+type Runner struct {
+	opts RunnerOptions
+}
+
+// NewRunner returns a new Runner
+func NewRunner(opts RunnerOptions) (*Runner, error) {
+	if opts.APIKey == "" {
+		return nil, errors.New("an API key is required")
+	}
+
+	if opts.Fs == nil {
+		opts.Fs = afero.NewOsFs()
+	}
+
+	if opts.Out == nil {
+		opts.Out = ioutil.Discard
+	}
+
+	if opts.Log == nil {
+		opts.Log = &log.Logger{Out: ioutil.Discard}
+	}
+
+	if opts.TelemetryClient == nil {
+		opts.TelemetryClient = &stripe.NoOpTelemetryClient{}
+	}
+
+	return &Runner{opts: opts}, nil
+}
+
+// Trigger runs the fixture of an event, see EventNames, and returns the
+// names of the steps that ran.
+func (r *Runner) Trigger(ctx context.Context, event string) ([]string, error) {
+	fixture, err := BuildFromEvent(r.opts.Fs, r.opts.APIKey, r.opts.StripeAccount, r.opts.APIBaseURL, event, r.opts.Skip, r.opts.Override, r.opts.Add, r.opts.Remove)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.execute(ctx, fixture)
+}
+
+// RunFile runs a fixture file and returns the names of the steps that ran
+func (r *Runner) RunFile(ctx context.Context, path string) ([]string, error) {
+	fixture, err := BuildFromFixtureFile(r.opts.Fs, r.opts.APIKey, r.opts.StripeAccount, r.opts.APIBaseURL, path, r.opts.Skip, r.opts.Override, r.opts.Add, r.opts.Remove)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.execute(ctx, fixture)
+}
+
+// RunRaw runs a fixture given as JSON and returns the names of the steps
+// that ran. Skip, Override, Add and Remove don't apply to raw fixtures.
+func (r *Runner) RunRaw(ctx context.Context, raw string) ([]string, error) {
+	fixture, err := BuildFromFixtureString(r.opts.Fs, r.opts.APIKey, r.opts.StripeAccount, r.opts.APIBaseURL, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.execute(ctx, fixture)
+}
+
+func (r *Runner) execute(ctx context.Context, fixture *Fixture) ([]string, error) {
+	fixture.Out = r.opts.Out
+	fixture.Log = r.opts.Log
+
+	requestNames, err := fixture.Execute(stripe.WithTelemetryClient(ctx, r.opts.TelemetryClient))
+	if err != nil {
+		return nil, fmt.Errorf("Trigger failed: %s\n", err)
+	}
+
+	return requestNames, nil
+}
@@ -0,0 +1,86 @@
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerRunFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case customersPath:
+			res.Write([]byte(`{"id": "cust_12345"}`))
+		case chargePath:
+			res.Write([]byte(`{"id": "char_12345"}`))
+		}
+	}))
+	defer ts.Close()
+
+	afero.WriteFile(fs, file, []byte(testFixture), os.ModePerm)
+
+	runner, err := NewRunner(RunnerOptions{APIKey: apiKey, APIBaseURL: ts.URL, Fs: fs})
+	require.NoError(t, err)
+
+	names, err := runner.RunFile(context.Background(), file)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cust_bender", "char_bender", "capt_bender"}, names)
+
+	_, err = NewRunner(RunnerOptions{})
+	require.EqualError(t, err, "an API key is required")
+}
+
+type runnerTelemetryClient struct {
+	requests int32
+}
+
+func (c *runnerTelemetryClient) SendAPIRequestEvent(ctx context.Context, requestID string, livemode bool) (*http.Response, error) {
+	atomic.AddInt32(&c.requests, 1)
+	return nil, nil
+}
+
+func (c *runnerTelemetryClient) SendEvent(ctx context.Context, eventName string, eventValue string) {}
+
+func TestRunnerInjection(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Write([]byte(`{"id": "cust_12345"}`))
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	telemetry := &runnerTelemetryClient{}
+
+	runner, err := NewRunner(RunnerOptions{APIKey: apiKey, APIBaseURL: ts.URL, Out: &out, TelemetryClient: telemetry})
+	require.NoError(t, err)
+
+	_, err = runner.RunRaw(context.Background(), `{"_meta": {"template_version": 0}, "fixtures": [{"name": "cust", "path": "/v1/customers", "method": "post"}]}`)
+	require.NoError(t, err)
+	require.Equal(t, "Setting up fixture for: cust\nRunning fixture for: cust\n", out.String())
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&telemetry.requests) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRunnerRunRawFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(http.StatusBadRequest)
+		res.Write([]byte(`{"error": {"type": "invalid_request_error", "message": "Invalid amount"}}`))
+	}))
+	defer ts.Close()
+
+	runner, err := NewRunner(RunnerOptions{APIKey: apiKey, APIBaseURL: ts.URL, Fs: afero.NewMemMapFs()})
+	require.NoError(t, err)
+
+	_, err = runner.RunRaw(context.Background(), failureTestFixture)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Trigger failed")
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"os"
 	"sort"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 
 	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/suggest"
 )
 
 //go:embed triggers/*
@@ -19,7 +22,11 @@ var Events = map[string]string{
 	"account.updated":                          "triggers/account.updated.json",
 	"balance.available":                        "triggers/balance.available.json",
 	"charge.captured":                          "triggers/charge.captured.json",
+	"charge.dispute.closed":                    "triggers/charge.dispute.closed.json",
 	"charge.dispute.created":                   "triggers/charge.disputed.created.json",
+	"charge.dispute.funds_reinstated":          "triggers/charge.dispute.funds_reinstated.json",
+	"charge.dispute.funds_withdrawn":           "triggers/charge.dispute.funds_withdrawn.json",
+	"charge.dispute.updated":                   "triggers/charge.dispute.updated.json",
 	"charge.failed":                            "triggers/charge.failed.json",
 	"charge.refunded":                          "triggers/charge.refunded.json",
 	"charge.succeeded":                         "triggers/charge.succeeded.json",
@@ -78,6 +85,8 @@ var Events = map[string]string{
 	"quote.accepted":                           "triggers/quote.accepted.json",
 	"reporting.report_run.succeeded":           "triggers/reporting.report_run.succeeded.json",
 	"reporting.report_run.failed":              "triggers/reporting.report_run.failed.json",
+	"review.closed":                            "triggers/review.closed.json",
+	"review.opened":                            "triggers/review.opened.json",
 }
 
 // BuildFromFixtureFile creates a new fixture struct for a file
@@ -109,6 +118,25 @@ func BuildFromFixtureString(fs afero.Fs, apiKey, stripeAccount, apiBaseURL, raw
 	return fixture, nil
 }
 
+// BuildFromEvent creates a new fixture for a supported event name, or for a
+// fixture file path
+func BuildFromEvent(fs afero.Fs, apiKey, stripeAccount, apiBaseURL, event string, skip, override, add, remove []string) (*Fixture, error) {
+	if file, ok := Events[event]; ok {
+		return BuildFromFixtureFile(fs, apiKey, stripeAccount, apiBaseURL, file, skip, override, add, remove)
+	}
+
+	exists, _ := afero.Exists(fs, event)
+	if !exists {
+		if match, ok := suggest.Closest(event, EventNames()); ok {
+			return nil, fmt.Errorf("The event ‘%s’ is not supported by the Stripe CLI. Did you mean ‘%s’?", event, match)
+		}
+
+		return nil, fmt.Errorf(fmt.Sprintf("The event ‘%s’ is not supported by the Stripe CLI.", event))
+	}
+
+	return BuildFromFixtureFile(fs, apiKey, stripeAccount, apiBaseURL, event, skip, override, add, remove)
+}
+
 // EventList prints out a padded list of supported trigger events for printing the help file
 func EventList() string {
 	var eventList string
@@ -131,48 +159,36 @@ func EventNames() []string {
 	return names
 }
 
-// Trigger triggers a Stripe event.
+// Trigger triggers a Stripe event. It's kept for compatibility, programs
+// should use a Runner.
 func Trigger(ctx context.Context, event string, stripeAccount string, baseURL string, apiKey string, skip, override, add, remove []string, raw string) ([]string, error) {
-	var fixture *Fixture
-	var err error
-	fs := afero.NewOsFs()
-
 	// send event triggered
 	telemetryClient := stripe.GetTelemetryClient(ctx)
 	if telemetryClient != nil {
 		go telemetryClient.SendEvent(ctx, "Triggered Event", event)
 	}
 
-	if len(raw) == 0 {
-		if file, ok := Events[event]; ok {
-			fixture, err = BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, file, skip, override, add, remove)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			exists, _ := afero.Exists(fs, event)
-			if !exists {
-				return nil, fmt.Errorf(fmt.Sprintf("The event ‘%s’ is not supported by the Stripe CLI.", event))
-			}
-
-			fixture, err = BuildFromFixtureFile(fs, apiKey, stripeAccount, baseURL, event, skip, override, add, remove)
-			if err != nil {
-				return nil, err
-			}
-		}
-	} else {
-		fixture, err = BuildFromFixtureString(fs, apiKey, stripeAccount, baseURL, raw)
-		if err != nil {
-			return nil, err
-		}
+	runner, err := NewRunner(RunnerOptions{
+		APIKey:          apiKey,
+		APIBaseURL:      baseURL,
+		StripeAccount:   stripeAccount,
+		Skip:            skip,
+		Override:        override,
+		Add:             add,
+		Remove:          remove,
+		Out:             os.Stdout,
+		Log:             log.StandardLogger(),
+		TelemetryClient: telemetryClient,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	requestNames, err := fixture.Execute(ctx)
-	if err != nil {
-		return nil, fmt.Errorf(fmt.Sprintf("Trigger failed: %s\n", err))
+	if len(raw) == 0 {
+		return runner.Trigger(ctx, event)
 	}
 
-	return requestNames, nil
+	return runner.RunRaw(ctx, raw)
 }
 
 func reverseMap() map[string]string {
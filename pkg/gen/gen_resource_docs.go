@@ -0,0 +1,258 @@
+//go:build resource_docs
+// +build resource_docs
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/cmd/resource"
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+const (
+	pathStripeSpec = "../../api/openapi-spec/spec3.sdk.json"
+
+	pathOutput = "../spec/resource_docs.json"
+
+	// maxDescriptionLength is the length descriptions are truncated to, to
+	// keep the bundled docs small
+	maxDescriptionLength = 300
+)
+
+var (
+	htmlTags     = regexp.MustCompile(`<[^>]+>`)
+	markdownLink = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	whitespace   = regexp.MustCompile(`\s+`)
+)
+
+func main() {
+	// generate `resource_docs.json` from OpenAPI spec file, keeping the
+	// first paragraph of the descriptions
+
+	// load API spec
+	api, err := spec.LoadSpec(pathStripeSpec)
+	if err != nil {
+		panic(err)
+	}
+
+	docs := &spec.ResourceDocs{
+		Version:   api.Info.Version,
+		Resources: make(map[string]*spec.ResourceDoc),
+	}
+
+	for name, schema := range api.Components.Schemas {
+		if schema.XStripeOperations == nil {
+			continue
+		}
+
+		for _, op := range *schema.XStripeOperations {
+			// Only the "service" operations have commands
+			if op.MethodOn != "service" {
+				continue
+			}
+
+			specOp := api.Paths[spec.Path(op.Path)][op.Operation]
+			if specOp == nil || specOp.Deprecated != nil && *specOp.Deprecated {
+				continue
+			}
+
+			key := resourceKey(name)
+			if _, ok := docs.Resources[key]; !ok {
+				docs.Resources[key] = &spec.ResourceDoc{
+					Object:      name,
+					Description: describe(schema.Description),
+					Fields:      fields(schema, nil, ""),
+					Operations:  make(map[string]*spec.OperationDoc),
+				}
+			}
+
+			docs.Resources[key].Operations[op.MethodName] = &spec.OperationDoc{
+				Method:      strings.ToUpper(string(op.Operation)),
+				Path:        op.Path,
+				Description: describe(specOp.Description),
+				Parameters:  parameters(specOp),
+			}
+		}
+	}
+
+	// the docs are bundled in the binary, so they're not indented
+	data, err := json.Marshal(docs)
+	if err != nil {
+		panic(err)
+	}
+
+	// write docs to disk
+	fmt.Printf("writing %s\n", pathOutput)
+	err = ioutil.WriteFile(pathOutput, append(data, '\n'), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// resourceKey returns the name of the command of a resource, prefixed by its
+// namespace if any
+func resourceKey(schemaName string) string {
+	if strings.Contains(schemaName, ".") {
+		components := strings.SplitN(schemaName, ".", 2)
+		return components[0] + "." + resource.GetResourceCmdName(components[1])
+	}
+
+	return resource.GetResourceCmdName(schemaName)
+}
+
+func parameters(op *spec.Operation) []spec.FieldDoc {
+	var params []spec.FieldDoc
+
+	for _, param := range op.Parameters {
+		if param.In != spec.ParameterQuery {
+			continue
+		}
+
+		params = append(params, field(param.Name, param.Schema, param.Required, param.Description))
+	}
+
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/x-www-form-urlencoded"]; ok && media.Schema != nil {
+			params = append(params, fields(media.Schema, media.Schema.Required, "")...)
+		}
+	}
+
+	return params
+}
+
+// fields documents the properties of an object schema, and the properties
+// of the inline objects they hold, one level deep
+func fields(schema *spec.Schema, required []string, parent string) []spec.FieldDoc {
+	var docs []spec.FieldDoc
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := schema.Properties[name]
+
+		fullName := name
+		if parent != "" {
+			fullName = fmt.Sprintf("%s[%s]", parent, name)
+		}
+
+		docs = append(docs, field(fullName, property, contains(required, name), property.Description))
+
+		if object := inlineObject(property); parent == "" && object != nil {
+			docs = append(docs, fields(object, object.Required, name)...)
+		}
+	}
+
+	return docs
+}
+
+// inlineObject returns the object schema with properties a schema holds
+// directly or as an alternative, e.g. objects that can also be unset with ""
+func inlineObject(schema *spec.Schema) *spec.Schema {
+	if len(schema.Properties) > 0 {
+		return schema
+	}
+
+	for _, alternative := range schema.AnyOf {
+		if len(alternative.Properties) > 0 {
+			return alternative
+		}
+	}
+
+	return nil
+}
+
+func field(name string, schema *spec.Schema, required bool, description string) spec.FieldDoc {
+	doc := spec.FieldDoc{
+		Name:        name,
+		Type:        typeName(schema),
+		Required:    required,
+		Description: describe(description),
+	}
+
+	for _, value := range enum(schema) {
+		doc.Enum = append(doc.Enum, value)
+	}
+
+	return doc
+}
+
+func typeName(schema *spec.Schema) string {
+	switch {
+	case schema == nil:
+		return ""
+	case schema.Ref != "":
+		return strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+	case len(schema.AnyOf) > 0:
+		var names []string
+		for _, alternative := range schema.AnyOf {
+			if name := typeName(alternative); name != "" && !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+
+		return strings.Join(names, " | ")
+	case schema.Type == spec.TypeArray:
+		return "array of " + typeName(schema.Items)
+	case schema.Type == spec.TypeString && len(schema.Enum) == 1 && schema.Enum[0] == "":
+		// Strings only accepting "" are used to unset fields
+		return "empty string"
+	default:
+		return schema.Type
+	}
+}
+
+func enum(schema *spec.Schema) []string {
+	if schema == nil {
+		return nil
+	}
+
+	var values []string
+
+	for _, value := range schema.Enum {
+		if s, ok := value.(string); ok && s != "" {
+			values = append(values, s)
+		}
+	}
+
+	for _, alternative := range schema.AnyOf {
+		values = append(values, enum(alternative)...)
+	}
+
+	return values
+}
+
+// describe returns the first paragraph of a description as plain text
+func describe(description string) string {
+	description = strings.SplitN(description, "\n\n", 2)[0]
+	description = strings.SplitN(description, "</p>", 2)[0]
+	description = markdownLink.ReplaceAllString(description, "$1")
+	description = htmlTags.ReplaceAllString(description, "")
+	description = strings.TrimSpace(whitespace.ReplaceAllString(description, " "))
+
+	if len(description) > maxDescriptionLength {
+		description = strings.TrimSpace(description[:maxDescriptionLength]) + "…"
+	}
+
+	return description
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+//go:build resource_schemas
+// +build resource_schemas
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+const (
+	pathStripeSpec = "../../api/openapi-spec/spec3.sdk.json"
+
+	pathOutput = "../spec/resource_schemas.json"
+)
+
+func main() {
+	// generate `resource_schemas.json` from OpenAPI spec file, keeping only
+	// what's needed to validate payloads
+
+	// load API spec
+	api, err := spec.LoadSpec(pathStripeSpec)
+	if err != nil {
+		panic(err)
+	}
+
+	schemas := &spec.ResourceSchemas{
+		Version: api.Info.Version,
+		Schemas: make(map[string]*spec.Schema, len(api.Components.Schemas)),
+	}
+
+	for name, schema := range api.Components.Schemas {
+		schemas.Schemas[name] = strip(schema)
+	}
+
+	schemas.Deprecated = deprecations(api)
+
+	data, err := json.MarshalIndent(schemas, "", " ")
+	if err != nil {
+		panic(err)
+	}
+
+	// write schemas to disk
+	fmt.Printf("writing %s\n", pathOutput)
+	err = ioutil.WriteFile(pathOutput, append(data, '\n'), 0644)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// deprecations lists the deprecated operations, and the parameters whose
+// description starts with `[Deprecated]`.
+func deprecations(api *spec.Spec) []spec.Deprecation {
+	deprecated := make([]spec.Deprecation, 0)
+
+	isDeprecated := func(description string) bool {
+		return strings.HasPrefix(description, "[Deprecated]")
+	}
+
+	for path, verbs := range api.Paths {
+		for verb, op := range verbs {
+			if op.Deprecated != nil && *op.Deprecated {
+				deprecated = append(deprecated, spec.Deprecation{Method: string(verb), Path: string(path)})
+				continue
+			}
+
+			for _, param := range op.Parameters {
+				if isDeprecated(param.Description) {
+					deprecated = append(deprecated, spec.Deprecation{Method: string(verb), Path: string(path), Param: param.Name})
+				}
+			}
+
+			if op.RequestBody == nil {
+				continue
+			}
+
+			schema := op.RequestBody.Content["application/x-www-form-urlencoded"].Schema
+			if schema == nil {
+				continue
+			}
+
+			for name, property := range schema.Properties {
+				if isDeprecated(property.Description) {
+					deprecated = append(deprecated, spec.Deprecation{Method: string(verb), Path: string(path), Param: name})
+				}
+			}
+		}
+	}
+
+	sort.Slice(deprecated, func(i, j int) bool {
+		a, b := deprecated[i], deprecated[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Param < b.Param
+	})
+
+	return deprecated
+}
+
+func strip(schema *spec.Schema) *spec.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	stripped := &spec.Schema{
+		AdditionalProperties: schema.AdditionalProperties,
+		Items:                strip(schema.Items),
+		Nullable:             schema.Nullable,
+		Type:                 schema.Type,
+		Ref:                  schema.Ref,
+	}
+
+	for _, alternative := range schema.AnyOf {
+		stripped.AnyOf = append(stripped.AnyOf, strip(alternative))
+	}
+
+	if len(schema.Properties) > 0 {
+		stripped.Properties = make(map[string]*spec.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			stripped.Properties[name] = strip(property)
+		}
+	}
+
+	return stripped
+}
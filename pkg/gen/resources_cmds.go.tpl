@@ -19,8 +19,10 @@ func addAllResourcesCmds(rootCmd *cobra.Command) {
 	r{{ (printf "%s_%s" $nsName $resName) | ToCamel }}Cmd := resource.NewResourceCmd({{ if ne $nsName "" }}ns{{ $nsName | ToCamel }}Cmd.Cmd{{ else }}rootCmd{{ end }}, "{{ $resName }}"){{ end }}{{ end }}
 	{{ end }}
 
-	// Operation commands{{ range $nsName, $nsData := .Namespaces }}{{ range $resName, $resData := $nsData.Resources }}{{ range $opName, $opData := $resData.Operations }}
-	resource.NewOperationCmd(r{{ (printf "%s_%s" $nsName $resName) | ToCamel }}Cmd.Cmd, "{{ $opName }}", "{{ $opData.Path }}", http.Method{{ $opData.HTTPVerb | ToCamel }}, map[string]string{ {{range $prop, $propType := $opData.PropFlags }}
-		"{{ $prop }}": "{{ $propType }}",{{ end }}
-	}, &Config){{ end }}{{ end }}{{ end }}
+	// Operation commands, built when their resource command is used{{ range $nsName, $nsData := .Namespaces }}{{ range $resName, $resData := $nsData.Resources }}{{ if $resData.Operations }}{{ $resCmd := (printf "r%sCmd" ((printf "%s_%s" $nsName $resName) | ToCamel)) }}
+	resource.DeferOperationCmds({{ $resCmd }}.Cmd, func() { {{ range $opName, $opData := $resData.Operations }}
+		resource.NewOperationCmd({{ $resCmd }}.Cmd, "{{ $opName }}", "{{ $opData.Path }}", http.Method{{ $opData.HTTPVerb | ToCamel }}, map[string]string{ {{range $prop, $propType := $opData.PropFlags }}
+			"{{ $prop }}": "{{ $propType }}",{{ end }}
+		}, &Config){{ end }}
+	}){{ end }}{{ end }}{{ end }}
 }
@@ -0,0 +1,91 @@
+package gha
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// OutputMode is the value of the `--output` flag enabling GitHub Actions
+// workflow commands
+const OutputMode = "gha"
+
+//
+// Public functions
+//
+
+// Error reports an error annotation on the workflow run.
+func Error(w io.Writer, message string) {
+	command(w, "error", message)
+}
+
+// Warning reports a warning annotation on the workflow run.
+func Warning(w io.Writer, message string) {
+	command(w, "warning", message)
+}
+
+// Notice reports a notice annotation on the workflow run.
+func Notice(w io.Writer, message string) {
+	command(w, "notice", message)
+}
+
+// Mask prevents value from being printed in the workflow logs.
+func Mask(w io.Writer, value string) {
+	if value == "" {
+		return
+	}
+
+	command(w, "add-mask", value)
+}
+
+// Group starts a collapsible group of log lines.
+func Group(w io.Writer, title string) {
+	command(w, "group", title)
+}
+
+// EndGroup ends the current group of log lines.
+func EndGroup(w io.Writer) {
+	fmt.Fprintln(w, "::endgroup::")
+}
+
+// AppendSummary appends markdown to the job summary. It does nothing when
+// not running in GitHub Actions.
+func AppendSummary(markdown string) error {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if !strings.HasSuffix(markdown, "\n") {
+		markdown += "\n"
+	}
+
+	_, err = f.WriteString(markdown)
+
+	return err
+}
+
+//
+// Private functions
+//
+
+func command(w io.Writer, name, message string) {
+	fmt.Fprintf(w, "::%s::%s\n", name, escapeData(message))
+}
+
+// escapeData escapes a workflow command message so that it's rendered on a
+// single line
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+
+	return s
+}
@@ -0,0 +1,44 @@
+package gha
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands(t *testing.T) {
+	var b bytes.Buffer
+
+	Group(&b, "Trigger charge.succeeded")
+	Error(&b, "Trigger failed: 100% broken\nsecond line")
+	Mask(&b, "sk_test_123")
+	Mask(&b, "")
+	EndGroup(&b)
+
+	require.Equal(t, `::group::Trigger charge.succeeded
+::error::Trigger failed: 100%25 broken%0Asecond line
+::add-mask::sk_test_123
+::endgroup::
+`, b.String())
+}
+
+func TestAppendSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	t.Setenv("GITHUB_STEP_SUMMARY", path)
+
+	require.NoError(t, AppendSummary("### First"))
+	require.NoError(t, AppendSummary("### Second\n"))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "### First\n### Second\n", string(content))
+}
+
+func TestAppendSummaryOutsideActions(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "")
+
+	require.NoError(t, AppendSummary("ignored"))
+}
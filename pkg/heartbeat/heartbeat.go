@@ -0,0 +1,267 @@
+package heartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/shutdown"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// DefaultInterval is the default time between two heartbeats
+const DefaultInterval = 30 * time.Second
+
+//
+// Public types
+//
+
+// Heartbeat describes the health of a long-running session at a point in time
+type Heartbeat struct {
+	Time      time.Time `json:"time"`
+	StartedAt time.Time `json:"started_at"`
+
+	// State is the connection state: starting, loading, ready, reconnecting or done
+	State      string `json:"state"`
+	Reconnects int    `json:"reconnects"`
+
+	// Events is the total number of events received in the session
+	Events int `json:"events"`
+	// EventsPerMinute is the event throughput since the previous heartbeat
+	EventsPerMinute float64    `json:"events_per_minute"`
+	LastEventAt     *time.Time `json:"last_event_at,omitempty"`
+}
+
+// Config provides the configuration of a Monitor
+type Config struct {
+	// Path is the file the heartbeat is written to. The file is replaced
+	// atomically so readers never see a partial heartbeat.
+	Path string
+
+	// Interval is the time between two heartbeats. Defaults to DefaultInterval.
+	Interval time.Duration
+
+	Log *log.Logger
+}
+
+// Monitor tracks the elements streamed by a session (proxy, log tailer) and
+// periodically writes a heartbeat so supervisors can detect a wedged session.
+type Monitor struct {
+	cfg *Config
+
+	mu             sync.Mutex
+	startedAt      time.Time
+	state          string
+	reconnects     int
+	events         int
+	lastEventAt    time.Time
+	lastBeatAt     time.Time
+	lastBeatEvents int
+
+	visitor *websocket.Visitor
+}
+
+//
+// Public functions
+//
+
+// New returns a new Monitor.
+func New(cfg *Config) *Monitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	if cfg.Log == nil {
+		cfg.Log = &log.Logger{Out: ioutil.Discard}
+	}
+
+	now := time.Now()
+
+	m := &Monitor{
+		cfg:        cfg,
+		startedAt:  now,
+		lastBeatAt: now,
+		state:      "starting",
+	}
+
+	m.visitor = &websocket.Visitor{
+		VisitStatus: func(se websocket.StateElement) error {
+			switch se.State {
+			case websocket.Loading:
+				m.setState("loading")
+			case websocket.Reconnecting:
+				m.setState("reconnecting")
+			case websocket.Ready:
+				m.setState("ready")
+			case websocket.Done:
+				m.setState("done")
+			}
+			return nil
+		},
+		VisitData: func(de websocket.DataElement) error {
+			// Responses from local endpoints are not events received from Stripe
+			if _, ok := de.Data.(proxy.EndpointResponse); !ok {
+				m.recordEvent()
+			}
+			return nil
+		},
+	}
+
+	return m
+}
+
+// Start creates a Monitor and runs it until ctx is canceled. It returns nil
+// if no heartbeat file is configured. On shutdown, the final heartbeat is
+// written before the process exits.
+func Start(ctx context.Context, cfg *Config) *Monitor {
+	if cfg.Path == "" {
+		return nil
+	}
+
+	m := New(cfg)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		m.Run(ctx)
+	}()
+
+	shutdown.OnShutdown(ctx, "heartbeat", func(ctx context.Context) {
+		<-done
+	})
+
+	return m
+}
+
+// Observe updates the session health from an element of the stream. It is
+// safe to call on a nil Monitor.
+func (m *Monitor) Observe(el websocket.IElement) {
+	if m == nil {
+		return
+	}
+
+	el.Accept(m.visitor) // #nosec G104
+}
+
+// MarkReady sets the state to ready, for sessions that don't stream state
+// elements themselves.
+func (m *Monitor) MarkReady() {
+	if m == nil {
+		return
+	}
+
+	m.setState("ready")
+}
+
+// Beat returns the current heartbeat and starts a new throughput window.
+func (m *Monitor) Beat() Heartbeat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	hb := Heartbeat{
+		Time:       now,
+		StartedAt:  m.startedAt,
+		State:      m.state,
+		Reconnects: m.reconnects,
+		Events:     m.events,
+	}
+
+	if elapsed := now.Sub(m.lastBeatAt); elapsed > 0 {
+		hb.EventsPerMinute = float64(m.events-m.lastBeatEvents) / elapsed.Minutes()
+	}
+
+	if !m.lastEventAt.IsZero() {
+		lastEventAt := m.lastEventAt
+		hb.LastEventAt = &lastEventAt
+	}
+
+	m.lastBeatAt = now
+	m.lastBeatEvents = m.events
+
+	return hb
+}
+
+// Run writes a heartbeat every interval until ctx is canceled. A final
+// heartbeat is written when it returns.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	m.write()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.setState("done")
+			m.write()
+
+			return
+		case <-ticker.C:
+			m.write()
+		}
+	}
+}
+
+//
+// Private functions
+//
+
+func (m *Monitor) setState(state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if state == "reconnecting" {
+		m.reconnects++
+	}
+
+	m.state = state
+}
+
+func (m *Monitor) recordEvent() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events++
+	m.lastEventAt = time.Now()
+}
+
+func (m *Monitor) write() {
+	err := writeFileAtomic(m.cfg.Path, m.Beat())
+	if err != nil {
+		logging.WithPrefix(m.cfg.Log, "heartbeat.Monitor.write").Warnf("Failed to write heartbeat: %v", err)
+	}
+}
+
+func writeFileAtomic(path string, hb Heartbeat) error {
+	data, err := json.Marshal(hb)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,79 @@
+package heartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+func TestObserve(t *testing.T) {
+	m := New(&Config{})
+
+	require.Equal(t, "starting", m.Beat().State)
+
+	m.Observe(websocket.StateElement{State: websocket.Loading})
+	m.Observe(websocket.StateElement{State: websocket.Ready})
+	m.Observe(websocket.DataElement{Data: proxy.StripeEvent{ID: "evt_123"}})
+	m.Observe(websocket.DataElement{Data: proxy.EndpointResponse{}})
+	m.Observe(&websocket.StateElement{State: websocket.Reconnecting})
+
+	hb := m.Beat()
+	require.Equal(t, "reconnecting", hb.State)
+	require.Equal(t, 1, hb.Reconnects)
+	require.Equal(t, 1, hb.Events)
+	require.NotNil(t, hb.LastEventAt)
+	require.Greater(t, hb.EventsPerMinute, 0.0)
+
+	// The throughput window restarts after each beat
+	require.Equal(t, 0.0, m.Beat().EventsPerMinute)
+}
+
+func TestObserveNilMonitor(t *testing.T) {
+	var m *Monitor
+
+	require.NotPanics(t, func() {
+		m.Observe(websocket.StateElement{State: websocket.Ready})
+		m.MarkReady()
+	})
+}
+
+func TestStartWithoutPath(t *testing.T) {
+	require.Nil(t, Start(context.Background(), &Config{}))
+}
+
+func TestRunWritesHeartbeatFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heartbeat.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := New(&Config{Path: path, Interval: time.Hour})
+	m.MarkReady()
+
+	done := make(chan struct{})
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := ioutil.ReadFile(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var hb Heartbeat
+	require.NoError(t, json.Unmarshal(data, &hb))
+	require.Equal(t, "done", hb.State)
+}
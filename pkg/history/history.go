@@ -0,0 +1,228 @@
+// Package history keeps a local, opt-in history of the commands run with the
+// CLI, with their secrets removed.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/redact"
+)
+
+// FileName is the name of the history file in the config folder
+const FileName = "history.jsonl"
+
+// maxSize is the number of entries past which the oldest ones are dropped
+// from the history
+const maxSize = 1000
+
+// redacted replaces the secrets of the arguments
+const redacted = "[REDACTED]"
+
+// secretFlags are the flags whose values are always secret
+var secretFlags = map[string]bool{
+	"--api-key": true,
+}
+
+//
+// Public types
+//
+
+// Entry is a command run with the CLI
+type Entry struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Args     []string  `json:"args"`
+	Dir      string    `json:"dir,omitempty"`
+	ExitCode int       `json:"exit_code"`
+
+	// Redacted tells whether secrets were removed from the arguments, in
+	// which case the command can't be rerun as is
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// Command returns the command line of the entry
+func (e *Entry) Command() string {
+	return strings.Join(append([]string{"stripe"}, e.Args...), " ")
+}
+
+//
+// Public functions
+//
+
+// Enable starts recording the command run by this process in the history at
+// path.
+func Enable(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	historyPath = path
+	started = time.Now()
+}
+
+// Record adds the command run by this process to the history, with its exit
+// code. It does nothing unless recording was enabled.
+func Record(args []string, exitCode int) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if historyPath == "" {
+		return nil
+	}
+
+	entries, err := Load(historyPath)
+	if err != nil {
+		return err
+	}
+
+	e := Entry{ID: 1, Time: started, ExitCode: exitCode}
+	e.Args, e.Redacted = Redact(args)
+
+	if dir, err := os.Getwd(); err == nil {
+		e.Dir = dir
+	}
+
+	if len(entries) > 0 {
+		e.ID = entries[len(entries)-1].ID + 1
+	}
+
+	entries = append(entries, e)
+	if len(entries) > maxSize {
+		entries = entries[len(entries)-maxSize:]
+	}
+
+	return save(historyPath, entries)
+}
+
+// Load reads the entries of the history at path, oldest first. A missing
+// history has no entries.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// Clear deletes the history at path.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Find returns the entry with the given ID.
+func Find(entries []Entry, id int) (Entry, bool) {
+	for _, e := range entries {
+		if e.ID == id {
+			return e, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// Search returns the entries whose command contains query, ignoring case.
+func Search(entries []Entry, query string) []Entry {
+	query = strings.ToLower(query)
+
+	var matches []Entry
+
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.Command()), query) {
+			matches = append(matches, e)
+		}
+	}
+
+	return matches
+}
+
+// Redact returns the arguments with their secrets replaced, and whether
+// there were any.
+func Redact(args []string) ([]string, bool) {
+	result := make([]string, 0, len(args))
+	found := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name := strings.SplitN(arg, "=", 2)[0]
+
+		switch {
+		case secretFlags[name] && strings.Contains(arg, "="):
+			result = append(result, name+"="+redacted)
+			found = true
+		case secretFlags[name] && i+1 < len(args):
+			result = append(result, name, redacted)
+			found = true
+			i++
+		case redact.HasSecrets(arg):
+			result = append(result, redact.Secrets(arg, redacted))
+			found = true
+		default:
+			result = append(result, arg)
+		}
+	}
+
+	return result, found
+}
+
+//
+// Private variables
+//
+
+var (
+	mu          sync.Mutex
+	historyPath string
+	started     time.Time
+)
+
+//
+// Private functions
+//
+
+func save(path string, entries []Entry) error {
+	var b strings.Builder
+
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(b.String()), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	args, found := Redact([]string{"customers", "list", "--api-key", "sk_test_123456789"})
+	require.True(t, found)
+	require.Equal(t, []string{"customers", "list", "--api-key", redacted}, args)
+
+	args, found = Redact([]string{"post", "/v1/webhook_endpoints", "--api-key=rk_live_123", "-d", "description=whsec_abc123 rotated"})
+	require.True(t, found)
+	require.Equal(t, []string{"post", "/v1/webhook_endpoints", "--api-key=" + redacted, "-d", "description=" + redacted + " rotated"}, args)
+
+	args, found = Redact([]string{"trigger", "customer.created"})
+	require.False(t, found)
+	require.Equal(t, []string{"trigger", "customer.created"}, args)
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	require.NoError(t, Record([]string{"version"}, 0))
+
+	entries, err := Load(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	Enable(path)
+	defer Enable("")
+
+	require.NoError(t, Record([]string{"trigger", "customer.created"}, 0))
+	require.NoError(t, Record([]string{"customers", "list", "--api-key", "sk_test_123456789"}, 1))
+
+	entries, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 2, entries[1].ID)
+	require.Equal(t, 1, entries[1].ExitCode)
+	require.True(t, entries[1].Redacted)
+
+	require.Equal(t, []Entry{entries[0]}, Search(entries, "CUSTOMER.created"))
+
+	e, ok := Find(entries, 2)
+	require.True(t, ok)
+	require.Equal(t, "stripe customers list --api-key "+redacted, e.Command())
+
+	require.NoError(t, Clear(path))
+	require.NoError(t, Clear(path))
+
+	entries, err = Load(path)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
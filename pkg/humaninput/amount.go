@@ -0,0 +1,291 @@
+// Package humaninput parses the amounts and dates typed by humans in the
+// flags of the convenience commands, e.g. `--amount "$19.99"` or
+// `--proration-date "next friday"`. Scripts pass --strict to only accept
+// amounts in the smallest currency unit and Unix timestamps.
+package humaninput
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Amount is an amount of money, either in the smallest currency unit, e.g.
+// 1999, or in the main unit, e.g. $19.99, 19,99 EUR or ¥500.
+type Amount struct {
+	// digits are the integer and fraction parts of the amount, e.g. "19"
+	// and "99"
+	digits   string
+	fraction string
+
+	// currency is the currency of the input, e.g. usd for $19.99
+	currency string
+
+	// separator is set when the fraction may be a group of digits instead,
+	// e.g. for 1.500, which is 1.5 in KWD but 1500 in JPY
+	separator byte
+
+	// minor is set when the amount is in the smallest currency unit
+	minor bool
+}
+
+// MinorUnits returns an amount in the smallest currency unit
+func MinorUnits(amount int64) Amount {
+	return Amount{digits: strconv.FormatInt(amount, 10), minor: true}
+}
+
+// ParseAmount parses an amount. Integers without a currency are in the
+// smallest currency unit, e.g. 1999 is $19.99 in usd. With a decimal
+// separator, a currency symbol or a currency code, the amount is in the main
+// unit. Decimal points and commas are both accepted, and a single one
+// followed by 3 digits, e.g. 1.500, is read according to the currency, see
+// In. With strict set, only integers are accepted.
+func ParseAmount(input string, strict bool) (Amount, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return Amount{}, fmt.Errorf("the amount is empty")
+	}
+
+	if isDigits(s) {
+		return Amount{digits: s, minor: true}, nil
+	}
+
+	if strict {
+		return Amount{}, fmt.Errorf("invalid amount %q: with --strict, amounts are integers in the smallest currency unit, e.g. 1999", input)
+	}
+
+	if strings.HasPrefix(s, "-") {
+		return Amount{}, fmt.Errorf("invalid amount %q: the amount must be positive", input)
+	}
+
+	s, currency := splitCurrency(s)
+
+	integer, fraction, separator, err := splitDecimal(s)
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid amount %q: %v", input, err)
+	}
+
+	return Amount{digits: integer, fraction: fraction, currency: currency, separator: separator}, nil
+}
+
+// IsZero returns whether the amount is unset or zero
+func (a Amount) IsZero() bool {
+	return strings.Trim(a.digits+a.fraction, "0") == ""
+}
+
+// Currency returns the currency of the input, e.g. usd for $19.99, or an
+// empty string when it has none.
+func (a Amount) Currency() string {
+	return a.currency
+}
+
+// In returns the amount in the smallest unit of currency. currency can be
+// empty when the input has a currency. It's an error for the currency of the
+// input to be different.
+func (a Amount) In(currency string) (int64, error) {
+	currency = strings.ToLower(currency)
+
+	if a.currency != "" && currency != "" && a.currency != currency {
+		return 0, fmt.Errorf("the amount is in %s, but the currency is %s", strings.ToUpper(a.currency), strings.ToUpper(currency))
+	}
+
+	if a.minor {
+		return strconv.ParseInt(a.digits, 10, 64)
+	}
+
+	if currency == "" {
+		currency = a.currency
+	}
+
+	if currency == "" {
+		return 0, fmt.Errorf("the currency of %s is unknown: add it to the amount, e.g. %s USD, or pass the amount in the smallest currency unit", a, a)
+	}
+
+	exponent := CurrencyExponent(currency)
+
+	// A single separator followed by 3 digits is a decimal separator in the
+	// currencies with 3 decimals, and groups digits in the ones without
+	// decimals. In the others, 1,000 groups digits but 1.000 is rejected as
+	// it's often meant as 1.00.
+	if a.separator != 0 && exponent != 3 {
+		if exponent == 2 && a.separator == '.' {
+			return 0, fmt.Errorf("%s is ambiguous in %s: write it without the separator, e.g. %s%s, or with %d decimals", a, strings.ToUpper(currency), a.digits, a.fraction, exponent)
+		}
+
+		a.digits, a.fraction = a.digits+a.fraction, ""
+	}
+
+	if len(a.fraction) > exponent {
+		return 0, fmt.Errorf("%s has more decimals than %s, which has %d", a, strings.ToUpper(currency), exponent)
+	}
+
+	minor := a.digits + a.fraction + strings.Repeat("0", exponent-len(a.fraction))
+
+	amount, err := strconv.ParseInt(minor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is too large", a)
+	}
+
+	return amount, nil
+}
+
+func (a Amount) String() string {
+	if a.fraction == "" {
+		return a.digits
+	}
+
+	return a.digits + "." + a.fraction
+}
+
+// CurrencyExponent returns the number of decimals of the main unit of a
+// currency, see https://stripe.com/docs/currencies#zero-decimal
+func CurrencyExponent(currency string) int {
+	currency = strings.ToLower(currency)
+
+	switch {
+	case zeroDecimalCurrencies[currency]:
+		return 0
+	case threeDecimalCurrencies[currency]:
+		return 3
+	default:
+		return 2
+	}
+}
+
+//
+// Private variables
+//
+
+var zeroDecimalCurrencies = map[string]bool{
+	"bif": true, "clp": true, "djf": true, "gnf": true, "jpy": true,
+	"kmf": true, "krw": true, "mga": true, "pyg": true, "rwf": true,
+	"ugx": true, "vnd": true, "vuv": true, "xaf": true, "xof": true,
+	"xpf": true,
+}
+
+var threeDecimalCurrencies = map[string]bool{
+	"bhd": true, "jod": true, "kwd": true, "omr": true, "tnd": true,
+}
+
+// currencySymbols are the symbols of the main currencies, longest first so
+// that US$ isn't read as $
+var currencySymbols = []struct {
+	symbol   string
+	currency string
+}{
+	{"US$", "usd"}, {"CA$", "cad"}, {"AU$", "aud"}, {"NZ$", "nzd"},
+	{"HK$", "hkd"}, {"S$", "sgd"}, {"A$", "aud"}, {"C$", "cad"},
+	{"R$", "brl"}, {"$", "usd"}, {"€", "eur"}, {"£", "gbp"},
+	{"¥", "jpy"}, {"₹", "inr"}, {"₩", "krw"},
+}
+
+//
+// Private functions
+//
+
+// splitCurrency removes the currency symbol or code before or after the
+// number of s, and returns the currency.
+func splitCurrency(s string) (string, string) {
+	for _, cs := range currencySymbols {
+		if strings.HasPrefix(s, cs.symbol) {
+			return strings.TrimSpace(strings.TrimPrefix(s, cs.symbol)), cs.currency
+		}
+
+		if strings.HasSuffix(s, cs.symbol) {
+			return strings.TrimSpace(strings.TrimSuffix(s, cs.symbol)), cs.currency
+		}
+	}
+
+	if len(s) > 3 && isCode(s[:3]) {
+		return strings.TrimSpace(s[3:]), strings.ToLower(s[:3])
+	}
+
+	if len(s) > 3 && isCode(s[len(s)-3:]) {
+		return strings.TrimSpace(s[:len(s)-3]), strings.ToLower(s[len(s)-3:])
+	}
+
+	return s, ""
+}
+
+// splitDecimal returns the integer and fraction parts of a number using
+// points or commas as decimal separators, and points, commas, spaces or
+// apostrophes to group digits, e.g. 1,234.56, 1.234,56 or 1 234,56. When a
+// single separator is followed by 3 digits, e.g. 1.500, it can't tell
+// whether it groups digits without the currency and returns it.
+func splitDecimal(s string) (string, string, byte, error) {
+	s = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\'' || r == '_' || r == '\u00a0' || r == '\u202f' {
+			return -1
+		}
+		return r
+	}, s)
+
+	if s == "" {
+		return "", "", 0, fmt.Errorf("there is no number")
+	}
+
+	decimal := -1
+	var ambiguous byte
+
+	lastPoint, lastComma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case lastPoint >= 0 && lastComma >= 0:
+		// The separator coming last is the decimal one
+		decimal = lastPoint
+		if lastComma > lastPoint {
+			decimal = lastComma
+		}
+	case lastPoint >= 0 || lastComma >= 0:
+		sep := lastPoint
+		if lastComma >= 0 {
+			sep = lastComma
+		}
+
+		// Repeated separators group digits, e.g. 1,000,000
+		if strings.Count(s, string(s[sep])) == 1 {
+			decimal = sep
+
+			// 1 to 3 digits then 3 digits may be a group, e.g. 1,000
+			if sep >= 1 && sep <= 3 && len(s)-sep-1 == 3 {
+				ambiguous = s[sep]
+			}
+		}
+	}
+
+	integer, fraction := s, ""
+	if decimal >= 0 {
+		integer, fraction = s[:decimal], s[decimal+1:]
+	}
+
+	integer = strings.NewReplacer(".", "", ",", "").Replace(integer)
+	if integer == "" {
+		integer = "0"
+	}
+
+	if !isDigits(integer) || (fraction != "" && !isDigits(fraction)) {
+		return "", "", 0, fmt.Errorf("it isn't a number")
+	}
+
+	return integer, fraction, ambiguous, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isCode(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+
+	return true
+}
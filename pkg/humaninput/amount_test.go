@@ -0,0 +1,94 @@
+package humaninput
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		expected int64
+	}{
+		{"1999", "usd", 1999},
+		{"1999", "jpy", 1999},
+		{"$19.99", "", 1999},
+		{"$19.99", "usd", 1999},
+		{"19.99 USD", "", 1999},
+		{"EUR 19,99", "", 1999},
+		{"19,99 €", "", 1999},
+		{"€1.234,50", "", 123450},
+		{"1 234,50 eur", "", 123450},
+		{"$1,000", "", 100000},
+		{"1'000.25 CHF", "", 100025},
+		{"¥500", "", 500},
+		{"5", "", 5},
+		{"5 JPY", "", 5},
+		{"19.5", "usd", 1950},
+		{".5", "usd", 50},
+		{"1.5 KWD", "", 1500},
+		{"1.500 KWD", "", 1500},
+		{"1,500", "bhd", 1500},
+		{"1.500", "jod", 1500},
+		{"1.000 OMR", "", 1000},
+		{"2,250 TND", "", 2250},
+		{"1,000.500 KWD", "", 1000500},
+		{"1.000", "jpy", 1000},
+		{"¥1,000", "", 1000},
+		{"1,000", "usd", 100000},
+		{"1,000.00", "usd", 100000},
+		{"1.000,00 €", "", 100000},
+		{"1,000,000 JPY", "", 1000000},
+	}
+
+	for _, test := range tests {
+		amount, err := ParseAmount(test.input, false)
+		require.NoError(t, err, test.input)
+
+		minor, err := amount.In(test.currency)
+		require.NoError(t, err, test.input)
+		require.Equal(t, test.expected, minor, test.input)
+	}
+}
+
+func TestParseAmountErrors(t *testing.T) {
+	_, err := ParseAmount("$19.99", true)
+	require.EqualError(t, err, `invalid amount "$19.99": with --strict, amounts are integers in the smallest currency unit, e.g. 1999`)
+
+	_, err = ParseAmount("-5", false)
+	require.EqualError(t, err, `invalid amount "-5": the amount must be positive`)
+
+	_, err = ParseAmount("twenty", false)
+	require.EqualError(t, err, `invalid amount "twenty": it isn't a number`)
+
+	amount, err := ParseAmount("19.99", false)
+	require.NoError(t, err)
+	_, err = amount.In("")
+	require.EqualError(t, err, "the currency of 19.99 is unknown: add it to the amount, e.g. 19.99 USD, or pass the amount in the smallest currency unit")
+	_, err = amount.In("jpy")
+	require.EqualError(t, err, "19.99 has more decimals than JPY, which has 0")
+
+	amount, err = ParseAmount("$1.000", false)
+	require.NoError(t, err)
+	_, err = amount.In("")
+	require.EqualError(t, err, "1.000 is ambiguous in USD: write it without the separator, e.g. 1000, or with 2 decimals")
+
+	amount, err = ParseAmount("12345.678", false)
+	require.NoError(t, err)
+	_, err = amount.In("usd")
+	require.EqualError(t, err, "12345.678 has more decimals than USD, which has 2")
+
+	amount, err = ParseAmount("$19.99", false)
+	require.NoError(t, err)
+	require.Equal(t, "usd", amount.Currency())
+	_, err = amount.In("eur")
+	require.EqualError(t, err, "the amount is in USD, but the currency is EUR")
+}
+
+func TestAmountIsZero(t *testing.T) {
+	require.True(t, Amount{}.IsZero())
+	require.True(t, MinorUnits(0).IsZero())
+	require.False(t, MinorUnits(900).IsZero())
+}
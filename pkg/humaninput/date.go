@@ -0,0 +1,130 @@
+package humaninput
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the accepted absolute dates, in local time unless they
+// have a time zone
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+	"wednesday": time.Wednesday, "thursday": time.Thursday, "friday": time.Friday,
+	"saturday": time.Saturday,
+}
+
+// ParseTime parses a date relative to now. It accepts:
+//
+//   - Unix timestamps, e.g. 1767225600
+//   - dates and times, e.g. 2026-11-01, 2026-11-01 15:04 or RFC 3339
+//   - now, today, tomorrow and yesterday
+//   - weekdays, e.g. friday or next friday, which are the next one after
+//     today
+//   - offsets, e.g. in 3 days, 2 weeks ago, next month
+//
+// Dates without a time are at midnight in the time zone of now. With strict
+// set, only Unix timestamps are accepted.
+func ParseTime(input string, now time.Time, strict bool) (time.Time, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(input), " "))
+
+	if isDigits(s) {
+		timestamp, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q: %v", input, err)
+		}
+
+		return time.Unix(timestamp, 0), nil
+	}
+
+	if strict {
+		return time.Time{}, fmt.Errorf("invalid date %q: with --strict, dates are Unix timestamps, e.g. 1767225600", input)
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(s), now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch s {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	words := strings.Fields(s)
+
+	if weekday, ok := weekdays[strings.TrimPrefix(s, "next ")]; ok {
+		days := (int(weekday)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if len(words) == 2 && (words[0] == "next" || words[0] == "last") {
+		n := 1
+		if words[0] == "last" {
+			n = -1
+		}
+
+		if t, ok := addUnits(now, today, n, words[1]); ok {
+			return t, nil
+		}
+	}
+
+	if len(words) == 3 && (words[0] == "in" || words[2] == "ago") {
+		count, unit := words[1], words[2]
+		sign := 1
+
+		if words[2] == "ago" {
+			count, unit = words[0], words[1]
+			sign = -1
+		}
+
+		n, err := strconv.Atoi(count)
+		if err == nil {
+			if t, ok := addUnits(now, today, sign*n, unit); ok {
+				return t, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q: use a date like 2026-11-01, a Unix timestamp, or words like tomorrow, next friday or in 3 days", input)
+}
+
+// addUnits adds n units of time to now, or to today for units of a day or
+// more
+func addUnits(now, today time.Time, n int, unit string) (time.Time, bool) {
+	switch strings.TrimSuffix(unit, "s") {
+	case "minute":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "hour":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "day":
+		return today.AddDate(0, 0, n), true
+	case "week":
+		return today.AddDate(0, 0, 7*n), true
+	case "month":
+		return today.AddDate(0, n, 0), true
+	case "year":
+		return today.AddDate(n, 0, 0), true
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,53 @@
+package humaninput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"1767225600", time.Unix(1767225600, 0)},
+		{"2026-11-01", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-11-01 09:15", time.Date(2026, 11, 1, 9, 15, 0, 0, time.UTC)},
+		{"2026-11-01T09:15:00+02:00", time.Date(2026, 11, 1, 7, 15, 0, 0, time.UTC)},
+		{"now", now},
+		{"today", time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)},
+		{"Tomorrow", time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{"friday", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"next friday", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"next wednesday", time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)},
+		{"in 3 days", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"in 2 hours", time.Date(2026, 10, 14, 17, 30, 0, 0, time.UTC)},
+		{"2 weeks ago", time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)},
+		{"next month", time.Date(2026, 11, 14, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		parsed, err := ParseTime(test.input, now, false)
+		require.NoError(t, err, test.input)
+		require.True(t, test.expected.Equal(parsed), "%s: expected %s, got %s", test.input, test.expected, parsed)
+	}
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	_, err := ParseTime("next friday", now, true)
+	require.EqualError(t, err, `invalid date "next friday": with --strict, dates are Unix timestamps, e.g. 1767225600`)
+
+	parsed, err := ParseTime("1767225600", now, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(1767225600), parsed.Unix())
+
+	_, err = ParseTime("someday", now, false)
+	require.EqualError(t, err, `invalid date "someday": use a date like 2026-11-01, a Unix timestamp, or words like tomorrow, next friday or in 3 days`)
+}
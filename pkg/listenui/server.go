@@ -0,0 +1,220 @@
+// Package listenui serves a local web page listing the events received by a
+// `stripe listen` session, with payload inspection and replays.
+package listenui
+
+import (
+	"context"
+	_ "embed" // for the index page
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+//
+// Public types
+//
+
+// Session is the listen session whose events are displayed. It's
+// implemented by *proxy.Proxy.
+type Session interface {
+	RecentEvents() []proxy.ReceivedEvent
+	ReplayEvent(id string) (*proxy.StripeEvent, error)
+}
+
+// Server serves the event viewer
+type Server struct {
+	session Session
+	mux     *http.ServeMux
+
+	// host and port are the ones the server listens on, set by Listen
+	host string
+	port string
+}
+
+//
+// Public functions
+//
+
+// New returns a new Server for session.
+func New(session Session) *Server {
+	s := &Server{
+		session: session,
+		mux:     http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/", s.handleIndex)
+	s.mux.HandleFunc("/api/events", s.handleEvents)
+	s.mux.HandleFunc("/api/events/", s.handleReplay)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler. Requests are only served for the hosts
+// the server listens on, so that other sites can't read the events through
+// the developer's browser with DNS rebinding.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedHost(r.Host) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// Listen starts listening on addr. When addr has no host, e.g. ":4500", the
+// server only listens on localhost since payloads may hold customer data.
+// Requests must name localhost, 127.0.0.1, [::1] or the host of addr, with
+// the port listened on. It returns the URL of the event viewer.
+func (s *Server) Listen(ctx context.Context, addr string) (string, error) {
+	if strings.HasPrefix(addr, ":") {
+		addr = "localhost" + addr
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+
+	s.host, _, _ = net.SplitHostPort(addr)
+	_, s.port, _ = net.SplitHostPort(listener.Addr().String())
+
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.WithPrefix(log.StandardLogger(), "listenui.Server.Listen").Error(err)
+		}
+	}()
+
+	return "http://" + listener.Addr().String(), nil
+}
+
+//
+// Private variables
+//
+
+//go:embed index.html
+var indexPage []byte
+
+//
+// Private functions
+//
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexPage) // #nosec G104
+}
+
+// handleEvents lists the received events, filtered by the `type` query
+// parameter, which accepts wildcards like `charge.*`, and by the `q` query
+// parameter, matched against the payloads.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	typeFilter := r.URL.Query().Get("type")
+	query := r.URL.Query().Get("q")
+
+	events := make([]proxy.ReceivedEvent, 0)
+
+	for _, evt := range s.session.RecentEvents() {
+		if typeFilter != "" {
+			if ok, _ := path.Match(typeFilter, evt.Type); !ok {
+				continue
+			}
+		}
+
+		if query != "" && !strings.Contains(evt.Payload, query) {
+			continue
+		}
+
+		events = append(events, evt)
+	}
+
+	writeJSON(w, http.StatusOK, events)
+}
+
+// handleReplay replays an event, on POST /api/events/{id}/replay.
+func (s *Server) handleReplay(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/events/"), "/replay")
+	if id == "" || strings.Contains(id, "/") || !strings.HasSuffix(r.URL.Path, "/replay") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Reject requests from other sites, which could replay events through
+	// the developer's browser.
+	if origin := r.Header.Get("Origin"); origin != "" && origin != "http://"+r.Host {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	evt, err := s.session.ReplayEvent(id)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": evt.ID, "type": evt.Type})
+}
+
+// allowedHost returns whether host, the Host header of a request, names the
+// server. The port isn't checked until Listen is called.
+func (s *Server) allowedHost(host string) bool {
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		name = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		port = ""
+	}
+
+	if s.port != "" && port != s.port {
+		return false
+	}
+
+	switch name = strings.ToLower(name); name {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+
+	// Hosts binding every interface, e.g. 0.0.0.0, don't name the server
+	if ip := net.ParseIP(s.host); s.host == "" || (ip != nil && ip.IsUnspecified()) {
+		return false
+	}
+
+	return name == s.host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) // #nosec G104
+}
@@ -0,0 +1,145 @@
+package listenui
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/proxy"
+)
+
+type fakeSession struct {
+	replayed []string
+}
+
+func (s *fakeSession) RecentEvents() []proxy.ReceivedEvent {
+	return []proxy.ReceivedEvent{
+		{ID: "evt_2", Type: "invoice.paid", Payload: `{"id":"evt_2","customer":"cus_123"}`},
+		{ID: "evt_1", Type: "charge.succeeded", Payload: `{"id":"evt_1"}`},
+	}
+}
+
+func (s *fakeSession) ReplayEvent(id string) (*proxy.StripeEvent, error) {
+	if id != "evt_1" {
+		return nil, proxy.ErrEventNotFound
+	}
+
+	s.replayed = append(s.replayed, id)
+
+	return &proxy.StripeEvent{ID: id, Type: "charge.succeeded"}, nil
+}
+
+// newRequest returns a request to the server, which only serves localhost
+func newRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Host = "localhost"
+
+	return req
+}
+
+func listEvents(t *testing.T, server *Server, query string) []proxy.ReceivedEvent {
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, newRequest(http.MethodGet, "/api/events"+query, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	events := make([]proxy.ReceivedEvent, 0)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &events))
+
+	return events
+}
+
+func TestListEvents(t *testing.T) {
+	server := New(&fakeSession{})
+
+	require.Len(t, listEvents(t, server, ""), 2)
+
+	events := listEvents(t, server, "?type=charge.*")
+	require.Len(t, events, 1)
+	require.Equal(t, "evt_1", events[0].ID)
+
+	events = listEvents(t, server, "?q=cus_123")
+	require.Len(t, events, 1)
+	require.Equal(t, "evt_2", events[0].ID)
+}
+
+func TestReplayEvent(t *testing.T) {
+	session := &fakeSession{}
+	server := New(session)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, newRequest(http.MethodPost, "/api/events/evt_1/replay", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, []string{"evt_1"}, session.replayed)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, newRequest(http.MethodPost, "/api/events/evt_3/replay", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, newRequest(http.MethodGet, "/api/events/evt_1/replay", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	req := newRequest(http.MethodPost, "/api/events/evt_1/replay", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Len(t, session.replayed, 1)
+}
+
+func TestIndex(t *testing.T) {
+	server := New(&fakeSession{})
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, newRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "<title>Stripe CLI - Events</title>")
+}
+
+func TestRejectOtherHosts(t *testing.T) {
+	session := &fakeSession{}
+	server := New(session)
+
+	for _, host := range []string{"localhost:4500", "127.0.0.1", "[::1]:4500"} {
+		req := newRequest(http.MethodGet, "/api/events", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, host)
+	}
+
+	// A site rebinding its domain to 127.0.0.1
+	for _, target := range []string{"/", "/api/events", "/api/events/evt_1/replay"} {
+		req := newRequest(http.MethodPost, target, nil)
+		req.Host = "attacker.example.com:4500"
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusForbidden, rec.Code, target)
+	}
+	require.Empty(t, session.replayed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	url, err := server.Listen(ctx, "127.0.0.1:0")
+	require.NoError(t, err)
+
+	resp, err := http.Get(url + "/api/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The port must be the one listened on
+	req, err := http.NewRequest(http.MethodGet, url+"/api/events", nil)
+	require.NoError(t, err)
+	req.Host = "localhost:1"
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -0,0 +1,336 @@
+package loadgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the default maximum number of scenarios running at
+// the same time
+const DefaultConcurrency = 5
+
+//
+// Public types
+//
+
+// Scenario is a trigger or fixture run as part of the load, picked with a
+// probability proportional to its weight
+type Scenario struct {
+	Name   string
+	Weight int
+}
+
+// RunFunc runs a single scenario
+type RunFunc func(ctx context.Context, scenario string) error
+
+// Config configures a load generation run
+type Config struct {
+	Scenarios []Scenario
+
+	// Rate is the target number of scenarios started per second
+	Rate float64
+
+	// Duration is how long the load is generated for, including the ramp-up
+	Duration time.Duration
+
+	// RampUp is the time over which the rate increases linearly from zero
+	// to Rate
+	RampUp time.Duration
+
+	// Concurrency is the maximum number of scenarios running at the same
+	// time. Scenarios that would exceed it are dropped.
+	Concurrency int
+
+	// Run runs a single scenario
+	Run RunFunc
+
+	// Used for tests
+	rand *rand.Rand
+}
+
+// Stats are the results of the runs of a scenario
+type Stats struct {
+	Count  int
+	Errors int
+	P50    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+
+	latencies []time.Duration
+}
+
+// Report summarizes a load generation run
+type Report struct {
+	Duration  time.Duration
+	Started   int
+	Dropped   int
+	Total     Stats
+	Scenarios map[string]*Stats
+}
+
+//
+// Public functions
+//
+
+// ParseScenario parses a scenario in the `name=weight` format. The weight
+// defaults to 1.
+func ParseScenario(value string) (Scenario, error) {
+	parts := strings.SplitN(value, "=", 2)
+
+	scenario := Scenario{Name: parts[0], Weight: 1}
+	if scenario.Name == "" {
+		return Scenario{}, fmt.Errorf("invalid scenario %q: missing name", value)
+	}
+
+	if len(parts) == 2 {
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight <= 0 {
+			return Scenario{}, fmt.Errorf("invalid scenario %q: weight must be a positive integer", value)
+		}
+
+		scenario.Weight = weight
+	}
+
+	return scenario, nil
+}
+
+// Run generates load until the configured duration elapses or ctx is done,
+// then waits for the running scenarios and returns a report.
+func Run(ctx context.Context, cfg *Config) (*Report, error) {
+	if len(cfg.Scenarios) == 0 {
+		return nil, fmt.Errorf("at least one scenario is required")
+	}
+
+	if cfg.Rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+
+	if cfg.rand == nil {
+		cfg.rand = rand.New(rand.NewSource(time.Now().UnixNano())) // #nosec G404
+	}
+
+	totalWeight := 0
+	for _, s := range cfg.Scenarios {
+		totalWeight += s.Weight
+	}
+
+	report := &Report{Scenarios: make(map[string]*Stats)}
+	for _, s := range cfg.Scenarios {
+		report.Scenarios[s.Name] = &Stats{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	slots := make(chan struct{}, cfg.Concurrency)
+	start := time.Now()
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	// budget accumulates the fractional number of scenarios to start
+	budget := 0.0
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case now := <-ticker.C:
+			elapsed := now.Sub(start)
+			if elapsed >= cfg.Duration {
+				break loop
+			}
+
+			budget += cfg.currentRate(elapsed) * tickInterval.Seconds()
+
+			for ; budget >= 1; budget-- {
+				scenario := cfg.pick(totalWeight)
+
+				select {
+				case slots <- struct{}{}:
+				default:
+					report.Dropped++
+					continue
+				}
+
+				report.Started++
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+					defer func() { <-slots }()
+
+					runStart := time.Now()
+					err := cfg.Run(ctx, scenario)
+					latency := time.Since(runStart)
+
+					mu.Lock()
+					defer mu.Unlock()
+
+					report.Scenarios[scenario].add(latency, err)
+					report.Total.add(latency, err)
+				}()
+			}
+		}
+	}
+
+	wg.Wait()
+
+	report.Duration = time.Since(start)
+	report.Total.computePercentiles()
+	for _, stats := range report.Scenarios {
+		stats.computePercentiles()
+	}
+
+	return report, nil
+}
+
+// Print writes a human readable version of the report to w.
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "Ran for %s: %d started, %d dropped (concurrency limit)\n\n", r.Duration.Round(time.Millisecond), r.Started, r.Dropped)
+
+	names := make([]string, 0, len(r.Scenarios))
+	for name := range r.Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "%-40s %8s %8s %10s %10s %10s\n", "SCENARIO", "COUNT", "ERRORS", "P50", "P95", "P99")
+	for _, name := range names {
+		printStats(w, name, r.Scenarios[name])
+	}
+	printStats(w, "total", &r.Total)
+}
+
+// JSON returns the report as JSON, with latencies in milliseconds.
+func (r *Report) JSON() ([]byte, error) {
+	type jsonStats struct {
+		Count  int     `json:"count"`
+		Errors int     `json:"errors"`
+		P50    float64 `json:"p50_ms"`
+		P95    float64 `json:"p95_ms"`
+		P99    float64 `json:"p99_ms"`
+	}
+
+	toJSON := func(s *Stats) jsonStats {
+		return jsonStats{
+			Count:  s.Count,
+			Errors: s.Errors,
+			P50:    milliseconds(s.P50),
+			P95:    milliseconds(s.P95),
+			P99:    milliseconds(s.P99),
+		}
+	}
+
+	scenarios := make(map[string]jsonStats, len(r.Scenarios))
+	for name, stats := range r.Scenarios {
+		scenarios[name] = toJSON(stats)
+	}
+
+	return json.MarshalIndent(struct {
+		Duration  float64              `json:"duration_ms"`
+		Started   int                  `json:"started"`
+		Dropped   int                  `json:"dropped"`
+		Total     jsonStats            `json:"total"`
+		Scenarios map[string]jsonStats `json:"scenarios"`
+	}{
+		Duration:  milliseconds(r.Duration),
+		Started:   r.Started,
+		Dropped:   r.Dropped,
+		Total:     toJSON(&r.Total),
+		Scenarios: scenarios,
+	}, "", "  ")
+}
+
+//
+// Private constants
+//
+
+const tickInterval = 50 * time.Millisecond
+
+//
+// Private functions
+//
+
+// currentRate returns the target rate after elapsed time, accounting for
+// the ramp-up.
+func (cfg *Config) currentRate(elapsed time.Duration) float64 {
+	if cfg.RampUp <= 0 || elapsed >= cfg.RampUp {
+		return cfg.Rate
+	}
+
+	return cfg.Rate * float64(elapsed) / float64(cfg.RampUp)
+}
+
+// pick randomly selects a scenario according to the weights.
+func (cfg *Config) pick(totalWeight int) string {
+	n := cfg.rand.Intn(totalWeight)
+
+	for _, s := range cfg.Scenarios {
+		if n < s.Weight {
+			return s.Name
+		}
+		n -= s.Weight
+	}
+
+	return cfg.Scenarios[len(cfg.Scenarios)-1].Name
+}
+
+func (s *Stats) add(latency time.Duration, err error) {
+	s.Count++
+	if err != nil {
+		s.Errors++
+	}
+
+	s.latencies = append(s.latencies, latency)
+}
+
+func (s *Stats) computePercentiles() {
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+
+	s.P50 = percentile(s.latencies, 50)
+	s.P95 = percentile(s.latencies, 95)
+	s.P99 = percentile(s.latencies, 99)
+}
+
+// percentile returns the p-th percentile of sorted latencies using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func printStats(w io.Writer, name string, s *Stats) {
+	fmt.Fprintf(w, "%-40s %8d %8d %10s %10s %10s\n",
+		name,
+		s.Count,
+		s.Errors,
+		s.P50.Round(time.Millisecond),
+		s.P95.Round(time.Millisecond),
+		s.P99.Round(time.Millisecond),
+	)
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
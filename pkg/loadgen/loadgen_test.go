@@ -0,0 +1,113 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScenario(t *testing.T) {
+	scenario, err := ParseScenario("charge.succeeded=3")
+	require.NoError(t, err)
+	require.Equal(t, Scenario{Name: "charge.succeeded", Weight: 3}, scenario)
+
+	scenario, err = ParseScenario("./fixtures/checkout.json")
+	require.NoError(t, err)
+	require.Equal(t, Scenario{Name: "./fixtures/checkout.json", Weight: 1}, scenario)
+
+	_, err = ParseScenario("charge.succeeded=0")
+	require.Error(t, err)
+
+	_, err = ParseScenario("=2")
+	require.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	var runs int32
+
+	report, err := Run(context.Background(), &Config{
+		Scenarios: []Scenario{
+			{Name: "charge.succeeded", Weight: 3},
+			{Name: "customer.created", Weight: 1},
+		},
+		Rate:        100,
+		Duration:    300 * time.Millisecond,
+		Concurrency: 100,
+		Run: func(ctx context.Context, scenario string) error {
+			atomic.AddInt32(&runs, 1)
+			if scenario == "customer.created" {
+				return errors.New("boom")
+			}
+			return nil
+		},
+		rand: rand.New(rand.NewSource(1)),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int(runs), report.Started)
+	require.Equal(t, report.Started, report.Total.Count)
+	require.Greater(t, report.Started, 10)
+	require.Equal(t, report.Scenarios["customer.created"].Count, report.Total.Errors)
+	require.Greater(t, report.Scenarios["charge.succeeded"].Count, report.Scenarios["customer.created"].Count)
+	require.Zero(t, report.Dropped)
+
+	var b bytes.Buffer
+	report.Print(&b)
+	require.Contains(t, b.String(), "charge.succeeded")
+
+	data, err := report.JSON()
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"p99_ms"`)
+}
+
+func TestRunDropsOverConcurrency(t *testing.T) {
+	block := make(chan struct{})
+
+	done := make(chan *Report)
+	go func() {
+		report, _ := Run(context.Background(), &Config{
+			Scenarios:   []Scenario{{Name: "charge.succeeded", Weight: 1}},
+			Rate:        100,
+			Duration:    200 * time.Millisecond,
+			Concurrency: 1,
+			Run: func(ctx context.Context, scenario string) error {
+				<-block
+				return nil
+			},
+		})
+		done <- report
+	}()
+
+	time.Sleep(250 * time.Millisecond)
+	close(block)
+
+	report := <-done
+	require.Equal(t, 1, report.Started)
+	require.Greater(t, report.Dropped, 0)
+}
+
+func TestCurrentRate(t *testing.T) {
+	cfg := &Config{Rate: 10, RampUp: 10 * time.Second}
+
+	require.Equal(t, 0.0, cfg.currentRate(0))
+	require.Equal(t, 5.0, cfg.currentRate(5*time.Second))
+	require.Equal(t, 10.0, cfg.currentRate(20*time.Second))
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	require.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	require.Equal(t, 95*time.Millisecond, percentile(latencies, 95))
+	require.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	require.Zero(t, percentile(nil, 50))
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//
+// Public types
+//
+
+// ComponentFormatter formats the entries of its base level and above with
+// Formatter, and the more verbose ones only for the enabled components, so
+// that users can debug one subsystem without the logs of the others.
+type ComponentFormatter struct {
+	Formatter log.Formatter
+
+	// BaseLevel is the level logged for all the components
+	BaseLevel log.Level
+
+	packages map[string]bool
+}
+
+//
+// Public functions
+//
+
+// Components lists the components that can be debugged separately
+func Components() []string {
+	names := make([]string, 0, len(componentPackages))
+	for name := range componentPackages {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewComponentFormatter returns a formatter logging the entries more verbose
+// than baseLevel only for components, a comma-separated list of names
+// returned by Components, or "all".
+func NewComponentFormatter(formatter log.Formatter, baseLevel log.Level, components string) (*ComponentFormatter, error) {
+	f := &ComponentFormatter{
+		Formatter: formatter,
+		BaseLevel: baseLevel,
+		packages:  make(map[string]bool),
+	}
+
+	for _, name := range strings.Split(components, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if name == "all" {
+			f.packages = nil
+			break
+		}
+
+		packages, ok := componentPackages[name]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized debug component: %s. Expected one of all, %s", name, strings.Join(Components(), ", "))
+		}
+
+		for _, pkg := range packages {
+			f.packages[pkg] = true
+		}
+	}
+
+	return f, nil
+}
+
+// Format formats the entry, or returns nothing if it's filtered out
+func (f *ComponentFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if entry.Level > f.BaseLevel && f.packages != nil && !f.packages[entryPackage(entry)] {
+		return nil, nil
+	}
+
+	return f.Formatter.Format(entry)
+}
+
+//
+// Private variables
+//
+
+// componentPackages maps the components to the packages logging for them,
+// as named in the prefix field of the entries, e.g. "proxy" for
+// "proxy.Proxy.Run"
+var componentPackages = map[string][]string{
+	"api":       {"stripe"},
+	"auth":      {"stripeauth"},
+	"config":    {"config"},
+	"daemon":    {"gRPC", "cmd"},
+	"listen":    {"proxy", "listenui", "heartbeat", "stripecli"},
+	"logs":      {"logtailing"},
+	"notify":    {"notify"},
+	"samples":   {"samples"},
+	"schedule":  {"schedule"},
+	"shutdown":  {"shutdown"},
+	"websocket": {"websocket", "stripecli"},
+}
+
+//
+// Private functions
+//
+
+func entryPackage(entry *log.Entry) string {
+	prefix, _ := entry.Data["prefix"].(string)
+
+	if i := strings.Index(prefix, "."); i >= 0 {
+		return prefix[:i]
+	}
+
+	return prefix
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentFormatter(t *testing.T) {
+	formatter, err := NewComponentFormatter(&log.TextFormatter{DisableTimestamp: true}, log.InfoLevel, "listen, websocket")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(formatter)
+	logger.SetLevel(log.DebugLevel)
+
+	logger.WithField("prefix", "proxy.Proxy.Run").Debug("proxy debug")
+	logger.WithField("prefix", "websocket.Client.readPump").Debug("websocket debug")
+	logger.WithField("prefix", "logtailing.Tailer.Run").Debug("tailer debug")
+	logger.WithField("prefix", "logtailing.Tailer.Run").Info("tailer info")
+	logger.Debug("unprefixed debug")
+
+	out := buf.String()
+	require.Contains(t, out, "proxy debug")
+	require.Contains(t, out, "websocket debug")
+	require.Contains(t, out, "tailer info")
+	require.NotContains(t, out, "tailer debug")
+	require.NotContains(t, out, "unprefixed debug")
+}
+
+func TestComponentFormatterAll(t *testing.T) {
+	formatter, err := NewComponentFormatter(&log.TextFormatter{}, log.InfoLevel, "all")
+	require.NoError(t, err)
+
+	out, err := formatter.Format(&log.Entry{Level: log.DebugLevel, Data: log.Fields{"prefix": "notify.Send"}, Message: "hello"})
+	require.NoError(t, err)
+	require.Contains(t, string(out), "hello")
+}
+
+func TestComponentFormatterUnknown(t *testing.T) {
+	_, err := NewComponentFormatter(&log.TextFormatter{}, log.InfoLevel, "listen,plugins")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unrecognized debug component: plugins")
+}
@@ -0,0 +1,36 @@
+// Package logging is the facade the CLI logs through. Entries are made with
+// WithPrefix, which names the function logging them, so that --debug can
+// filter them by component, and the standard logger is set up from the
+// global --log-level, --log-format and --log-file flags.
+package logging
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// WithPrefix returns an entry of logger for the function or method named
+// prefix, e.g. "proxy.Proxy.Run". The package of the prefix is the
+// component the entry belongs to.
+func WithPrefix(logger log.FieldLogger, prefix string) *log.Entry {
+	return logger.WithField("prefix", prefix)
+}
+
+// SetOutputFile makes the standard logger append to the file at path, and
+// returns the function restoring the previous output and closing the file.
+func SetOutputFile(path string) (func() error, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := log.StandardLogger()
+	previous := logger.Out
+	logger.SetOutput(f)
+
+	return func() error {
+		logger.SetOutput(previous)
+		return f.Close()
+	}, nil
+}
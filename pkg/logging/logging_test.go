@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPrefix(t *testing.T) {
+	entry := WithPrefix(log.New(), "proxy.Proxy.Run")
+	require.Equal(t, "proxy.Proxy.Run", entry.Data["prefix"])
+	require.Equal(t, "proxy", entryPackage(entry))
+}
+
+func TestSetOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stripe.log")
+	previous := log.StandardLogger().Out
+
+	closeFile, err := SetOutputFile(path)
+	require.NoError(t, err)
+
+	log.Warn("to the file")
+	require.NoError(t, closeFile())
+	require.Equal(t, previous, log.StandardLogger().Out)
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "to the file")
+
+	_, err = SetOutputFile(filepath.Join(t.TempDir(), "missing", "stripe.log"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/briandowns/spinner"
 
@@ -24,6 +25,9 @@ var canOpenBrowser = open.CanOpenBrowser
 
 const stripeCLIAuthPath = "/stripecli/auth"
 
+// keyLifetime is how long the keys created by the login flow are valid
+const keyLifetime = 90 * 24 * time.Hour
+
 // Links provides the URLs for the CLI to continue the login flow
 type Links struct {
 	BrowserURL       string `json:"browser_url"`
@@ -104,6 +108,7 @@ func ConfigureProfile(config *config.Config, response *PollAPIKeyResponse) error
 	config.Profile.TestModePublishableKey = response.TestModePublishableKey
 	config.Profile.DisplayName = response.AccountDisplayName
 	config.Profile.AccountID = response.AccountID
+	config.Profile.KeysExpireAt = time.Now().Add(keyLifetime)
 
 	profileErr := config.Profile.CreateProfile()
 	if profileErr != nil {
@@ -84,7 +84,7 @@ func getConfigureAPIKey(input io.Reader) (string, error) {
 		return "", err
 	}
 
-	fmt.Printf("Your API key is: %s\n", redactAPIKey(apiKey))
+	fmt.Printf("Your API key is: %s\n", RedactAPIKey(apiKey))
 
 	return apiKey, nil
 }
@@ -104,11 +104,11 @@ func getConfigureDeviceName(input io.Reader) string {
 	return deviceName
 }
 
-// redactAPIKey returns a redacted version of API keys. The first 8 and last 4
+// RedactAPIKey returns a redacted version of API keys. The first 8 and last 4
 // characters are not redacted, everything else is replaced by "*" characters.
 //
 // It panics if the provided string has less than 12 characters.
-func redactAPIKey(apiKey string) string {
+func RedactAPIKey(apiKey string) string {
 	var b strings.Builder
 
 	b.WriteString(apiKey[0:8])                         // #nosec G104 (gosec bug: https://github.com/securego/gosec/issues/267)
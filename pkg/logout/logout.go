@@ -1,13 +1,17 @@
 package logout
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/stripe/stripe-cli/pkg/config"
 )
 
-// Logout function is used to clear the credentials set for the current Profile
-func Logout(config *config.Config) error {
+// Logout function is used to clear the credentials set for the current Profile.
+// With revoke, the keys created by `stripe login` are revoked server-side
+// first, and the credentials are kept if that fails.
+func Logout(ctx context.Context, config *config.Config, apiBaseURL string, revoke bool) error {
 	liveKey, _ := config.Profile.GetAPIKey(true)
 	testKey, _ := config.Profile.GetAPIKey(false)
 
@@ -18,6 +22,12 @@ func Logout(config *config.Config) error {
 
 	fmt.Println("Logging out...")
 
+	if revoke {
+		if err := revokeLoginKeys(ctx, &config.Profile, apiBaseURL); err != nil {
+			return err
+		}
+	}
+
 	profileName := config.Profile.ProfileName
 
 	err := config.RemoveProfile(profileName)
@@ -34,10 +44,22 @@ func Logout(config *config.Config) error {
 	return nil
 }
 
-// All function is used to clear the credentials on all profiles
-func All(cfg *config.Config) error {
+// All function is used to clear the credentials on all profiles. With revoke,
+// the keys created by `stripe login` are revoked server-side first, and the
+// credentials are kept if that fails.
+func All(ctx context.Context, cfg *config.Config, apiBaseURL string, revoke bool) error {
 	fmt.Println("Logging out...")
 
+	if revoke {
+		for _, name := range cfg.ListProfiles() {
+			profile := &config.Profile{ProfileName: name}
+
+			if err := revokeLoginKeys(ctx, profile, apiBaseURL); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
 	err := cfg.RemoveAllProfiles()
 	if err != nil {
 		return err
@@ -47,3 +69,29 @@ func All(cfg *config.Config) error {
 
 	return nil
 }
+
+func revokeLoginKeys(ctx context.Context, profile *config.Profile, apiBaseURL string) error {
+	for _, livemode := range []bool{false, true} {
+		key, ok := profile.GetLoginKey(livemode)
+		if !ok {
+			continue
+		}
+
+		mode := "test mode"
+		if livemode {
+			mode = "live mode"
+		}
+
+		err := RevokeKey(ctx, apiBaseURL, key)
+		if errors.Is(err, ErrRevocationUnsupported) {
+			fmt.Printf("Warning: %s, the %s key of %s is only deleted from this machine. Roll it in the Dashboard if it was copied elsewhere.\n", err, mode, profile.ProfileName)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("%w. The credentials were kept, run `stripe logout --revoke=false` to only delete them from this machine", err)
+		}
+
+		fmt.Printf("Revoked the %s key of %s.\n", mode, profile.ProfileName)
+	}
+
+	return nil
+}
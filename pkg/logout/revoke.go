@@ -0,0 +1,62 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// revokePath is the endpoint revoking the key a request is authenticated
+// with. It only accepts the keys created by the login flow. It isn't in the
+// public API reference, so the API not knowing it is handled as revocation
+// being unsupported rather than as a failure.
+const revokePath = "/v1/stripecli/revoke"
+
+// ErrRevocationUnsupported is returned when the API doesn't have the
+// endpoint revoking keys
+var ErrRevocationUnsupported = errors.New("the API doesn't support revoking the keys of stripe login")
+
+// RevokeKey revokes a key created by `stripe login` server-side, then checks
+// that the API rejects it
+func RevokeKey(ctx context.Context, apiBaseURL, apiKey string) error {
+	baseURL, err := url.Parse(apiBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := &stripe.Client{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodPost, revokePath, "", nil)
+	if err != nil {
+		return fmt.Errorf("could not revoke the key: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		return ErrRevocationUnsupported
+	}
+
+	// Keys that were already revoked are rejected
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("could not revoke the key: unexpected status %d", resp.StatusCode)
+	}
+
+	resp, err = client.PerformRequest(ctx, http.MethodGet, "/v1/account", "", nil)
+	if err != nil {
+		return fmt.Errorf("could not verify that the key was revoked: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("the key still works after being revoked")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package logout
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeKey(t *testing.T) {
+	revoked := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer rk_test_123", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == revokePath:
+			revoked = true
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/account" && revoked:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/account":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	require.NoError(t, RevokeKey(context.Background(), ts.URL, "rk_test_123"))
+	require.True(t, revoked)
+}
+
+func TestRevokeKeyStillWorking(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	err := RevokeKey(context.Background(), ts.URL, "rk_test_123")
+	require.EqualError(t, err, "the key still works after being revoked")
+}
+
+func TestRevokeKeyFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	err := RevokeKey(context.Background(), ts.URL, "rk_test_123")
+	require.EqualError(t, err, "could not revoke the key: unexpected status 500")
+}
+
+func TestRevokeKeyUnsupported(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusMethodNotAllowed} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		err := RevokeKey(context.Background(), ts.URL, "rk_test_123")
+		require.Equal(t, ErrRevocationUnsupported, err)
+
+		ts.Close()
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/stripeauth"
 	"github.com/stripe/stripe-cli/pkg/websocket"
 )
@@ -47,6 +48,10 @@ type Config struct {
 	// Force use of unencrypted ws:// protocol instead of wss://
 	NoWSS bool
 
+	// Tuning adjusts the keepalive, reconnections and timeouts of the
+	// websocket connection
+	Tuning websocket.Tuning
+
 	// OutCh is the channel to send logs and statuses to for processing in other packages
 	OutCh chan websocket.IElement
 }
@@ -135,10 +140,14 @@ func (t *Tailer) Run(ctx context.Context) error {
 			session.WebSocketID,
 			session.WebSocketAuthorizedFeature,
 			&websocket.Config{
-				EventHandler:      websocket.EventHandlerFunc(t.processRequestLogEvent),
-				Log:               t.cfg.Log,
-				NoWSS:             t.cfg.NoWSS,
-				ReconnectInterval: time.Duration(session.ReconnectDelay) * time.Second,
+				EventHandler:       websocket.EventHandlerFunc(t.processRequestLogEvent),
+				Log:                t.cfg.Log,
+				NoWSS:              t.cfg.NoWSS,
+				ReconnectInterval:  time.Duration(session.ReconnectDelay) * time.Second,
+				PingPeriod:         t.cfg.Tuning.PingPeriod,
+				ConnectAttemptWait: t.cfg.Tuning.ReconnectMaxBackoff,
+				ConnectTimeout:     t.cfg.Tuning.ConnectTimeout,
+				OnReconnect:        t.logReconnect,
 			},
 		)
 
@@ -155,6 +164,9 @@ func (t *Tailer) Run(ctx context.Context) error {
 
 		select {
 		case <-ctx.Done():
+			// Let the websocket client send the close frame before returning
+			<-t.webSocketClient.Stopped()
+
 			t.cfg.OutCh <- &websocket.StateElement{
 				State: websocket.Done,
 			}
@@ -178,13 +190,15 @@ func (t *Tailer) Run(ctx context.Context) error {
 		t.webSocketClient.Stop()
 	}
 
-	log.WithFields(log.Fields{
-		"prefix": "logtailing.Tailer.Run",
-	}).Debug("Bye!")
+	logging.WithPrefix(log.StandardLogger(), "logtailing.Tailer.Run").Debug("Bye!")
 
 	return nil
 }
 
+func (t *Tailer) logReconnect(downtime time.Duration) {
+	logging.WithPrefix(t.cfg.Log, "logtailing.Tailer.logReconnect").Infof("Connection to Stripe lost, reconnected after %s", downtime.Round(time.Millisecond))
+}
+
 func (t *Tailer) createSession(ctx context.Context) (*stripeauth.StripeCLISession, error) {
 	var session *stripeauth.StripeCLISession
 
@@ -231,8 +245,7 @@ func (t *Tailer) processRequestLogEvent(msg websocket.IncomingMessage) {
 
 	requestLogEvent := msg.RequestLogEvent
 
-	t.cfg.Log.WithFields(log.Fields{
-		"prefix":     "logtailing.Tailer.processRequestLogEvent",
+	logging.WithPrefix(t.cfg.Log, "logtailing.Tailer.processRequestLogEvent").WithFields(log.Fields{
 		"webhook_id": requestLogEvent.RequestLogID,
 	}).Debugf("Processing request log event")
 
@@ -0,0 +1,377 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/offline"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// Conditions that can trigger a notification
+const (
+	// ListenForwardFailed is raised when `stripe listen` fails to forward an
+	// event to a local endpoint
+	ListenForwardFailed = "listen_forward_failed"
+
+	// LogsServerErrorSpike is raised when `stripe logs tail` sees many 5xx
+	// responses in a short time
+	LogsServerErrorSpike = "logs_5xx_spike"
+
+	// FixturesCompleted is raised when a fixture run completes
+	FixturesCompleted = "fixtures_completed"
+)
+
+// Sink types
+const (
+	SinkSlack   = "slack"
+	SinkHTTP    = "http"
+	SinkDesktop = "desktop"
+)
+
+// DefaultCooldown is the minimum time between two notifications for the same
+// condition
+const DefaultCooldown = time.Minute
+
+// DefaultSpikeThreshold is the number of 5xx responses in DefaultSpikeWindow
+// that makes a spike
+const DefaultSpikeThreshold = 5
+
+// DefaultSpikeWindow is the time window 5xx responses are counted in
+const DefaultSpikeWindow = time.Minute
+
+const sendTimeout = 10 * time.Second
+
+// windowsNotificationScript shows a balloon tip from the notification area,
+// which Windows 10 and later show as a toast notification
+const windowsNotificationScript = `Add-Type -AssemblyName System.Windows.Forms
+$icon = New-Object System.Windows.Forms.NotifyIcon
+$icon.Icon = [System.Drawing.SystemIcons]::Information
+$icon.Visible = $true
+$icon.ShowBalloonTip(5000, $env:STRIPE_NOTIFICATION_TITLE, $env:STRIPE_NOTIFICATION_MESSAGE, [System.Windows.Forms.ToolTipIcon]::None)
+Start-Sleep -Seconds 5
+$icon.Dispose()`
+
+//
+// Public types
+//
+
+// SinkConfig configures a destination for notifications. Conditions lists
+// the conditions this sink is notified of; all conditions are sent when it
+// is empty.
+type SinkConfig struct {
+	Type       string   `mapstructure:"type"`
+	URL        string   `mapstructure:"url"`
+	Conditions []string `mapstructure:"conditions"`
+}
+
+// Config is the `[notifications]` section of the configuration file
+type Config struct {
+	Sinks []SinkConfig `mapstructure:"sinks"`
+
+	Cooldown       time.Duration `mapstructure:"cooldown"`
+	SpikeThreshold int           `mapstructure:"spike_threshold"`
+	SpikeWindow    time.Duration `mapstructure:"spike_window"`
+}
+
+// Notification is an alert sent to the configured sinks
+type Notification struct {
+	Condition string    `json:"condition"`
+	Title     string    `json:"title"`
+	Message   string    `json:"message"`
+	Time      time.Time `json:"time"`
+}
+
+// Notifier sends notifications to the configured sinks. A nil Notifier
+// ignores all notifications.
+type Notifier struct {
+	cfg   *Config
+	sinks []sink
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+
+	// Used for tests
+	now func() time.Time
+}
+
+// SpikeDetector counts events in a sliding time window
+type SpikeDetector struct {
+	threshold int
+	window    time.Duration
+	times     []time.Time
+}
+
+//
+// Public functions
+//
+
+// Load reads the `[notifications]` section of the configuration file. It
+// returns a nil Notifier when no sinks are configured.
+func Load() (*Notifier, error) {
+	return load(viper.GetViper())
+}
+
+// New creates a Notifier from cfg. It returns a nil Notifier when no sinks
+// are configured.
+func New(cfg *Config) (*Notifier, error) {
+	if len(cfg.Sinks) == 0 {
+		return nil, nil
+	}
+
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = DefaultCooldown
+	}
+
+	if cfg.SpikeThreshold == 0 {
+		cfg.SpikeThreshold = DefaultSpikeThreshold
+	}
+
+	if cfg.SpikeWindow == 0 {
+		cfg.SpikeWindow = DefaultSpikeWindow
+	}
+
+	sinks := make([]sink, 0, len(cfg.Sinks))
+
+	for _, sc := range cfg.Sinks {
+		s, err := newSink(sc)
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = append(sinks, s)
+	}
+
+	return &Notifier{
+		cfg:      cfg,
+		sinks:    sinks,
+		lastSent: make(map[string]time.Time),
+		now:      time.Now,
+	}, nil
+}
+
+// Desktop sends a native desktop notification, regardless of the configured
+// sinks.
+func Desktop(ctx context.Context, title, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	return sendDesktopNotification(ctx, Notification{Title: title, Message: message, Time: time.Now()})
+}
+
+// NewSpikeDetector returns a detector for the spike settings of the
+// Notifier.
+func (n *Notifier) NewSpikeDetector() *SpikeDetector {
+	if n == nil {
+		return NewSpikeDetector(DefaultSpikeThreshold, DefaultSpikeWindow)
+	}
+
+	return NewSpikeDetector(n.cfg.SpikeThreshold, n.cfg.SpikeWindow)
+}
+
+// Notify sends a notification to the sinks interested in its condition. A
+// condition is notified at most once per cooldown period. Errors are logged
+// rather than returned so that a broken sink never interrupts the command.
+func (n *Notifier) Notify(ctx context.Context, condition, title, message string) {
+	if n == nil {
+		return
+	}
+
+	now := n.now()
+
+	n.mu.Lock()
+	if last, ok := n.lastSent[condition]; ok && now.Sub(last) < n.cfg.Cooldown {
+		n.mu.Unlock()
+		return
+	}
+	n.lastSent[condition] = now
+	n.mu.Unlock()
+
+	notification := Notification{
+		Condition: condition,
+		Title:     title,
+		Message:   message,
+		Time:      now,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	for _, s := range n.sinks {
+		if !s.wants(condition) {
+			continue
+		}
+
+		if err := s.send(ctx, notification); err != nil {
+			logging.WithPrefix(log.StandardLogger(), "notify.Notifier.Notify").WithFields(log.Fields{
+				"sink": s.cfg.Type,
+			}).Warnf("Could not send notification: %v", err)
+		}
+	}
+}
+
+// NewSpikeDetector creates a detector reporting a spike when threshold
+// events happen within window.
+func NewSpikeDetector(threshold int, window time.Duration) *SpikeDetector {
+	return &SpikeDetector{
+		threshold: threshold,
+		window:    window,
+	}
+}
+
+// Add records an event happening at t and returns true when it completes a
+// spike. The count is reset after a spike so that it's only reported once.
+func (d *SpikeDetector) Add(t time.Time) bool {
+	cutoff := t.Add(-d.window)
+
+	kept := d.times[:0]
+	for _, previous := range d.times {
+		if previous.After(cutoff) {
+			kept = append(kept, previous)
+		}
+	}
+
+	d.times = append(kept, t)
+
+	if len(d.times) >= d.threshold {
+		d.times = d.times[:0]
+		return true
+	}
+
+	return false
+}
+
+//
+// Private types
+//
+
+type sink struct {
+	cfg  SinkConfig
+	send func(ctx context.Context, notification Notification) error
+}
+
+//
+// Private functions
+//
+
+func load(v *viper.Viper) (*Notifier, error) {
+	cfg := &Config{}
+
+	if err := v.UnmarshalKey("notifications", cfg); err != nil {
+		return nil, fmt.Errorf("invalid notifications configuration: %w", err)
+	}
+
+	return New(cfg)
+}
+
+func newSink(cfg SinkConfig) (sink, error) {
+	s := sink{cfg: cfg}
+
+	switch cfg.Type {
+	case SinkSlack:
+		s.send = func(ctx context.Context, notification Notification) error {
+			return postJSON(ctx, cfg.URL, map[string]string{
+				"text": fmt.Sprintf("*%s*\n%s", notification.Title, notification.Message),
+			})
+		}
+	case SinkHTTP:
+		s.send = func(ctx context.Context, notification Notification) error {
+			return postJSON(ctx, cfg.URL, notification)
+		}
+	case SinkDesktop:
+		s.send = sendDesktopNotification
+	default:
+		return sink{}, fmt.Errorf("unsupported notification sink type %q. Expected one of %s, %s, %s", cfg.Type, SinkSlack, SinkHTTP, SinkDesktop)
+	}
+
+	if cfg.Type != SinkDesktop && cfg.URL == "" {
+		return sink{}, fmt.Errorf("notification sink %q requires a url", cfg.Type)
+	}
+
+	return s, nil
+}
+
+func (s sink) wants(condition string) bool {
+	if len(s.cfg.Conditions) == 0 {
+		return true
+	}
+
+	for _, c := range s.cfg.Conditions {
+		if c == condition {
+			return true
+		}
+	}
+
+	return false
+}
+
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	if err := offline.Check("sending notifications"); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: stripe.HTTPTransport(),
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func sendDesktopNotification(ctx context.Context, notification Notification) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", notification.Message, notification.Title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "notify-send", notification.Title, notification.Message)
+	case "windows":
+		// The title and message go through the environment to avoid quoting
+		// them in the script
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsNotificationScript)
+		cmd.Env = append(os.Environ(),
+			"STRIPE_NOTIFICATION_TITLE="+notification.Title,
+			"STRIPE_NOTIFICATION_MESSAGE="+notification.Message,
+		)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	return cmd.Run()
+}
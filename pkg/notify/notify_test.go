@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithoutSinks(t *testing.T) {
+	n, err := New(&Config{})
+	require.NoError(t, err)
+	require.Nil(t, n)
+
+	require.NotPanics(t, func() {
+		n.Notify(context.Background(), FixturesCompleted, "Fixtures completed", "")
+	})
+}
+
+func TestNewInvalidSink(t *testing.T) {
+	_, err := New(&Config{Sinks: []SinkConfig{{Type: "pager"}}})
+	require.Error(t, err)
+
+	_, err = New(&Config{Sinks: []SinkConfig{{Type: SinkSlack}}})
+	require.Error(t, err)
+}
+
+func TestNotify(t *testing.T) {
+	slackBodies := make([]map[string]string, 0)
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		slackBodies = append(slackBodies, body)
+	}))
+	defer slack.Close()
+
+	notifications := make([]Notification, 0)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification Notification
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+		notifications = append(notifications, notification)
+	}))
+	defer hook.Close()
+
+	n, err := New(&Config{Sinks: []SinkConfig{
+		{Type: SinkSlack, URL: slack.URL, Conditions: []string{ListenForwardFailed}},
+		{Type: SinkHTTP, URL: hook.URL},
+	}})
+	require.NoError(t, err)
+
+	now := time.Now()
+	n.now = func() time.Time { return now }
+
+	n.Notify(context.Background(), ListenForwardFailed, "Webhook forwarding failed", "connection refused")
+	n.Notify(context.Background(), ListenForwardFailed, "Webhook forwarding failed", "connection refused")
+	n.Notify(context.Background(), FixturesCompleted, "Fixtures completed", "Ran fixtures from seed.json")
+
+	require.Equal(t, []map[string]string{{"text": "*Webhook forwarding failed*\nconnection refused"}}, slackBodies)
+	require.Len(t, notifications, 2)
+	require.Equal(t, ListenForwardFailed, notifications[0].Condition)
+	require.Equal(t, FixturesCompleted, notifications[1].Condition)
+
+	now = now.Add(DefaultCooldown)
+	n.Notify(context.Background(), ListenForwardFailed, "Webhook forwarding failed", "connection refused")
+	require.Len(t, slackBodies, 2)
+}
+
+func TestSpikeDetector(t *testing.T) {
+	d := NewSpikeDetector(3, time.Minute)
+	start := time.Now()
+
+	require.False(t, d.Add(start))
+	require.False(t, d.Add(start.Add(10*time.Second)))
+	require.False(t, d.Add(start.Add(2*time.Minute)))
+	require.False(t, d.Add(start.Add(2*time.Minute+time.Second)))
+	require.True(t, d.Add(start.Add(2*time.Minute+2*time.Second)))
+	require.False(t, d.Add(start.Add(2*time.Minute+3*time.Second)))
+}
+
+func TestLoad(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("toml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(`
+[notifications]
+cooldown = "5m"
+spike_threshold = 10
+
+[[notifications.sinks]]
+type = "http"
+url = "http://localhost:8080/alerts"
+conditions = ["logs_5xx_spike"]
+`)))
+
+	n, err := load(v)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Minute, n.cfg.Cooldown)
+	require.Equal(t, 10, n.cfg.SpikeThreshold)
+	require.Equal(t, DefaultSpikeWindow, n.cfg.SpikeWindow)
+	require.Len(t, n.sinks, 1)
+	require.Equal(t, []string{LogsServerErrorSpike}, n.sinks[0].cfg.Conditions)
+}
@@ -0,0 +1,55 @@
+// Package offline implements the --offline mode, in which the operations
+// needing the network fail right away with a clierrors.OfflineError instead
+// of waiting for timeouts. Requests to local servers, e.g. stripe-mock or a
+// Unix socket, are still allowed.
+package offline
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/stripe/stripe-cli/pkg/clierrors"
+)
+
+// Enabled is set by the global --offline flag
+var Enabled bool
+
+// Check returns an error when the CLI is offline. operation describes what
+// needs the network, e.g. "checking the status of Stripe".
+func Check(operation string) error {
+	if !Enabled {
+		return nil
+	}
+
+	return &clierrors.OfflineError{Err: fmt.Errorf("%s needs network access, which --offline disables", operation)}
+}
+
+// CheckURL is like Check, but allows the requests to the loopback interface
+func CheckURL(operation string, u *url.URL) error {
+	if u != nil && isLoopback(u.Hostname()) {
+		return nil
+	}
+
+	return Check(operation)
+}
+
+// UsingCache returns the note telling that a command used cached data
+// instead of fetching it, or an empty string when the CLI is online.
+func UsingCache(what string) string {
+	if !Enabled {
+		return ""
+	}
+
+	return fmt.Sprintf("Offline: using the cached %s, which can be out of date.", what)
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
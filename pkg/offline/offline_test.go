@@ -0,0 +1,43 @@
+package offline
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/clierrors"
+)
+
+func TestCheck(t *testing.T) {
+	defer func() { Enabled = false }()
+
+	require.NoError(t, Check("checking the status of Stripe"))
+	require.Equal(t, "", UsingCache("policy"))
+
+	Enabled = true
+
+	err := Check("checking the status of Stripe")
+	require.EqualError(t, err, "checking the status of Stripe needs network access, which --offline disables")
+	require.Equal(t, clierrors.KindOffline, clierrors.Kind(err))
+
+	var offlineErr *clierrors.OfflineError
+	require.True(t, errors.As(err, &offlineErr))
+
+	require.Equal(t, "Offline: using the cached policy, which can be out of date.", UsingCache("policy"))
+}
+
+func TestCheckURL(t *testing.T) {
+	defer func() { Enabled = false }()
+	Enabled = true
+
+	for _, rawURL := range []string{"http://localhost:12111", "http://127.0.0.1:12111/v1/customers", "http://[::1]:12111"} {
+		u, _ := url.Parse(rawURL)
+		require.NoError(t, CheckURL("GET /v1/customers", u), rawURL)
+	}
+
+	u, _ := url.Parse("https://api.stripe.com/v1/customers")
+	require.Error(t, CheckURL("GET /v1/customers", u))
+	require.Error(t, CheckURL("GET /v1/customers", nil))
+}
@@ -0,0 +1,189 @@
+// Package payments computes the amounts of refunds and captures from the
+// state of charges and payment intents, so that they don't have to be
+// worked out by hand, and prints the balance transactions they create.
+package payments
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+)
+
+//
+// Public types
+//
+
+// RefundParams describes a refund of a charge, either of a percentage of
+// its captured amount or of an amount
+type RefundParams struct {
+	Charge  string
+	Percent float64
+
+	// Amount is converted to the smallest unit of the currency of the
+	// charge once it's retrieved
+	Amount humaninput.Amount
+	Reason string
+}
+
+// Plan is a refund or a capture, with its amount worked out
+type Plan struct {
+	// ID is the ID of the charge refunded or of the payment intent captured
+	ID       string
+	Amount   int64
+	Currency string
+	Livemode bool
+
+	// Available is the amount left to refund or to capture before this one
+	Available int64
+}
+
+//
+// Public functions
+//
+
+// PlanRefund retrieves a charge and works out the amount of a refund of it.
+// The amount of a percentage is rounded down, so that refunding 50% twice
+// never refunds more than the charge.
+func PlanRefund(ctx context.Context, client simulate.APIClient, params RefundParams) (*Plan, error) {
+	if (params.Percent == 0) == params.Amount.IsZero() {
+		return nil, fmt.Errorf("either a percentage or an amount is required")
+	}
+
+	if params.Percent < 0 || params.Percent > 100 {
+		return nil, fmt.Errorf("the percentage must be between 0 and 100")
+	}
+
+	charge, err := client.Request(ctx, http.MethodGet, "/v1/charges/"+params.Charge, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		ID:        charge.Get("id").String(),
+		Currency:  charge.Get("currency").String(),
+		Livemode:  charge.Get("livemode").Bool(),
+		Available: charge.Get("amount_captured").Int() - charge.Get("amount_refunded").Int(),
+	}
+
+	if params.Percent > 0 {
+		plan.Amount = int64(math.Floor(float64(charge.Get("amount_captured").Int()) * params.Percent / 100))
+	} else {
+		plan.Amount, err = params.Amount.In(plan.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case plan.Available <= 0:
+		return nil, fmt.Errorf("charge %s has nothing left to refund", plan.ID)
+	case plan.Amount == 0:
+		return nil, fmt.Errorf("%g%% of charge %s rounds down to nothing", params.Percent, plan.ID)
+	case plan.Amount > plan.Available:
+		return nil, fmt.Errorf("can't refund %s, only %s of charge %s is left to refund",
+			preview.FormatAmount(plan.Amount, plan.Currency), preview.FormatAmount(plan.Available, plan.Currency), plan.ID)
+	}
+
+	return plan, nil
+}
+
+// Refund refunds a charge as planned and returns the refund, with its
+// balance transaction expanded
+func Refund(ctx context.Context, client simulate.APIClient, plan *Plan, reason string) (gjson.Result, error) {
+	params := []string{
+		"charge=" + plan.ID,
+		"amount=" + strconv.FormatInt(plan.Amount, 10),
+		"expand[]=balance_transaction",
+	}
+
+	if reason != "" {
+		params = append(params, "reason="+reason)
+	}
+
+	return client.Request(ctx, http.MethodPost, "/v1/refunds", params)
+}
+
+// PlanCaptureRemaining retrieves a payment intent and works out the amount
+// left to capture
+func PlanCaptureRemaining(ctx context.Context, client simulate.APIClient, id string) (*Plan, error) {
+	intent, err := client.Request(ctx, http.MethodGet, "/v1/payment_intents/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if status := intent.Get("status").String(); status != "requires_capture" {
+		return nil, fmt.Errorf("payment intent %s is %s, there's nothing to capture", id, status)
+	}
+
+	capturable := intent.Get("amount_capturable").Int()
+
+	return &Plan{
+		ID:        intent.Get("id").String(),
+		Amount:    capturable,
+		Currency:  intent.Get("currency").String(),
+		Livemode:  intent.Get("livemode").Bool(),
+		Available: capturable,
+	}, nil
+}
+
+// BalanceTransaction returns the balance transaction of the latest charge
+// of a captured payment intent
+func BalanceTransaction(ctx context.Context, client simulate.APIClient, intent gjson.Result) (gjson.Result, error) {
+	charge := intent.Get("latest_charge")
+	if !charge.Exists() || charge.Type == gjson.Null {
+		return gjson.Result{}, fmt.Errorf("payment intent %s has no charge", intent.Get("id").String())
+	}
+
+	if !charge.IsObject() {
+		var err error
+
+		charge, err = client.Request(ctx, http.MethodGet, "/v1/charges/"+charge.String(), []string{"expand[]=balance_transaction"})
+		if err != nil {
+			return gjson.Result{}, err
+		}
+	}
+
+	return charge.Get("balance_transaction"), nil
+}
+
+// Describe returns a sentence describing a planned refund or capture, e.g.
+// `Refund USD 5.00 of the USD 10.00 left on ch_123 (test mode)`
+func Describe(verb string, plan *Plan) string {
+	mode := "test"
+	if plan.Livemode {
+		mode = "live"
+	}
+
+	return fmt.Sprintf("%s %s of the %s left on %s (%s mode)", verb,
+		preview.FormatAmount(plan.Amount, plan.Currency),
+		preview.FormatAmount(plan.Available, plan.Currency),
+		plan.ID, mode)
+}
+
+// RenderBalanceTransaction prints the amounts of a balance transaction
+func RenderBalanceTransaction(w io.Writer, txn gjson.Result) {
+	if !txn.IsObject() {
+		fmt.Fprintf(w, "%s The balance transaction isn't available yet\n", ansi.WarningGlyph())
+		return
+	}
+
+	currency := txn.Get("currency").String()
+
+	fmt.Fprintf(w, "Balance transaction %s (%s)\n", txn.Get("id").String(), txn.Get("type").String())
+	fmt.Fprintf(w, "  Amount: %s\n", preview.FormatAmount(txn.Get("amount").Int(), currency))
+	fmt.Fprintf(w, "  Fee:    %s\n", preview.FormatAmount(txn.Get("fee").Int(), currency))
+	fmt.Fprintf(w, "  Net:    %s\n", preview.FormatAmount(txn.Get("net").Int(), currency))
+	fmt.Fprintf(w, "  Status: %s, available on %s\n", txn.Get("status").String(),
+		time.Unix(txn.Get("available_on").Int(), 0).UTC().Format("2006-01-02"))
+}
@@ -0,0 +1,91 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+)
+
+type paymentsClient struct {
+	requests []string
+}
+
+func (c *paymentsClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+
+	switch path {
+	case "/v1/charges/ch_123":
+		return gjson.Parse(`{"id":"ch_123","currency":"usd","livemode":false,"amount_captured":1001,"amount_refunded":200}`), nil
+	case "/v1/payment_intents/pi_123":
+		return gjson.Parse(`{"id":"pi_123","status":"requires_capture","currency":"usd","amount_capturable":700}`), nil
+	case "/v1/payment_intents/pi_456":
+		return gjson.Parse(`{"id":"pi_456","status":"succeeded"}`), nil
+	case "/v1/refunds":
+		return gjson.Parse(`{"id":"re_123","amount":500}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestPlanRefund(t *testing.T) {
+	client := &paymentsClient{}
+
+	plan, err := PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Percent: 50})
+	require.NoError(t, err)
+	require.Equal(t, int64(500), plan.Amount)
+	require.Equal(t, int64(801), plan.Available)
+	require.Equal(t, "Refund USD 5.00 of the USD 8.01 left on ch_123 (test mode)", Describe("Refund", plan))
+
+	_, err = PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Amount: humaninput.MinorUnits(900)})
+	require.EqualError(t, err, "can't refund USD 9.00, only USD 8.01 of charge ch_123 is left to refund")
+
+	amount, err := humaninput.ParseAmount("$7.50", false)
+	require.NoError(t, err)
+	amountPlan, err := PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Amount: amount})
+	require.NoError(t, err)
+	require.Equal(t, int64(750), amountPlan.Amount)
+
+	amount, err = humaninput.ParseAmount("7.50 EUR", false)
+	require.NoError(t, err)
+	_, err = PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Amount: amount})
+	require.EqualError(t, err, "the amount is in EUR, but the currency is USD")
+
+	_, err = PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Percent: 50, Amount: humaninput.MinorUnits(100)})
+	require.Error(t, err)
+
+	_, err = PlanRefund(context.Background(), client, RefundParams{Charge: "ch_123", Percent: 150})
+	require.Error(t, err)
+
+	_, err = Refund(context.Background(), client, plan, "duplicate")
+	require.NoError(t, err)
+	require.Equal(t, "POST /v1/refunds charge=ch_123&amount=500&expand[]=balance_transaction&reason=duplicate", client.requests[len(client.requests)-1])
+}
+
+func TestPlanCaptureRemaining(t *testing.T) {
+	client := &paymentsClient{}
+
+	plan, err := PlanCaptureRemaining(context.Background(), client, "pi_123")
+	require.NoError(t, err)
+	require.Equal(t, int64(700), plan.Amount)
+
+	_, err = PlanCaptureRemaining(context.Background(), client, "pi_456")
+	require.EqualError(t, err, "payment intent pi_456 is succeeded, there's nothing to capture")
+}
+
+func TestRenderBalanceTransaction(t *testing.T) {
+	var out bytes.Buffer
+
+	RenderBalanceTransaction(&out, gjson.Parse(`{"id":"txn_123","type":"refund","currency":"usd","amount":-500,"fee":0,"net":-500,"status":"pending","available_on":1700000000}`))
+	require.Contains(t, out.String(), "Balance transaction txn_123 (refund)")
+	require.Contains(t, out.String(), "available on 2023-11-14")
+
+	out.Reset()
+	RenderBalanceTransaction(&out, gjson.Parse(`"txn_123"`))
+	require.Contains(t, out.String(), "isn't available yet")
+}
@@ -0,0 +1,358 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/offline"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+//
+// Public constants
+//
+
+// Livemode rules
+const (
+	// LivemodeAllow allows all the commands in livemode
+	LivemodeAllow = "allow"
+	// LivemodeDeny only allows the commands of LivemodeAllowedCommands in
+	// livemode
+	LivemodeDeny = "deny"
+)
+
+//
+// Public types
+//
+
+// Policy restricts the commands the CLI can run, e.g. on shared machines.
+// Commands are named by their path without the binary name, e.g. "get" or
+// "logs tail", and match the rules naming them or one of their parents.
+type Policy struct {
+	Name string `json:"name"`
+
+	// AllowedCommands are the only commands allowed to run, if set
+	AllowedCommands []string `json:"allowed_commands"`
+
+	// BlockedResources are the API resources that can't be used, e.g.
+	// "payouts" or "issuing/cards", whichever command sends the requests
+	BlockedResources []string `json:"blocked_resources"`
+
+	// Livemode is LivemodeAllow (the default) or LivemodeDeny
+	Livemode string `json:"livemode"`
+
+	// LivemodeAllowedCommands are the commands allowed in livemode when it's
+	// denied
+	LivemodeAllowedCommands []string `json:"livemode_allowed_commands"`
+}
+
+// SignedPolicy is the content of a policy file. The signature is the
+// base64 encoded Ed25519 signature of the compacted policy JSON, so that the
+// file can be reindented.
+type SignedPolicy struct {
+	Policy    json.RawMessage `json:"policy"`
+	Signature string          `json:"signature"`
+}
+
+// Invocation describes a command the CLI is about to run
+type Invocation struct {
+	// Command is the path of the command, without the binary name
+	Command string
+
+	// Resource is the API resource the command uses, if any, e.g. "payouts"
+	// or "issuing/cards/ic_123"
+	Resource string
+
+	Livemode bool
+}
+
+// Error is returned for invocations the policy doesn't allow
+type Error struct {
+	policy string
+	reason string
+}
+
+//
+// Public functions
+//
+
+// Sign returns the content of a policy file for the policy JSON, signed with
+// privateKey
+func Sign(policyJSON []byte, privateKey ed25519.PrivateKey) ([]byte, error) {
+	var p Policy
+	if err := decodeStrict(policyJSON, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	compact, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(SignedPolicy{
+		Policy:    compact,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, compact)),
+	}, "", "  ")
+}
+
+// Verify checks the signature of the content of a policy file and returns
+// the policy. publicKey is the base64 encoded Ed25519 public key of the
+// policy's author.
+func Verify(data []byte, publicKey string) (*Policy, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid policy public key, expected a base64 encoded Ed25519 public key")
+	}
+
+	var signed SignedPolicy
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, signed.Policy); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil || !ed25519.Verify(key, compact.Bytes(), signature) {
+		return nil, fmt.Errorf("the signature of the policy is invalid")
+	}
+
+	var p Policy
+	if err := decodeStrict(signed.Policy, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// SystemConfigFile returns the path of the file where administrators set
+// the location of the policy and its public key, with the policy and
+// policy_public_key keys. It's outside of the config of the user so that
+// --config, --config-dir and STRIPE_CONFIG_DIR can't disable the policy.
+func SystemConfigFile() string {
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("ProgramData"), "Stripe", "stripe-cli", "policy.toml")
+	case "darwin":
+		return "/Library/Application Support/Stripe/stripe-cli/policy.toml"
+	default:
+		return "/etc/stripe-cli/policy.toml"
+	}
+}
+
+// ReadSystemConfig returns the location of the policy, a path or an https
+// URL, and its public key from the system config file at path. They're
+// empty when the file doesn't exist, i.e. when no policy is set.
+func ReadSystemConfig(fs afero.Fs, path string) (string, string, error) {
+	if _, err := fs.Stat(path); os.IsNotExist(err) {
+		return "", "", nil
+	}
+
+	v := viper.New()
+	v.SetFs(fs)
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return "", "", fmt.Errorf("could not read %s: %w", path, err)
+	}
+
+	source, publicKey := v.GetString("policy"), v.GetString("policy_public_key")
+	if source != "" && publicKey == "" {
+		return "", "", fmt.Errorf("%s sets a policy without a policy_public_key", path)
+	}
+
+	return source, publicKey, nil
+}
+
+// Load reads the policy file at source, a path or an https URL, and verifies
+// it. Policies fetched from URLs are cached to cacheFile, which is used
+// when the URL can't be reached or the CLI is offline.
+func Load(ctx context.Context, fs afero.Fs, source, publicKey, cacheFile string) (*Policy, error) {
+	if !strings.HasPrefix(source, "https://") {
+		data, err := afero.ReadFile(fs, source)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the policy: %w", err)
+		}
+
+		return Verify(data, publicKey)
+	}
+
+	fetchErr := offline.Check("fetching the policy")
+	if fetchErr == nil {
+		var data []byte
+		data, fetchErr = fetch(ctx, source)
+		if fetchErr == nil {
+			p, err := Verify(data, publicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := afero.WriteFile(fs, cacheFile, data, 0600); err != nil {
+				logging.WithPrefix(log.StandardLogger(), "policy.Load").WithFields(log.Fields{
+					"path": cacheFile,
+				}).WithError(err).Warn("Could not cache the policy, it won't be available offline")
+			}
+
+			return p, nil
+		}
+	}
+
+	data, err := afero.ReadFile(fs, cacheFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the policy: %w", fetchErr)
+	}
+
+	if note := offline.UsingCache("policy"); note != "" {
+		fmt.Fprintln(os.Stderr, note)
+	}
+
+	return Verify(data, publicKey)
+}
+
+// Check returns an *Error if the policy doesn't allow the invocation
+func (p *Policy) Check(inv Invocation) error {
+	if len(p.AllowedCommands) > 0 && !matchesCommand(p.AllowedCommands, inv.Command) {
+		return p.error(fmt.Sprintf("the `%s` command isn't allowed", inv.Command))
+	}
+
+	if err := p.checkResource(inv.Resource); err != nil {
+		return err
+	}
+
+	if inv.Livemode && p.Livemode == LivemodeDeny && !matchesCommand(p.LivemodeAllowedCommands, inv.Command) {
+		return p.error(fmt.Sprintf("the `%s` command isn't allowed in livemode", inv.Command))
+	}
+
+	return nil
+}
+
+// CheckRequest returns an *Error if the policy blocks the resource of the
+// API request to path, e.g. /v1/payouts/po_123. The CLI checks every API
+// request with it, whichever command makes the request.
+func (p *Policy) CheckRequest(path string) error {
+	return p.checkResource(ResourceOfPath(path))
+}
+
+// ResourceOfPath returns the API resource of a request path, e.g.
+// issuing/cards/ic_123 for /v1/issuing/cards/ic_123?expand[]=cardholder
+func ResourceOfPath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	return strings.Trim(strings.TrimPrefix(path, "/v1/"), "/")
+}
+
+func (e *Error) Error() string {
+	if e.policy == "" {
+		return fmt.Sprintf("blocked by policy: %s", e.reason)
+	}
+
+	return fmt.Sprintf("blocked by the %q policy: %s", e.policy, e.reason)
+}
+
+//
+// Private functions
+//
+
+func (p *Policy) validate() error {
+	switch p.Livemode {
+	case "", LivemodeAllow, LivemodeDeny:
+		return nil
+	default:
+		return fmt.Errorf("invalid policy: unknown livemode rule %q, expected %s or %s", p.Livemode, LivemodeAllow, LivemodeDeny)
+	}
+}
+
+func (p *Policy) checkResource(resource string) error {
+	if resource == "" {
+		return nil
+	}
+
+	for _, blocked := range p.BlockedResources {
+		blocked = strings.Trim(blocked, "/")
+		if resource == blocked || strings.HasPrefix(resource, blocked+"/") {
+			return p.error(fmt.Sprintf("the %s resource is blocked", blocked))
+		}
+	}
+
+	return nil
+}
+
+func (p *Policy) error(reason string) error {
+	return &Error{policy: p.Name, reason: reason}
+}
+
+// matchesCommand returns whether command, or one of its parents, is in
+// commands. "*" matches all the commands.
+func matchesCommand(commands []string, command string) bool {
+	for _, c := range commands {
+		c = strings.Join(strings.Fields(c), " ")
+
+		if c == "*" || command == c || strings.HasPrefix(command, c+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeStrict decodes JSON, rejecting unknown fields so that typos in
+// rules don't silently allow commands
+func decodeStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Transport: stripe.HTTPTransport()}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
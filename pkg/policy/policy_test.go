@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func newKey(t *testing.T) (string, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(publicKey), privateKey
+}
+
+func TestSignAndVerify(t *testing.T) {
+	publicKey, privateKey := newKey(t)
+
+	signed, err := Sign([]byte(`{"name": "Shared", "allowed_commands": ["get", "logs tail"], "livemode": "deny"}`), privateKey)
+	require.NoError(t, err)
+
+	p, err := Verify(signed, publicKey)
+	require.NoError(t, err)
+	require.Equal(t, "Shared", p.Name)
+	require.Equal(t, []string{"get", "logs tail"}, p.AllowedCommands)
+	require.Equal(t, LivemodeDeny, p.Livemode)
+}
+
+func TestSignRejectsInvalidPolicies(t *testing.T) {
+	_, privateKey := newKey(t)
+
+	_, err := Sign([]byte(`{"allowed_command": ["get"]}`), privateKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown field")
+
+	_, err = Sign([]byte(`{"livemode": "sometimes"}`), privateKey)
+	require.Error(t, err)
+}
+
+func TestVerifyRejectsTamperedPolicies(t *testing.T) {
+	publicKey, privateKey := newKey(t)
+
+	signed, err := Sign([]byte(`{"livemode": "deny"}`), privateKey)
+	require.NoError(t, err)
+
+	var file SignedPolicy
+	require.NoError(t, json.Unmarshal(signed, &file))
+
+	file.Policy = json.RawMessage(`{"livemode":"allow"}`)
+	tampered, err := json.Marshal(file)
+	require.NoError(t, err)
+
+	_, err = Verify(tampered, publicKey)
+	require.EqualError(t, err, "the signature of the policy is invalid")
+
+	otherKey, _ := newKey(t)
+	_, err = Verify(signed, otherKey)
+	require.EqualError(t, err, "the signature of the policy is invalid")
+
+	_, err = Verify(signed, "not a key")
+	require.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	publicKey, privateKey := newKey(t)
+
+	signed, err := Sign([]byte(`{"name": "Shared"}`), privateKey)
+	require.NoError(t, err)
+	require.NoError(t, afero.WriteFile(fs, "/policy.json", signed, 0600))
+
+	p, err := Load(context.Background(), fs, "/policy.json", publicKey, "/cache.json")
+	require.NoError(t, err)
+	require.Equal(t, "Shared", p.Name)
+
+	_, err = Load(context.Background(), fs, "/missing.json", publicKey, "/cache.json")
+	require.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	p := &Policy{
+		Name:                    "Shared",
+		AllowedCommands:         []string{"get", "logs", "customers"},
+		BlockedResources:        []string{"customers/"},
+		Livemode:                LivemodeDeny,
+		LivemodeAllowedCommands: []string{"logs tail"},
+	}
+
+	require.NoError(t, p.Check(Invocation{Command: "get", Resource: "charges/ch_123"}))
+	require.NoError(t, p.Check(Invocation{Command: "logs tail", Livemode: true}))
+
+	err := p.Check(Invocation{Command: "post", Resource: "charges"})
+	require.EqualError(t, err, "blocked by the \"Shared\" policy: the `post` command isn't allowed")
+
+	err = p.Check(Invocation{Command: "get", Resource: "customers/cus_123"})
+	require.EqualError(t, err, "blocked by the \"Shared\" policy: the customers resource is blocked")
+
+	err = p.Check(Invocation{Command: "customers list", Resource: "customers"})
+	require.Error(t, err)
+
+	// Resources only match whole path segments
+	require.NoError(t, p.Check(Invocation{Command: "get", Resource: "customers_search"}))
+
+	err = p.Check(Invocation{Command: "get", Resource: "charges", Livemode: true})
+	require.EqualError(t, err, "blocked by the \"Shared\" policy: the `get` command isn't allowed in livemode")
+
+	// Commands only match whole words
+	err = p.Check(Invocation{Command: "logsx"})
+	require.Error(t, err)
+}
+
+func TestCheckAllowsEverythingByDefault(t *testing.T) {
+	p := &Policy{}
+
+	require.NoError(t, p.Check(Invocation{Command: "post", Resource: "payouts", Livemode: true}))
+}
+
+func TestCheckRequest(t *testing.T) {
+	p := &Policy{BlockedResources: []string{"payouts", "issuing/cards"}}
+
+	require.NoError(t, p.CheckRequest("/v1/charges/ch_123"))
+	require.NoError(t, p.CheckRequest("/v1/issuing/cardholders"))
+
+	require.EqualError(t, p.CheckRequest("/v1/payouts"), "blocked by policy: the payouts resource is blocked")
+	require.Error(t, p.CheckRequest("/v1/issuing/cards/ic_123?expand[]=cardholder"))
+}
+
+func TestReadSystemConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	source, publicKey, err := ReadSystemConfig(fs, "/etc/stripe-cli/policy.toml")
+	require.NoError(t, err)
+	require.Equal(t, "", source)
+	require.Equal(t, "", publicKey)
+
+	require.NoError(t, afero.WriteFile(fs, "/etc/stripe-cli/policy.toml", []byte("policy = \"https://example.com/policy.json\"\npolicy_public_key = \"key\"\n"), 0644))
+
+	source, publicKey, err = ReadSystemConfig(fs, "/etc/stripe-cli/policy.toml")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/policy.json", source)
+	require.Equal(t, "key", publicKey)
+
+	require.NoError(t, afero.WriteFile(fs, "/etc/stripe-cli/policy.toml", []byte("policy = \"/policy.json\"\n"), 0644))
+
+	_, _, err = ReadSystemConfig(fs, "/etc/stripe-cli/policy.toml")
+	require.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+)
+
+// ExchangeRatesFileName is the name of the exchange rates cache in the
+// config folder
+const ExchangeRatesFileName = "exchange_rates.json"
+
+// exchangeRatesTTL is how long fetched exchange rates are used for
+const exchangeRatesTTL = 24 * time.Hour
+
+//
+// Public types
+//
+
+// ExchangeRates are the rates Stripe converts an amount in any currency to
+// Currency with: 1 Currency is worth Rates[currency] of another currency
+type ExchangeRates struct {
+	Currency  string             `json:"currency"`
+	Rates     map[string]float64 `json:"rates"`
+	FetchedAt time.Time          `json:"fetched_at"`
+}
+
+//
+// Public functions
+//
+
+// LoadExchangeRates returns the exchange rates to currency, from the cache
+// at cachePath when they were fetched less than a day ago.
+func LoadExchangeRates(ctx context.Context, client APIClient, cachePath, currency string) (*ExchangeRates, error) {
+	currency = strings.ToLower(currency)
+
+	cache := make(map[string]*ExchangeRates)
+	if data, err := ioutil.ReadFile(cachePath); err == nil {
+		json.Unmarshal(data, &cache) // #nosec G104
+	}
+
+	if rates, ok := cache[currency]; ok && time.Since(rates.FetchedAt) < exchangeRatesTTL {
+		return rates, nil
+	}
+
+	response, err := client.Request(ctx, http.MethodGet, "/v1/exchange_rates/"+currency, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := &ExchangeRates{
+		Currency:  currency,
+		Rates:     make(map[string]float64),
+		FetchedAt: time.Now(),
+	}
+
+	for code, rate := range response.Get("rates").Map() {
+		rates.Rates[code] = rate.Float()
+	}
+
+	if len(rates.Rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates to %s", strings.ToUpper(currency))
+	}
+
+	cache[currency] = rates
+
+	if data, err := json.MarshalIndent(cache, "", "  "); err == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), os.ModePerm); err == nil {
+			ioutil.WriteFile(cachePath, data, 0600) // #nosec G104
+		}
+	}
+
+	return rates, nil
+}
+
+// Convert converts an amount in the smallest unit of currency to the
+// smallest unit of r.Currency. It returns false when there's no rate for
+// currency.
+func (r *ExchangeRates) Convert(amount int64, currency string) (int64, bool) {
+	currency = strings.ToLower(currency)
+	if currency == r.Currency {
+		return amount, true
+	}
+
+	rate, ok := r.Rates[currency]
+	if !ok || rate == 0 {
+		return 0, false
+	}
+
+	converted := majorUnits(amount, currency) / rate
+
+	if humaninput.CurrencyExponent(r.Currency) == 0 {
+		return int64(math.Round(converted)), true
+	}
+
+	return int64(math.Round(converted * 100)), true
+}
+
+// Converts returns whether amounts in currency are displayed converted: r
+// isn't nil, currency isn't r.Currency and there's a rate for it.
+func (r *ExchangeRates) Converts(currency string) bool {
+	if r == nil || strings.ToLower(currency) == r.Currency {
+		return false
+	}
+
+	_, ok := r.Rates[strings.ToLower(currency)]
+
+	return ok
+}
+
+// FormatConverted formats an amount converted to r.Currency, marked as
+// approximate, e.g. "≈ USD 27.15". It returns an empty string unless r
+// Converts currency.
+func (r *ExchangeRates) FormatConverted(amount int64, currency string) string {
+	if !r.Converts(currency) {
+		return ""
+	}
+
+	converted, ok := r.Convert(amount, currency)
+	if !ok {
+		return ""
+	}
+
+	return approximately() + " " + FormatAmount(converted, r.Currency)
+}
+
+// Label describes the conversions, to show alongside them.
+func (r *ExchangeRates) Label() string {
+	return fmt.Sprintf("%s Approximate amounts in %s, at Stripe's exchange rates of %s",
+		approximately(), strings.ToUpper(r.Currency), r.FetchedAt.Format("2006-01-02"))
+}
+
+//
+// Private functions
+//
+
+func approximately() string {
+	if ansi.ASCIIOnly {
+		return "~"
+	}
+
+	return "≈"
+}
+
+func majorUnits(amount int64, currency string) float64 {
+	if humaninput.CurrencyExponent(currency) == 0 {
+		return float64(amount)
+	}
+
+	return float64(amount) / 100
+}
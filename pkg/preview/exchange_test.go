@@ -0,0 +1,65 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestLoadExchangeRates(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), ExchangeRatesFileName)
+	client := &fakeClient{response: map[string]string{
+		"/v1/exchange_rates/usd": `{"id":"usd","object":"exchange_rate","rates":{"eur":0.9,"jpy":150.0}}`,
+	}}
+
+	rates, err := LoadExchangeRates(context.Background(), client, cachePath, "USD")
+	require.NoError(t, err)
+	require.Equal(t, "usd", rates.Currency)
+	require.Equal(t, 0.9, rates.Rates["eur"])
+
+	// The rates are cached for a day
+	_, err = LoadExchangeRates(context.Background(), client, cachePath, "usd")
+	require.NoError(t, err)
+	require.Len(t, client.requests, 1)
+
+	client.response["/v1/exchange_rates/usd"] = `{"id":"usd","object":"exchange_rate","rates":{}}`
+	_, err = LoadExchangeRates(context.Background(), client, filepath.Join(t.TempDir(), ExchangeRatesFileName), "usd")
+	require.EqualError(t, err, "no exchange rates to USD")
+}
+
+func TestConvert(t *testing.T) {
+	rates := &ExchangeRates{Currency: "usd", Rates: map[string]float64{"eur": 0.8, "jpy": 150}}
+
+	converted, ok := rates.Convert(2000, "EUR")
+	require.True(t, ok)
+	require.Equal(t, int64(2500), converted)
+
+	converted, ok = rates.Convert(1500, "jpy")
+	require.True(t, ok)
+	require.Equal(t, int64(1000), converted)
+
+	_, ok = rates.Convert(1000, "gbp")
+	require.False(t, ok)
+
+	require.Equal(t, "≈ USD 25.00", rates.FormatConverted(2000, "eur"))
+	require.Equal(t, "", rates.FormatConverted(2000, "usd"))
+
+	var none *ExchangeRates
+	require.Equal(t, "", none.FormatConverted(2000, "eur"))
+}
+
+func TestRenderTaxConverted(t *testing.T) {
+	var b bytes.Buffer
+
+	rates := &ExchangeRates{Currency: "usd", Rates: map[string]float64{"eur": 0.8}, FetchedAt: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)}
+
+	RenderTax(&b, gjson.Parse(`{"currency": "eur", "amount_total": 2000, "tax_amount_exclusive": 0, "tax_amount_inclusive": 0}`), rates)
+
+	require.Contains(t, b.String(), "Total                  EUR 20.00  ≈ USD 25.00")
+	require.Contains(t, b.String(), "≈ Approximate amounts in USD, at Stripe's exchange rates of 2026-10-15")
+}
@@ -0,0 +1,112 @@
+package preview
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// InvoiceParams describes a subscription change to preview the upcoming
+// invoice for
+type InvoiceParams struct {
+	Subscription string
+
+	// Price replaces the price of the first subscription item. The current
+	// upcoming invoice is previewed when it's empty.
+	Price    string
+	Quantity int64
+
+	// ProrationDate is the Unix timestamp the prorations are computed at,
+	// or 0 for now
+	ProrationDate int64
+}
+
+// UpcomingInvoice returns the upcoming invoice of a subscription, after the
+// requested change.
+func UpcomingInvoice(ctx context.Context, client APIClient, params InvoiceParams) (gjson.Result, error) {
+	subscription, err := client.Request(ctx, http.MethodGet, "/v1/subscriptions/"+params.Subscription, nil)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	data := []string{
+		"customer=" + subscription.Get("customer").String(),
+		"subscription=" + params.Subscription,
+	}
+
+	if params.Price != "" {
+		item := subscription.Get("items.data.0.id").String()
+		if item == "" {
+			return gjson.Result{}, fmt.Errorf("subscription %s has no items", params.Subscription)
+		}
+
+		data = append(data,
+			"subscription_items[0][id]="+item,
+			"subscription_items[0][price]="+params.Price,
+			"subscription_proration_behavior=create_prorations",
+		)
+
+		if params.Quantity > 0 {
+			data = append(data, "subscription_items[0][quantity]="+strconv.FormatInt(params.Quantity, 10))
+		}
+	}
+
+	if params.ProrationDate > 0 {
+		data = append(data, "subscription_proration_date="+strconv.FormatInt(params.ProrationDate, 10))
+	}
+
+	return client.Request(ctx, http.MethodGet, "/v1/invoices/upcoming", data)
+}
+
+// RenderInvoice writes the lines and totals of an invoice to w. The totals
+// are also converted with rates when it isn't nil.
+func RenderInvoice(w io.Writer, invoice gjson.Result, rates *ExchangeRates) {
+	currency := invoice.Get("currency").String()
+
+	fmt.Fprintf(w, "Upcoming invoice for %s", invoice.Get("customer").String())
+	if next := invoice.Get("next_payment_attempt").Int(); next > 0 {
+		fmt.Fprintf(w, ", due %s", time.Unix(next, 0).Format("2006-01-02"))
+	}
+	fmt.Fprint(w, "\n\n")
+
+	fmt.Fprintf(w, "%-50s %-23s %16s\n", "DESCRIPTION", "PERIOD", "AMOUNT")
+
+	for _, line := range invoice.Get("lines.data").Array() {
+		period := fmt.Sprintf("%s - %s",
+			time.Unix(line.Get("period.start").Int(), 0).Format("2006-01-02"),
+			time.Unix(line.Get("period.end").Int(), 0).Format("2006-01-02"),
+		)
+
+		description := line.Get("description").String()
+		if line.Get("proration").Bool() {
+			description += " (proration)"
+		}
+
+		fmt.Fprintf(w, "%-50s %-23s %16s\n", description, period, FormatAmount(line.Get("amount").Int(), currency))
+	}
+
+	total := func(label string, amount int64) {
+		fmt.Fprintf(w, "%-74s %16s", label, FormatAmount(amount, currency))
+		if converted := rates.FormatConverted(amount, currency); converted != "" {
+			fmt.Fprintf(w, "  %s", converted)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	total("Subtotal", invoice.Get("subtotal").Int())
+	if invoice.Get("tax").Exists() {
+		total("Tax", invoice.Get("tax").Int())
+	}
+	total("Total", invoice.Get("total").Int())
+	total("Amount due", invoice.Get("amount_due").Int())
+
+	if rates.Converts(currency) {
+		fmt.Fprintf(w, "\n%s\n", rates.Label())
+	}
+}
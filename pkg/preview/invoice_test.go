@@ -0,0 +1,42 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestUpcomingInvoice(t *testing.T) {
+	client := &fakeClient{response: map[string]string{
+		"/v1/subscriptions/sub_123": `{"id":"sub_123","customer":"cus_123","items":{"data":[{"id":"si_123"}]}}`,
+	}}
+
+	_, err := UpcomingInvoice(context.Background(), client, InvoiceParams{Subscription: "sub_123", Price: "price_456", Quantity: 2, ProrationDate: 1767225600})
+	require.NoError(t, err)
+
+	require.Equal(t, "GET /v1/invoices/upcoming customer=cus_123&subscription=sub_123&subscription_items[0][id]=si_123&subscription_items[0][price]=price_456&subscription_proration_behavior=create_prorations&subscription_items[0][quantity]=2&subscription_proration_date=1767225600", client.requests[1])
+}
+
+func TestRenderInvoice(t *testing.T) {
+	var b bytes.Buffer
+
+	RenderInvoice(&b, gjson.Parse(`{
+		"customer": "cus_123",
+		"currency": "usd",
+		"subtotal": 1500,
+		"total": 1500,
+		"amount_due": 1500,
+		"lines": {"data": [
+			{"description": "Unused time on Basic", "amount": -1000, "proration": true, "period": {"start": 1600000000, "end": 1600000000}},
+			{"description": "1 × Pro", "amount": 2500, "period": {"start": 1600000000, "end": 1602592000}}
+		]}
+	}`), nil)
+
+	require.Contains(t, b.String(), "Unused time on Basic (proration)")
+	require.Contains(t, b.String(), "USD -10.00")
+	require.Contains(t, b.String(), "Amount due")
+	require.NotContains(t, b.String(), "Tax")
+}
@@ -0,0 +1,198 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/humaninput"
+)
+
+//
+// Public types
+//
+
+// APIClient sends requests to the Stripe API
+type APIClient interface {
+	Request(ctx context.Context, method, path string, params []string) (gjson.Result, error)
+}
+
+// Address is a customer address, as read from an address file
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+}
+
+// TaxParams describes the purchase to calculate taxes for
+type TaxParams struct {
+	Customer string
+	Price    string
+	Quantity int64
+	Address  *Address
+}
+
+//
+// Public functions
+//
+
+// ParseAddress parses an address file.
+func ParseAddress(data []byte) (*Address, error) {
+	address := &Address{}
+
+	if err := json.Unmarshal(data, address); err != nil {
+		return nil, fmt.Errorf("invalid address file: %w", err)
+	}
+
+	if address.Country == "" {
+		return nil, fmt.Errorf("invalid address file: country is required")
+	}
+
+	return address, nil
+}
+
+// Tax calculates the taxes due on a purchase of a price with the Stripe
+// Tax calculations API.
+func Tax(ctx context.Context, client APIClient, params TaxParams) (gjson.Result, error) {
+	if params.Customer == "" && params.Address == nil {
+		return gjson.Result{}, fmt.Errorf("a customer or an address is required")
+	}
+
+	if params.Quantity <= 0 {
+		params.Quantity = 1
+	}
+
+	price, err := client.Request(ctx, http.MethodGet, "/v1/prices/"+params.Price, nil)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	data := []string{
+		"currency=" + price.Get("currency").String(),
+		"line_items[0][amount]=" + strconv.FormatInt(price.Get("unit_amount").Int()*params.Quantity, 10),
+		"line_items[0][quantity]=" + strconv.FormatInt(params.Quantity, 10),
+		"line_items[0][reference]=" + params.Price,
+		"line_items[0][product]=" + price.Get("product").String(),
+		"expand[]=line_items",
+	}
+
+	if behavior := price.Get("tax_behavior").String(); behavior == "inclusive" || behavior == "exclusive" {
+		data = append(data, "line_items[0][tax_behavior]="+behavior)
+	}
+
+	if params.Customer != "" {
+		data = append(data, "customer="+params.Customer)
+	}
+
+	if params.Address != nil {
+		data = append(data, addressParams("customer_details[address]", params.Address)...)
+		data = append(data, "customer_details[address_source]=billing")
+	}
+
+	return client.Request(ctx, http.MethodPost, "/v1/tax/calculations", data)
+}
+
+// RenderTax writes a human readable breakdown of a tax calculation to w. The
+// totals are also converted with rates when it isn't nil.
+func RenderTax(w io.Writer, calculation gjson.Result, rates *ExchangeRates) {
+	currency := calculation.Get("currency").String()
+	exclusive := calculation.Get("tax_amount_exclusive").Int()
+	inclusive := calculation.Get("tax_amount_inclusive").Int()
+	total := calculation.Get("amount_total").Int()
+
+	line := func(label string, amount int64) {
+		fmt.Fprintf(w, "%-22s %s", label, FormatAmount(amount, currency))
+		if converted := rates.FormatConverted(amount, currency); converted != "" {
+			fmt.Fprintf(w, "  %s", converted)
+		}
+		fmt.Fprintln(w)
+	}
+
+	line("Subtotal", total-exclusive)
+	line("Tax (exclusive)", exclusive)
+	if inclusive > 0 {
+		line("Tax (inclusive)", inclusive)
+	}
+	line("Total", total)
+	if rates.Converts(currency) {
+		fmt.Fprintln(w, rates.Label())
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%-14s %-14s %9s %14s %14s  %s\n", "JURISDICTION", "TYPE", "RATE", "TAXABLE", "TAX", "REASON")
+
+	for _, breakdown := range calculation.Get("tax_breakdown").Array() {
+		details := breakdown.Get("tax_rate_details")
+
+		jurisdiction := details.Get("country").String()
+		if state := details.Get("state").String(); state != "" {
+			jurisdiction += " - " + state
+		}
+
+		taxType := details.Get("tax_type").String()
+		if taxType == "" {
+			taxType = "-"
+		}
+
+		fmt.Fprintf(w, "%-14s %-14s %8s%% %14s %14s  %s\n",
+			jurisdiction,
+			taxType,
+			details.Get("percentage_decimal").String(),
+			FormatAmount(breakdown.Get("taxable_amount").Int(), currency),
+			FormatAmount(breakdown.Get("amount").Int(), currency),
+			breakdown.Get("taxability_reason").String(),
+		)
+	}
+}
+
+// FormatAmount formats an amount in the smallest currency unit, e.g.
+// 2500 usd is "USD 25.00".
+func FormatAmount(amount int64, currency string) string {
+	code := strings.ToUpper(currency)
+
+	if humaninput.CurrencyExponent(currency) == 0 {
+		return fmt.Sprintf("%s %d", code, amount)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s %s%d.%02d", code, sign, amount/100, amount%100)
+}
+
+//
+// Private functions
+//
+
+func addressParams(prefix string, address *Address) []string {
+	fields := []struct{ name, value string }{
+		{"line1", address.Line1},
+		{"line2", address.Line2},
+		{"city", address.City},
+		{"state", address.State},
+		{"postal_code", address.PostalCode},
+		{"country", address.Country},
+	}
+
+	params := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if field.value != "" {
+			params = append(params, fmt.Sprintf("%s[%s]=%s", prefix, field.name, field.value))
+		}
+	}
+
+	return params
+}
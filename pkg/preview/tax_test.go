@@ -0,0 +1,75 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type fakeClient struct {
+	requests []string
+	response map[string]string
+}
+
+func (c *fakeClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+	return gjson.Parse(c.response[path]), nil
+}
+
+func TestParseAddress(t *testing.T) {
+	address, err := ParseAddress([]byte(`{"line1":"1 Main St","postal_code":"94111","country":"US","state":"CA"}`))
+	require.NoError(t, err)
+	require.Equal(t, &Address{Line1: "1 Main St", PostalCode: "94111", Country: "US", State: "CA"}, address)
+
+	_, err = ParseAddress([]byte(`{"line1":"1 Main St"}`))
+	require.Error(t, err)
+}
+
+func TestTax(t *testing.T) {
+	client := &fakeClient{response: map[string]string{
+		"/v1/prices/price_123": `{"id":"price_123","currency":"usd","unit_amount":2500,"product":"prod_123","tax_behavior":"exclusive"}`,
+	}}
+
+	_, err := Tax(context.Background(), client, TaxParams{
+		Price:    "price_123",
+		Quantity: 2,
+		Address:  &Address{PostalCode: "94111", Country: "US"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "POST /v1/tax/calculations currency=usd&line_items[0][amount]=5000&line_items[0][quantity]=2&line_items[0][reference]=price_123&line_items[0][product]=prod_123&expand[]=line_items&line_items[0][tax_behavior]=exclusive&customer_details[address][postal_code]=94111&customer_details[address][country]=US&customer_details[address_source]=billing", client.requests[1])
+
+	_, err = Tax(context.Background(), client, TaxParams{Price: "price_123"})
+	require.Error(t, err)
+}
+
+func TestRenderTax(t *testing.T) {
+	var b bytes.Buffer
+
+	RenderTax(&b, gjson.Parse(`{
+		"currency": "usd",
+		"amount_total": 2716,
+		"tax_amount_exclusive": 216,
+		"tax_amount_inclusive": 0,
+		"tax_breakdown": [{
+			"amount": 216,
+			"taxable_amount": 2500,
+			"taxability_reason": "standard_rated",
+			"tax_rate_details": {"country": "US", "state": "CA", "percentage_decimal": "8.625", "tax_type": "sales_tax"}
+		}]
+	}`), nil)
+
+	require.Contains(t, b.String(), "Subtotal               USD 25.00")
+	require.Contains(t, b.String(), "Total                  USD 27.16")
+	require.Contains(t, b.String(), "US - CA        sales_tax         8.625%      USD 25.00       USD 2.16  standard_rated")
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "USD 25.00", FormatAmount(2500, "usd"))
+	require.Equal(t, "EUR -0.05", FormatAmount(-5, "eur"))
+	require.Equal(t, "JPY 2500", FormatAmount(2500, "jpy"))
+}
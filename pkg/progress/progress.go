@@ -0,0 +1,92 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+//
+// Public types
+//
+
+// Event is a progress update for a long operation, written as one JSON
+// object per line
+type Event struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Stage     string    `json:"stage"`
+	Percent   int       `json:"percent"`
+	Message   string    `json:"message,omitempty"`
+}
+
+//
+// Public functions
+//
+
+// Enable starts writing progress events to w.
+func Enable(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	out = w
+}
+
+// EnableFD starts writing progress events to the given file descriptor,
+// which must have been opened by the parent process (e.g. `3>progress.log`).
+func EnableFD(fd int) error {
+	f := os.NewFile(uintptr(fd), "progress")
+	if f == nil {
+		return fmt.Errorf("invalid progress file descriptor %d", fd)
+	}
+
+	if _, err := f.Stat(); err != nil {
+		return fmt.Errorf("progress file descriptor %d is not open: %w", fd, err)
+	}
+
+	Enable(f)
+
+	return nil
+}
+
+// Report writes a progress event. It does nothing unless progress reporting
+// was enabled. Percent is clamped between 0 and 100.
+func Report(operation, stage string, percent int, message string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if out == nil {
+		return
+	}
+
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+
+	data, err := json.Marshal(Event{
+		Time:      time.Now(),
+		Operation: operation,
+		Stage:     stage,
+		Percent:   percent,
+		Message:   message,
+	})
+	if err != nil {
+		return
+	}
+
+	out.Write(append(data, '\n')) // #nosec G104
+}
+
+//
+// Private variables
+//
+
+var (
+	mu  sync.Mutex
+	out io.Writer
+)
@@ -0,0 +1,44 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	var b bytes.Buffer
+	Enable(&b)
+	defer Enable(nil)
+
+	Report("fixtures", "customer", 50, "Running fixture for: customer")
+	Report("fixtures", "done", 120, "")
+
+	events := make([]Event, 0)
+	scanner := bufio.NewScanner(&b)
+	for scanner.Scan() {
+		var evt Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &evt))
+		events = append(events, evt)
+	}
+
+	require.Len(t, events, 2)
+	require.Equal(t, "fixtures", events[0].Operation)
+	require.Equal(t, "customer", events[0].Stage)
+	require.Equal(t, 50, events[0].Percent)
+	require.Equal(t, "Running fixture for: customer", events[0].Message)
+	require.Equal(t, 100, events[1].Percent)
+}
+
+func TestReportDisabled(t *testing.T) {
+	require.NotPanics(t, func() {
+		Report("fixtures", "customer", 50, "")
+	})
+}
+
+func TestEnableFDInvalid(t *testing.T) {
+	require.Error(t, EnableFD(987))
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"bytes"
+	"compress/gzip"
 	"io/ioutil"
 	"net/http"
 	"regexp"
@@ -10,6 +11,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/websocket"
 )
 
@@ -21,6 +24,10 @@ import (
 type EndpointConfig struct {
 	HTTPClient *http.Client
 
+	// Compress indicates whether to gzip the request bodies larger than
+	// minCompressedSize
+	Compress bool
+
 	Log *log.Logger
 
 	ResponseHandler EndpointResponseHandler
@@ -86,11 +93,26 @@ func (c *EndpointClient) SupportsEventType(connect bool, eventType string) bool
 
 // Post sends a message to the local endpoint.
 func (c *EndpointClient) Post(evtCtx eventContext, body string, headers map[string]string) error {
-	c.cfg.Log.WithFields(log.Fields{
-		"prefix": "proxy.EndpointClient.Post",
-	}).Debug("Forwarding event to local endpoint")
+	logging.WithPrefix(c.cfg.Log, "proxy.EndpointClient.Post").Debug("Forwarding event to local endpoint")
+
+	reqBody := []byte(body)
+	compressed := false
+
+	if c.cfg.Compress && len(reqBody) >= minCompressedSize {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(reqBody); err != nil {
+			return err
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+
+		reqBody = buf.Bytes()
+		compressed = true
+	}
 
-	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewBuffer([]byte(body)))
+	req, err := http.NewRequest(http.MethodPost, c.URL, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return err
 	}
@@ -108,6 +130,10 @@ func (c *EndpointClient) Post(evtCtx eventContext, body string, headers map[stri
 		}
 	}
 
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
 	resp, err := c.cfg.HTTPClient.Do(req)
 	if err != nil {
 		c.cfg.OutCh <- websocket.ErrorElement{
@@ -142,7 +168,8 @@ func NewEndpointClient(url string, headers []string, connect bool, events []stri
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				return http.ErrUseLastResponse
 			},
-			Timeout: defaultTimeout,
+			Timeout:   defaultTimeout,
+			Transport: endpointTransport(false),
 		}
 	}
 
@@ -165,12 +192,30 @@ func NewEndpointClient(url string, headers []string, connect bool, events []stri
 
 const (
 	defaultTimeout = 30 * time.Second
+
+	// minCompressedSize is the minimum size of the payloads compressed when
+	// forwarding, since compressing smaller ones doesn't save much
+	minCompressedSize = 1024
 )
 
 //
 // Private functions
 //
 
+// endpointTransport returns a transport for forwarding events to a local
+// endpoint, keeping enough idle connections for bursts of events to reuse
+// them. Unlike the API clients, it ignores the proxy environment variables.
+func endpointTransport(skipVerify bool) *http.Transport {
+	tlsConfig := cryptopolicy.Current().TLSConfig()
+	tlsConfig.InsecureSkipVerify = skipVerify // #nosec G402
+
+	return &http.Transport{
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		TLSClientConfig:     tlsConfig,
+	}
+}
+
 func convertToMap(events []string) map[string]bool {
 	eventsMap := make(map[string]bool)
 	for _, event := range events {
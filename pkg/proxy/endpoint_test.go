@@ -1,9 +1,12 @@
 package proxy
 
 import (
+	"compress/gzip"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 
@@ -134,3 +137,32 @@ func TestClientHandler_Redirects(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestClientHandler_Compress(t *testing.T) {
+	large := `{"data":"` + strings.Repeat("a", minCompressedSize) + `"}`
+
+	bodies := make(chan string, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			reader = gz
+		}
+
+		body, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+
+		bodies <- r.Header.Get("Content-Encoding") + " " + string(body)
+	}))
+	defer ts.Close()
+
+	client := NewEndpointClient(ts.URL, []string{}, false, []string{"*"}, &EndpointConfig{Compress: true})
+	evtCtx := eventContext{event: &StripeEvent{ID: "evt_123"}}
+
+	require.NoError(t, client.Post(evtCtx, "{}", map[string]string{}))
+	require.Equal(t, " {}", <-bodies)
+
+	require.NoError(t, client.Post(evtCtx, large, map[string]string{}))
+	require.Equal(t, "gzip "+large, <-bodies)
+}
@@ -0,0 +1,197 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/websocket"
+)
+
+// webhooksWebSocketFeature is the websocket feature of webhook events
+const webhooksWebSocketFeature = "webhooks"
+
+//
+// Public types
+//
+
+// ListenerOptions configures a Listener. The zero values are the defaults of
+// `stripe listen`.
+type ListenerOptions struct {
+	// APIKey authenticates the session with Stripe (required)
+	APIKey string
+	// APIBaseURL is the URL of the API (default: stripe.DefaultAPIBaseURL)
+	APIBaseURL string
+	// DeviceName identifies the session in the Dashboard (default: the
+	// hostname)
+	DeviceName string
+
+	// ForwardURL is where events are forwarded to, if any
+	ForwardURL string
+	// ForwardHeaders are added to the forwarded events, as `Key: Value`
+	ForwardHeaders []string
+	// ForwardConnectURL is where Connect events are forwarded to (default:
+	// ForwardURL)
+	ForwardConnectURL string
+	// ForwardConnectHeaders are added to the forwarded Connect events
+	// (default: ForwardHeaders)
+	ForwardConnectHeaders []string
+	// UseConfiguredWebhooks forwards events to the paths of the webhook
+	// endpoints of the account, on the host of ForwardURL
+	UseConfiguredWebhooks bool
+
+	// Events are the types of the events to listen to (default: all)
+	Events []string
+	// UseLatestAPIVersion receives events in the latest API version instead
+	// of the version of the account
+	UseLatestAPIVersion bool
+
+	// SkipVerify skips the verification of the certificates of HTTPS
+	// endpoints
+	SkipVerify bool
+	// Compress compresses large payloads with gzip
+	Compress bool
+
+	// QueueSize, QueuePolicy and DeadLetterFile configure the queue of the
+	// events waiting to be forwarded, see Config
+	QueueSize      int
+	QueuePolicy    string
+	DeadLetterFile string
+
+	// Tuning adjusts the keepalive, reconnections and timeouts of the
+	// connection to Stripe
+	Tuning websocket.Tuning
+
+	// Visitor handles what happens to the listener: received events and
+	// endpoint responses as data, connection states as statuses, and
+	// failures as errors. A nil handler ignores its elements, and an error
+	// returned by a handler stops the listener.
+	Visitor *websocket.Visitor
+
+	// Log receives the debug logs of the listener (default: discarded)
+	Log *log.Logger
+	// TelemetryClient receives the telemetry events of the listener
+	// (default: a NoOpTelemetryClient)
+	TelemetryClient stripe.TelemetryClient
+}
+
+// A Listener receives the webhook events of an account and forwards them to
+// local endpoints, like `stripe listen` does. It's the entry point for Go
+// programs embedding the CLI's listen machinery.
+//
+// Events are forwarded with the same semantics as `stripe listen`: each
+// event is forwarded once to each matching endpoint, in the order it was
+// received, and isn't retried when the endpoint fails. The failure is
+// reported to the VisitError handler as a FailedToPostError or a
+// FailedToReadResponseError, and the event can be sent again with
+// `stripe events resend`. The connection to Stripe is reconnected when it
+// drops, and the session renewed when it expires.
+//
+// Listeners don't share any state, so a program can run several at once,
+// e.g. with different API keys.
+type Listener struct {
+	proxy     *Proxy
+	outCh     chan websocket.IElement
+	visitor   *websocket.Visitor
+	telemetry stripe.TelemetryClient
+}
+
+//
+// Public functions
+//
+
+// NewListener returns a new Listener. ctx is only used to load the webhook
+// endpoints of the account with UseConfiguredWebhooks.
+func NewListener(ctx context.Context, opts ListenerOptions) (*Listener, error) {
+	if opts.APIKey == "" {
+		return nil, errors.New("an API key is required")
+	}
+
+	if opts.DeviceName == "" {
+		opts.DeviceName, _ = os.Hostname()
+	}
+
+	visitor := opts.Visitor
+	if visitor == nil {
+		visitor = &websocket.Visitor{}
+	}
+
+	if opts.TelemetryClient == nil {
+		opts.TelemetryClient = &stripe.NoOpTelemetryClient{}
+	}
+
+	outCh := make(chan websocket.IElement)
+
+	p, err := Init(stripe.WithTelemetryClient(ctx, opts.TelemetryClient), &Config{
+		DeviceName:            opts.DeviceName,
+		Key:                   opts.APIKey,
+		APIBaseURL:            opts.APIBaseURL,
+		ForwardURL:            opts.ForwardURL,
+		ForwardHeaders:        opts.ForwardHeaders,
+		ForwardConnectURL:     opts.ForwardConnectURL,
+		ForwardConnectHeaders: opts.ForwardConnectHeaders,
+		UseConfiguredWebhooks: opts.UseConfiguredWebhooks,
+		Events:                opts.Events,
+		WebSocketFeature:      webhooksWebSocketFeature,
+		UseLatestAPIVersion:   opts.UseLatestAPIVersion,
+		SkipVerify:            opts.SkipVerify,
+		Compress:              opts.Compress,
+		QueueSize:             opts.QueueSize,
+		QueuePolicy:           opts.QueuePolicy,
+		DeadLetterFile:        opts.DeadLetterFile,
+		Tuning:                opts.Tuning,
+		Log:                   opts.Log,
+		OutCh:                 outCh,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Listener{proxy: p, outCh: outCh, visitor: visitor, telemetry: opts.TelemetryClient}, nil
+}
+
+// Listen connects to Stripe and forwards events until ctx is canceled, which
+// returns nil. It returns an error when the session can't be created or
+// renewed, or when a handler of the visitor returns an error. A Listener can
+// only listen once.
+func (l *Listener) Listen(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(stripe.WithTelemetryClient(ctx, l.telemetry))
+	defer cancel()
+
+	runErr := make(chan error, 1)
+
+	go func() {
+		runErr <- l.proxy.Run(ctx)
+	}()
+
+	for el := range l.outCh {
+		if err := el.Accept(l.visitor); err != nil {
+			cancel()
+
+			// Let the proxy shut down without blocking on its output
+			go func() {
+				for range l.outCh {
+				}
+			}()
+
+			return err
+		}
+	}
+
+	return <-runErr
+}
+
+// Connected returns a channel closed once the listener is connected to
+// Stripe and ready to receive events.
+func (l *Listener) Connected() <-chan struct{} {
+	return l.proxy.IsConnected()
+}
+
+// Reauthorize replaces the session of the listener with one authorized with
+// another API key, e.g. after the key was rolled.
+func (l *Listener) Reauthorize(key string) {
+	l.proxy.Reauthorize(key)
+}
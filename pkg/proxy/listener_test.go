@@ -0,0 +1,22 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewListener(t *testing.T) {
+	_, err := NewListener(context.Background(), ListenerOptions{})
+	require.EqualError(t, err, "an API key is required")
+
+	_, err = NewListener(context.Background(), ListenerOptions{APIKey: "sk_test_123", UseConfiguredWebhooks: true})
+	require.EqualError(t, err, "load_from_webhooks_api requires a location to forward to with forward_to")
+
+	listener, err := NewListener(context.Background(), ListenerOptions{APIKey: "sk_test_123", ForwardURL: "localhost:4242/webhooks"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"*"}, listener.proxy.cfg.Events)
+	require.Len(t, listener.proxy.endpointClients, 2)
+	require.NotEmpty(t, listener.proxy.cfg.DeviceName)
+}
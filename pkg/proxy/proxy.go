@@ -2,7 +2,6 @@ package proxy
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +18,8 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/eventformat"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/requests"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/pkg/stripeauth"
@@ -66,7 +67,8 @@ func (f FailedToReadResponseError) Error() string {
 type Config struct {
 	// DeviceName is the name of the device sent to Stripe to help identify the device
 	DeviceName string
-	// Key is the API key used to authenticate with Stripe
+	// Key is the API key used to authenticate with Stripe, until it's
+	// replaced with Reauthorize
 	Key string
 	// URL to which requests are sent
 	APIBaseURL string
@@ -99,10 +101,24 @@ type Config struct {
 	UseLatestAPIVersion bool
 	// Indicates whether to skip certificate verification when forwarding webhooks to HTTPS endpoints
 	SkipVerify bool
+	// Indicates whether to compress large payloads with gzip when forwarding webhooks
+	Compress bool
+
+	// QueueSize is the maximum number of events waiting to be forwarded
+	// (default: DefaultQueueSize)
+	QueueSize int
+	// QueuePolicy is applied to new events when the queue is full, one of
+	// the QueuePolicy constants (default: QueuePolicyBlock)
+	QueuePolicy string
+	// DeadLetterFile is where events are written with QueuePolicyDeadLetter
+	DeadLetterFile string
 	// The logger used to log messages to stdin/err
 	Log *log.Logger
 	// Force use of unencrypted ws:// protocol instead of wss://
 	NoWSS bool
+	// Tuning adjusts the keepalive, reconnections and timeouts of the
+	// websocket connection
+	Tuning websocket.Tuning
 
 	// OutCh is the channel to send logs and statuses to for processing in other packages
 	OutCh chan websocket.IElement
@@ -120,6 +136,15 @@ type Proxy struct {
 
 	// Events is the supported event types for the command
 	events map[string]bool
+
+	session *sessionState
+
+	// queue holds the events waiting to be forwarded to the local endpoints
+	queue *forwardQueue
+
+	// reauthorize is signaled to replace the websocket session with one
+	// authorized with the current key
+	reauthorize chan struct{}
 }
 
 const maxConnectAttempts = 3
@@ -159,10 +184,14 @@ func (p *Proxy) Run(ctx context.Context) error {
 			session.WebSocketID,
 			session.WebSocketAuthorizedFeature,
 			&websocket.Config{
-				Log:               p.cfg.Log,
-				NoWSS:             p.cfg.NoWSS,
-				ReconnectInterval: time.Duration(session.ReconnectDelay) * time.Second,
-				EventHandler:      websocket.EventHandlerFunc(p.processWebhookEvent),
+				Log:                p.cfg.Log,
+				NoWSS:              p.cfg.NoWSS,
+				ReconnectInterval:  time.Duration(session.ReconnectDelay) * time.Second,
+				PingPeriod:         p.cfg.Tuning.PingPeriod,
+				ConnectAttemptWait: p.cfg.Tuning.ReconnectMaxBackoff,
+				ConnectTimeout:     p.cfg.Tuning.ConnectTimeout,
+				EventHandler:       websocket.EventHandlerFunc(p.processWebhookEvent),
+				OnReconnect:        p.recordReconnect,
 			},
 		)
 
@@ -188,6 +217,9 @@ func (p *Proxy) Run(ctx context.Context) error {
 
 		select {
 		case <-ctx.Done():
+			// Let the websocket client send the close frame before returning
+			<-p.webSocketClient.Stopped()
+
 			p.cfg.OutCh <- &websocket.StateElement{
 				State: websocket.Done,
 			}
@@ -204,6 +236,14 @@ func (p *Proxy) Run(ctx context.Context) error {
 				}
 				return err
 			}
+		case <-p.reauthorize:
+			p.webSocketClient.Stop()
+			<-p.webSocketClient.Stopped()
+
+			nAttempts = 0
+			p.cfg.OutCh <- &websocket.StateElement{
+				State: websocket.Reconnecting,
+			}
 		}
 	}
 
@@ -211,9 +251,7 @@ func (p *Proxy) Run(ctx context.Context) error {
 		p.webSocketClient.Stop()
 	}
 
-	log.WithFields(log.Fields{
-		"prefix": "proxy.Proxy.Run",
-	}).Debug("Bye!")
+	logging.WithPrefix(p.cfg.Log, "proxy.Proxy.Run").Debug("Bye!")
 
 	return nil
 }
@@ -228,17 +266,13 @@ func GetSessionSecret(ctx context.Context, deviceName, key, baseURL string) (str
 		WebSocketFeature: "webhooks",
 	})
 	if err != nil {
-		log.WithFields(log.Fields{
-			"prefix": "proxy.Proxy.GetSessionSecret",
-		}).Debug(err)
+		logging.WithPrefix(log.StandardLogger(), "proxy.Proxy.GetSessionSecret").Debug(err)
 		return "", err
 	}
 
 	session, err := p.createSession(ctx)
 	if err != nil {
-		log.WithFields(log.Fields{
-			"prefix": "proxy.Proxy.GetSessionSecret",
-		}).Debug(fmt.Sprintf("Error while authenticating with Stripe: %v", err))
+		logging.WithPrefix(log.StandardLogger(), "proxy.Proxy.GetSessionSecret").Debug(fmt.Sprintf("Error while authenticating with Stripe: %v", err))
 		return "", err
 	}
 
@@ -261,7 +295,11 @@ func (p *Proxy) createSession(ctx context.Context) (*stripeauth.StripeCLISession
 				ForwardConnectURL: p.cfg.ForwardConnectURL,
 			}
 
-			session, err = p.stripeAuthClient.Authorize(ctx, p.cfg.DeviceName, p.cfg.WebSocketFeature, nil, &devURLMap)
+			p.session.mu.Lock()
+			stripeAuthClient := p.stripeAuthClient
+			p.session.mu.Unlock()
+
+			session, err = stripeAuthClient.Authorize(ctx, p.cfg.DeviceName, p.cfg.WebSocketFeature, nil, &devURLMap)
 
 			if err == nil {
 				exitCh <- struct{}{}
@@ -285,8 +323,7 @@ func (p *Proxy) createSession(ctx context.Context) (*stripeauth.StripeCLISession
 
 func (p *Proxy) filterWebhookEvent(msg *websocket.WebhookEvent) bool {
 	if msg.Endpoint.APIVersion != nil && !p.cfg.UseLatestAPIVersion {
-		p.cfg.Log.WithFields(log.Fields{
-			"prefix":      "proxy.Proxy.filterWebhookEvent",
+		logging.WithPrefix(p.cfg.Log, "proxy.Proxy.filterWebhookEvent").WithFields(log.Fields{
 			"api_version": getAPIVersionString(msg.Endpoint.APIVersion),
 		}).Debugf("Received event with non-default API version, ignoring")
 
@@ -294,9 +331,7 @@ func (p *Proxy) filterWebhookEvent(msg *websocket.WebhookEvent) bool {
 	}
 
 	if msg.Endpoint.APIVersion == nil && p.cfg.UseLatestAPIVersion {
-		p.cfg.Log.WithFields(log.Fields{
-			"prefix": "proxy.Proxy.filterWebhookEvent",
-		}).Debugf("Received event with default API version, ignoring")
+		logging.WithPrefix(p.cfg.Log, "proxy.Proxy.filterWebhookEvent").Debugf("Received event with default API version, ignoring")
 
 		return true
 	}
@@ -316,6 +351,10 @@ func (p *Proxy) formatOutput(format string, eventPayload string) string {
 	switch strings.ToUpper(format) {
 	// The distinction between this and PrintJSON is that this output is stripped of all pretty format.
 	case outputFormatJSON:
+		if changes := eventformat.Changes(event); len(changes) > 0 {
+			event["changes"] = changes
+		}
+
 		outputJSON, _ := json.Marshal(event)
 		return fmt.Sprintln(ansi.ColorizeJSON(string(outputJSON), false, os.Stdout))
 	default:
@@ -331,8 +370,7 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 
 	webhookEvent := msg.WebhookEvent
 
-	p.cfg.Log.WithFields(log.Fields{
-		"prefix":                   "proxy.Proxy.processWebhookEvent",
+	logging.WithPrefix(p.cfg.Log, "proxy.Proxy.processWebhookEvent").WithFields(log.Fields{
 		"webhook_id":               webhookEvent.WebhookID,
 		"webhook_converesation_id": webhookEvent.WebhookConversationID,
 	}).Debugf("Processing webhook event")
@@ -353,9 +391,9 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 	}
 
 	evt.Request = req
+	evt.Payload = webhookEvent.EventPayload
 
-	p.cfg.Log.WithFields(log.Fields{
-		"prefix":                  "proxy.Proxy.processWebhookEvent",
+	logging.WithPrefix(p.cfg.Log, "proxy.Proxy.processWebhookEvent").WithFields(log.Fields{
 		"webhook_id":              webhookEvent.WebhookID,
 		"webhook_conversation_id": webhookEvent.WebhookConversationID,
 		"event_id":                evt.ID,
@@ -364,8 +402,10 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 	}).Trace("Webhook event trace")
 
 	// at this point the message is valid so we can acknowledge it
-	ackMessage := websocket.NewEventAck(webhookEvent.WebhookID, webhookEvent.WebhookConversationID)
-	p.webSocketClient.SendMessage(ackMessage)
+	if p.webSocketClient != nil {
+		ackMessage := websocket.NewEventAck(webhookEvent.WebhookID, webhookEvent.WebhookConversationID)
+		p.webSocketClient.SendMessage(ackMessage)
+	}
 
 	if p.filterWebhookEvent(webhookEvent) {
 		return
@@ -377,21 +417,14 @@ func (p *Proxy) processWebhookEvent(msg websocket.IncomingMessage) {
 		event:                 &evt,
 	}
 
-	if p.events["*"] || p.events[evt.Type] {
+	if p.wantsEvent(evt.Type) {
 		p.cfg.OutCh <- websocket.DataElement{
 			Data:      evt,
 			Marshaled: p.formatOutput(outputFormatJSON, webhookEvent.EventPayload),
 		}
 
-		for _, endpoint := range p.endpointClients {
-			if endpoint.SupportsEventType(evt.IsConnect(), evt.Type) {
-				// TODO: handle errors returned by endpointClients
-				go endpoint.Post(
-					evtCtx,
-					webhookEvent.EventPayload,
-					webhookEvent.HTTPHeaders,
-				)
-			}
+		if p.acceptEvent(evtCtx, webhookEvent.EventPayload, webhookEvent.HTTPHeaders) {
+			p.forwardEvent(evtCtx, webhookEvent.EventPayload, webhookEvent.HTTPHeaders)
 		}
 	}
 }
@@ -407,6 +440,8 @@ func (p *Proxy) processEndpointResponse(evtCtx eventContext, forwardURL string,
 
 	body := truncate(string(buf), maxBodySize, true)
 
+	p.recordResponse(evtCtx.event.ID, resp.StatusCode)
+
 	p.cfg.OutCh <- websocket.DataElement{
 		Data: EndpointResponse{
 			Event: evtCtx.event,
@@ -414,6 +449,11 @@ func (p *Proxy) processEndpointResponse(evtCtx eventContext, forwardURL string,
 		},
 	}
 
+	// Replayed events were already answered, don't report them to Stripe again
+	if evtCtx.replayed {
+		return
+	}
+
 	idx := 0
 	headers := make(map[string]string)
 
@@ -515,15 +555,25 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 		}
 	}
 
+	queue, err := newForwardQueue(cfg.QueueSize, cfg.QueuePolicy, cfg.DeadLetterFile)
+	if err != nil {
+		return nil, err
+	}
+
 	p := &Proxy{
 		cfg: cfg,
 		stripeAuthClient: stripeauth.NewClient(cfg.Key, &stripeauth.Config{
 			Log:        cfg.Log,
 			APIBaseURL: cfg.APIBaseURL,
 		}),
-		events: convertToMap(cfg.Events),
+		events:      convertToMap(cfg.Events),
+		session:     newSessionState(),
+		queue:       queue,
+		reauthorize: make(chan struct{}, 1),
 	}
 
+	p.queue.start(p.runForward)
+
 	for _, route := range endpointRoutes {
 		// append to endpointClients
 		p.endpointClients = append(p.endpointClients, NewEndpointClient(
@@ -537,10 +587,9 @@ func Init(ctx context.Context, cfg *Config) (*Proxy, error) {
 						return http.ErrUseLastResponse
 					},
 					Timeout: defaultTimeout,
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.SkipVerify},
-					},
+					Transport: endpointTransport(cfg.SkipVerify),
 				},
+				Compress:        cfg.Compress,
 				Log:             p.cfg.Log,
 				ResponseHandler: EndpointResponseHandlerFunc(p.processEndpointResponse),
 				OutCh:           p.cfg.OutCh,
@@ -597,6 +646,7 @@ type eventContext struct {
 	webhookID             string
 	webhookConversationID string
 	event                 *StripeEvent
+	replayed              bool
 }
 
 //
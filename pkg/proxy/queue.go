@@ -0,0 +1,189 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+//
+// Public constants
+//
+
+// Policies applied to new events when the queue of pending forwards is full
+const (
+	// QueuePolicyBlock waits for room in the queue, holding back the
+	// processing of incoming events
+	QueuePolicyBlock = "block"
+	// QueuePolicyDropOldest drops the oldest pending forward to make room
+	QueuePolicyDropOldest = "drop-oldest"
+	// QueuePolicyDeadLetter writes the event to the dead-letter file instead
+	// of forwarding it
+	QueuePolicyDeadLetter = "dead-letter"
+)
+
+// DefaultQueueSize is the default number of pending forwards kept in memory
+const DefaultQueueSize = 1000
+
+//
+// Public types
+//
+
+// DeadLetter is an event that couldn't be queued for forwarding, as written
+// to the dead-letter file, one JSON object per line.
+type DeadLetter struct {
+	EventID   string            `json:"event_id"`
+	EventType string            `json:"event_type"`
+	URL       string            `json:"url"`
+	Payload   string            `json:"payload"`
+	Headers   map[string]string `json:"headers"`
+	DroppedAt time.Time         `json:"dropped_at"`
+}
+
+//
+// Private types
+//
+
+type forwardJob struct {
+	endpoint *EndpointClient
+	evtCtx   eventContext
+	payload  string
+	headers  map[string]string
+}
+
+// forwardQueue bounds the number of forwards waiting for a slow endpoint.
+// Forwards are run by a fixed number of workers.
+type forwardQueue struct {
+	jobs           chan forwardJob
+	policy         string
+	deadLetterFile string
+
+	// mu guards the counters and the dead-letter file writes
+	mu           sync.Mutex
+	dropped      int
+	deadLettered int
+}
+
+//
+// Private constants
+//
+
+// forwardWorkers is the number of requests to local endpoints made
+// concurrently
+const forwardWorkers = 10
+
+//
+// Private functions
+//
+
+func newForwardQueue(size int, policy, deadLetterFile string) (*forwardQueue, error) {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+
+	switch policy {
+	case "":
+		policy = QueuePolicyBlock
+	case QueuePolicyBlock, QueuePolicyDropOldest:
+	case QueuePolicyDeadLetter:
+		if deadLetterFile == "" {
+			return nil, fmt.Errorf("the %s queue policy requires a dead-letter file", QueuePolicyDeadLetter)
+		}
+	default:
+		return nil, fmt.Errorf("unknown queue policy %q, expected one of %s, %s or %s", policy, QueuePolicyBlock, QueuePolicyDropOldest, QueuePolicyDeadLetter)
+	}
+
+	return &forwardQueue{
+		jobs:           make(chan forwardJob, size),
+		policy:         policy,
+		deadLetterFile: deadLetterFile,
+	}, nil
+}
+
+// start runs the workers, which call forward for each queued job.
+func (q *forwardQueue) start(forward func(forwardJob)) {
+	for i := 0; i < forwardWorkers; i++ {
+		go func() {
+			for job := range q.jobs {
+				forward(job)
+			}
+		}()
+	}
+}
+
+// push queues a job, applying the queue policy if the queue is full.
+func (q *forwardQueue) push(job forwardJob) error {
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+	}
+
+	switch q.policy {
+	case QueuePolicyDropOldest:
+		for {
+			select {
+			case q.jobs <- job:
+				return nil
+			default:
+			}
+
+			select {
+			case <-q.jobs:
+				q.mu.Lock()
+				q.dropped++
+				q.mu.Unlock()
+			default:
+			}
+		}
+	case QueuePolicyDeadLetter:
+		return q.writeDeadLetter(job)
+	default:
+		q.jobs <- job
+		return nil
+	}
+}
+
+func (q *forwardQueue) writeDeadLetter(job forwardJob) error {
+	line, err := json.Marshal(DeadLetter{
+		EventID:   job.evtCtx.event.ID,
+		EventType: job.evtCtx.event.Type,
+		URL:       job.endpoint.URL,
+		Payload:   job.payload,
+		Headers:   job.headers,
+		DroppedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	f, err := os.OpenFile(q.deadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	q.deadLettered++
+
+	return nil
+}
+
+func (q *forwardQueue) depth() int {
+	return len(q.jobs)
+}
+
+func (q *forwardQueue) counts() (dropped, deadLettered int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dropped, q.deadLettered
+}
@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestForwardJob(id string) forwardJob {
+	return forwardJob{
+		endpoint: &EndpointClient{URL: "http://localhost/webhooks"},
+		evtCtx:   eventContext{event: &StripeEvent{ID: id, Type: "charge.created"}},
+		payload:  `{"id":"` + id + `"}`,
+	}
+}
+
+func TestForwardQueueDropOldest(t *testing.T) {
+	q, err := newForwardQueue(2, QueuePolicyDropOldest, "")
+	require.NoError(t, err)
+
+	for _, id := range []string{"evt_1", "evt_2", "evt_3"} {
+		require.NoError(t, q.push(newTestForwardJob(id)))
+	}
+
+	require.Equal(t, 2, q.depth())
+	require.Equal(t, "evt_2", (<-q.jobs).evtCtx.event.ID)
+	require.Equal(t, "evt_3", (<-q.jobs).evtCtx.event.ID)
+
+	dropped, _ := q.counts()
+	require.Equal(t, 1, dropped)
+}
+
+func TestForwardQueueDeadLetter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letters.jsonl")
+
+	q, err := newForwardQueue(1, QueuePolicyDeadLetter, path)
+	require.NoError(t, err)
+
+	require.NoError(t, q.push(newTestForwardJob("evt_1")))
+	require.NoError(t, q.push(newTestForwardJob("evt_2")))
+	require.Equal(t, 1, q.depth())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	require.True(t, scanner.Scan())
+
+	var letter DeadLetter
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &letter))
+	require.Equal(t, "evt_2", letter.EventID)
+	require.Equal(t, "http://localhost/webhooks", letter.URL)
+	require.Equal(t, `{"id":"evt_2"}`, letter.Payload)
+	require.False(t, scanner.Scan())
+
+	_, deadLettered := q.counts()
+	require.Equal(t, 1, deadLettered)
+}
+
+func TestForwardQueueBlock(t *testing.T) {
+	q, err := newForwardQueue(1, "", "")
+	require.NoError(t, err)
+	require.Equal(t, QueuePolicyBlock, q.policy)
+
+	require.NoError(t, q.push(newTestForwardJob("evt_1")))
+
+	pushed := make(chan struct{})
+	go func() {
+		q.push(newTestForwardJob("evt_2"))
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		require.FailNow(t, "push should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-q.jobs
+	<-pushed
+	require.Equal(t, 1, q.depth())
+}
+
+func TestForwardQueueInvalidPolicy(t *testing.T) {
+	_, err := newForwardQueue(1, "drop-newest", "")
+	require.Error(t, err)
+
+	_, err = newForwardQueue(1, QueuePolicyDeadLetter, "")
+	require.Error(t, err)
+}
@@ -0,0 +1,392 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/stripeauth"
+)
+
+//
+// Public types
+//
+
+// SessionStats summarizes the activity of a listen session.
+type SessionStats struct {
+	// StartedAt is when the proxy was initialized
+	StartedAt time.Time
+	// LastEventAt is when the last event was received, zero if none was
+	LastEventAt time.Time
+
+	// EventsReceived is the number of events received from Stripe that
+	// passed the event filter
+	EventsReceived int
+	// EventsForwarded is the number of requests made to local endpoints
+	EventsForwarded int
+	// EventsSkipped is the number of events received while forwarding was paused
+	EventsSkipped int
+	// ForwardErrors is the number of requests to local endpoints that failed
+	ForwardErrors int
+	// Replays is the number of events replayed from the session
+	Replays int
+
+	// Reconnects is the number of times the websocket connection was lost
+	// and reestablished
+	Reconnects int
+	// Downtime is the total time the websocket connection was down
+	Downtime time.Duration
+
+	// QueueDepth is the number of events waiting to be forwarded
+	QueueDepth int
+	// QueueCapacity is the maximum number of events waiting to be forwarded
+	QueueCapacity int
+	// EventsDropped is the number of forwards dropped because the queue was full
+	EventsDropped int
+	// EventsDeadLettered is the number of forwards written to the dead-letter
+	// file because the queue was full
+	EventsDeadLettered int
+
+	// ResponseStatuses counts the endpoint responses by HTTP status code
+	ResponseStatuses map[int]int
+
+	// Paused is whether forwarding is currently paused
+	Paused bool
+	// Events is the list of event types currently listened to
+	Events []string
+}
+
+// ReceivedEvent is an event received during the session, as returned by
+// RecentEvents.
+type ReceivedEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	ReceivedAt time.Time `json:"received_at"`
+	Payload    string    `json:"payload"`
+
+	// Responses are the HTTP status codes returned by the local endpoints
+	// for the event and its replays
+	Responses []int `json:"responses"`
+}
+
+// ErrNoEventToReplay is returned by Replay when no event was received yet.
+var ErrNoEventToReplay = errors.New("no event has been received yet")
+
+// ErrEventNotFound is returned by ReplayEvent when the event isn't in the
+// session history.
+var ErrEventNotFound = errors.New("event not found in the session history")
+
+//
+// Public functions
+//
+
+// Pause stops forwarding events to local endpoints. Events keep being
+// received and displayed.
+func (p *Proxy) Pause() {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.paused = true
+}
+
+// Resume resumes forwarding events to local endpoints.
+func (p *Proxy) Resume() {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.paused = false
+}
+
+// TogglePause pauses forwarding if it is running and resumes it otherwise.
+// It returns whether forwarding is now paused.
+func (p *Proxy) TogglePause() bool {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.paused = !p.session.paused
+
+	return p.session.paused
+}
+
+// Replay forwards the last received event to the local endpoints again. The
+// endpoint responses are displayed but not reported back to Stripe.
+func (p *Proxy) Replay() (*StripeEvent, error) {
+	p.session.mu.Lock()
+	history := p.session.history
+	p.session.mu.Unlock()
+
+	if len(history) == 0 {
+		return nil, ErrNoEventToReplay
+	}
+
+	return p.ReplayEvent(history[len(history)-1].evtCtx.event.ID)
+}
+
+// ReplayEvent forwards an event of the session history to the local
+// endpoints again, like Replay.
+func (p *Proxy) ReplayEvent(id string) (*StripeEvent, error) {
+	p.session.mu.Lock()
+	received := p.session.find(id)
+	if received != nil {
+		p.session.stats.Replays++
+	}
+	p.session.mu.Unlock()
+
+	if received == nil {
+		return nil, ErrEventNotFound
+	}
+
+	evtCtx := received.evtCtx
+	evtCtx.replayed = true
+
+	p.forwardEvent(evtCtx, received.payload, received.headers)
+
+	return evtCtx.event, nil
+}
+
+// RecentEvents returns the events of the session history, most recent
+// first.
+func (p *Proxy) RecentEvents() []ReceivedEvent {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	events := make([]ReceivedEvent, 0, len(p.session.history))
+
+	for i := len(p.session.history) - 1; i >= 0; i-- {
+		received := p.session.history[i]
+
+		events = append(events, ReceivedEvent{
+			ID:         received.evtCtx.event.ID,
+			Type:       received.evtCtx.event.Type,
+			ReceivedAt: received.receivedAt,
+			Payload:    received.payload,
+			Responses:  append([]int{}, received.responses...),
+		})
+	}
+
+	return events
+}
+
+// Reauthorize replaces the API key of the session, e.g. after the user
+// logged in again, and reconnects with a new websocket session authorized
+// with it. Events are received with the old key until then. The key is only
+// held by the auth client, which is read under the session lock, so the
+// Config isn't changed.
+func (p *Proxy) Reauthorize(key string) {
+	p.session.mu.Lock()
+	p.stripeAuthClient = stripeauth.NewClient(key, &stripeauth.Config{
+		Log:        p.cfg.Log,
+		APIBaseURL: p.cfg.APIBaseURL,
+	})
+	p.session.mu.Unlock()
+
+	select {
+	case p.reauthorize <- struct{}{}:
+	default:
+		// A reauthorization is already pending
+	}
+}
+
+// SetEvents replaces the list of event types the session listens to. Event
+// types match exactly, so unknown types and wildcards other than "*" are
+// rejected, leaving the list unchanged.
+func (p *Proxy) SetEvents(events []string) error {
+	if len(events) == 0 {
+		events = []string{"*"}
+	}
+
+	for _, event := range events {
+		if event != "*" && !IsValidEventType(event) {
+			return fmt.Errorf("%q isn't an event type, use exact types like charge.succeeded, or * for all events", event)
+		}
+	}
+
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.cfg.Events = events
+	p.events = convertToMap(events)
+
+	// Endpoints loaded from the webhooks API keep their own configured events.
+	if !p.cfg.UseConfiguredWebhooks {
+		for _, endpoint := range p.endpointClients {
+			endpoint.events = convertToMap(events)
+		}
+	}
+
+	return nil
+}
+
+// Stats returns a snapshot of the session statistics.
+func (p *Proxy) Stats() SessionStats {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	stats := p.session.stats
+	stats.Paused = p.session.paused
+	stats.QueueDepth = p.queue.depth()
+	stats.QueueCapacity = cap(p.queue.jobs)
+	stats.EventsDropped, stats.EventsDeadLettered = p.queue.counts()
+	stats.ResponseStatuses = make(map[int]int, len(p.session.stats.ResponseStatuses))
+	for status, count := range p.session.stats.ResponseStatuses {
+		stats.ResponseStatuses[status] = count
+	}
+
+	stats.Events = make([]string, 0, len(p.events))
+	for event := range p.events {
+		stats.Events = append(stats.Events, event)
+	}
+	sort.Strings(stats.Events)
+
+	return stats
+}
+
+//
+// Private types
+//
+
+type receivedEvent struct {
+	evtCtx     eventContext
+	payload    string
+	headers    map[string]string
+	receivedAt time.Time
+	responses  []int
+}
+
+// sessionState holds the mutable state of a listen session. It is guarded by
+// its own mutex since it's updated from the websocket goroutines and read
+// from interactive commands.
+type sessionState struct {
+	mu sync.Mutex
+
+	paused  bool
+	history []*receivedEvent
+	stats   SessionStats
+}
+
+//
+// Private constants
+//
+
+// maxSessionHistory is the number of received events kept for replays
+const maxSessionHistory = 200
+
+//
+// Private functions
+//
+
+func newSessionState() *sessionState {
+	return &sessionState{
+		stats: SessionStats{
+			StartedAt:        time.Now(),
+			ResponseStatuses: make(map[int]int),
+		},
+	}
+}
+
+// acceptEvent records an incoming event and returns whether it should be
+// forwarded to the local endpoints.
+func (p *Proxy) acceptEvent(evtCtx eventContext, payload string, headers map[string]string) bool {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.stats.EventsReceived++
+	p.session.stats.LastEventAt = time.Now()
+	p.session.history = append(p.session.history, &receivedEvent{
+		evtCtx:     evtCtx,
+		payload:    payload,
+		headers:    headers,
+		receivedAt: p.session.stats.LastEventAt,
+	})
+
+	if len(p.session.history) > maxSessionHistory {
+		p.session.history = p.session.history[len(p.session.history)-maxSessionHistory:]
+	}
+
+	if p.session.paused {
+		p.session.stats.EventsSkipped++
+		return false
+	}
+
+	return true
+}
+
+func (p *Proxy) wantsEvent(eventType string) bool {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	return p.events["*"] || p.events[eventType]
+}
+
+func (p *Proxy) forwardEvent(evtCtx eventContext, payload string, headers map[string]string) {
+	p.session.mu.Lock()
+	endpoints := make([]*EndpointClient, 0, len(p.endpointClients))
+	for _, endpoint := range p.endpointClients {
+		if endpoint.SupportsEventType(evtCtx.event.IsConnect(), evtCtx.event.Type) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	p.session.mu.Unlock()
+
+	for _, endpoint := range endpoints {
+		err := p.queue.push(forwardJob{
+			endpoint: endpoint,
+			evtCtx:   evtCtx,
+			payload:  payload,
+			headers:  headers,
+		})
+		if err != nil {
+			logging.WithPrefix(p.cfg.Log, "proxy.Proxy.forwardEvent").Errorf("Could not write event %s to the dead-letter file: %v", evtCtx.event.ID, err)
+		}
+	}
+}
+
+func (p *Proxy) runForward(job forwardJob) {
+	err := job.endpoint.Post(job.evtCtx, job.payload, job.headers)
+	p.recordForward(err)
+}
+
+func (p *Proxy) recordForward(err error) {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.stats.EventsForwarded++
+	if err != nil {
+		p.session.stats.ForwardErrors++
+	}
+}
+
+func (p *Proxy) recordReconnect(downtime time.Duration) {
+	p.session.mu.Lock()
+	p.session.stats.Reconnects++
+	p.session.stats.Downtime += downtime
+	p.session.mu.Unlock()
+
+	logging.WithPrefix(p.cfg.Log, "proxy.Proxy.recordReconnect").Infof("Connection to Stripe lost, reconnected after %s", downtime.Round(time.Millisecond))
+}
+
+func (p *Proxy) recordResponse(eventID string, statusCode int) {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	p.session.stats.ResponseStatuses[statusCode]++
+
+	if received := p.session.find(eventID); received != nil {
+		received.responses = append(received.responses, statusCode)
+	}
+}
+
+// find returns the most recent event with the given ID in the history. The
+// caller must hold the mutex.
+func (s *sessionState) find(id string) *receivedEvent {
+	for i := len(s.history) - 1; i >= 0; i-- {
+		if s.history[i].evtCtx.event.ID == id {
+			return s.history[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionPause(t *testing.T) {
+	p, err := Init(context.Background(), &Config{ForwardURL: "http://localhost"})
+	require.NoError(t, err)
+
+	evtCtx := eventContext{event: &StripeEvent{ID: "evt_123", Type: "charge.created"}}
+
+	require.True(t, p.acceptEvent(evtCtx, "{}", nil))
+	require.True(t, p.TogglePause())
+	require.False(t, p.acceptEvent(evtCtx, "{}", nil))
+
+	stats := p.Stats()
+	require.True(t, stats.Paused)
+	require.Equal(t, 2, stats.EventsReceived)
+	require.Equal(t, 1, stats.EventsSkipped)
+	require.False(t, stats.LastEventAt.IsZero())
+
+	p.Resume()
+	require.False(t, p.Stats().Paused)
+}
+
+func TestSessionReplayWithoutEvent(t *testing.T) {
+	p, err := Init(context.Background(), &Config{ForwardURL: "http://localhost"})
+	require.NoError(t, err)
+
+	_, err = p.Replay()
+	require.Equal(t, ErrNoEventToReplay, err)
+}
+
+func TestSessionSetEvents(t *testing.T) {
+	p, err := Init(context.Background(), &Config{
+		ForwardURL: "http://localhost",
+		Events:     []string{"charge.created"},
+	})
+	require.NoError(t, err)
+
+	require.True(t, p.wantsEvent("charge.created"))
+	require.False(t, p.wantsEvent("invoice.paid"))
+
+	require.NoError(t, p.SetEvents([]string{"invoice.paid", "customer.created"}))
+
+	require.False(t, p.wantsEvent("charge.created"))
+	require.True(t, p.wantsEvent("invoice.paid"))
+	require.True(t, p.endpointClients[0].SupportsEventType(false, "invoice.paid"))
+	require.False(t, p.endpointClients[0].SupportsEventType(false, "charge.created"))
+	require.Equal(t, []string{"customer.created", "invoice.paid"}, p.Stats().Events)
+
+	err = p.SetEvents([]string{"charge.*"})
+	require.EqualError(t, err, "\"charge.*\" isn't an event type, use exact types like charge.succeeded, or * for all events")
+	require.Error(t, p.SetEvents([]string{"invoice.paid", "not.an_event"}))
+	require.True(t, p.wantsEvent("invoice.paid"))
+
+	require.NoError(t, p.SetEvents(nil))
+	require.True(t, p.wantsEvent("charge.created"))
+}
+
+func TestSessionRecordResponses(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	p.recordResponse("evt_123", 200)
+	p.recordResponse("evt_123", 200)
+	p.recordResponse("evt_456", 500)
+	p.recordForward(nil)
+
+	stats := p.Stats()
+	require.Equal(t, map[int]int{200: 2, 500: 1}, stats.ResponseStatuses)
+	require.Equal(t, 1, stats.EventsForwarded)
+	require.Equal(t, 0, stats.ForwardErrors)
+}
+
+func TestSessionRecentEvents(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	p.acceptEvent(eventContext{event: &StripeEvent{ID: "evt_123", Type: "charge.created"}}, `{"id":"evt_123"}`, nil)
+	p.acceptEvent(eventContext{event: &StripeEvent{ID: "evt_456", Type: "invoice.paid"}}, `{"id":"evt_456"}`, nil)
+	p.recordResponse("evt_123", 500)
+
+	events := p.RecentEvents()
+	require.Len(t, events, 2)
+	require.Equal(t, "evt_456", events[0].ID)
+	require.Equal(t, "charge.created", events[1].Type)
+	require.Equal(t, `{"id":"evt_123"}`, events[1].Payload)
+	require.Equal(t, []int{500}, events[1].Responses)
+
+	evt, err := p.ReplayEvent("evt_123")
+	require.NoError(t, err)
+	require.Equal(t, "evt_123", evt.ID)
+	require.Equal(t, 1, p.Stats().Replays)
+
+	_, err = p.ReplayEvent("evt_789")
+	require.Equal(t, ErrEventNotFound, err)
+}
+
+func TestSessionReauthorize(t *testing.T) {
+	p, err := Init(context.Background(), &Config{Key: "sk_test_old"})
+	require.NoError(t, err)
+
+	authClient := p.stripeAuthClient
+
+	p.Reauthorize("sk_test_new")
+	p.Reauthorize("sk_test_newer")
+
+	require.NotSame(t, authClient, p.stripeAuthClient)
+	require.Equal(t, "sk_test_old", p.cfg.Key)
+	require.Len(t, p.reauthorize, 1)
+}
+
+func TestSessionRecordReconnect(t *testing.T) {
+	p, err := Init(context.Background(), &Config{})
+	require.NoError(t, err)
+
+	p.recordReconnect(2 * time.Second)
+	p.recordReconnect(500 * time.Millisecond)
+
+	stats := p.Stats()
+	require.Equal(t, 2, stats.Reconnects)
+	require.Equal(t, 2500*time.Millisecond, stats.Downtime)
+}
@@ -16,6 +16,9 @@ type StripeEvent struct {
 	Type            string                 `json:"type"`
 	RequestData     interface{}            `json:"request"`
 	Request         StripeRequest
+
+	// Payload is the JSON of the event as it was received
+	Payload string `json:"-"`
 }
 
 // StripeRequest is a representation of the Request field in a Stripe `event` object
@@ -39,6 +42,11 @@ func (e *StripeEvent) URLForEventType() string {
 	return fmt.Sprintf("%s/events?type=%s", baseDashboardURL(e.Livemode, e.Account), e.Type)
 }
 
+// IsValidEventType returns whether eventType is an event type of the API.
+func IsValidEventType(eventType string) bool {
+	return eventType != "*" && validEvents[eventType]
+}
+
 func baseDashboardURL(livemode bool, account string) string {
 	maybeTest := ""
 	if !livemode {
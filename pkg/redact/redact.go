@@ -0,0 +1,39 @@
+// Package redact removes the secrets, i.e. secret and restricted API keys
+// and webhook signing secrets, from the text the CLI keeps in files, e.g.
+// transcripts and the command history.
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// secretRegex matches the secrets wherever they appear in text, e.g. in the
+// value of --data. Its group is the prefix of the secret, e.g. sk_test_.
+var secretRegex = regexp.MustCompile(`\b((?:sk|rk)_(?:test|live)_|whsec_)[0-9a-zA-Z]+`)
+
+// HasSecrets returns whether text holds secrets
+func HasSecrets(text string) bool {
+	return secretRegex.MatchString(text)
+}
+
+// Secrets replaces the secrets in text with replacement
+func Secrets(text, replacement string) string {
+	return secretRegex.ReplaceAllLiteralString(text, replacement)
+}
+
+// MaskSecrets replaces the secrets in text with a masked version keeping
+// their prefix and last 4 characters, e.g. sk_test_****5678, so that they
+// can still be told apart.
+func MaskSecrets(text string) string {
+	return secretRegex.ReplaceAllStringFunc(text, func(secret string) string {
+		prefix := secretRegex.FindStringSubmatch(secret)[1]
+		rest := secret[len(prefix):]
+
+		if len(rest) <= 4 {
+			return prefix + strings.Repeat("*", len(rest))
+		}
+
+		return prefix + strings.Repeat("*", len(rest)-4) + rest[len(rest)-4:]
+	})
+}
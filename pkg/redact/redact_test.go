@@ -0,0 +1,25 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasSecrets(t *testing.T) {
+	require.True(t, HasSecrets("--data key=sk_live_abc123"))
+	require.True(t, HasSecrets("whsec_abc"))
+	require.False(t, HasSecrets("pk_test_1234 cus_123"))
+	require.False(t, HasSecrets("ask_test_123"))
+}
+
+func TestSecrets(t *testing.T) {
+	require.Equal(t, "key=[REDACTED], id=cus_123", Secrets("key=rk_test_abc123, id=cus_123", "[REDACTED]"))
+}
+
+func TestMaskSecrets(t *testing.T) {
+	require.Equal(t, "key sk_test_****************5678", MaskSecrets("key sk_test_abcdefghijkl12345678"))
+	require.Equal(t, "rk_live_**1234", MaskSecrets("rk_live_ab1234"))
+	require.Equal(t, "whsec_*****cdef", MaskSecrets("whsec_12345cdef"))
+	require.Equal(t, "pk_test_1234 cus_123", MaskSecrets("pk_test_1234 cus_123"))
+}
@@ -16,9 +16,12 @@ import (
 	"strings"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/clierrors"
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/cursors"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +35,7 @@ type RequestParameters struct {
 	limit         string
 	version       string
 	stripeAccount string
+	fields        []string
 }
 
 // AppendData appends data to the request parameters.
@@ -44,6 +48,11 @@ func (r *RequestParameters) AppendExpand(fields []string) {
 	r.expand = append(r.expand, fields...)
 }
 
+// SetFields sets the response fields to display.
+func (r *RequestParameters) SetFields(fields []string) {
+	r.fields = fields
+}
+
 // SetIdempotency sets the value for the `Idempotency-Key` header.
 func (r *RequestParameters) SetIdempotency(value string) {
 	r.idempotency = value
@@ -100,10 +109,29 @@ type Base struct {
 
 	APIBaseURL string
 
+	// Log receives the debug logs of the requests (default: the standard
+	// logger)
+	Log *log.Logger
+
 	Livemode bool
 
+	// FormatOutput formats the response for the output instead of printing
+	// it as JSON, e.g. to pretty-print events
+	FormatOutput func(body []byte) (string, error)
+
 	autoConfirm bool
 	showHeaders bool
+	copy        bool
+	dryRun      bool
+
+	saveCursorName   string
+	resumeCursorName string
+
+	// cursor is the position reached in the list, when resuming or saving it
+	cursor *cursors.Cursor
+
+	stream    bool
+	streamOut io.Writer
 }
 
 var confirmationCommands = map[string]bool{http.MethodDelete: true}
@@ -155,6 +183,12 @@ func (rb *Base) InitFlags() {
 	rb.Cmd.Flags().BoolVarP(&rb.showHeaders, "show-headers", "s", false, "Show response headers")
 	rb.Cmd.Flags().BoolVar(&rb.Livemode, "live", false, "Make a live request (default: test)")
 	rb.Cmd.Flags().BoolVar(&rb.DarkStyle, "dark-style", false, "Use a darker color scheme better suited for lighter command-lines")
+	rb.Cmd.Flags().BoolVar(&rb.copy, "copy", false, "Copy the ID of the returned object to the clipboard, or its URL when it has one, e.g. for Checkout Sessions")
+	rb.Cmd.Flags().StringSliceVar(&rb.Parameters.fields, "fields", []string{}, "A comma-separated list of response fields to display. Ex: \"id,status,lines.data.amount\"")
+
+	if rb.Method != http.MethodGet {
+		rb.Cmd.Flags().BoolVar(&rb.dryRun, "dry-run", false, "Print the HTTP request, with the API key redacted, instead of sending it")
+	}
 
 	// Conditionally add flags for GET requests. I'm doing it here to keep `limit`, `start_after` and `ending_before` unexported
 	if rb.Method == http.MethodGet {
@@ -169,6 +203,10 @@ func (rb *Base) InitFlags() {
 		if rb.Cmd.Flags().Lookup("ending-before") == nil {
 			rb.Cmd.Flags().StringVarP(&rb.Parameters.endingBefore, "ending-before", "b", "", "Retrieve the previous page in the list. This is a cursor for pagination and should be an object ID")
 		}
+
+		rb.Cmd.Flags().StringVar(&rb.saveCursorName, "save-cursor", "", "Save the position reached in the list under this name, to resume from it later")
+		rb.Cmd.Flags().StringVar(&rb.resumeCursorName, "resume-cursor", "", "Retrieve the page after the position saved under this name with --save-cursor")
+		rb.Cmd.Flags().BoolVar(&rb.stream, "stream", false, "Write every object of the list as NDJSON, one per line, fetching the next page once the previous one was written")
 	}
 
 	// Hidden configuration flags, useful for dev/debugging
@@ -194,6 +232,22 @@ func (rb *Base) MakeMultiPartRequest(ctx context.Context, apiKey, path string, p
 
 // MakeRequest will make a request to the Stripe API with the specific variables given to it
 func (rb *Base) MakeRequest(ctx context.Context, apiKey, path string, params *RequestParameters, errOnStatus bool) ([]byte, error) {
+	warnDeprecated(rb.Method, path, params)
+
+	if err := rb.resumeCursor(path, params); err != nil {
+		return []byte{}, err
+	}
+
+	// When selecting fields that point inside related objects, expand them
+	// so that a single `--fields customer.email` is enough on a retrieve.
+	if len(params.fields) > 0 && rb.Method == http.MethodGet {
+		params.AppendExpand(rb.missingExpansions(path, params))
+	}
+
+	if rb.stream && rb.Method == http.MethodGet {
+		return []byte{}, rb.streamList(ctx, apiKey, path, params)
+	}
+
 	data, err := rb.buildDataForRequest(params)
 	if err != nil {
 		return []byte{}, err
@@ -203,6 +257,64 @@ func (rb *Base) MakeRequest(ctx context.Context, apiKey, path string, params *Re
 }
 
 func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params *RequestParameters, data string, errOnStatus bool, additionalConfigure func(req *http.Request)) ([]byte, error) {
+	if rb.dryRun {
+		return []byte{}, rb.printDryRun(apiKey, path, params, data, additionalConfigure)
+	}
+
+	journal := rb.journalMutation(ctx, apiKey, path, params, data)
+
+	body, err := rb.doRequest(ctx, apiKey, path, params, data, errOnStatus, additionalConfigure)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	journal(body)
+
+	if err := rb.saveCursor(path, params, body); err != nil {
+		return []byte{}, err
+	}
+
+	if !rb.SuppressOutput {
+		output := body
+		if len(params.fields) > 0 {
+			output, err = SelectFields(body, params.fields)
+			if err != nil {
+				return []byte{}, err
+			}
+		}
+
+		// A renderer registered for the type of the object replaces the
+		// formatting of the command
+		result, rendered, err := render(output)
+		if err != nil {
+			return []byte{}, err
+		}
+
+		if !rendered {
+			result = ansi.ColorizeJSON(string(output), rb.DarkStyle, os.Stdout)
+			if rb.FormatOutput != nil {
+				result, err = rb.FormatOutput(output)
+				if err != nil {
+					return []byte{}, err
+				}
+			}
+		}
+
+		if err := ansi.Page(result, os.Stdout); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	if rb.copy {
+		if err := copyResponseValue(body); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	return body, nil
+}
+
+func (rb *Base) doRequest(ctx context.Context, apiKey, path string, params *RequestParameters, data string, errOnStatus bool, additionalConfigure func(req *http.Request)) ([]byte, error) {
 	parsedBaseURL, err := url.Parse(rb.APIBaseURL)
 	if err != nil {
 		return []byte{}, err
@@ -212,43 +324,59 @@ func (rb *Base) performRequest(ctx context.Context, apiKey, path string, params
 		BaseURL: parsedBaseURL,
 		APIKey:  apiKey,
 		Verbose: rb.showHeaders,
+		Log:     rb.Log,
 	}
 
-	configure := func(req *http.Request) {
-		rb.setIdempotencyHeader(req, params)
-		rb.setStripeAccountHeader(req, params)
-		rb.setVersionHeader(req, params)
-		if additionalConfigure != nil {
-			additionalConfigure(req)
-		}
-	}
-
-	resp, err := client.PerformRequest(ctx, rb.Method, path, data, configure)
+	resp, err := client.PerformRequest(ctx, rb.Method, path, data, rb.configureRequest(params, additionalConfigure))
 
 	if err != nil {
+		// Canceled requests didn't fail to reach Stripe, and offline ones
+		// didn't try
+		var offlineErr *clierrors.OfflineError
+		if (ctx == nil || ctx.Err() == nil) && !errors.As(err, &offlineErr) {
+			err = &clierrors.NetworkError{Err: err}
+		}
+
 		return []byte{}, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 
-	if resp.StatusCode == 401 || (errOnStatus && resp.StatusCode >= 300) {
+	if resp.StatusCode == 401 {
+		return []byte{}, &clierrors.AuthError{Err: compileRequestError(body, resp.StatusCode)}
+	}
+
+	if errOnStatus && resp.StatusCode >= 300 {
 		requestError := compileRequestError(body, resp.StatusCode)
 		return []byte{}, requestError
 	}
 
-	if !rb.SuppressOutput {
-		if err != nil {
-			return []byte{}, err
-		}
-
-		result := ansi.ColorizeJSON(string(body), rb.DarkStyle, os.Stdout)
-		fmt.Print(result)
+	if err != nil {
+		return []byte{}, err
 	}
 
 	return body, nil
 }
 
+// missingExpansions returns the expansions required by the selected fields
+// that were not already requested.
+func (rb *Base) missingExpansions(path string, params *RequestParameters) []string {
+	requested := make(map[string]bool)
+	for _, e := range params.expand {
+		requested[e] = true
+	}
+
+	missing := make([]string, 0)
+	for _, e := range fieldExpansions(path, params.fields) {
+		if !requested[e] {
+			missing = append(missing, e)
+		}
+	}
+
+	return missing
+}
+
 func compileRequestError(body []byte, statusCode int) RequestError {
 	type requestErrorContent struct {
 		Code string `json:"code"`
@@ -386,6 +514,17 @@ func encode(keys []string, values []string) string {
 	return buf.String()
 }
 
+func (rb *Base) configureRequest(params *RequestParameters, additionalConfigure func(req *http.Request)) func(req *http.Request) {
+	return func(req *http.Request) {
+		rb.setIdempotencyHeader(req, params)
+		rb.setStripeAccountHeader(req, params)
+		rb.setVersionHeader(req, params)
+		if additionalConfigure != nil {
+			additionalConfigure(req)
+		}
+	}
+}
+
 func (rb *Base) setIdempotencyHeader(request *http.Request, params *RequestParameters) {
 	if params.idempotency != "" {
 		request.Header.Set("Idempotency-Key", params.idempotency)
@@ -419,7 +558,8 @@ func (rb *Base) confirmCommand() (bool, error) {
 }
 
 func (rb *Base) getUserConfirmation(reader *bufio.Reader) (bool, error) {
-	if _, needsConfirmation := confirmationCommands[rb.Method]; needsConfirmation && !rb.autoConfirm {
+	// Nothing is sent on dry runs
+	if _, needsConfirmation := confirmationCommands[rb.Method]; needsConfirmation && !rb.autoConfirm && !rb.dryRun {
 		confirmationPrompt := fmt.Sprintf("Are you sure you want to perform the command: %s?\nEnter 'yes' to confirm: ", rb.Method)
 		fmt.Print(confirmationPrompt)
 
@@ -438,6 +578,12 @@ func (rb *Base) getUserConfirmation(reader *bufio.Reader) (bool, error) {
 	return true, nil
 }
 
+// ObjectPath returns the API path of an object ID or of a path with or
+// without the /v1 prefix, as accepted by the get command.
+func ObjectPath(arg string) (string, error) {
+	return createOrNormalizePath(arg)
+}
+
 func createOrNormalizePath(arg string) (string, error) {
 	if idRegex.Match([]byte(arg)) {
 		matches := idRegex.FindStringSubmatch(arg)
@@ -12,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/clierrors"
 )
 
 func TestBuildDataForRequest(t *testing.T) {
@@ -154,6 +156,26 @@ func TestMakeRequest_ErrOnAPIKeyExpired(t *testing.T) {
 	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/foo/bar", params, false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Request failed, status=401, body=")
+	require.Equal(t, clierrors.KindAuth, clierrors.Kind(err))
+	require.True(t, IsAPIKeyExpiredError(err))
+}
+
+func TestMakeRequest_NetworkError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/foo/bar", &RequestParameters{}, false)
+	require.Error(t, err)
+	require.Equal(t, clierrors.KindNetwork, clierrors.Kind(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = rb.MakeRequest(ctx, "sk_test_1234", "/foo/bar", &RequestParameters{}, false)
+	require.Error(t, err)
+	require.Equal(t, "", clierrors.Kind(err))
 }
 
 func TestMakeMultiPartRequest(t *testing.T) {
@@ -0,0 +1,51 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/clipboard"
+)
+
+// copyResponseValue puts the value of the response --copy is for on the
+// clipboard, and tells which one it copied
+func copyResponseValue(body []byte) error {
+	label, value, err := copiedValue(body)
+	if err != nil {
+		return err
+	}
+
+	if err := clipboard.Copy(value); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Copied the %s %s to the clipboard.\n", label, value)
+
+	return nil
+}
+
+// copiedValue returns the URL of the returned object when it has one, e.g.
+// for Checkout Sessions and Payment Links, and its ID otherwise
+func copiedValue(body []byte) (string, string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return "", "", fmt.Errorf("could not find a value to copy in the response: %w", err)
+	}
+
+	switch object["object"] {
+	case "list", "search_result":
+		return "", "", fmt.Errorf("--copy only works with responses of a single object")
+	}
+
+	if url, ok := object["url"].(string); ok && strings.HasPrefix(url, "https://") {
+		return "URL", url, nil
+	}
+
+	if id, ok := object["id"].(string); ok && id != "" {
+		return "ID", id, nil
+	}
+
+	return "", "", fmt.Errorf("the response has no ID to copy")
+}
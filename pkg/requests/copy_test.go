@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopiedValue(t *testing.T) {
+	label, value, err := copiedValue([]byte(`{"id": "cus_123", "object": "customer"}`))
+	require.NoError(t, err)
+	require.Equal(t, "ID", label)
+	require.Equal(t, "cus_123", value)
+
+	label, value, err = copiedValue([]byte(`{"id": "cs_test_123", "object": "checkout.session", "url": "https://checkout.stripe.com/c/pay/cs_test_123"}`))
+	require.NoError(t, err)
+	require.Equal(t, "URL", label)
+	require.Equal(t, "https://checkout.stripe.com/c/pay/cs_test_123", value)
+
+	_, _, err = copiedValue([]byte(`{"object": "list", "url": "/v1/customers", "data": []}`))
+	require.Error(t, err)
+
+	_, _, err = copiedValue([]byte(`{"error": {"message": "No such customer"}}`))
+	require.Error(t, err)
+}
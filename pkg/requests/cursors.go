@@ -0,0 +1,100 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/cursors"
+)
+
+// resumeCursor starts a list where the cursor saved under
+// rb.resumeCursorName left it.
+func (rb *Base) resumeCursor(path string, params *RequestParameters) error {
+	if rb.resumeCursorName == "" {
+		return nil
+	}
+
+	if params.startingAfter != "" || params.endingBefore != "" {
+		return fmt.Errorf("--resume-cursor can't be used with --starting-after or --ending-before")
+	}
+
+	cursor, err := cursors.Get(rb.resumeCursorName)
+	if err != nil {
+		return err
+	}
+
+	path = strings.SplitN(path, "?", 2)[0]
+	if cursor.Path != path {
+		return fmt.Errorf("cursor %s was saved for %s, not %s", cursor.Name, cursor.Path, path)
+	}
+
+	if cursor.Livemode != rb.Livemode {
+		return fmt.Errorf("cursor %s was saved in %s, use --live=%t", cursor.Name, modeName(cursor.Livemode), cursor.Livemode)
+	}
+
+	if cursor.Done {
+		return fmt.Errorf("cursor %s reached the end of the list after %d objects", cursor.Name, cursor.Objects)
+	}
+
+	params.startingAfter = cursor.StartingAfter
+	rb.cursor = cursor
+
+	return nil
+}
+
+// saveCursor saves the position reached by a list request under
+// rb.saveCursorName. It's called for each page when streaming.
+func (rb *Base) saveCursor(path string, params *RequestParameters, body []byte) error {
+	if rb.saveCursorName == "" {
+		return nil
+	}
+
+	if params.endingBefore != "" {
+		return fmt.Errorf("--save-cursor can't be used with --ending-before")
+	}
+
+	var list struct {
+		Object  string `json:"object"`
+		HasMore bool   `json:"has_more"`
+		Data    []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil || list.Object != "list" {
+		return fmt.Errorf("--save-cursor only works with list requests")
+	}
+
+	cursor := cursors.Cursor{
+		Name:          rb.saveCursorName,
+		Path:          strings.SplitN(path, "?", 2)[0],
+		Livemode:      rb.Livemode,
+		StartingAfter: params.startingAfter,
+		Done:          !list.HasMore,
+	}
+
+	if rb.cursor != nil && rb.cursor.Path == cursor.Path {
+		cursor.Objects = rb.cursor.Objects
+	}
+
+	if len(list.Data) > 0 {
+		cursor.StartingAfter = list.Data[len(list.Data)-1].ID
+		cursor.Objects += len(list.Data)
+	}
+
+	if err := cursors.Save(cursor); err != nil {
+		return err
+	}
+
+	rb.cursor = &cursor
+
+	return nil
+}
+
+func modeName(livemode bool) string {
+	if livemode {
+		return "live mode"
+	}
+
+	return "test mode"
+}
@@ -0,0 +1,69 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/cursors"
+)
+
+func TestSaveAndResumeCursor(t *testing.T) {
+	cursors.Enable(filepath.Join(t.TempDir(), cursors.FileName))
+	defer cursors.Enable("")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("starting_after") {
+		case "":
+			w.Write([]byte(`{"object":"list","has_more":true,"data":[{"id":"txn_1"},{"id":"txn_2"}]}`))
+		case "txn_2":
+			w.Write([]byte(`{"object":"list","has_more":false,"data":[{"id":"txn_3"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, SuppressOutput: true, saveCursorName: "export"}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/balance_transactions", &RequestParameters{}, true)
+	require.NoError(t, err)
+
+	cursor, err := cursors.Get("export")
+	require.NoError(t, err)
+	require.Equal(t, "txn_2", cursor.StartingAfter)
+	require.Equal(t, 2, cursor.Objects)
+	require.False(t, cursor.Done)
+
+	rb = Base{APIBaseURL: ts.URL, Method: http.MethodGet, SuppressOutput: true, saveCursorName: "export", resumeCursorName: "export"}
+
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/balance_transactions", &RequestParameters{}, true)
+	require.NoError(t, err)
+
+	cursor, err = cursors.Get("export")
+	require.NoError(t, err)
+	require.Equal(t, "txn_3", cursor.StartingAfter)
+	require.Equal(t, 3, cursor.Objects)
+	require.True(t, cursor.Done)
+
+	rb = Base{APIBaseURL: ts.URL, Method: http.MethodGet, SuppressOutput: true, resumeCursorName: "export"}
+
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/balance_transactions", &RequestParameters{}, true)
+	require.EqualError(t, err, "cursor export reached the end of the list after 3 objects")
+}
+
+func TestResumeCursorOtherPath(t *testing.T) {
+	cursors.Enable(filepath.Join(t.TempDir(), cursors.FileName))
+	defer cursors.Enable("")
+
+	require.NoError(t, cursors.Save(cursors.Cursor{Name: "export", Path: "/v1/customers", StartingAfter: "cus_1"}))
+
+	rb := Base{Method: http.MethodGet, resumeCursorName: "export"}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/charges", &RequestParameters{}, true)
+	require.EqualError(t, err, "cursor export was saved for /v1/customers, not /v1/charges")
+}
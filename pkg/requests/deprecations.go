@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stripe/stripe-cli/pkg/deprecation"
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+var (
+	schemasOnce sync.Once
+	schemas     *spec.ResourceSchemas
+)
+
+// warnDeprecated reports the deprecated operation or parameters a request
+// uses, according to the bundled specification
+func warnDeprecated(method, path string, params *RequestParameters) {
+	for _, warning := range deprecations(method, path, params) {
+		deprecation.Warn(warning)
+	}
+}
+
+func deprecations(method, path string, params *RequestParameters) []deprecation.Warning {
+	schemasOnce.Do(func() {
+		schemas, _ = spec.LoadResourceSchemas()
+	})
+
+	if schemas == nil {
+		return nil
+	}
+
+	path = strings.SplitN(path, "?", 2)[0]
+
+	if schemas.IsDeprecated(method, path, "") {
+		return []deprecation.Warning{deprecation.Operation(method, path)}
+	}
+
+	names := make(map[string]bool)
+	for _, datum := range params.data {
+		name := strings.SplitN(strings.SplitN(datum, "=", 2)[0], "[", 2)[0]
+		names[name] = true
+	}
+
+	var warnings []deprecation.Warning
+	for name := range names {
+		if schemas.IsDeprecated(method, path, name) {
+			warnings = append(warnings, deprecation.Parameter(method, path, name))
+		}
+	}
+
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].Name < warnings[j].Name
+	})
+
+	return warnings
+}
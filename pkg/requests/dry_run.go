@@ -0,0 +1,81 @@
+package requests
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/login"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+// printDryRun prints the request that would be sent instead of sending it
+func (rb *Base) printDryRun(apiKey, path string, params *RequestParameters, data string, additionalConfigure func(req *http.Request)) error {
+	parsedBaseURL, err := url.Parse(rb.APIBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  apiKey,
+	}
+
+	req, err := client.NewRequest(rb.Method, path, data, rb.configureRequest(params, additionalConfigure))
+	if err != nil {
+		return err
+	}
+
+	return writeDryRun(os.Stdout, req, data)
+}
+
+// writeDryRun writes a request in the HTTP/1.1 wire format, with the API key
+// redacted and the binary bodies of file uploads elided
+func writeDryRun(w io.Writer, req *http.Request, data string) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\n", req.Method, req.URL.RequestURI())
+	fmt.Fprintf(&b, "Host: %s\n", req.URL.Host)
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if name == "Authorization" {
+				value = redactAuthorization(value)
+			}
+
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+
+	if req.Method == http.MethodPost {
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+			fmt.Fprintf(&b, "\n<multipart form data, %d bytes>\n", len(data))
+		} else if data != "" {
+			fmt.Fprintf(&b, "\n%s\n", data)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func redactAuthorization(value string) string {
+	key := strings.TrimPrefix(value, "Bearer ")
+	if len(key) < 12 {
+		return "Bearer " + strings.Repeat("*", len(key))
+	}
+
+	return "Bearer " + login.RedactAPIKey(key)
+}
@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/stripe"
+)
+
+func TestWriteDryRun(t *testing.T) {
+	baseURL, _ := url.Parse("https://api.stripe.com")
+	client := &stripe.Client{BaseURL: baseURL, APIKey: "sk_test_1234567890abcdef"}
+
+	req, err := client.NewRequest(http.MethodPost, "/v1/customers", "email=jenny%40example.com", func(req *http.Request) {
+		req.Header.Set("Idempotency-Key", "abc")
+		req.Header.Del("User-Agent")
+		req.Header.Del("X-Stripe-Client-User-Agent")
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDryRun(&buf, req, "email=jenny%40example.com"))
+	require.Equal(t, `POST /v1/customers HTTP/1.1
+Host: api.stripe.com
+Accept-Encoding: gzip, deflate
+Authorization: Bearer sk_test_************cdef
+Content-Type: application/x-www-form-urlencoded
+Idempotency-Key: abc
+
+email=jenny%40example.com
+`, buf.String())
+}
+
+func TestWriteDryRunDelete(t *testing.T) {
+	baseURL, _ := url.Parse("https://api.stripe.com")
+	client := &stripe.Client{BaseURL: baseURL, APIKey: "sk_test_1234567890abcdef"}
+
+	req, err := client.NewRequest(http.MethodDelete, "/v1/customers/cus_123", "", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDryRun(&buf, req, ""))
+	require.Contains(t, buf.String(), "DELETE /v1/customers/cus_123 HTTP/1.1\n")
+	require.NotContains(t, buf.String(), "1234567890ab")
+}
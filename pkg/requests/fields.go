@@ -0,0 +1,206 @@
+package requests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+// listEnvelopeFields are kept when selecting fields on a list response so
+// the output still describes pagination.
+var listEnvelopeFields = []string{"object", "has_more", "url"}
+
+// SelectFields returns a copy of the JSON response body containing only the
+// given dot-separated field paths (e.g. `id`, `lines.data.amount`). Arrays
+// encountered along a path are traversed element-wise. When the body is a list
+// object and a path does not start with `data`, the path is applied to every
+// element of `data` instead, so `--fields id,status` works for both retrieve
+// and list calls.
+func SelectFields(body []byte, fields []string) ([]byte, error) {
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	paths := fieldPaths(response, fields)
+
+	var selected interface{}
+	for _, path := range paths {
+		if picked, ok := pickField(response, path); ok {
+			selected = mergeFields(selected, picked)
+		}
+	}
+
+	if isList(response) {
+		for _, field := range listEnvelopeFields {
+			if picked, ok := pickField(response, []string{field}); ok {
+				selected = mergeFields(selected, picked)
+			}
+		}
+	}
+
+	if selected == nil {
+		selected = map[string]interface{}{}
+	}
+
+	result, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result, '\n'), nil
+}
+
+// fieldExpansions returns the `expand[]` values needed to resolve the given
+// field paths on the response of a GET request to path, from the bundled
+// resource schemas: whenever a field path walks through an expandable field
+// and continues past it, the field is expanded. Only the retrieve, list and
+// search operations of the resources are known to return the resource.
+func fieldExpansions(path string, fields []string) []string {
+	docsOnce.Do(func() {
+		docs, _ = spec.LoadResourceDocs()
+	})
+
+	schemasOnce.Do(func() {
+		schemas, _ = spec.LoadResourceSchemas()
+	})
+
+	if docs == nil || schemas == nil {
+		return nil
+	}
+
+	resource, operation, _, ok := docs.MatchResource(http.MethodGet, strings.SplitN(path, "?", 2)[0])
+	if !ok {
+		return nil
+	}
+
+	list := false
+	switch operation {
+	case "retrieve":
+	case "list", "search":
+		list = true
+	default:
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	expansions := make([]string, 0)
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		fieldPath := strings.Split(field, ".")
+		prefix := ""
+		if list {
+			if fieldPath[0] == "data" {
+				fieldPath = fieldPath[1:]
+			}
+			prefix = "data."
+		}
+
+		for _, expandable := range schemas.ExpandablePaths(resource.Object, fieldPath) {
+			if !seen[prefix+expandable] {
+				seen[prefix+expandable] = true
+				expansions = append(expansions, prefix+expandable)
+			}
+		}
+	}
+
+	return expansions
+}
+
+func fieldPaths(response interface{}, fields []string) [][]string {
+	paths := make([][]string, 0, len(fields))
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		path := strings.Split(field, ".")
+		if isList(response) && path[0] != "data" {
+			path = append([]string{"data"}, path...)
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+func isList(response interface{}) bool {
+	m, ok := response.(map[string]interface{})
+	return ok && m["object"] == "list"
+}
+
+// pickField returns the subset of value found at path, preserving the
+// surrounding structure.
+func pickField(value interface{}, path []string) (interface{}, bool) {
+	if len(path) == 0 {
+		return value, true
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			return nil, false
+		}
+
+		picked, ok := pickField(child, path[1:])
+		if !ok {
+			return nil, false
+		}
+
+		return map[string]interface{}{path[0]: picked}, true
+	case []interface{}:
+		picked := make([]interface{}, len(v))
+		for i, elem := range v {
+			p, ok := pickField(elem, path)
+			if !ok {
+				p = map[string]interface{}{}
+			}
+			picked[i] = p
+		}
+
+		return picked, true
+	default:
+		return nil, false
+	}
+}
+
+// mergeFields deep-merges two values produced by pickField.
+func mergeFields(dst, src interface{}) interface{} {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok {
+			return s
+		}
+
+		for k, v := range s {
+			d[k] = mergeFields(d[k], v)
+		}
+
+		return d
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok || len(d) != len(s) {
+			return s
+		}
+
+		for i := range s {
+			d[i] = mergeFields(d[i], s[i])
+		}
+
+		return d
+	default:
+		return src
+	}
+}
@@ -0,0 +1,60 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectFields(t *testing.T) {
+	body := []byte(`{"id": "in_123", "status": "open", "total": 300, "lines": {"object": "list", "data": [{"id": "il_1", "amount": 100}, {"id": "il_2", "amount": 200}]}}`)
+
+	output, err := SelectFields(body, []string{"id", "lines.data.amount"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id": "in_123", "lines": {"data": [{"amount": 100}, {"amount": 200}]}}`, string(output))
+}
+
+func TestSelectFieldsList(t *testing.T) {
+	body := []byte(`{"object": "list", "has_more": false, "url": "/v1/charges", "data": [{"id": "ch_1", "status": "succeeded", "amount": 100}]}`)
+
+	output, err := SelectFields(body, []string{"id", "status"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"object": "list", "has_more": false, "url": "/v1/charges", "data": [{"id": "ch_1", "status": "succeeded"}]}`, string(output))
+}
+
+func TestSelectFieldsUnknownField(t *testing.T) {
+	output, err := SelectFields([]byte(`{"id": "ch_1"}`), []string{"nope"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(output))
+}
+
+func TestFieldExpansions(t *testing.T) {
+	require.Equal(t, []string{"customer"}, fieldExpansions("/v1/charges/ch_123", []string{"id", "customer.email", "customer"}))
+	require.Equal(t, []string{"data.customer"}, fieldExpansions("/v1/charges?limit=3", []string{"customer.email", "data.customer.name"}))
+	require.Equal(t, []string{"lines.data.price.product"}, fieldExpansions("/v1/invoices/in_123", []string{"lines.data.price.product.name"}))
+	require.Empty(t, fieldExpansions("/v1/charges/ch_123", []string{"id", "billing_details.address.city"}))
+	require.Empty(t, fieldExpansions("/v1/nope/123", []string{"customer.email"}))
+}
+
+func TestMakeRequestExpandsSelectedFields(t *testing.T) {
+	var queries []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "ch_123", "customer": {"id": "cus_1234", "email": "fry@planex.com"}}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, SuppressOutput: true}
+	rb.Method = http.MethodGet
+
+	params := &RequestParameters{fields: []string{"customer.email"}}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/charges/ch_123", params, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"expand[]=customer"}, queries)
+}
@@ -0,0 +1,63 @@
+package requests
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/tidwall/gjson"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
+)
+
+// stdoutIsTerminal returns whether the output is read by a human. Renderers
+// are skipped otherwise, so that scripts keep reading JSON.
+var stdoutIsTerminal = func() bool {
+	return ansi.IsTerminal(os.Stdout)
+}
+
+// objectType returns the type of the object of a response. Lists have the
+// type of their objects.
+func objectType(body []byte) string {
+	object := gjson.GetBytes(body, "object").String()
+	if object == "list" || object == "search_result" {
+		return gjson.GetBytes(body, "data.0.object").String()
+	}
+
+	return object
+}
+
+// render pipes body to the renderer registered in the config file for the
+// type of its object, and returns what it printed. ok is false when there is
+// no renderer for the type.
+func render(body []byte) (output string, ok bool, err error) {
+	if !stdoutIsTerminal() {
+		return "", false, nil
+	}
+
+	object := objectType(body)
+	if object == "" {
+		return "", false, nil
+	}
+
+	args, err := config.GetRenderer(object)
+	if err != nil || len(args) == 0 {
+		return "", false, err
+	}
+
+	var out bytes.Buffer
+
+	cmd := exec.Command(args[0], args[1:]...) // #nosec G204
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "STRIPE_OBJECT_TYPE="+object)
+
+	if err := cmd.Run(); err != nil {
+		return "", false, fmt.Errorf("renderer of %s (%s) failed: %w", object, args[0], err)
+	}
+
+	return out.String(), true, nil
+}
@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectType(t *testing.T) {
+	require.Equal(t, "customer", objectType([]byte(`{"id": "cus_123", "object": "customer"}`)))
+	require.Equal(t, "customer", objectType([]byte(`{"object": "list", "data": [{"id": "cus_123", "object": "customer"}]}`)))
+	require.Equal(t, "", objectType([]byte(`{"object": "list", "data": []}`)))
+	require.Equal(t, "", objectType([]byte(`{"error": {"message": "No such customer"}}`)))
+}
+
+func TestRender(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("renderers.customer", `sh -c 'echo "$STRIPE_OBJECT_TYPE"; tr a-z A-Z'`)
+	viper.Set("renderers.charge", "false")
+
+	defer func(isTerminal func() bool) { stdoutIsTerminal = isTerminal }(stdoutIsTerminal)
+	stdoutIsTerminal = func() bool { return true }
+
+	output, ok, err := render([]byte(`{"id": "cus_123", "object": "customer"}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "customer\n{\"ID\": \"CUS_123\", \"OBJECT\": \"CUSTOMER\"}", output)
+
+	_, ok, err = render([]byte(`{"id": "pi_123", "object": "payment_intent"}`))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = render([]byte(`{"id": "ch_123", "object": "charge"}`))
+	require.EqualError(t, err, "renderer of charge (false) failed: exit status 1")
+
+	// Scripts keep reading JSON
+	stdoutIsTerminal = func() bool { return false }
+	_, ok, err = render([]byte(`{"id": "cus_123", "object": "customer"}`))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
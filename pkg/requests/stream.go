@@ -0,0 +1,96 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// streamList writes every object of the list at path to rb.streamOut as
+// NDJSON, one line per object. A page is only fetched once the previous one
+// was written: a slow consumer blocks the writes and so the fetching, and
+// memory use doesn't grow with the size of the list.
+func (rb *Base) streamList(ctx context.Context, apiKey, path string, params *RequestParameters) error {
+	if params.endingBefore != "" {
+		return fmt.Errorf("--stream can't be used with --ending-before")
+	}
+
+	out := rb.streamOut
+	if out == nil {
+		out = os.Stdout
+	}
+
+	for {
+		data, err := rb.buildDataForRequest(params)
+		if err != nil {
+			return err
+		}
+
+		body, err := rb.doRequest(ctx, apiKey, path, params, data, true, nil)
+		if err != nil {
+			return err
+		}
+
+		var list struct {
+			Object  string            `json:"object"`
+			HasMore bool              `json:"has_more"`
+			Data    []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(body, &list); err != nil || list.Object != "list" {
+			return fmt.Errorf("--stream only works with list requests")
+		}
+
+		var page bytes.Buffer
+		for _, object := range list.Data {
+			if len(params.fields) > 0 {
+				object, err = SelectFields(object, params.fields)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := json.Compact(&page, object); err != nil {
+				return err
+			}
+
+			page.WriteByte('\n')
+		}
+
+		if _, err := out.Write(page.Bytes()); err != nil {
+			// The consumer stopped reading, e.g. `| head`
+			if errors.Is(err, syscall.EPIPE) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err := rb.saveCursor(path, params, body); err != nil {
+			return err
+		}
+
+		if !list.HasMore || len(list.Data) == 0 {
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		params.startingAfter = lastID(list.Data)
+	}
+}
+
+func lastID(objects []json.RawMessage) string {
+	var object struct {
+		ID string `json:"id"`
+	}
+
+	json.Unmarshal(objects[len(objects)-1], &object) // #nosec G104
+
+	return object.ID
+}
@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+
+		switch r.URL.Query().Get("starting_after") {
+		case "":
+			w.Write([]byte(`{"object":"list","has_more":true,"data":[{"id":"cus_1","email":"a@example.com"},{"id":"cus_2","email":"b@example.com"}]}`))
+		case "cus_2":
+			w.Write([]byte(`{"object":"list","has_more":false,"data":[{"id":"cus_3","email":"c@example.com"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, stream: true, streamOut: &out}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{limit: "100"}, true)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"cus_1","email":"a@example.com"}
+{"id":"cus_2","email":"b@example.com"}
+{"id":"cus_3","email":"c@example.com"}
+`, out.String())
+
+	out.Reset()
+
+	_, err = rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers", &RequestParameters{limit: "100", fields: []string{"email"}}, true)
+	require.NoError(t, err)
+	require.Equal(t, `{"email":"a@example.com"}
+{"email":"b@example.com"}
+{"email":"c@example.com"}
+`, out.String())
+}
+
+func TestStreamListNotAList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"object":"customer","id":"cus_1"}`))
+	}))
+	defer ts.Close()
+
+	rb := Base{APIBaseURL: ts.URL, Method: http.MethodGet, stream: true, streamOut: &bytes.Buffer{}}
+
+	_, err := rb.MakeRequest(context.Background(), "sk_test_1234", "/v1/customers/cus_1", &RequestParameters{}, true)
+	require.EqualError(t, err, "--stream only works with list requests")
+}
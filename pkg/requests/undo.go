@@ -0,0 +1,96 @@
+package requests
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/spec"
+	"github.com/stripe/stripe-cli/pkg/stripe"
+	"github.com/stripe/stripe-cli/pkg/undo"
+)
+
+var (
+	docsOnce sync.Once
+	docs     *spec.ResourceDocs
+)
+
+// journalMutation returns the function recording a request in the undo
+// journal once it succeeded. The object an update changes is retrieved
+// before the update is sent, to be able to restore it.
+func (rb *Base) journalMutation(ctx context.Context, apiKey, path string, params *RequestParameters, data string) func(body []byte) {
+	if rb.Method == http.MethodGet || !undo.Enabled() {
+		return func(body []byte) {}
+	}
+
+	docsOnce.Do(func() {
+		docs, _ = spec.LoadResourceDocs()
+	})
+
+	if docs == nil {
+		return func(body []byte) {}
+	}
+
+	path = strings.SplitN(path, "?", 2)[0]
+
+	var previous map[string]interface{}
+	if undo.NeedsPrevious(docs, rb.Method, path) {
+		previous = rb.retrieve(ctx, apiKey, path, params)
+	}
+
+	return func(body []byte) {
+		var response map[string]interface{}
+		if err := json.Unmarshal(body, &response); err != nil || response["error"] != nil {
+			return
+		}
+
+		mutation := undo.Plan(docs, rb.Method, path, data, response, previous)
+		mutation.Livemode = strings.Contains(apiKey, "_live_")
+		mutation.StripeAccount = params.stripeAccount
+
+		if err := undo.Record(mutation); err != nil {
+			logging.WithPrefix(log.StandardLogger(), "requests.Base.journalMutation").Debugf("Could not record mutation in the undo journal: %v", err)
+		}
+	}
+}
+
+// retrieve gets the object at path, returning nil when it can't
+func (rb *Base) retrieve(ctx context.Context, apiKey, path string, params *RequestParameters) map[string]interface{} {
+	parsedBaseURL, err := url.Parse(rb.APIBaseURL)
+	if err != nil {
+		return nil
+	}
+
+	client := &stripe.Client{
+		BaseURL: parsedBaseURL,
+		APIKey:  apiKey,
+	}
+
+	resp, err := client.PerformRequest(ctx, http.MethodGet, path, "", func(req *http.Request) {
+		rb.setStripeAccountHeader(req, params)
+		rb.setVersionHeader(req, params)
+	})
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode >= 300 {
+		return nil
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil
+	}
+
+	return object
+}
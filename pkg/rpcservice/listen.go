@@ -79,6 +79,8 @@ func (srv *RPCService) Listen(req *rpc.ListenRequest, stream rpc.StripeCLI_Liste
 	for {
 		select {
 		case e := <-proxyOutCh:
+			srv.cfg.Heartbeat.Observe(e)
+
 			err := e.Accept(proxyVisitor)
 			if err != nil {
 				return err
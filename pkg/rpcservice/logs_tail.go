@@ -62,6 +62,8 @@ func (srv *RPCService) LogsTail(req *rpc.LogsTailRequest, stream rpc.StripeCLI_L
 	for {
 		select {
 		case e := <-logtailingOutCh:
+			srv.cfg.Heartbeat.Observe(e)
+
 			err := e.Accept(logtailingVisitor)
 			if err != nil {
 				return err
@@ -7,6 +7,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 
 	"google.golang.org/grpc"
@@ -94,9 +95,7 @@ func serverStreamInterceptor(
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler,
 ) error {
-	log.WithFields(log.Fields{
-		"prefix": "gRPC",
-	}).Debugf("Streaming method invoked: %v", info.FullMethod)
+	logging.WithPrefix(log.StandardLogger(), "gRPC").Debugf("Streaming method invoked: %v", info.FullMethod)
 	wrappedStream := newWrappedStream(stream, info.FullMethod, srv.(*RPCService))
 	if err := authorize(wrappedStream.Context()); err != nil {
 		return err
@@ -112,9 +111,7 @@ func serverUnaryInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
-	log.WithFields(log.Fields{
-		"prefix": "gRPC",
-	}).Debugf("Unary method invoked: %v, req: %v", info.FullMethod, req)
+	logging.WithPrefix(log.StandardLogger(), "gRPC").Debugf("Unary method invoked: %v, req: %v", info.FullMethod, req)
 	newCtx := updateContextWithTelemetry(ctx, info.FullMethod, info.Server.(*RPCService))
 	if err := authorize(newCtx); err != nil {
 		return nil, err
@@ -9,15 +9,21 @@ import (
 	"net"
 	"os"
 	"syscall"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
 	"github.com/stripe/stripe-cli/pkg/config"
+	"github.com/stripe/stripe-cli/pkg/heartbeat"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 	"github.com/stripe/stripe-cli/rpc"
 )
 
+// gracefulStopTimeout is how long in-flight RPCs are given to complete when
+// the server is stopped
+const gracefulStopTimeout = 2 * time.Second
+
 // Config provides the configuration for the RPC service.
 type Config struct {
 	// Port is the port number to listen to on localhost
@@ -28,6 +34,10 @@ type Config struct {
 
 	// UserCfg is the Stripe CLI config of the user
 	UserCfg *config.Config
+
+	// Heartbeat is updated with the elements streamed by listen and logs tail
+	// sessions, if set
+	Heartbeat *heartbeat.Monitor
 }
 
 // RPCService implements the gRPC interface and starts the gRPC server.
@@ -86,11 +96,33 @@ func (srv *RPCService) Run(ctx context.Context) {
 
 	rpc.RegisterStripeCLIServer(srv.grpcServer, srv)
 
+	go func() {
+		<-ctx.Done()
+		srv.stop()
+	}()
+
 	if err := srv.grpcServer.Serve(lis); err != nil {
 		srv.cfg.Log.Fatalf("Failed to serve gRPC server on %s: %v", lis.Addr().String(), err)
 	}
 }
 
+// stop gives in-flight RPCs a chance to complete, then cancels the remaining
+// ones such as long-running listen and logs tail streams.
+func (srv *RPCService) stop() {
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(gracefulStopTimeout):
+		srv.grpcServer.Stop()
+	}
+}
+
 func (srv *RPCService) createListener() net.Listener {
 	// if port is 0, an available port is automatically chosen
 	address := fmt.Sprintf("[%s]:%d", net.IPv6loopback.String(), srv.cfg.Port)
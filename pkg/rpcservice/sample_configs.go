@@ -7,16 +7,18 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	gitpkg "github.com/stripe/stripe-cli/pkg/git"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
 )
 
 // Make overridable for tests
-var fetchRawSampleIntegrations = func(req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
+var fetchRawSampleIntegrations = func(config *config.Config, req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
 	sample := samples.Samples{
-		Fs:  afero.NewOsFs(),
-		Git: gitpkg.Operations{},
+		Config: config,
+		Fs:     afero.NewOsFs(),
+		Git:    gitpkg.Operations{},
 	}
 	err := sample.Initialize(req.SampleName)
 	if err != nil {
@@ -27,7 +29,7 @@ var fetchRawSampleIntegrations = func(req *rpc.SampleConfigsRequest) ([]samples.
 
 // SampleConfigs returns a list of available configs for a given Stripe sample.
 func (srv *RPCService) SampleConfigs(ctx context.Context, req *rpc.SampleConfigsRequest) (*rpc.SampleConfigsResponse, error) {
-	rawSampleIntegrations, err := fetchRawSampleIntegrations(req)
+	rawSampleIntegrations, err := fetchRawSampleIntegrations(srv.cfg.UserCfg, req)
 
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to fetch configs for sample %s: %v", req.SampleName, err)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
 
@@ -16,7 +17,7 @@ import (
 )
 
 func TestSampleConfigsReturnsListOfIntegrations(t *testing.T) {
-	fetchRawSampleIntegrations = func(req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
+	fetchRawSampleIntegrations = func(config *config.Config, req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
 		return []samples.SampleConfigIntegration{
 			{
 				Name:    "using-webhooks",
@@ -73,7 +74,7 @@ func TestSampleConfigsReturnsListOfIntegrations(t *testing.T) {
 }
 
 func TestSampleConfigsReturnsEmpty(t *testing.T) {
-	fetchRawSampleIntegrations = func(req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
+	fetchRawSampleIntegrations = func(config *config.Config, req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
 		return []samples.SampleConfigIntegration{}, nil
 	}
 
@@ -98,7 +99,7 @@ func TestSampleConfigsReturnsEmpty(t *testing.T) {
 }
 
 func TestSampleConfigsReturnsError(t *testing.T) {
-	fetchRawSampleIntegrations = func(req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
+	fetchRawSampleIntegrations = func(config *config.Config, req *rpc.SampleConfigsRequest) ([]samples.SampleConfigIntegration, error) {
 		return nil, errors.New("foo")
 	}
 
@@ -3,6 +3,7 @@ package rpcservice
 import (
 	"context"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
 
@@ -15,7 +16,7 @@ var createSample = samples.Create
 
 // SampleCreate creates a sample at a given path with the selected integration, client language, and server language.
 func (srv *RPCService) SampleCreate(ctx context.Context, req *rpc.SampleCreateRequest) (*rpc.SampleCreateResponse, error) {
-	selectedConfig, err := getSelectedConfig(req)
+	selectedConfig, err := getSelectedConfig(srv.cfg.UserCfg, req)
 	if err != nil {
 		return nil, err
 	}
@@ -46,9 +47,9 @@ func (srv *RPCService) SampleCreate(ctx context.Context, req *rpc.SampleCreateRe
 	return nil, status.Error(codes.Internal, "An unknown error occurred")
 }
 
-func getSelectedConfig(req *rpc.SampleCreateRequest) (*samples.SelectedConfig, error) {
+func getSelectedConfig(config *config.Config, req *rpc.SampleCreateRequest) (*samples.SelectedConfig, error) {
 	// Validate the selected integration exists
-	sampleConfig, err := getSampleConfig(req.SampleName, req.ForceRefresh)
+	sampleConfig, err := getSampleConfig(config, req.SampleName, req.ForceRefresh)
 	if err != nil {
 		return nil, err
 	}
@@ -15,7 +15,7 @@ import (
 )
 
 func TestSampleCreateSucceeds(t *testing.T) {
-	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+	getSampleConfig = func(config *config.Config, sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
 		return &samples.SampleConfig{
 			Integrations: []samples.SampleConfigIntegration{
 				{
@@ -71,7 +71,7 @@ func TestSampleCreateSucceeds(t *testing.T) {
 }
 
 func TestSampleCreateFailsWhenGetSampleConfigFails(t *testing.T) {
-	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+	getSampleConfig = func(config *config.Config, sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
 		return nil, errors.New("getSampleConfig failed")
 	}
 
@@ -112,7 +112,7 @@ func TestSampleCreateFailsWhenGetSampleConfigFails(t *testing.T) {
 }
 
 func TestSampleCreateFailsWhenIntegrationDoesntExist(t *testing.T) {
-	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+	getSampleConfig = func(config *config.Config, sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
 		return &samples.SampleConfig{
 			Integrations: []samples.SampleConfigIntegration{
 				{
@@ -161,7 +161,7 @@ func TestSampleCreateFailsWhenIntegrationDoesntExist(t *testing.T) {
 }
 
 func TestSampleCreateFailsWhenCreateSampleFails(t *testing.T) {
-	getSampleConfig = func(sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
+	getSampleConfig = func(config *config.Config, sampleName string, forceRefresh bool) (*samples.SampleConfig, error) {
 		return &samples.SampleConfig{
 			Integrations: []samples.SampleConfigIntegration{
 				{
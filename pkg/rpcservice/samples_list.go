@@ -6,18 +6,19 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
 )
 
 // Make overridable for tests
-var fetchRawSamplesList = func() (map[string]*samples.SampleData, error) {
-	return samples.GetSamples("list")
+var fetchRawSamplesList = func(config *config.Config) (map[string]*samples.SampleData, error) {
+	return samples.GetSamples(config, "list")
 }
 
 // SamplesList returns a list of available Stripe samples
 func (srv *RPCService) SamplesList(ctx context.Context, req *rpc.SamplesListRequest) (*rpc.SamplesListResponse, error) {
-	rawSamplesList, err := fetchRawSamplesList()
+	rawSamplesList, err := fetchRawSamplesList(srv.cfg.UserCfg)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to fetch Stripe samples list: %v", err)
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/stripe/stripe-cli/pkg/config"
 	"github.com/stripe/stripe-cli/pkg/samples"
 	"github.com/stripe/stripe-cli/rpc"
 
@@ -16,7 +17,7 @@ import (
 )
 
 func TestSamplesListReturnsList(t *testing.T) {
-	fetchRawSamplesList = func() (map[string]*samples.SampleData, error) {
+	fetchRawSamplesList = func(config *config.Config) (map[string]*samples.SampleData, error) {
 		list := make(map[string]*samples.SampleData)
 
 		list["accept-a-card-payment"] = &samples.SampleData{
@@ -65,7 +66,7 @@ func TestSamplesListReturnsList(t *testing.T) {
 }
 
 func TestSamplesListReturnsEmptyList(t *testing.T) {
-	fetchRawSamplesList = func() (map[string]*samples.SampleData, error) {
+	fetchRawSamplesList = func(config *config.Config) (map[string]*samples.SampleData, error) {
 		list := make(map[string]*samples.SampleData)
 		return list, nil
 	}
@@ -85,7 +86,7 @@ func TestSamplesListReturnsEmptyList(t *testing.T) {
 }
 
 func TestSamplesListReturnsError(t *testing.T) {
-	fetchRawSamplesList = func() (map[string]*samples.SampleData, error) {
+	fetchRawSamplesList = func(config *config.Config) (map[string]*samples.SampleData, error) {
 		return nil, errors.New("foo")
 	}
 
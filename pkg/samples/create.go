@@ -11,6 +11,7 @@ import (
 
 	"github.com/stripe/stripe-cli/pkg/config"
 	gitpkg "github.com/stripe/stripe-cli/pkg/git"
+	"github.com/stripe/stripe-cli/pkg/logging"
 
 	"gopkg.in/src-d/go-git.v4"
 )
@@ -80,9 +81,8 @@ func Create(
 				Out: os.Stdout,
 			}
 
-			logger.WithFields(log.Fields{
-				"prefix": "samples.create.forceRefresh",
-				"error":  err,
+			logging.WithPrefix(&logger, "samples.create.forceRefresh").WithFields(log.Fields{
+				"error": err,
 			}).Debug("Could not clear cache")
 		}
 	}
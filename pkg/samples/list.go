@@ -10,7 +10,9 @@ import (
 	"gopkg.in/src-d/go-git.v4"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/config"
 	gitpkg "github.com/stripe/stripe-cli/pkg/git"
+	"github.com/stripe/stripe-cli/pkg/offline"
 )
 
 const sampleListGithubURL = "https://github.com/stripe-samples/samples-list.git"
@@ -55,10 +57,16 @@ func (s *Samples) getFromCacheOrGithub(noNetwork bool) error {
 	}
 
 	if _, err := s.Fs.Stat(listPath); os.IsNotExist(err) {
+		if err := offline.Check("downloading the list of samples"); err != nil {
+			return err
+		}
+
 		err = s.Git.Clone(listPath, sampleListGithubURL)
 		if err != nil {
 			return err
 		}
+	} else if offline.Enabled {
+		fmt.Fprintln(os.Stderr, offline.UsingCache("list of samples"))
 	} else if !noNetwork {
 		err := s.Git.Pull(listPath)
 		if err != nil {
@@ -124,10 +132,11 @@ func (s *Samples) getSamples(mode string) (map[string]*SampleData, error) {
 // we want to be available in the CLI to some of their metadata.
 // TODO: what do we want to name these for it to be easier for users to select?
 // TODO: should we group them by products for easier exploring?
-func GetSamples(mode string) (map[string]*SampleData, error) {
+func GetSamples(config *config.Config, mode string) (map[string]*SampleData, error) {
 	sample := Samples{
-		Fs:  afero.NewOsFs(),
-		Git: gitpkg.Operations{},
+		Config: config,
+		Fs:     afero.NewOsFs(),
+		Git:    gitpkg.Operations{},
 	}
 
 	return sample.getSamples(mode)
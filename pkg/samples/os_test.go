@@ -10,6 +10,8 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/config"
 )
 
 func home() string {
@@ -32,7 +34,8 @@ func TestCacheFolder(t *testing.T) {
 	viper.SetFs(fs)
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 
 	expectedPath := filepath.Join(home(), ".config", "stripe", "samples-cache")
@@ -50,7 +53,8 @@ func TestAppCacheFolder(t *testing.T) {
 	viper.SetFs(fs)
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 
 	expectedPath := filepath.Join(home(), ".config", "stripe", "samples-cache", "bender")
@@ -66,7 +70,8 @@ func TestMakeFolder(t *testing.T) {
 	viper.SetFs(fs)
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 
 	wd, _ := os.Getwd()
@@ -92,7 +97,8 @@ func TestMakeFolderExists(t *testing.T) {
 	viper.SetFs(fs)
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 
 	wd, _ := os.Getwd()
@@ -114,7 +120,8 @@ func TestGetFolders(t *testing.T) {
 	fs.Create("zoidberg")
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 	folders, err := sample.GetFolders("/")
 
@@ -131,7 +138,8 @@ func TestGetFiles(t *testing.T) {
 	fs.Mkdir("zoidberg", os.ModePerm)
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 	}
 	files, err := sample.GetFiles("/")
 
@@ -18,6 +18,8 @@ import (
 	"github.com/stripe/stripe-cli/pkg/config"
 	g "github.com/stripe/stripe-cli/pkg/git"
 	gitpkg "github.com/stripe/stripe-cli/pkg/git"
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/offline"
 	"github.com/stripe/stripe-cli/pkg/stripeauth"
 )
 
@@ -147,10 +149,16 @@ func (s *Samples) Initialize(app string) error {
 		if !ok {
 			return fmt.Errorf("Sample %s does not exist", app)
 		}
+		if err := offline.Check("downloading the sample"); err != nil {
+			return err
+		}
+
 		err = s.Git.Clone(appPath, sampleData.GitRepo())
 		if err != nil {
 			return err
 		}
+	} else if offline.Enabled {
+		fmt.Fprintln(os.Stderr, offline.UsingCache("copy of "+app))
 	} else {
 		err := s.Git.Pull(appPath)
 		if err != nil {
@@ -295,9 +303,14 @@ func (s *Samples) ConfigureDotEnv(ctx context.Context, sampleLocation string) er
 			return fmt.Errorf("we could not set the publishable key in the .env file; please set this manually or login again to set it automatically next time")
 		}
 
-		apiKey, err := s.Config.Profile.GetAPIKey(false)
+		// Prefer the secret key since the servers of some samples use APIs
+		// that restricted keys can't
+		apiKey, err := s.Config.Profile.GetSecretKey(false)
 		if err != nil {
-			return err
+			apiKey, err = s.Config.Profile.GetAPIKey(false)
+			if err != nil {
+				return err
+			}
 		}
 
 		deviceName, err := s.Config.Profile.GetDeviceName()
@@ -368,10 +381,11 @@ func contains(s []string, e string) bool {
 }
 
 // GetSampleConfig returns the available config for this sample
-func GetSampleConfig(sampleName string, forceRefresh bool) (*SampleConfig, error) {
+func GetSampleConfig(config *config.Config, sampleName string, forceRefresh bool) (*SampleConfig, error) {
 	sample := Samples{
-		Fs:  afero.NewOsFs(),
-		Git: gitpkg.Operations{},
+		Config: config,
+		Fs:     afero.NewOsFs(),
+		Git:    gitpkg.Operations{},
 	}
 
 	if forceRefresh {
@@ -381,9 +395,8 @@ func GetSampleConfig(sampleName string, forceRefresh bool) (*SampleConfig, error
 				Out: os.Stdout,
 			}
 
-			logger.WithFields(log.Fields{
-				"prefix": "samples.create.forceRefresh",
-				"error":  err,
+			logging.WithPrefix(&logger, "samples.create.forceRefresh").WithFields(log.Fields{
+				"error": err,
 			}).Debug("Could not clear cache")
 		}
 	}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stripe/stripe-cli/pkg/config"
 )
 
 type mockGit struct {
@@ -56,7 +58,8 @@ func TestInitialize(t *testing.T) {
 	name := "accept-a-payment"
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 		Git: &mockGit{
 			fs: fs,
 		},
@@ -80,7 +83,8 @@ func TestInitializeFailsWithEmptyName(t *testing.T) {
 	name := ""
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 		Git: &mockGit{
 			fs: fs,
 		},
@@ -102,7 +106,8 @@ func TestInitializeFailsWithNonexistentSample(t *testing.T) {
 	name := "foo"
 
 	sample := Samples{
-		Fs: fs,
+		Config: &config.Config{},
+		Fs:     fs,
 		Git: &mockGit{
 			fs: fs,
 		},
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpecErrors(t *testing.T) {
+	for expr, expected := range map[string]string{
+		"* * * *":      `invalid schedule "* * * *": expected 5 fields (minute hour day-of-month month day-of-week), got 4`,
+		"60 * * * *":   `invalid schedule "60 * * * *": invalid minute "60", expected a value between 0 and 59`,
+		"* * * 0 *":    `invalid schedule "* * * 0 *": invalid month "0", expected a value between 1 and 12`,
+		"*/0 * * * *":  `invalid schedule "*/0 * * * *": invalid step in minute "*/0"`,
+		"0 10-2 * * *": `invalid schedule "0 10-2 * * *": invalid range in hour "10-2"`,
+		"0 9 * * mon":  `invalid schedule "0 9 * * mon": invalid day of week "mon", expected a value between 0 and 7`,
+		"@fortnightly": `invalid schedule "@fortnightly": expected 5 fields (minute hour day-of-month month day-of-week), got 1`,
+	} {
+		_, err := ParseSpec(expr)
+		require.EqualError(t, err, expected)
+	}
+}
+
+func TestSpecNext(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2022, time.March, 16, 10, 30, 15, 0, time.UTC)
+
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":        time.Date(2022, time.March, 16, 10, 31, 0, 0, time.UTC),
+		"*/15 * * * *":     time.Date(2022, time.March, 16, 10, 45, 0, 0, time.UTC),
+		"0 9 * * 1":        time.Date(2022, time.March, 21, 9, 0, 0, 0, time.UTC),
+		"0 9 * * 7":        time.Date(2022, time.March, 20, 9, 0, 0, 0, time.UTC),
+		"30 8-11 * * *":    time.Date(2022, time.March, 16, 11, 30, 0, 0, time.UTC),
+		"0 0 1 * *":        time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":     time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"0 12 29 2 *":      time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+		"0 0 13 * 5":       time.Date(2022, time.March, 18, 0, 0, 0, 0, time.UTC),
+		"@hourly":          time.Date(2022, time.March, 16, 11, 0, 0, 0, time.UTC),
+		"@weekly":          time.Date(2022, time.March, 20, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *":       {},
+		"0-30/10 10 * * *": time.Date(2022, time.March, 17, 10, 0, 0, 0, time.UTC),
+	} {
+		spec, err := ParseSpec(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, expected, spec.Next(now), expr)
+	}
+}
@@ -0,0 +1,158 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	exec "golang.org/x/sys/execabs"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+)
+
+//
+// Public types
+//
+
+// Runner runs the scheduled tasks while the daemon is running. The tasks file
+// is read on every minute so that tasks added or removed are picked up
+// without restarting the daemon.
+type Runner struct {
+	Store *Store
+
+	// Executable is the path of the CLI binary the tasks run with
+	Executable string
+
+	// ConfigFile is passed to the tasks with --config, if set
+	ConfigFile string
+
+	Log *log.Logger
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+//
+// Public functions
+//
+
+// Run runs the tasks when they're due, until ctx is done. It then waits for
+// the running tasks, which are killed.
+func (r *Runner) Run(ctx context.Context) {
+	for {
+		next := time.Now().Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			r.wg.Wait()
+			return
+		case <-timer.C:
+			r.runDue(ctx, next)
+		}
+	}
+}
+
+//
+// Private functions
+//
+
+// runDue starts the tasks due at the minute t
+func (r *Runner) runDue(ctx context.Context, t time.Time) {
+	tasks, err := r.Store.List()
+	if err != nil {
+		logging.WithPrefix(r.Log, "schedule.Runner.runDue").Error(err)
+		return
+	}
+
+	for _, task := range tasks {
+		spec, err := ParseSpec(task.Schedule)
+		if err != nil {
+			continue
+		}
+
+		if !spec.Next(t.Add(-time.Minute)).Equal(t) {
+			continue
+		}
+
+		r.mu.Lock()
+		if r.running == nil {
+			r.running = make(map[string]bool)
+		}
+		alreadyRunning := r.running[task.ID]
+		r.running[task.ID] = true
+		r.mu.Unlock()
+
+		if alreadyRunning {
+			logging.WithPrefix(r.Log, "schedule.Runner.runDue").WithFields(log.Fields{
+				"task": task.ID,
+			}).Warn("Skipping a run of the task, the previous one is still running")
+			continue
+		}
+
+		r.wg.Add(1)
+
+		go func(task Task) {
+			defer r.wg.Done()
+			defer func() {
+				r.mu.Lock()
+				delete(r.running, task.ID)
+				r.mu.Unlock()
+			}()
+
+			if err := r.runTask(ctx, task, t); err != nil {
+				logging.WithPrefix(r.Log, "schedule.Runner.runDue").WithFields(log.Fields{
+					"task": task.ID,
+				}).Error(err)
+			}
+		}(task)
+	}
+}
+
+// runTask runs a task, appending its output to its log file
+func (r *Runner) runTask(ctx context.Context, task Task, t time.Time) error {
+	logFile := r.Store.LogFile(task.ID)
+	if err := r.Store.Fs.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+		return err
+	}
+
+	f, err := r.Store.Fs.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	args := append([]string{}, task.Args...)
+	if task.ProjectName != "" {
+		args = append(args, "--project-name", task.ProjectName)
+	}
+	if r.ConfigFile != "" {
+		args = append(args, "--config", r.ConfigFile)
+	}
+
+	fmt.Fprintf(f, "--- %s: stripe %s\n", t.Format(time.RFC3339), strings.Join(task.Args, " "))
+
+	cmd := exec.CommandContext(ctx, r.Executable, args...)
+	cmd.Stdout = f
+	cmd.Stderr = f
+
+	start := time.Now()
+	err = cmd.Run()
+	took := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		fmt.Fprintf(f, "--- failed after %s: %s\n", took, err)
+		return nil
+	}
+
+	fmt.Fprintf(f, "--- succeeded in %s\n", took)
+
+	return nil
+}
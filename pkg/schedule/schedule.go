@@ -0,0 +1,151 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+//
+// Public types
+//
+
+// Task is a CLI command run on a schedule by the daemon
+type Task struct {
+	ID       string `json:"id"`
+	Schedule string `json:"schedule"`
+
+	// Args are the arguments passed to the CLI, without the binary name
+	Args []string `json:"args"`
+
+	// ProjectName is the project whose keys the command runs with
+	ProjectName string `json:"project_name"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store reads and writes the scheduled tasks of the CLI, kept in a JSON file
+// in the config folder.
+type Store struct {
+	Fs afero.Fs
+
+	// Dir is the folder of the tasks file and of the task logs
+	Dir string
+}
+
+//
+// Public functions
+//
+
+// List returns the scheduled tasks, in the order they were added
+func (s *Store) List() ([]Task, error) {
+	data, err := afero.ReadFile(s.Fs, s.tasksFile())
+	if os.IsNotExist(err) {
+		return []Task{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("%s is not a valid schedule file: %w", s.tasksFile(), err)
+	}
+
+	return tasks, nil
+}
+
+// Add schedules a task and returns it with its ID set
+func (s *Store) Add(task Task) (Task, error) {
+	if _, err := ParseSpec(task.Schedule); err != nil {
+		return task, err
+	}
+
+	if len(task.Args) == 0 {
+		return task, fmt.Errorf("no command to schedule")
+	}
+
+	tasks, err := s.List()
+	if err != nil {
+		return task, err
+	}
+
+	// IDs are increasing numbers, so that they're easy to type
+	last := 0
+	for _, t := range tasks {
+		if id, err := strconv.Atoi(t.ID); err == nil && id > last {
+			last = id
+		}
+	}
+
+	task.ID = strconv.Itoa(last + 1)
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+
+	return task, s.write(append(tasks, task))
+}
+
+// Remove unschedules the task with the given ID and deletes its logs
+func (s *Store) Remove(id string) error {
+	tasks, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	for i, t := range tasks {
+		if t.ID != id {
+			continue
+		}
+
+		if err := s.write(append(tasks[:i], tasks[i+1:]...)); err != nil {
+			return err
+		}
+
+		if err := s.Fs.Remove(s.LogFile(id)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no scheduled task with ID %s", id)
+}
+
+// LogFile returns the path of the file the output of the runs of a task is
+// appended to
+func (s *Store) LogFile(id string) string {
+	return filepath.Join(s.Dir, "schedule_logs", id+".log")
+}
+
+//
+// Private functions
+//
+
+func (s *Store) tasksFile() string {
+	return filepath.Join(s.Dir, "schedule.json")
+}
+
+// write replaces the tasks file atomically, since the daemon may be reading
+// it at the same time
+func (s *Store) write(tasks []Task) error {
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := s.Fs.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+
+	tmp := s.tasksFile() + ".tmp"
+	if err := afero.WriteFile(s.Fs, tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return s.Fs.Rename(tmp, s.tasksFile())
+}
@@ -0,0 +1,75 @@
+package schedule
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := &Store{Fs: afero.NewMemMapFs(), Dir: "/config"}
+
+	tasks, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	first, err := store.Add(Task{Schedule: "0 9 * * 1", Args: []string{"fixtures", "seed.json"}})
+	require.NoError(t, err)
+	require.Equal(t, "1", first.ID)
+
+	second, err := store.Add(Task{Schedule: "@daily", Args: []string{"trigger", "charge.succeeded"}})
+	require.NoError(t, err)
+	require.Equal(t, "2", second.ID)
+
+	_, err = store.Add(Task{Schedule: "0 9 * *", Args: []string{"status"}})
+	require.Error(t, err)
+
+	require.NoError(t, store.Remove("1"))
+	require.EqualError(t, store.Remove("1"), "no scheduled task with ID 1")
+
+	tasks, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	require.Equal(t, []string{"trigger", "charge.succeeded"}, tasks[0].Args)
+
+	// IDs aren't reused while later tasks exist
+	third, err := store.Add(Task{Schedule: "@hourly", Args: []string{"status"}})
+	require.NoError(t, err)
+	require.Equal(t, "3", third.ID)
+}
+
+func TestRunnerRunsDueTasks(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{Fs: afero.NewOsFs(), Dir: dir}
+
+	due, err := store.Add(Task{Schedule: "0 9 * * 1", Args: []string{"-c", "echo ran with $1 $2", "sh"}, ProjectName: "sandbox"})
+	require.NoError(t, err)
+
+	notDue, err := store.Add(Task{Schedule: "0 10 * * 1", Args: []string{"-c", "echo ran"}})
+	require.NoError(t, err)
+
+	runner := &Runner{
+		Store:      store,
+		Executable: "/bin/sh",
+		Log:        log.StandardLogger(),
+	}
+
+	// A Monday at 9
+	runner.runDue(context.Background(), time.Date(2022, time.March, 21, 9, 0, 0, 0, time.UTC))
+	runner.wg.Wait()
+
+	output, err := ioutil.ReadFile(store.LogFile(due.ID))
+	require.NoError(t, err)
+	require.Contains(t, string(output), "--- 2022-03-21T09:00:00Z: stripe -c echo ran with $1 $2 sh\n")
+	require.Contains(t, string(output), "ran with --project-name sandbox\n")
+	require.Contains(t, string(output), "--- succeeded in")
+
+	require.NoFileExists(t, store.LogFile(notDue.ID))
+	require.Equal(t, filepath.Join(dir, "schedule_logs", "2.log"), store.LogFile(notDue.ID))
+}
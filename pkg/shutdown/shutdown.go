@@ -0,0 +1,200 @@
+package shutdown
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/stripe/stripe-cli/pkg/logging"
+)
+
+// DefaultDeadline is the default time given to the CLI to wind down once
+// shutdown has started
+const DefaultDeadline = 5 * time.Second
+
+// exitCodeInterrupted is the conventional exit code of a process terminated
+// by SIGINT
+const exitCodeInterrupted = 130
+
+//
+// Public types
+//
+
+// Hook is a function run when the CLI shuts down. The context passed to it
+// expires when the shutdown deadline is reached.
+type Hook func(ctx context.Context)
+
+// Config provides the configuration of a Coordinator
+type Config struct {
+	// Deadline is the maximum time given to the running command and the
+	// shutdown hooks once a signal is received. Defaults to DefaultDeadline.
+	Deadline time.Duration
+
+	Log *log.Logger
+
+	// Exit terminates the process when the deadline is exceeded or a second
+	// signal is received. Defaults to os.Exit.
+	Exit func(code int)
+}
+
+// Coordinator propagates termination signals to the running command through
+// context cancellation, then runs the registered shutdown hooks (closing
+// connections, flushing telemetry, etc.) within a global deadline.
+type Coordinator struct {
+	cfg *Config
+
+	mu     sync.Mutex
+	hooks  []namedHook
+	cancel context.CancelFunc
+
+	signalOnce   sync.Once
+	shutdownOnce sync.Once
+}
+
+//
+// Public functions
+//
+
+// New returns a new Coordinator.
+func New(cfg *Config) *Coordinator {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	if cfg.Deadline == 0 {
+		cfg.Deadline = DefaultDeadline
+	}
+
+	if cfg.Log == nil {
+		cfg.Log = &log.Logger{Out: ioutil.Discard}
+	}
+
+	if cfg.Exit == nil {
+		cfg.Exit = os.Exit
+	}
+
+	return &Coordinator{
+		cfg: cfg,
+	}
+}
+
+// Context returns a copy of ctx that is canceled when shutdown starts. The
+// coordinator is stored in the returned context so commands can register
+// hooks with OnShutdown.
+func (c *Coordinator) Context(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	return context.WithValue(ctx, coordinatorKey{}, c)
+}
+
+// HandleSignals starts shutting down when SIGINT or SIGTERM is received. The
+// context is canceled right away so the running command can return, and the
+// process is terminated if it hasn't exited within the deadline. A second
+// signal terminates the process immediately.
+func (c *Coordinator) HandleSignals() {
+	signalCh := make(chan os.Signal, 2)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signalCh
+		logging.WithPrefix(c.cfg.Log, "shutdown.Coordinator.HandleSignals").Debugf("Received %s, cleaning up...", sig)
+
+		c.signalOnce.Do(c.cancelContext)
+
+		time.AfterFunc(c.cfg.Deadline, func() {
+			logging.WithPrefix(c.cfg.Log, "shutdown.Coordinator.HandleSignals").Warnf("Failed to shut down within %s, exiting", c.cfg.Deadline)
+			c.cfg.Exit(exitCodeInterrupted)
+		})
+
+		<-signalCh
+		c.cfg.Exit(exitCodeInterrupted)
+	}()
+}
+
+// OnShutdown registers a hook to run on shutdown. Hooks run in the reverse
+// order of registration.
+func (c *Coordinator) OnShutdown(name string, hook Hook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, namedHook{name: name, hook: hook})
+}
+
+// Shutdown cancels the context and runs the registered hooks. Hooks that are
+// still running once the deadline is reached are abandoned. Only the first
+// call has an effect.
+func (c *Coordinator) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		c.signalOnce.Do(c.cancelContext)
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Deadline)
+		defer cancel()
+
+		c.mu.Lock()
+		hooks := c.hooks
+		c.mu.Unlock()
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			c.runHook(ctx, hooks[i])
+		}
+	})
+}
+
+// OnShutdown registers a hook with the coordinator stored in ctx. It does
+// nothing if the context was not created by a Coordinator.
+func OnShutdown(ctx context.Context, name string, hook Hook) {
+	if c, ok := ctx.Value(coordinatorKey{}).(*Coordinator); ok {
+		c.OnShutdown(name, hook)
+	}
+}
+
+//
+// Private types
+//
+
+type coordinatorKey struct{}
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+//
+// Private functions
+//
+
+func (c *Coordinator) cancelContext() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+func (c *Coordinator) runHook(ctx context.Context, h namedHook) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		h.hook(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logging.WithPrefix(c.cfg.Log, "shutdown.Coordinator.Shutdown").WithFields(log.Fields{
+			"hook": h.name,
+		}).Warn("Shutdown deadline exceeded, abandoning hook")
+	}
+}
@@ -0,0 +1,45 @@
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownRunsHooksInReverseOrder(t *testing.T) {
+	c := New(nil)
+	ctx := c.Context(context.Background())
+
+	order := make([]string, 0)
+	OnShutdown(ctx, "first", func(ctx context.Context) { order = append(order, "first") })
+	OnShutdown(ctx, "second", func(ctx context.Context) { order = append(order, "second") })
+
+	c.Shutdown()
+	c.Shutdown()
+
+	require.Equal(t, []string{"second", "first"}, order)
+	require.Error(t, ctx.Err())
+}
+
+func TestShutdownAbandonsSlowHooks(t *testing.T) {
+	c := New(&Config{Deadline: 10 * time.Millisecond})
+	c.Context(context.Background())
+
+	ran := false
+	c.OnShutdown("slow", func(ctx context.Context) { time.Sleep(time.Second) })
+	c.OnShutdown("fast", func(ctx context.Context) { ran = true })
+
+	start := time.Now()
+	c.Shutdown()
+
+	require.True(t, ran)
+	require.Less(t, int64(time.Since(start)), int64(500*time.Millisecond))
+}
+
+func TestOnShutdownWithoutCoordinator(t *testing.T) {
+	require.NotPanics(t, func() {
+		OnShutdown(context.Background(), "noop", func(ctx context.Context) {})
+	})
+}
@@ -0,0 +1,393 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/requests"
+)
+
+// DefaultPollInterval is the time between two checks of a test clock that
+// is advancing
+const DefaultPollInterval = 2 * time.Second
+
+// Test payment methods used during the simulation
+const (
+	workingPaymentMethod = "pm_card_visa"
+	failingPaymentMethod = "pm_card_chargeCustomerFail"
+)
+
+//
+// Public types
+//
+
+// APIClient sends requests to the Stripe API
+type APIClient interface {
+	Request(ctx context.Context, method, path string, params []string) (gjson.Result, error)
+}
+
+// BillingConfig configures a billing simulation
+type BillingConfig struct {
+	Client APIClient
+
+	// Price is the ID of the price the customer subscribes to
+	Price string
+
+	// Months is the number of billing periods to walk through
+	Months int
+
+	// FailMonth is the month in which the renewal payment fails and is
+	// then recovered. Zero disables the failure.
+	FailMonth int
+
+	PollInterval time.Duration
+
+	// Used for tests
+	now func() time.Time
+}
+
+// TimelineEvent is a Stripe event generated during the simulation
+type TimelineEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// TimelineEntry is a step of the simulation, with the events it generated
+type TimelineEntry struct {
+	Month         int             `json:"month"`
+	SimulatedTime time.Time       `json:"simulated_time"`
+	Step          string          `json:"step"`
+	Events        []TimelineEvent `json:"events"`
+}
+
+// BillingReport is the result of a billing simulation
+type BillingReport struct {
+	TestClock    string          `json:"test_clock"`
+	Customer     string          `json:"customer"`
+	Subscription string          `json:"subscription"`
+	Timeline     []TimelineEntry `json:"timeline"`
+}
+
+//
+// Public functions
+//
+
+// NewAPIClient returns an APIClient sending requests with apiKey.
+func NewAPIClient(apiKey, apiBaseURL string) APIClient {
+	return &requestsClient{apiKey: apiKey, apiBaseURL: apiBaseURL}
+}
+
+// Billing walks a new subscription to price through cfg.Months billing
+// periods using a test clock. The renewal payment of cfg.FailMonth fails
+// and is then recovered by switching to a working card and paying the open
+// invoice.
+func Billing(ctx context.Context, cfg *BillingConfig) (*BillingReport, error) {
+	if cfg.Months <= 0 {
+		return nil, fmt.Errorf("months must be positive")
+	}
+
+	if cfg.FailMonth < 0 || cfg.FailMonth > cfg.Months {
+		return nil, fmt.Errorf("fail month must be between 1 and %d", cfg.Months)
+	}
+
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+
+	if cfg.now == nil {
+		cfg.now = time.Now
+	}
+
+	sim := &billingSimulation{
+		cfg:    cfg,
+		report: &BillingReport{},
+		seen:   make(map[string]bool),
+		since:  cfg.now().Unix(),
+	}
+
+	if err := sim.setUp(ctx); err != nil {
+		return sim.report, err
+	}
+
+	for month := 1; month <= cfg.Months; month++ {
+		if err := sim.runMonth(ctx, month); err != nil {
+			return sim.report, err
+		}
+	}
+
+	return sim.report, nil
+}
+
+// Print writes a human readable timeline to w.
+func (r *BillingReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "Test clock:   %s\n", r.TestClock)
+	fmt.Fprintf(w, "Customer:     %s\n", r.Customer)
+	fmt.Fprintf(w, "Subscription: %s\n\n", r.Subscription)
+
+	for _, entry := range r.Timeline {
+		fmt.Fprintf(w, "%s  month %d  %s\n", entry.SimulatedTime.Format("2006-01-02"), entry.Month, entry.Step)
+
+		for _, evt := range entry.Events {
+			fmt.Fprintf(w, "                      --> %s [%s]\n", evt.Type, evt.ID)
+		}
+	}
+}
+
+//
+// Private types
+//
+
+type billingSimulation struct {
+	cfg    *BillingConfig
+	report *BillingReport
+
+	start time.Time
+
+	// seen and since are used to only report each event once
+	seen  map[string]bool
+	since int64
+}
+
+type requestsClient struct {
+	apiKey     string
+	apiBaseURL string
+}
+
+//
+// Private functions
+//
+
+func (c *requestsClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	base := requests.Base{
+		Method:         method,
+		SuppressOutput: true,
+		APIBaseURL:     c.apiBaseURL,
+	}
+
+	rp := &requests.RequestParameters{}
+	rp.AppendData(params)
+
+	body, err := base.MakeRequest(ctx, c.apiKey, path, rp, true)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	return gjson.ParseBytes(body), nil
+}
+
+func (s *billingSimulation) setUp(ctx context.Context) error {
+	client := s.cfg.Client
+	s.start = s.cfg.now().UTC().Truncate(time.Hour)
+
+	clock, err := client.Request(ctx, http.MethodPost, "/v1/test_helpers/test_clocks", []string{
+		"frozen_time=" + unix(s.start),
+		"name=stripe simulate billing",
+	})
+	if err != nil {
+		return err
+	}
+	s.report.TestClock = clock.Get("id").String()
+
+	customer, err := client.Request(ctx, http.MethodPost, "/v1/customers", []string{
+		"test_clock=" + s.report.TestClock,
+		"description=Created by stripe simulate billing",
+	})
+	if err != nil {
+		return err
+	}
+	s.report.Customer = customer.Get("id").String()
+
+	if err := s.setPaymentMethod(ctx, workingPaymentMethod); err != nil {
+		return err
+	}
+
+	subscription, err := client.Request(ctx, http.MethodPost, "/v1/subscriptions", []string{
+		"customer=" + s.report.Customer,
+		"items[0][price]=" + s.cfg.Price,
+	})
+	if err != nil {
+		return err
+	}
+	s.report.Subscription = subscription.Get("id").String()
+
+	return s.record(ctx, 0, s.start, fmt.Sprintf("Subscribed %s to %s", s.report.Customer, s.cfg.Price))
+}
+
+func (s *billingSimulation) runMonth(ctx context.Context, month int) error {
+	failing := month == s.cfg.FailMonth
+
+	if failing {
+		if err := s.setPaymentMethod(ctx, failingPaymentMethod); err != nil {
+			return err
+		}
+	}
+
+	// Advance a bit past the renewal so that the invoice is finalized and
+	// its payment attempted
+	target := s.start.AddDate(0, month, 0).Add(2 * time.Hour)
+	if err := s.advanceClock(ctx, target); err != nil {
+		return err
+	}
+
+	step := "Renewed subscription"
+	if failing {
+		step = "Renewal payment failed"
+	}
+
+	if err := s.record(ctx, month, target, step); err != nil {
+		return err
+	}
+
+	if !failing {
+		return nil
+	}
+
+	invoices, err := s.recoverPayment(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.record(ctx, month, target, fmt.Sprintf("Recovered payment of %d invoice(s) with a working card", invoices))
+}
+
+// setPaymentMethod attaches a test payment method to the customer and makes
+// it the default for invoices.
+func (s *billingSimulation) setPaymentMethod(ctx context.Context, paymentMethod string) error {
+	pm, err := s.cfg.Client.Request(ctx, http.MethodPost, "/v1/payment_methods/"+paymentMethod+"/attach", []string{
+		"customer=" + s.report.Customer,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cfg.Client.Request(ctx, http.MethodPost, "/v1/customers/"+s.report.Customer, []string{
+		"invoice_settings[default_payment_method]=" + pm.Get("id").String(),
+	})
+
+	return err
+}
+
+// recoverPayment switches back to a working card and pays the open invoices.
+func (s *billingSimulation) recoverPayment(ctx context.Context) (int, error) {
+	if err := s.setPaymentMethod(ctx, workingPaymentMethod); err != nil {
+		return 0, err
+	}
+
+	invoices, err := s.cfg.Client.Request(ctx, http.MethodGet, "/v1/invoices", []string{
+		"customer=" + s.report.Customer,
+		"status=open",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	paid := 0
+
+	for _, invoice := range invoices.Get("data").Array() {
+		_, err := s.cfg.Client.Request(ctx, http.MethodPost, "/v1/invoices/"+invoice.Get("id").String()+"/pay", nil)
+		if err != nil {
+			return paid, err
+		}
+
+		paid++
+	}
+
+	return paid, nil
+}
+
+// advanceClock advances the test clock and waits until it's ready.
+func (s *billingSimulation) advanceClock(ctx context.Context, target time.Time) error {
+	path := "/v1/test_helpers/test_clocks/" + s.report.TestClock
+
+	_, err := s.cfg.Client.Request(ctx, http.MethodPost, path+"/advance", []string{
+		"frozen_time=" + unix(target),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		clock, err := s.cfg.Client.Request(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return err
+		}
+
+		switch status := clock.Get("status").String(); status {
+		case "ready":
+			return nil
+		case "advancing":
+		default:
+			return fmt.Errorf("test clock %s is %s", s.report.TestClock, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.cfg.PollInterval):
+		}
+	}
+}
+
+// record adds a timeline entry with the events related to the simulation
+// that were not reported yet.
+func (s *billingSimulation) record(ctx context.Context, month int, simulated time.Time, step string) error {
+	events, err := s.cfg.Client.Request(ctx, http.MethodGet, "/v1/events", []string{
+		"created[gte]=" + strconv.FormatInt(s.since, 10),
+		"limit=100",
+	})
+	if err != nil {
+		return err
+	}
+
+	entry := TimelineEntry{
+		Month:         month,
+		SimulatedTime: simulated,
+		Step:          step,
+		Events:        []TimelineEvent{},
+	}
+
+	// Events are listed newest first
+	data := events.Get("data").Array()
+	for i := len(data) - 1; i >= 0; i-- {
+		evt := data[i]
+		id := evt.Get("id").String()
+
+		if s.seen[id] || !s.related(evt) {
+			continue
+		}
+
+		s.seen[id] = true
+		entry.Events = append(entry.Events, TimelineEvent{ID: id, Type: evt.Get("type").String()})
+	}
+
+	s.report.Timeline = append(s.report.Timeline, entry)
+
+	return nil
+}
+
+// related returns whether evt is about one of the simulation's objects.
+func (s *billingSimulation) related(evt gjson.Result) bool {
+	object := evt.Get("data.object")
+
+	for _, id := range []string{s.report.TestClock, s.report.Customer, s.report.Subscription} {
+		if id == "" {
+			continue
+		}
+
+		if object.Get("id").String() == id || object.Get("customer").String() == id || object.Get("subscription").String() == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
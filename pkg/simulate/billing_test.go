@@ -0,0 +1,108 @@
+package simulate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type fakeClient struct {
+	requests []string
+	polls    int
+	events   []string
+}
+
+func (c *fakeClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+
+	switch {
+	case path == "/v1/test_helpers/test_clocks":
+		return gjson.Parse(`{"id":"clock_123"}`), nil
+	case path == "/v1/customers":
+		c.events = append(c.events, `{"id":"evt_cus","type":"customer.created","data":{"object":{"id":"cus_123"}}}`)
+		return gjson.Parse(`{"id":"cus_123"}`), nil
+	case path == "/v1/subscriptions":
+		c.events = append(c.events, `{"id":"evt_sub","type":"customer.subscription.created","data":{"object":{"id":"sub_123","customer":"cus_123"}}}`)
+		return gjson.Parse(`{"id":"sub_123"}`), nil
+	case strings.HasSuffix(path, "/attach"):
+		return gjson.Parse(`{"id":"pm_attached"}`), nil
+	case strings.HasSuffix(path, "/advance"):
+		c.events = append(c.events, fmt.Sprintf(`{"id":"evt_inv%d","type":"invoice.paid","data":{"object":{"id":"in_%d","customer":"cus_123"}}}`, len(c.events), len(c.events)))
+		return gjson.Parse(`{"status":"advancing"}`), nil
+	case path == "/v1/test_helpers/test_clocks/clock_123":
+		c.polls++
+		if c.polls%2 == 1 {
+			return gjson.Parse(`{"status":"advancing"}`), nil
+		}
+		return gjson.Parse(`{"status":"ready"}`), nil
+	case path == "/v1/invoices":
+		return gjson.Parse(`{"data":[{"id":"in_open"}]}`), nil
+	case path == "/v1/events":
+		// Newest first, plus an unrelated event
+		data := []string{`{"id":"evt_other","type":"charge.succeeded","data":{"object":{"id":"ch_1","customer":"cus_other"}}}`}
+		for i := len(c.events) - 1; i >= 0; i-- {
+			data = append(data, c.events[i])
+		}
+		return gjson.Parse(`{"data":[` + strings.Join(data, ",") + `]}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestBilling(t *testing.T) {
+	client := &fakeClient{}
+	start := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	report, err := Billing(context.Background(), &BillingConfig{
+		Client:       client,
+		Price:        "price_123",
+		Months:       3,
+		FailMonth:    2,
+		PollInterval: time.Millisecond,
+		now:          func() time.Time { return start },
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "clock_123", report.TestClock)
+	require.Equal(t, "cus_123", report.Customer)
+	require.Equal(t, "sub_123", report.Subscription)
+
+	steps := make([]string, 0)
+	for _, entry := range report.Timeline {
+		steps = append(steps, entry.Step)
+	}
+	require.Equal(t, []string{
+		"Subscribed cus_123 to price_123",
+		"Renewed subscription",
+		"Renewal payment failed",
+		"Recovered payment of 1 invoice(s) with a working card",
+		"Renewed subscription",
+	}, steps)
+
+	require.Equal(t, []TimelineEvent{
+		{ID: "evt_cus", Type: "customer.created"},
+		{ID: "evt_sub", Type: "customer.subscription.created"},
+	}, report.Timeline[0].Events)
+	require.Len(t, report.Timeline[1].Events, 1)
+	require.Empty(t, report.Timeline[3].Events)
+
+	require.Equal(t, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), report.Timeline[2].SimulatedTime)
+	require.Contains(t, client.requests, http.MethodPost+" /v1/payment_methods/pm_card_chargeCustomerFail/attach customer=cus_123")
+	require.Contains(t, client.requests, http.MethodPost+" /v1/invoices/in_open/pay ")
+
+	var b bytes.Buffer
+	report.Print(&b)
+	require.Contains(t, b.String(), "2026-03-15  month 2  Renewal payment failed")
+}
+
+func TestBillingInvalidFailMonth(t *testing.T) {
+	_, err := Billing(context.Background(), &BillingConfig{Months: 3, FailMonth: 4})
+	require.Error(t, err)
+}
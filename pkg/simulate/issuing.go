@@ -0,0 +1,146 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+)
+
+//
+// Public types
+//
+
+// IssuingAuthorizationParams describes a simulated Issuing authorization
+type IssuingAuthorizationParams struct {
+	Card             string
+	Amount           int64
+	Currency         string
+	MerchantCategory string
+
+	// Capture captures the authorization right after creating it
+	Capture bool
+}
+
+// ScenarioStep is a step of a scenario, with the ID of the object it
+// created or updated
+type ScenarioStep struct {
+	Name     string `json:"name"`
+	ObjectID string `json:"object_id"`
+}
+
+//
+// Public functions
+//
+
+// IssuingAuthorization creates an Issuing authorization through the test
+// helpers, and optionally captures it. It returns the final authorization.
+func IssuingAuthorization(ctx context.Context, client APIClient, params IssuingAuthorizationParams) (gjson.Result, error) {
+	if params.Card == "" {
+		return gjson.Result{}, fmt.Errorf("a card is required")
+	}
+
+	if params.Amount <= 0 {
+		return gjson.Result{}, fmt.Errorf("amount must be positive")
+	}
+
+	data := []string{
+		"card=" + params.Card,
+		"amount=" + strconv.FormatInt(params.Amount, 10),
+	}
+
+	if params.Currency != "" {
+		data = append(data, "currency="+params.Currency)
+	}
+
+	if params.MerchantCategory != "" {
+		data = append(data, "merchant_data[category]="+params.MerchantCategory)
+	}
+
+	authorization, err := client.Request(ctx, http.MethodPost, "/v1/test_helpers/issuing/authorizations", data)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	if !params.Capture {
+		return authorization, nil
+	}
+
+	return client.Request(ctx, http.MethodPost, "/v1/test_helpers/issuing/authorizations/"+authorization.Get("id").String()+"/capture", nil)
+}
+
+// IssuingAuthCaptureDispute runs the authorization, capture and dispute
+// flow on card, calling onStep after each step.
+func IssuingAuthCaptureDispute(ctx context.Context, client APIClient, params IssuingAuthorizationParams, onStep func(ScenarioStep)) error {
+	params.Capture = false
+
+	authorization, err := IssuingAuthorization(ctx, client, params)
+	if err != nil {
+		return err
+	}
+
+	authorizationID := authorization.Get("id").String()
+	onStep(ScenarioStep{Name: "Authorization created", ObjectID: authorizationID})
+
+	authorization, err = client.Request(ctx, http.MethodPost, "/v1/test_helpers/issuing/authorizations/"+authorizationID+"/capture", nil)
+	if err != nil {
+		return err
+	}
+
+	transactionID, err := capturedTransaction(ctx, client, authorization)
+	if err != nil {
+		return err
+	}
+
+	onStep(ScenarioStep{Name: "Authorization captured", ObjectID: transactionID})
+
+	dispute, err := client.Request(ctx, http.MethodPost, "/v1/issuing/disputes", []string{
+		"transaction=" + transactionID,
+		"evidence[reason]=other",
+		"evidence[other][explanation]=(created by Stripe CLI)",
+		"evidence[other][product_description]=(created by Stripe CLI)",
+		"evidence[other][product_type]=merchandise",
+	})
+	if err != nil {
+		return err
+	}
+
+	disputeID := dispute.Get("id").String()
+	onStep(ScenarioStep{Name: "Dispute created", ObjectID: disputeID})
+
+	if _, err := client.Request(ctx, http.MethodPost, "/v1/issuing/disputes/"+disputeID+"/submit", nil); err != nil {
+		return err
+	}
+
+	onStep(ScenarioStep{Name: "Dispute submitted", ObjectID: disputeID})
+
+	return nil
+}
+
+//
+// Private functions
+//
+
+// capturedTransaction returns the ID of the transaction created when the
+// authorization was captured.
+func capturedTransaction(ctx context.Context, client APIClient, authorization gjson.Result) (string, error) {
+	if id := authorization.Get("transactions.0.id").String(); id != "" {
+		return id, nil
+	}
+
+	transactions, err := client.Request(ctx, http.MethodGet, "/v1/issuing/transactions", []string{
+		"authorization=" + authorization.Get("id").String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	id := transactions.Get("data.0.id").String()
+	if id == "" {
+		return "", fmt.Errorf("no transaction was created when capturing %s", authorization.Get("id").String())
+	}
+
+	return id, nil
+}
@@ -0,0 +1,69 @@
+package simulate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type issuingClient struct {
+	requests []string
+}
+
+func (c *issuingClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+
+	switch {
+	case path == "/v1/test_helpers/issuing/authorizations":
+		return gjson.Parse(`{"id":"iauth_123"}`), nil
+	case strings.HasSuffix(path, "/capture"):
+		return gjson.Parse(`{"id":"iauth_123","transactions":[]}`), nil
+	case path == "/v1/issuing/transactions":
+		return gjson.Parse(`{"data":[{"id":"ipi_123"}]}`), nil
+	case path == "/v1/issuing/disputes":
+		return gjson.Parse(`{"id":"idp_123"}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestIssuingAuthorization(t *testing.T) {
+	client := &issuingClient{}
+
+	authorization, err := IssuingAuthorization(context.Background(), client, IssuingAuthorizationParams{
+		Card:     "ic_123",
+		Amount:   2500,
+		Currency: "usd",
+		Capture:  true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "iauth_123", authorization.Get("id").String())
+	require.Equal(t, []string{
+		"POST /v1/test_helpers/issuing/authorizations card=ic_123&amount=2500&currency=usd",
+		"POST /v1/test_helpers/issuing/authorizations/iauth_123/capture ",
+	}, client.requests)
+
+	_, err = IssuingAuthorization(context.Background(), client, IssuingAuthorizationParams{Card: "ic_123"})
+	require.Error(t, err)
+}
+
+func TestIssuingAuthCaptureDispute(t *testing.T) {
+	client := &issuingClient{}
+	steps := make([]ScenarioStep, 0)
+
+	err := IssuingAuthCaptureDispute(context.Background(), client, IssuingAuthorizationParams{Card: "ic_123", Amount: 2500}, func(step ScenarioStep) {
+		steps = append(steps, step)
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []ScenarioStep{
+		{Name: "Authorization created", ObjectID: "iauth_123"},
+		{Name: "Authorization captured", ObjectID: "ipi_123"},
+		{Name: "Dispute created", ObjectID: "idp_123"},
+		{Name: "Dispute submitted", ObjectID: "idp_123"},
+	}, steps)
+	require.Contains(t, client.requests, "POST /v1/issuing/disputes/idp_123/submit ")
+}
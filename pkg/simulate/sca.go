@@ -0,0 +1,125 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// The expected outcomes of a 3D Secure authentication
+const (
+	ThreeDSecureAuthenticate = "authenticate"
+	ThreeDSecureFail         = "fail"
+)
+
+// threeDSecureCard is the test payment method of a card always requiring
+// a 3D Secure 2 challenge
+const threeDSecureCard = "pm_card_threeDSecure2Required"
+
+//
+// Public types
+//
+
+// ThreeDSecureParams describes a test payment requiring authentication
+type ThreeDSecureParams struct {
+	Price    string
+	Quantity int64
+
+	// ReturnURL is where the customer is sent back to after the challenge
+	ReturnURL string
+}
+
+//
+// Public functions
+//
+
+// ThreeDSecurePayment creates and confirms a test payment intent for a
+// price with a card requiring a 3D Secure challenge. The payment intent it
+// returns requires an action, whose URL is the challenge page.
+func ThreeDSecurePayment(ctx context.Context, client APIClient, params ThreeDSecureParams) (gjson.Result, error) {
+	if params.Price == "" {
+		return gjson.Result{}, fmt.Errorf("a price is required")
+	}
+
+	if params.Quantity <= 0 {
+		params.Quantity = 1
+	}
+
+	if params.ReturnURL == "" {
+		params.ReturnURL = "https://example.com/return"
+	}
+
+	price, err := client.Request(ctx, http.MethodGet, "/v1/prices/"+params.Price, nil)
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	if !price.Get("unit_amount").Exists() {
+		return gjson.Result{}, fmt.Errorf("price %s has no unit amount", params.Price)
+	}
+
+	intent, err := client.Request(ctx, http.MethodPost, "/v1/payment_intents", []string{
+		"amount=" + strconv.FormatInt(price.Get("unit_amount").Int()*params.Quantity, 10),
+		"currency=" + price.Get("currency").String(),
+		"payment_method=" + threeDSecureCard,
+		"payment_method_types[]=card",
+		"confirm=true",
+		"return_url=" + params.ReturnURL,
+		"metadata[price]=" + params.Price,
+	})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	if status := intent.Get("status").String(); status != "requires_action" {
+		return gjson.Result{}, fmt.Errorf("payment intent %s is %s instead of requiring authentication", intent.Get("id").String(), status)
+	}
+
+	return intent, nil
+}
+
+// ThreeDSecureURL returns the URL of the challenge page of a payment intent
+// requiring authentication.
+func ThreeDSecureURL(intent gjson.Result) string {
+	return intent.Get("next_action.redirect_to_url.url").String()
+}
+
+// WaitForAuthentication polls a payment intent until it no longer requires
+// an action, and returns it.
+func WaitForAuthentication(ctx context.Context, client APIClient, id string, interval time.Duration) (gjson.Result, error) {
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+
+	for {
+		intent, err := client.Request(ctx, http.MethodGet, "/v1/payment_intents/"+id, nil)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+
+		if intent.Get("status").String() != "requires_action" {
+			return intent, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return gjson.Result{}, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ThreeDSecureOutcome returns the outcome of the authentication of a payment
+// intent that no longer requires an action.
+func ThreeDSecureOutcome(intent gjson.Result) string {
+	switch intent.Get("status").String() {
+	case "succeeded", "requires_capture", "processing":
+		return ThreeDSecureAuthenticate
+	}
+
+	return ThreeDSecureFail
+}
@@ -0,0 +1,60 @@
+package simulate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type scaClient struct {
+	requests []string
+	statuses []string
+}
+
+func (c *scaClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+
+	switch path {
+	case "/v1/prices/price_123":
+		return gjson.Parse(`{"id":"price_123","unit_amount":1500,"currency":"eur"}`), nil
+	case "/v1/payment_intents":
+		return gjson.Parse(`{"id":"pi_123","status":"requires_action","next_action":{"redirect_to_url":{"url":"https://hooks.stripe.com/3d_secure_2/hosted"}}}`), nil
+	case "/v1/payment_intents/pi_123":
+		status := c.statuses[0]
+		c.statuses = c.statuses[1:]
+
+		return gjson.Parse(`{"id":"pi_123","status":"` + status + `"}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestThreeDSecurePayment(t *testing.T) {
+	client := &scaClient{}
+
+	intent, err := ThreeDSecurePayment(context.Background(), client, ThreeDSecureParams{Price: "price_123", Quantity: 2})
+	require.NoError(t, err)
+	require.Equal(t, "https://hooks.stripe.com/3d_secure_2/hosted", ThreeDSecureURL(intent))
+	require.Equal(t, []string{
+		"GET /v1/prices/price_123 ",
+		"POST /v1/payment_intents amount=3000&currency=eur&payment_method=pm_card_threeDSecure2Required&payment_method_types[]=card&confirm=true&return_url=https://example.com/return&metadata[price]=price_123",
+	}, client.requests)
+
+	_, err = ThreeDSecurePayment(context.Background(), client, ThreeDSecureParams{})
+	require.Error(t, err)
+}
+
+func TestWaitForAuthentication(t *testing.T) {
+	client := &scaClient{statuses: []string{"requires_action", "requires_action", "requires_payment_method"}}
+
+	intent, err := WaitForAuthentication(context.Background(), client, "pi_123", time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 3)
+	require.Equal(t, ThreeDSecureFail, ThreeDSecureOutcome(intent))
+
+	require.Equal(t, ThreeDSecureAuthenticate, ThreeDSecureOutcome(gjson.Parse(`{"status":"succeeded"}`)))
+}
@@ -0,0 +1,178 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+)
+
+//
+// Public types
+//
+
+// TreasuryParams describes money moving in or out of a Treasury financial
+// account
+type TreasuryParams struct {
+	FinancialAccount string
+	Amount           int64
+	Currency         string
+
+	// Network is the network the money moves on, `ach` or `us_domestic_wire`
+	Network string
+}
+
+// FinancialConnectionsSessionParams describes a Financial Connections
+// session to create
+type FinancialConnectionsSessionParams struct {
+	Customer    string
+	Permissions []string
+	ReturnURL   string
+}
+
+//
+// Public variables
+//
+
+// TreasuryScenarios are the money movement flows run by
+// RunTreasuryScenario, with their description
+var TreasuryScenarios = map[string]string{
+	"credit-reversal": "receive a credit, then reverse it",
+	"debit-reversal":  "fund the account, receive a debit, then reverse the debit",
+	"fund-and-spend":  "receive a credit, then a debit of the same amount",
+}
+
+//
+// Public functions
+//
+
+// TreasuryScenarioNames returns the names of the Treasury scenarios, sorted.
+func TreasuryScenarioNames() []string {
+	names := make([]string, 0, len(TreasuryScenarios))
+	for name := range TreasuryScenarios {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ReceivedCredit simulates money sent to a financial account from an
+// external account.
+func ReceivedCredit(ctx context.Context, client APIClient, params TreasuryParams) (gjson.Result, error) {
+	return treasuryTestHelper(ctx, client, "received_credits", params)
+}
+
+// ReceivedDebit simulates money pulled from a financial account by an
+// external account.
+func ReceivedDebit(ctx context.Context, client APIClient, params TreasuryParams) (gjson.Result, error) {
+	return treasuryTestHelper(ctx, client, "received_debits", params)
+}
+
+// RunTreasuryScenario runs one of the TreasuryScenarios on a financial
+// account, calling onStep after each step.
+func RunTreasuryScenario(ctx context.Context, client APIClient, name string, params TreasuryParams, onStep func(ScenarioStep)) error {
+	if _, ok := TreasuryScenarios[name]; !ok {
+		return fmt.Errorf("unsupported scenario %q. Supported scenarios: %v", name, TreasuryScenarioNames())
+	}
+
+	credit, err := ReceivedCredit(ctx, client, params)
+	if err != nil {
+		return err
+	}
+
+	creditID := credit.Get("id").String()
+	onStep(ScenarioStep{Name: "Credit received", ObjectID: creditID})
+
+	if name == "credit-reversal" {
+		reversal, err := client.Request(ctx, http.MethodPost, "/v1/treasury/credit_reversals", []string{"received_credit=" + creditID})
+		if err != nil {
+			return err
+		}
+
+		onStep(ScenarioStep{Name: "Credit reversed", ObjectID: reversal.Get("id").String()})
+
+		return nil
+	}
+
+	debit, err := ReceivedDebit(ctx, client, params)
+	if err != nil {
+		return err
+	}
+
+	debitID := debit.Get("id").String()
+	onStep(ScenarioStep{Name: "Debit received", ObjectID: debitID})
+
+	if name == "debit-reversal" {
+		reversal, err := client.Request(ctx, http.MethodPost, "/v1/treasury/debit_reversals", []string{"received_debit=" + debitID})
+		if err != nil {
+			return err
+		}
+
+		onStep(ScenarioStep{Name: "Debit reversed", ObjectID: reversal.Get("id").String()})
+	}
+
+	return nil
+}
+
+// FinancialConnectionsSession creates a Financial Connections session for a
+// customer. In test mode, the session's accounts are linked from the test
+// institutions of the authentication flow.
+func FinancialConnectionsSession(ctx context.Context, client APIClient, params FinancialConnectionsSessionParams) (gjson.Result, error) {
+	if params.Customer == "" {
+		return gjson.Result{}, fmt.Errorf("a customer is required")
+	}
+
+	permissions := params.Permissions
+	if len(permissions) == 0 {
+		permissions = []string{"payment_method"}
+	}
+
+	data := []string{
+		"account_holder[type]=customer",
+		"account_holder[customer]=" + params.Customer,
+	}
+
+	for _, permission := range permissions {
+		data = append(data, "permissions[]="+permission)
+	}
+
+	if params.ReturnURL != "" {
+		data = append(data, "return_url="+params.ReturnURL)
+	}
+
+	return client.Request(ctx, http.MethodPost, "/v1/financial_connections/sessions", data)
+}
+
+//
+// Private functions
+//
+
+func treasuryTestHelper(ctx context.Context, client APIClient, resource string, params TreasuryParams) (gjson.Result, error) {
+	if params.FinancialAccount == "" {
+		return gjson.Result{}, fmt.Errorf("a financial account is required")
+	}
+
+	if params.Amount <= 0 {
+		return gjson.Result{}, fmt.Errorf("amount must be positive")
+	}
+
+	if params.Currency == "" {
+		params.Currency = "usd"
+	}
+
+	if params.Network == "" {
+		params.Network = "ach"
+	}
+
+	return client.Request(ctx, http.MethodPost, "/v1/test_helpers/treasury/"+resource, []string{
+		"financial_account=" + params.FinancialAccount,
+		"amount=" + strconv.FormatInt(params.Amount, 10),
+		"currency=" + params.Currency,
+		"network=" + params.Network,
+	})
+}
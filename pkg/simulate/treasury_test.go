@@ -0,0 +1,79 @@
+package simulate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type treasuryClient struct {
+	requests []string
+}
+
+func (c *treasuryClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	c.requests = append(c.requests, method+" "+path+" "+strings.Join(params, "&"))
+
+	switch path {
+	case "/v1/test_helpers/treasury/received_credits":
+		return gjson.Parse(`{"id":"rc_123"}`), nil
+	case "/v1/test_helpers/treasury/received_debits":
+		return gjson.Parse(`{"id":"rd_123"}`), nil
+	case "/v1/treasury/credit_reversals":
+		return gjson.Parse(`{"id":"credrev_123"}`), nil
+	case "/v1/treasury/debit_reversals":
+		return gjson.Parse(`{"id":"debrev_123"}`), nil
+	case "/v1/financial_connections/sessions":
+		return gjson.Parse(`{"id":"fcsess_123"}`), nil
+	}
+
+	return gjson.Parse(`{}`), nil
+}
+
+func TestReceivedCredit(t *testing.T) {
+	client := &treasuryClient{}
+
+	credit, err := ReceivedCredit(context.Background(), client, TreasuryParams{FinancialAccount: "fa_123", Amount: 1000})
+	require.NoError(t, err)
+	require.Equal(t, "rc_123", credit.Get("id").String())
+	require.Equal(t, []string{
+		"POST /v1/test_helpers/treasury/received_credits financial_account=fa_123&amount=1000&currency=usd&network=ach",
+	}, client.requests)
+
+	_, err = ReceivedDebit(context.Background(), client, TreasuryParams{Amount: 1000})
+	require.Error(t, err)
+}
+
+func TestRunTreasuryScenario(t *testing.T) {
+	client := &treasuryClient{}
+	steps := make([]ScenarioStep, 0)
+
+	err := RunTreasuryScenario(context.Background(), client, "debit-reversal", TreasuryParams{FinancialAccount: "fa_123", Amount: 1000}, func(step ScenarioStep) {
+		steps = append(steps, step)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ScenarioStep{
+		{Name: "Credit received", ObjectID: "rc_123"},
+		{Name: "Debit received", ObjectID: "rd_123"},
+		{Name: "Debit reversed", ObjectID: "debrev_123"},
+	}, steps)
+
+	err = RunTreasuryScenario(context.Background(), client, "unknown", TreasuryParams{FinancialAccount: "fa_123", Amount: 1000}, func(ScenarioStep) {})
+	require.Error(t, err)
+}
+
+func TestFinancialConnectionsSession(t *testing.T) {
+	client := &treasuryClient{}
+
+	session, err := FinancialConnectionsSession(context.Background(), client, FinancialConnectionsSessionParams{
+		Customer:    "cus_123",
+		Permissions: []string{"balances", "ownership"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fcsess_123", session.Get("id").String())
+	require.Equal(t, []string{
+		"POST /v1/financial_connections/sessions account_holder[type]=customer&account_holder[customer]=cus_123&permissions[]=balances&permissions[]=ownership",
+	}, client.requests)
+}
@@ -0,0 +1,295 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+//
+// Public constants
+//
+
+// Redacted replaces the values of redacted fields
+const Redacted = "[redacted]"
+
+//
+// Public types
+//
+
+// Options configures how objects are normalized before being compared
+type Options struct {
+	// RedactFields are the names of the fields whose values are redacted,
+	// at any depth
+	RedactFields []string `json:"redact_fields"`
+
+	// RedactIDs redacts all the values that look like object IDs, such as the
+	// IDs of related objects, so that snapshots can be compared across
+	// accounts
+	RedactIDs bool `json:"redact_ids"`
+}
+
+// Snapshot is the normalized JSON of an API object, as stored in a snapshot
+// file
+type Snapshot struct {
+	Path    string      `json:"path"`
+	Options Options     `json:"options"`
+	Object  interface{} `json:"object"`
+}
+
+// Difference is a difference between a snapshot and the current state of an
+// object. Expected is nil for added fields and Actual is nil for removed
+// ones.
+type Difference struct {
+	Path     string
+	Expected interface{}
+	Actual   interface{}
+}
+
+//
+// Public functions
+//
+
+// New returns the snapshot of the API response body of the request to path.
+func New(path string, body []byte, opts Options) (*Snapshot, error) {
+	var object interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Path:    path,
+		Options: opts,
+		Object:  redact(object, opts),
+	}, nil
+}
+
+// Load reads a snapshot file
+func Load(fs afero.Fs, file string) (*Snapshot, error) {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s is not a valid snapshot file: %w", file, err)
+	}
+
+	if s.Path == "" {
+		return nil, fmt.Errorf("%s is not a valid snapshot file: missing path", file)
+	}
+
+	return &s, nil
+}
+
+// Save writes the snapshot to file. Object keys are sorted so that the files
+// can be compared and reviewed.
+func (s *Snapshot) Save(fs afero.Fs, file string) error {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(s); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fs, file, buf.Bytes(), 0644)
+}
+
+// Verify compares the snapshot to the current API response body, normalized
+// with the options of the snapshot.
+func (s *Snapshot) Verify(body []byte) ([]Difference, error) {
+	current, err := New(s.Path, body, s.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff("", s.Object, current.Object), nil
+}
+
+// FileName returns the name of the snapshot file of an API path, e.g.
+// v1_products_prod_123.json for /v1/products/prod_123.
+func FileName(path string) string {
+	name := strings.Trim(nonAlphanumeric.ReplaceAllString(path, "_"), "_")
+	return name + ".json"
+}
+
+// String returns the difference formatted for a failure report
+func (d Difference) String() string {
+	switch {
+	case d.Expected == nil:
+		return fmt.Sprintf("+ %s: %s", d.Path, formatValue(d.Actual))
+	case d.Actual == nil:
+		return fmt.Sprintf("- %s: %s", d.Path, formatValue(d.Expected))
+	default:
+		return fmt.Sprintf("~ %s: %s → %s", d.Path, formatValue(d.Expected), formatValue(d.Actual))
+	}
+}
+
+//
+// Private types
+//
+
+// null stands for JSON nulls in differences, where nil means missing
+type null struct{}
+
+//
+// Private variables
+//
+
+var nonAlphanumeric = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// objectID matches the IDs of API objects, e.g. prod_NWjs8kKbJWmuuc or
+// sub_sched_1Mr3YcLkdIwHu7ixjYp7MUvr
+var objectID = regexp.MustCompile(`^[a-z]+(?:_[a-z]+)*_[A-Za-z0-9]*[A-Z0-9][A-Za-z0-9]*$`)
+
+//
+// Private functions
+//
+
+func redact(value interface{}, opts Options) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if containsString(opts.RedactFields, key) && field != nil {
+				v[key] = Redacted
+				continue
+			}
+
+			v[key] = redact(field, opts)
+		}
+
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item, opts)
+		}
+
+		return v
+	case string:
+		if opts.RedactIDs && len(v) >= 12 && objectID.MatchString(v) {
+			return Redacted
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func diff(path string, expected, actual interface{}) []Difference {
+	expectedMap, expectedIsMap := expected.(map[string]interface{})
+	actualMap, actualIsMap := actual.(map[string]interface{})
+
+	if expectedIsMap && actualIsMap {
+		keys := make([]string, 0, len(expectedMap)+len(actualMap))
+		for key := range expectedMap {
+			keys = append(keys, key)
+		}
+		for key := range actualMap {
+			if _, ok := expectedMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		differences := make([]Difference, 0)
+		for _, key := range keys {
+			differences = append(differences, diffField(joinPath(path, key), expectedMap, actualMap, key)...)
+		}
+
+		return differences
+	}
+
+	expectedList, expectedIsList := expected.([]interface{})
+	actualList, actualIsList := actual.([]interface{})
+
+	if expectedIsList && actualIsList {
+		differences := make([]Difference, 0)
+
+		for i := 0; i < len(expectedList) || i < len(actualList); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			switch {
+			case i >= len(actualList):
+				differences = append(differences, Difference{Path: itemPath, Expected: nullable(expectedList[i])})
+			case i >= len(expectedList):
+				differences = append(differences, Difference{Path: itemPath, Actual: nullable(actualList[i])})
+			default:
+				differences = append(differences, diff(itemPath, expectedList[i], actualList[i])...)
+			}
+		}
+
+		return differences
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		return []Difference{{Path: path, Expected: nullable(expected), Actual: nullable(actual)}}
+	}
+
+	return nil
+}
+
+// diffField compares a field of two objects, telling apart missing fields
+// from added ones.
+func diffField(path string, expected, actual map[string]interface{}, key string) []Difference {
+	expectedValue, inExpected := expected[key]
+	actualValue, inActual := actual[key]
+
+	switch {
+	case !inActual:
+		return []Difference{{Path: path, Expected: nullable(expectedValue)}}
+	case !inExpected:
+		return []Difference{{Path: path, Actual: nullable(actualValue)}}
+	default:
+		return diff(path, expectedValue, actualValue)
+	}
+}
+
+func nullable(value interface{}) interface{} {
+	if value == nil {
+		return null{}
+	}
+
+	return value
+}
+
+func formatValue(value interface{}) string {
+	if _, ok := value.(null); ok {
+		return "null"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+)
+
+const product = `{
+	"id": "prod_NWjs8kKbJWmuuc",
+	"object": "product",
+	"created": 1678833149,
+	"default_price": "price_1Mr3YcLkdIwHu7ixjYp7MUvr",
+	"name": "Gold plan",
+	"description": null,
+	"images": [],
+	"metadata": {"tier": "gold"},
+	"tax_code": "txcd_10000000"
+}`
+
+var defaultOptions = Options{
+	RedactFields: []string{"id", "created"},
+	RedactIDs:    true,
+}
+
+func TestNewRedacts(t *testing.T) {
+	s, err := New("/v1/products/prod_NWjs8kKbJWmuuc", []byte(product), defaultOptions)
+	require.NoError(t, err)
+
+	object := s.Object.(map[string]interface{})
+	require.Equal(t, Redacted, object["id"])
+	require.Equal(t, Redacted, object["created"])
+	require.Equal(t, Redacted, object["default_price"])
+	require.Equal(t, Redacted, object["tax_code"])
+	require.Equal(t, "Gold plan", object["name"])
+	require.Equal(t, "product", object["object"])
+	require.Nil(t, object["description"])
+
+	s, err = New("/v1/products/prod_NWjs8kKbJWmuuc", []byte(product), Options{})
+	require.NoError(t, err)
+
+	object = s.Object.(map[string]interface{})
+	require.Equal(t, "prod_NWjs8kKbJWmuuc", object["id"])
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	s, err := New("/v1/products/prod_NWjs8kKbJWmuuc", []byte(product), defaultOptions)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(fs, "snapshots/product.json"))
+
+	loaded, err := Load(fs, "snapshots/product.json")
+	require.NoError(t, err)
+	require.Equal(t, s.Path, loaded.Path)
+	require.Equal(t, s.Options, loaded.Options)
+
+	differences, err := loaded.Verify([]byte(product))
+	require.NoError(t, err)
+	require.Empty(t, differences)
+
+	afero.WriteFile(fs, "snapshots/other.json", []byte(`{"object": {}}`), 0644)
+	_, err = Load(fs, "snapshots/other.json")
+	require.EqualError(t, err, "snapshots/other.json is not a valid snapshot file: missing path")
+}
+
+func TestVerifyReportsDifferences(t *testing.T) {
+	s, err := New("/v1/products/prod_NWjs8kKbJWmuuc", []byte(product), defaultOptions)
+	require.NoError(t, err)
+
+	// A product from another account, with changes
+	current := `{
+		"id": "prod_OtherAccount123",
+		"object": "product",
+		"created": 1678900000,
+		"default_price": "price_OtherAccount456",
+		"name": "Gold",
+		"description": "The gold plan",
+		"images": ["https://example.com/gold.png"],
+		"metadata": {},
+		"tax_code": "txcd_10000000"
+	}`
+
+	differences, err := s.Verify([]byte(current))
+	require.NoError(t, err)
+
+	report := make([]string, len(differences))
+	for i, d := range differences {
+		report[i] = d.String()
+	}
+
+	require.Equal(t, []string{
+		`~ description: null → "The gold plan"`,
+		`+ images[0]: "https://example.com/gold.png"`,
+		`- metadata.tier: "gold"`,
+		`~ name: "Gold plan" → "Gold"`,
+	}, report)
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "v1_products_prod_123.json", FileName("/v1/products/prod_123"))
+	require.Equal(t, "v1_prices_product_prod_123_limit_3.json", FileName("/v1/prices?product=prod_123&limit=3"))
+}
@@ -0,0 +1,165 @@
+package spec
+
+import (
+	_ "embed" // for the bundled resource docs
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//
+// Public types
+//
+
+// ResourceDocs are the docs of the API resources and their operations,
+// bundled from the OpenAPI specification to browse them offline.
+type ResourceDocs struct {
+	// Version is the Stripe API version of the specification the docs were
+	// bundled from.
+	Version string `json:"version"`
+
+	// Resources are keyed by the name of their command, prefixed by their
+	// namespace if any, e.g. `customers` or `terminal.readers`.
+	Resources map[string]*ResourceDoc `json:"resources"`
+}
+
+// ResourceDoc documents an API resource.
+type ResourceDoc struct {
+	Object      string                   `json:"object"`
+	Description string                   `json:"description,omitempty"`
+	Fields      []FieldDoc               `json:"fields,omitempty"`
+	Operations  map[string]*OperationDoc `json:"operations"`
+}
+
+// OperationDoc documents an operation on an API resource.
+type OperationDoc struct {
+	Method      string     `json:"method"`
+	Path        string     `json:"path"`
+	Description string     `json:"description,omitempty"`
+	Parameters  []FieldDoc `json:"parameters,omitempty"`
+}
+
+// FieldDoc documents a field of a resource or a parameter of an operation.
+// The fields of nested objects are named like the parameters of the CLI,
+// e.g. `address[city]`.
+type FieldDoc struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+//
+// Public functions
+//
+
+// LoadResourceDocs loads the bundled resource docs.
+func LoadResourceDocs() (*ResourceDocs, error) {
+	docs := &ResourceDocs{}
+
+	err := json.Unmarshal(resourceDocsData, docs)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding resource docs: %v", err)
+	}
+
+	return docs, nil
+}
+
+// Find returns the docs of the resource called name, either the name of its
+// command or of its object, e.g. `payment_intents` or `payment_intent`.
+// Namespaced resources can be separated by a dot or a space, e.g.
+// `terminal.readers` or `terminal readers`.
+func (rd *ResourceDocs) Find(name string) (string, *ResourceDoc, bool) {
+	name = strings.ReplaceAll(strings.TrimSpace(name), " ", ".")
+
+	if doc, ok := rd.Resources[name]; ok {
+		return name, doc, true
+	}
+
+	for key, doc := range rd.Resources {
+		if doc.Object == name {
+			return key, doc, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// FindOperation returns the docs of the operation with the HTTP method and
+// the path of the specification, e.g. `/v1/customers/{customer}`.
+func (rd *ResourceDocs) FindOperation(method, path string) (*OperationDoc, bool) {
+	for _, resource := range rd.Resources {
+		for _, operation := range resource.Operations {
+			if strings.EqualFold(operation.Method, method) && operation.Path == path {
+				return operation, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// MatchOperation returns the docs of the operation with the HTTP method whose
+// path template matches a concrete path, e.g. `/v1/customers/cus_123` for
+// `/v1/customers/{customer}`. Literal segments take precedence, so that
+// `/v1/invoices/upcoming` isn't taken for an invoice ID.
+func (rd *ResourceDocs) MatchOperation(method, path string) (*OperationDoc, bool) {
+	_, _, operation, ok := rd.MatchResource(method, path)
+
+	return operation, ok
+}
+
+// MatchResource is like MatchOperation, and also returns the resource of the
+// operation and the name of the operation, e.g. `retrieve`.
+func (rd *ResourceDocs) MatchResource(method, path string) (*ResourceDoc, string, *OperationDoc, bool) {
+	var best *OperationDoc
+	var bestResource *ResourceDoc
+	var bestName string
+
+	for _, resource := range rd.Resources {
+		for name, operation := range resource.Operations {
+			if !strings.EqualFold(operation.Method, method) || !matchPath(operation.Path, path) {
+				continue
+			}
+
+			if best == nil || strings.Count(operation.Path, "{") < strings.Count(best.Path, "{") {
+				best, bestResource, bestName = operation, resource, name
+			}
+		}
+	}
+
+	return bestResource, bestName, best, best != nil
+}
+
+// Names returns the names of the documented resources.
+func (rd *ResourceDocs) Names() []string {
+	names := make([]string, 0, len(rd.Resources))
+	for name := range rd.Resources {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// OperationNames returns the names of the operations of the resource.
+func (doc *ResourceDoc) OperationNames() []string {
+	names := make([]string, 0, len(doc.Operations))
+	for name := range doc.Operations {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+//
+// Private variables
+//
+
+//go:embed resource_docs.json
+var resourceDocsData []byte
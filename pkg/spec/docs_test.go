@@ -0,0 +1,57 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadResourceDocs(t *testing.T) {
+	docs, err := LoadResourceDocs()
+	require.NoError(t, err)
+	require.NotEmpty(t, docs.Version)
+
+	name, customers, ok := docs.Find("customer")
+	require.True(t, ok)
+	require.Equal(t, "customers", name)
+	require.Contains(t, customers.OperationNames(), "create")
+
+	create := customers.Operations["create"]
+	require.Equal(t, "POST", create.Method)
+	require.Equal(t, "/v1/customers", create.Path)
+	require.Contains(t, create.Parameters, FieldDoc{Name: "address[city]", Type: "string", Description: "City, district, suburb, town, or village."})
+}
+
+func TestFindNamespacedResourceDocs(t *testing.T) {
+	docs, err := LoadResourceDocs()
+	require.NoError(t, err)
+
+	name, readers, ok := docs.Find("terminal readers")
+	require.True(t, ok)
+	require.Equal(t, "terminal.readers", name)
+	require.Equal(t, "terminal.reader", readers.Object)
+
+	_, _, ok = docs.Find("readers")
+	require.False(t, ok)
+}
+
+func TestMatchOperation(t *testing.T) {
+	docs, err := LoadResourceDocs()
+	require.NoError(t, err)
+
+	operation, ok := docs.MatchOperation("post", "/v1/customers/cus_123")
+	require.True(t, ok)
+	require.Equal(t, "/v1/customers/{customer}", operation.Path)
+
+	operation, ok = docs.MatchOperation("GET", "/v1/invoices/upcoming")
+	require.True(t, ok)
+	require.Equal(t, "/v1/invoices/upcoming", operation.Path)
+
+	_, ok = docs.MatchOperation("DELETE", "/v1/charges/ch_123")
+	require.False(t, ok)
+
+	resource, name, _, ok := docs.MatchResource("GET", "/v1/charges")
+	require.True(t, ok)
+	require.Equal(t, "charge", resource.Object)
+	require.Equal(t, "list", name)
+}
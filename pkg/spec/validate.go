@@ -0,0 +1,358 @@
+package spec
+
+import (
+	_ "embed" // for the bundled resource schemas
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+//
+// Public types
+//
+
+// ResourceSchemas are the schemas of the API resources, bundled from the
+// OpenAPI specification to validate payloads, along with the deprecated
+// operations and parameters.
+type ResourceSchemas struct {
+	// Version is the Stripe API version of the specification the schemas
+	// were bundled from.
+	Version    string             `json:"version"`
+	Schemas    map[string]*Schema `json:"schemas"`
+	Deprecated []Deprecation      `json:"deprecated"`
+}
+
+// Deprecation is a deprecated operation, or a deprecated parameter of an
+// operation when Param is set.
+type Deprecation struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Param  string `json:"param,omitempty"`
+}
+
+// Problem is a difference between a payload and its schema.
+type Problem struct {
+	// Path is the path of the field in the payload, e.g. `data.object.amount`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+//
+// Public functions
+//
+
+// LoadResourceSchemas loads the bundled resource schemas.
+func LoadResourceSchemas() (*ResourceSchemas, error) {
+	schemas := &ResourceSchemas{}
+
+	err := json.Unmarshal(resourceSchemasData, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding resource schemas: %v", err)
+	}
+
+	return schemas, nil
+}
+
+// ValidateEvent validates an event payload against the event schema, and
+// its `data.object` against the schema of the object's type. It reports
+// unknown fields and type mismatches.
+func (rs *ResourceSchemas) ValidateEvent(payload []byte) ([]Problem, error) {
+	var event interface{}
+
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	problems := rs.Validate("event", event)
+
+	object, _ := lookup(event, "data", "object").(map[string]interface{})
+	if object == nil {
+		return append(problems, Problem{Path: "data.object", Message: "is missing"}), nil
+	}
+
+	objectType, _ := object["object"].(string)
+	schema, ok := rs.Schemas[objectType]
+	if !ok {
+		return append(problems, Problem{Path: "data.object.object", Message: fmt.Sprintf("unknown object type %q", objectType)}), nil
+	}
+
+	problems = append(problems, rs.validate(schema, object, "data.object")...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Path < problems[j].Path
+	})
+
+	return problems, nil
+}
+
+// IsDeprecated returns whether an operation is deprecated, or one of its
+// parameters when param isn't empty. Path segments like `${customer:id}`
+// match the `{customer}` segments of the specification paths.
+func (rs *ResourceSchemas) IsDeprecated(method, path, param string) bool {
+	for _, deprecation := range rs.Deprecated {
+		if strings.EqualFold(deprecation.Method, method) && deprecation.Param == param && matchPath(deprecation.Path, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExpandablePaths returns the expandable fields that path, e.g.
+// `customer.email`, goes through in the schema called name, as the values of
+// `expand[]`, e.g. `customer`. Fields ending path aren't expanded. Lists are
+// walked through their `data` field, e.g. `lines.data.price.product`.
+func (rs *ResourceSchemas) ExpandablePaths(name string, path []string) []string {
+	expandable := make([]string, 0)
+	schema := rs.Schemas[name]
+
+	for i, key := range path {
+		schema = rs.resolve(schema)
+		if schema != nil && schema.Type == TypeArray {
+			schema = rs.resolve(schema.Items)
+		}
+
+		if schema == nil || schema.Properties[key] == nil {
+			break
+		}
+
+		property := schema.Properties[key]
+		if len(property.AnyOf) == 0 {
+			schema = property
+			continue
+		}
+
+		// Expandable fields are either an ID or the object, e.g. a string
+		// or a customer
+		var object *Schema
+		isID := false
+		for _, alternative := range property.AnyOf {
+			switch {
+			case alternative.Type == TypeString:
+				isID = true
+			case alternative.Ref != "" && object == nil:
+				object = alternative
+			}
+		}
+
+		if isID && object != nil && i < len(path)-1 {
+			expandable = append(expandable, strings.Join(path[:i+1], "."))
+		}
+
+		schema = object
+	}
+
+	return expandable
+}
+
+// Validate validates a decoded JSON value against the schema called name.
+func (rs *ResourceSchemas) Validate(name string, value interface{}) []Problem {
+	schema, ok := rs.Schemas[name]
+	if !ok {
+		return []Problem{{Path: "$", Message: fmt.Sprintf("unknown schema %q", name)}}
+	}
+
+	return rs.validate(schema, value, "")
+}
+
+//
+// Private variables
+//
+
+//go:embed resource_schemas.json
+var resourceSchemasData []byte
+
+//
+// Private functions
+//
+
+func (rs *ResourceSchemas) validate(schema *Schema, value interface{}, path string) []Problem {
+	schema = rs.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" && len(schema.AnyOf) == 0 {
+			return nil
+		}
+
+		return []Problem{problem(path, "is null but should be %s", rs.describe(schema))}
+	}
+
+	if len(schema.AnyOf) > 0 {
+		return rs.validateAnyOf(schema, value, path)
+	}
+
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		return []Problem{problem(path, "is %s but should be %s", jsonType(value), schema.Type)}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return rs.validateObject(schema, v, path)
+	case []interface{}:
+		problems := make([]Problem, 0)
+		for i, item := range v {
+			problems = append(problems, rs.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+
+		return problems
+	}
+
+	return nil
+}
+
+// validateAnyOf reports the problems of the closest alternative: the one
+// with the fewest problems among those of the value's type.
+func (rs *ResourceSchemas) validateAnyOf(schema *Schema, value interface{}, path string) []Problem {
+	var closest []Problem
+
+	for _, alternative := range schema.AnyOf {
+		resolved := rs.resolve(alternative)
+		if resolved == nil || resolved.Type != "" && !hasType(value, resolved.Type) {
+			continue
+		}
+
+		problems := rs.validate(resolved, value, path)
+		if len(problems) == 0 {
+			return nil
+		}
+
+		if closest == nil || len(problems) < len(closest) {
+			closest = problems
+		}
+	}
+
+	if closest == nil {
+		return []Problem{problem(path, "is %s but should be %s", jsonType(value), rs.describe(schema))}
+	}
+
+	return closest
+}
+
+func (rs *ResourceSchemas) validateObject(schema *Schema, object map[string]interface{}, path string) []Problem {
+	problems := make([]Problem, 0)
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		property, ok := schema.Properties[key]
+		if !ok {
+			// Objects without properties, like metadata, accept any field
+			if len(schema.Properties) > 0 && schema.AdditionalProperties == nil {
+				problems = append(problems, problem(path+"."+key, "unknown field"))
+			}
+
+			continue
+		}
+
+		problems = append(problems, rs.validate(property, object[key], path+"."+key)...)
+	}
+
+	return problems
+}
+
+func (rs *ResourceSchemas) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = rs.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+
+	return schema
+}
+
+// describe returns the expected types of a schema, e.g. `string or charge`.
+func (rs *ResourceSchemas) describe(schema *Schema) string {
+	if len(schema.AnyOf) == 0 {
+		return schema.Type
+	}
+
+	types := make([]string, 0, len(schema.AnyOf))
+	for _, alternative := range schema.AnyOf {
+		if alternative.Ref != "" {
+			types = append(types, strings.TrimPrefix(alternative.Ref, "#/components/schemas/"))
+		} else {
+			types = append(types, alternative.Type)
+		}
+	}
+
+	return strings.Join(types, " or ")
+}
+
+func hasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case TypeInteger:
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case TypeNumber:
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == schemaType
+	}
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return TypeBoolean
+	case float64:
+		return TypeNumber
+	case string:
+		return TypeString
+	case []interface{}:
+		return TypeArray
+	default:
+		return TypeObject
+	}
+}
+
+func lookup(value interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		value = object[key]
+	}
+
+	return value
+}
+
+func matchPath(specPath, path string) bool {
+	specSegments := strings.Split(specPath, "/")
+	segments := strings.Split(path, "/")
+
+	if len(specSegments) != len(segments) {
+		return false
+	}
+
+	for i, segment := range specSegments {
+		if segment != segments[i] && !strings.HasPrefix(segment, "{") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func problem(path string, format string, args ...interface{}) Problem {
+	if path == "" {
+		path = "$"
+	}
+
+	return Problem{Path: strings.TrimPrefix(path, "."), Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEvent(t *testing.T) {
+	schemas, err := LoadResourceSchemas()
+	require.NoError(t, err)
+	require.NotEmpty(t, schemas.Version)
+
+	problems, err := schemas.ValidateEvent([]byte(`{
+		"id": "evt_123",
+		"object": "event",
+		"api_version": "2020-08-27",
+		"created": 1600000000,
+		"livemode": false,
+		"pending_webhooks": 1,
+		"request": {"id": null, "idempotency_key": null},
+		"type": "customer.created",
+		"data": {
+			"object": {
+				"id": "cus_123",
+				"object": "customer",
+				"balance": 0,
+				"created": 1600000000,
+				"email": null,
+				"livemode": false,
+				"metadata": {"order_id": "6735"},
+				"preferred_locales": ["en"]
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func TestValidateEventProblems(t *testing.T) {
+	schemas, err := LoadResourceSchemas()
+	require.NoError(t, err)
+
+	problems, err := schemas.ValidateEvent([]byte(`{
+		"id": "evt_123",
+		"object": "event",
+		"created": "yesterday",
+		"type": "charge.succeeded",
+		"data": {
+			"object": {
+				"id": "ch_123",
+				"object": "charge",
+				"amount": 10.5,
+				"customer": 42,
+				"colour": "blue",
+				"refunds": {"object": "list", "data": [{"id": "re_123", "amount": "100"}]}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []Problem{
+		{Path: "created", Message: "is string but should be integer"},
+		{Path: "data.object.amount", Message: "is number but should be integer"},
+		{Path: "data.object.colour", Message: "unknown field"},
+		{Path: "data.object.customer", Message: "is number but should be string or customer or deleted_customer"},
+		{Path: "data.object.refunds.data[0].amount", Message: "is string but should be integer"},
+	}, problems)
+}
+
+func TestValidateEventUnknownObject(t *testing.T) {
+	schemas, err := LoadResourceSchemas()
+	require.NoError(t, err)
+
+	problems, err := schemas.ValidateEvent([]byte(`{"data": {"object": {"object": "unicorn"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, []Problem{{Path: "data.object.object", Message: `unknown object type "unicorn"`}}, problems)
+
+	_, err = schemas.ValidateEvent([]byte(`{`))
+	require.Error(t, err)
+}
+
+func TestExpandablePaths(t *testing.T) {
+	schemas, err := LoadResourceSchemas()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"customer"}, schemas.ExpandablePaths("charge", []string{"customer", "email"}))
+	require.Equal(t, []string{"customer", "customer.default_source"}, schemas.ExpandablePaths("charge", []string{"customer", "default_source", "id"}))
+	require.Equal(t, []string{"lines.data.price.product"}, schemas.ExpandablePaths("invoice", []string{"lines", "data", "price", "product", "name"}))
+	require.Empty(t, schemas.ExpandablePaths("charge", []string{"customer"}))
+	require.Empty(t, schemas.ExpandablePaths("charge", []string{"nope", "email"}))
+}
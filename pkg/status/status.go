@@ -2,14 +2,17 @@ package status
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"text/template"
+	"time"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/offline"
+	"github.com/stripe/stripe-cli/pkg/stripe"
 )
 
 // Response contains the structure of system statuses from Stripe
@@ -31,11 +34,25 @@ type statuses struct {
 }
 
 // GetStatus makes a request to the Stripe status site and returns all the
-// current system statuses
-func GetStatus() (Response, error) {
+// current system statuses. The request is canceled with ctx.
+func GetStatus(ctx context.Context) (Response, error) {
 	var status Response
 
-	resp, err := http.Get("https://status.stripe.com/current")
+	if err := offline.Check("checking the status of Stripe"); err != nil {
+		return status, err
+	}
+
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: stripe.HTTPTransport(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://status.stripe.com/current", nil)
+	if err != nil {
+		return status, err
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return status, err
 	}
@@ -117,15 +134,13 @@ As of: %s`,
 }
 
 func emojifiedStatus(status string) string {
-	color := ansi.Color(os.Stdout)
-
 	switch status {
 	case "up":
-		return color.Green("✔").String()
+		return ansi.SuccessGlyph()
 	case "degraded":
-		return color.Yellow("!").String()
+		return ansi.WarningGlyph()
 	case "down":
-		return color.Red("✘").String()
+		return ansi.ErrorGlyph()
 	}
 
 	// To avoid potentially confusing users, if the status does not fit one of
@@ -1,9 +1,12 @@
 package status
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
 )
 
 func buildResponse() Response {
@@ -105,7 +108,22 @@ As of: July 21, 4:00 +0:00`
 
 func TestEmojification(t *testing.T) {
 	require.Equal(t, "✔", emojifiedStatus("up"))
-	require.Equal(t, "!", emojifiedStatus("degraded"))
+	require.Equal(t, "⚠", emojifiedStatus("degraded"))
 	require.Equal(t, "✘", emojifiedStatus("down"))
 	require.Equal(t, "", emojifiedStatus("foo"))
+
+	ansi.ASCIIOnly = true
+	defer func() { ansi.ASCIIOnly = false }()
+
+	require.Equal(t, "+", emojifiedStatus("up"))
+	require.Equal(t, "!", emojifiedStatus("degraded"))
+	require.Equal(t, "x", emojifiedStatus("down"))
+}
+
+func TestGetStatusCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetStatus(ctx)
+	require.ErrorIs(t, err, context.Canceled)
 }
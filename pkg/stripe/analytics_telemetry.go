@@ -42,6 +42,7 @@ type CLIAnalyticsEventMetadata struct {
 	CLIVersion        string `url:"cli_version"`        // the version of the CLI
 	OS                string `url:"os"`                 // the OS of the system
 	GeneratedResource bool   `url:"generated_resource"` // whether or not this was a generated resource
+	Experiments       string `url:"experiments"`        // the comma-separated names of the enabled experiments
 }
 
 // TelemetryClient is an interface that can send two types of events: an API request, and just general events.
@@ -55,6 +56,8 @@ type AnalyticsTelemetryClient struct {
 	BaseURL    *url.URL
 	wg         sync.WaitGroup
 	HTTPClient *http.Client
+
+	disabled bool
 }
 
 // NoOpTelemetryClient does not call any endpoint and returns an empty response
@@ -128,6 +131,11 @@ func (e *CLIAnalyticsEventMetadata) SetUserAgent(userAgent string) {
 	e.UserAgent = userAgent
 }
 
+// SetExperiments sets the enabled experiments on the CLIAnalyticsEventContext object
+func (e *CLIAnalyticsEventMetadata) SetExperiments(names []string) {
+	e.Experiments = strings.Join(names, ",")
+}
+
 // SetCommandPath sets the commandPath on the CLIAnalyticsEventContext object
 func (e *CLIAnalyticsEventMetadata) SetCommandPath(commandPath string) {
 	e.CommandPath = commandPath
@@ -182,6 +190,10 @@ func (a *AnalyticsTelemetryClient) SendEvent(ctx context.Context, eventName stri
 func (a *AnalyticsTelemetryClient) sendData(ctx context.Context, data url.Values) (*http.Response, error) {
 	a.wg.Add(1)
 	defer a.wg.Done()
+	if a.disabled {
+		return nil, nil
+	}
+
 	if a.BaseURL == nil {
 		analyticsURL, err := url.Parse(DefaultTelemetryEndpoint)
 		if err != nil {
@@ -210,6 +222,12 @@ func (a *AnalyticsTelemetryClient) sendData(ctx context.Context, data url.Values
 	return resp, nil
 }
 
+// Disable stops sending events, for users who opted out of telemetry in the
+// config file. It must be called before sending the first event.
+func (a *AnalyticsTelemetryClient) Disable() {
+	a.disabled = true
+}
+
 // Wait will return when all in-flight telemetry requests are complete.
 func (a *AnalyticsTelemetryClient) Wait() {
 	a.wg.Wait()
@@ -1,17 +1,21 @@
 package stripe
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
-	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/correlation"
+	"github.com/stripe/stripe-cli/pkg/logging"
+	"github.com/stripe/stripe-cli/pkg/offline"
 	"github.com/stripe/stripe-cli/pkg/useragent"
 )
 
@@ -41,13 +45,104 @@ type Client struct {
 	// stdout.
 	Verbose bool
 
+	// Log receives the debug logs of the requests. If left empty, the
+	// standard logger is used.
+	Log *log.Logger
+
 	// Cached HTTP client, lazily created the first time the Client is used to
 	// send a request.
 	httpClient *http.Client
 }
 
+// requestChecks are called with every request before it's sent, see
+// SetRequestCheck
+var requestChecks []func(req *http.Request) error
+
+// SetRequestCheck sets the function every request of PerformRequest goes
+// through before it's sent, replacing the ones added before. Requests are
+// failed with its error, e.g. to enforce the resources blocked by the policy
+// whichever command sends them. A nil check removes them all.
+func SetRequestCheck(check func(req *http.Request) error) {
+	requestChecks = nil
+	if check != nil {
+		requestChecks = append(requestChecks, check)
+	}
+}
+
+// AddRequestCheck adds a function every request of PerformRequest goes
+// through before it's sent, after the ones set before.
+func AddRequestCheck(check func(req *http.Request) error) {
+	requestChecks = append(requestChecks, check)
+}
+
 // PerformRequest sends a request to Stripe and returns the response.
 func (c *Client) PerformRequest(ctx context.Context, method, path string, params string, configure func(*http.Request)) (*http.Response, error) {
+	req, err := c.NewRequest(method, path, params, configure)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, check := range requestChecks {
+		if err := check(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if os.Getenv("STRIPE_CLI_UNIX_SOCKET") == "" {
+		if err := offline.CheckURL(fmt.Sprintf("%s %s", method, req.URL.Path), req.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = newHTTPClient(c.Verbose, os.Getenv("STRIPE_CLI_UNIX_SOCKET"))
+	}
+
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	logger := logging.WithPrefix(c.logger(), "stripe.Client.PerformRequest").WithFields(log.Fields{
+		"method": method,
+		"path":   req.URL.Path,
+	})
+	logger.Debug("Performing request")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.WithError(err).Debug("Request failed")
+		return nil, err
+	}
+
+	if err := decompressBody(resp); err != nil {
+		resp.Body.Close()
+		logger.WithError(err).Debug("Could not decompress response")
+		return nil, err
+	}
+
+	logger.WithFields(log.Fields{
+		"status":     resp.StatusCode,
+		"request_id": resp.Header.Get("Request-Id"),
+	}).Debug("Received response")
+
+	// RequestID of the API Request
+	requestID := resp.Header.Get("Request-Id")
+	livemode := strings.Contains(c.APIKey, "live")
+
+	// Remember requests that may cause events so that they can be linked
+	// back to this command when the events are received
+	if method != http.MethodGet {
+		if err := correlation.Record(requestID); err != nil {
+			logger.WithError(err).Debug("Could not record request in correlation index")
+		}
+	}
+
+	go sendTelemetryEvent(ctx, logger, requestID, livemode)
+	return resp, nil
+}
+
+// NewRequest builds the request PerformRequest sends, without sending it.
+func (c *Client) NewRequest(method, path string, params string, configure func(*http.Request)) (*http.Request, error) {
 	url, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -67,7 +162,7 @@ func (c *Client) PerformRequest(ctx context.Context, method, path string, params
 		return nil, err
 	}
 
-	req.Header.Set("Accept-Encoding", "identity")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("User-Agent", useragent.GetEncodedUserAgent())
 	req.Header.Set("X-Stripe-Client-User-Agent", useragent.GetEncodedStripeUserAgent())
@@ -80,33 +175,69 @@ func (c *Client) PerformRequest(ctx context.Context, method, path string, params
 		configure(req)
 	}
 
-	if c.httpClient == nil {
-		c.httpClient = newHTTPClient(c.Verbose, os.Getenv("STRIPE_CLI_UNIX_SOCKET"))
-	}
+	return req, nil
+}
 
-	if ctx != nil {
-		req = req.WithContext(ctx)
+// acceptEncoding lists the response encodings supported by decompressBody
+const acceptEncoding = "gzip, deflate"
+
+// decompressBody replaces the body of a compressed response with its
+// decompressed content, so that callers don't have to care about the
+// encoding. The transport only does it by itself when it set Accept-Encoding.
+func decompressBody(resp *http.Response) error {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		reader, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		reader, err = zlib.NewReader(resp.Body)
+	default:
+		return nil
 	}
 
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// RequestID of the API Request
-	requestID := resp.Header.Get("Request-Id")
-	livemode := strings.Contains(c.APIKey, "live")
-	go sendTelemetryEvent(ctx, requestID, livemode)
-	return resp, nil
+	resp.Body = &decompressedBody{ReadCloser: reader, compressed: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return nil
+}
+
+// decompressedBody closes both the decompressor and the underlying body.
+type decompressedBody struct {
+	io.ReadCloser
+	compressed io.ReadCloser
+}
+
+func (b *decompressedBody) Close() error {
+	b.ReadCloser.Close()
+	return b.compressed.Close()
+}
+
+func (c *Client) logger() *log.Logger {
+	if c.Log == nil {
+		return log.StandardLogger()
+	}
+
+	return c.Log
 }
 
-func sendTelemetryEvent(ctx context.Context, requestID string, livemode bool) {
+func sendTelemetryEvent(ctx context.Context, logger *log.Entry, requestID string, livemode bool) {
 	telemetryClient := GetTelemetryClient(ctx)
 	if telemetryClient != nil {
 		resp, err := telemetryClient.SendAPIRequestEvent(ctx, requestID, livemode)
 		// Don't throw exception if we fail to send the event
 		if err != nil {
-			log.Debugf("Error while sending telemetry data: %v\n", err)
+			logger.Debugf("Error while sending telemetry data: %v\n", err)
 		}
 		if resp != nil {
 			resp.Body.Close()
@@ -115,31 +246,9 @@ func sendTelemetryEvent(ctx context.Context, requestID string, livemode bool) {
 }
 
 func newHTTPClient(verbose bool, unixSocket string) *http.Client {
-	var httpTransport *http.Transport
-
+	httpTransport := HTTPTransport()
 	if unixSocket != "" {
-		dialFunc := func(network, addr string) (net.Conn, error) {
-			return net.Dial("unix", unixSocket)
-		}
-		dialContext := func(_ context.Context, _, _ string) (net.Conn, error) {
-			return net.Dial("unix", unixSocket)
-		}
-		httpTransport = &http.Transport{
-			DialContext:           dialContext,
-			DialTLS:               dialFunc,
-			ResponseHeaderTimeout: 30 * time.Second,
-			ExpectContinueTimeout: 10 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-		}
-	} else {
-		httpTransport = &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			TLSHandshakeTimeout: 10 * time.Second,
-		}
+		httpTransport = unixSocketTransport(unixSocket)
 	}
 
 	tr := &verboseTransport{
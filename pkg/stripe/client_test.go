@@ -1,7 +1,12 @@
 package stripe
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +14,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/offline"
 )
 
 func TestPerformRequest_ParamsEncoding_Delete(t *testing.T) {
@@ -142,3 +149,102 @@ func TestPerformRequest_ConfigureFunc(t *testing.T) {
 
 	defer resp.Body.Close()
 }
+
+func TestPerformRequest_DecompressesResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "gzip, deflate", r.Header.Get("Accept-Encoding"))
+
+		var buf bytes.Buffer
+		var writer io.WriteCloser
+		if r.URL.Path == "/gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			writer = gzip.NewWriter(&buf)
+		} else {
+			w.Header().Set("Content-Encoding", "deflate")
+			writer = zlib.NewWriter(&buf)
+		}
+
+		writer.Write([]byte(`{"id":"cus_123"}`))
+		writer.Close()
+		w.Write(buf.Bytes())
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{
+		BaseURL: baseURL,
+	}
+
+	for _, path := range []string{"/gzip", "/deflate"} {
+		resp, err := client.PerformRequest(context.Background(), http.MethodGet, path, "", nil)
+		require.NoError(t, err)
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, `{"id":"cus_123"}`, string(body))
+		require.Empty(t, resp.Header.Get("Content-Encoding"))
+	}
+}
+
+func TestPerformRequest_Offline(t *testing.T) {
+	defer func() { offline.Enabled = false }()
+	offline.Enabled = true
+
+	// Local servers like stripe-mock can still be reached
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{BaseURL: baseURL}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	baseURL, _ = url.Parse(DefaultAPIBaseURL)
+	client = Client{BaseURL: baseURL}
+
+	_, err = client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.EqualError(t, err, "GET /v1/customers needs network access, which --offline disables")
+}
+
+func TestPerformRequest_RequestCheck(t *testing.T) {
+	defer SetRequestCheck(nil)
+	SetRequestCheck(func(req *http.Request) error {
+		if req.URL.Path == "/v1/payouts" {
+			return errors.New("the payouts resource is blocked")
+		}
+		return nil
+	})
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { requests++ }))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+	client := Client{BaseURL: baseURL}
+
+	resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = client.PerformRequest(context.Background(), http.MethodPost, "/v1/payouts", "amount=100", nil)
+	require.EqualError(t, err, "the payouts resource is blocked")
+	require.Equal(t, 1, requests)
+
+	AddRequestCheck(func(req *http.Request) error {
+		if req.Method != http.MethodGet {
+			return errors.New("the session expired")
+		}
+		return nil
+	})
+
+	_, err = client.PerformRequest(context.Background(), http.MethodPost, "/v1/payouts", "amount=100", nil)
+	require.EqualError(t, err, "the payouts resource is blocked")
+
+	_, err = client.PerformRequest(context.Background(), http.MethodPost, "/v1/customers", "", nil)
+	require.EqualError(t, err, "the session expired")
+	require.Equal(t, 1, requests)
+}
@@ -0,0 +1,134 @@
+package stripe
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+)
+
+//
+// Public functions
+//
+
+// HTTPTransport returns the transport shared by the HTTP clients of the CLI.
+// Sharing it lets successive requests to the same host reuse kept-alive
+// connections, including HTTP/2 ones, instead of paying for a new TCP and TLS
+// handshake every time a client is created.
+//
+// The crypto policy is read on each request rather than when the transport is
+// created, so that the policy of the config applies even to the clients
+// created before the config is loaded, like the telemetry one.
+func HTTPTransport() http.RoundTripper {
+	return sharedTransport
+}
+
+//
+// Private types
+//
+
+// policyTransport sends the requests with a transport for the current crypto
+// policy. The transport is replaced when the policy changes, closing the
+// idle connections made with the previous one.
+type policyTransport struct {
+	mu        sync.Mutex
+	policy    cryptopolicy.Policy
+	transport *http.Transport
+}
+
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.current().RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the transport, see
+// http.Client.CloseIdleConnections
+func (t *policyTransport) CloseIdleConnections() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.transport != nil {
+		t.transport.CloseIdleConnections()
+	}
+}
+
+func (t *policyTransport) current() *http.Transport {
+	policy := cryptopolicy.Current()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.transport != nil && t.policy == *policy {
+		return t.transport
+	}
+
+	if t.transport != nil {
+		t.transport.CloseIdleConnections()
+	}
+
+	t.policy = *policy
+	t.transport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       policy.TLSConfig(),
+	}
+
+	return t.transport
+}
+
+//
+// Private variables
+//
+
+var (
+	sharedTransport = &policyTransport{}
+
+	unixSocketTransports   = make(map[string]*http.Transport)
+	unixSocketTransportsMu sync.Mutex
+)
+
+//
+// Private functions
+//
+
+// unixSocketTransport returns the shared transport sending all requests to
+// a unix socket, e.g. to go through a local proxy in tests.
+func unixSocketTransport(unixSocket string) *http.Transport {
+	unixSocketTransportsMu.Lock()
+	defer unixSocketTransportsMu.Unlock()
+
+	if transport, ok := unixSocketTransports[unixSocket]; ok {
+		return transport
+	}
+
+	dialFunc := func(network, addr string) (net.Conn, error) {
+		return net.Dial("unix", unixSocket)
+	}
+	dialContext := func(_ context.Context, _, _ string) (net.Conn, error) {
+		return net.Dial("unix", unixSocket)
+	}
+
+	transport := &http.Transport{
+		DialContext:           dialContext,
+		DialTLS:               dialFunc,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       90 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		ExpectContinueTimeout: 10 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+	}
+	unixSocketTransports[unixSocket] = transport
+
+	return transport
+}
@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+)
+
+// BenchmarkSequentialRequests compares bursts of requests made with a new
+// Client for each of them, as most commands do, sharing the transport or
+// creating a new one with each Client.
+func BenchmarkSequentialRequests(b *testing.B) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	defer ts.Close()
+
+	baseURL, _ := url.Parse(ts.URL)
+
+	request := func(b *testing.B, client *Client) {
+		resp, err := client.PerformRequest(context.Background(), http.MethodGet, "/v1/customers", "", nil)
+		require.NoError(b, err)
+
+		ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	b.Run("shared transport", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			request(b, &Client{BaseURL: baseURL})
+		}
+	})
+
+	b.Run("transport per client", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			transport := &http.Transport{}
+			request(b, &Client{BaseURL: baseURL, httpClient: &http.Client{Transport: transport}})
+			transport.CloseIdleConnections()
+		}
+	})
+}
+
+func TestHTTPTransportIsShared(t *testing.T) {
+	require.Same(t, HTTPTransport(), HTTPTransport())
+	require.Same(t, HTTPTransport(), newHTTPClient(false, "").Transport.(*verboseTransport).Transport)
+	require.Same(t, unixSocketTransport("/tmp/stripe.sock"), newHTTPClient(true, "/tmp/stripe.sock").Transport.(*verboseTransport).Transport)
+}
+
+func TestHTTPTransportAppliesPolicySetAfterward(t *testing.T) {
+	defer cryptopolicy.Set(cryptopolicy.Current())
+
+	transport := HTTPTransport()
+	client := &http.Client{Transport: transport}
+	require.Equal(t, uint16(tls.VersionTLS12), sharedTransport.current().TLSClientConfig.MinVersion)
+
+	policy, err := cryptopolicy.New("1.3", "", false)
+	require.NoError(t, err)
+	cryptopolicy.Set(policy)
+
+	require.Same(t, transport, client.Transport)
+	require.Equal(t, uint16(tls.VersionTLS13), sharedTransport.current().TLSClientConfig.MinVersion)
+}
@@ -23,7 +23,7 @@ var inspectHeaders = []string{
 }
 
 type verboseTransport struct {
-	Transport *http.Transport
+	Transport http.RoundTripper
 	Verbose   bool
 	Out       io.Writer
 }
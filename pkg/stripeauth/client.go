@@ -10,6 +10,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/stripe"
 )
 
@@ -34,6 +35,10 @@ type Client struct {
 
 	// Optional configuration parameters
 	cfg *Config
+
+	// Cached API client, so that authorizing new sessions after the first
+	// one reuses its kept-alive connections
+	apiClient *stripe.Client
 }
 
 // DeviceURLMap is a mapping of the urls that the device is listening
@@ -45,14 +50,7 @@ type DeviceURLMap struct {
 
 // Authorize sends a request to Stripe to initiate a new CLI session.
 func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeature string, filters *string, devURLMap *DeviceURLMap) (*StripeCLISession, error) {
-	c.cfg.Log.WithFields(log.Fields{
-		"prefix": "stripeauth.client.Authorize",
-	}).Debug("Authenticating with Stripe...")
-
-	parsedBaseURL, err := url.Parse(c.cfg.APIBaseURL)
-	if err != nil {
-		return nil, err
-	}
+	logging.WithPrefix(c.cfg.Log, "stripeauth.client.Authorize").Debug("Authenticating with Stripe...")
 
 	form := url.Values{}
 	form.Add("device_name", deviceName)
@@ -70,12 +68,19 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		form.Add("forward_connect_to_url", devURLMap.ForwardConnectURL)
 	}
 
-	client := &stripe.Client{
-		BaseURL: parsedBaseURL,
-		APIKey:  c.apiKey,
+	if c.apiClient == nil {
+		parsedBaseURL, err := url.Parse(c.cfg.APIBaseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		c.apiClient = &stripe.Client{
+			BaseURL: parsedBaseURL,
+			APIKey:  c.apiKey,
+		}
 	}
 
-	resp, err := client.PerformRequest(ctx, http.MethodPost, stripeCLISessionPath, form.Encode(), nil)
+	resp, err := c.apiClient.PerformRequest(ctx, http.MethodPost, stripeCLISessionPath, form.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -97,8 +102,7 @@ func (c *Client) Authorize(ctx context.Context, deviceName string, websocketFeat
 		return nil, err
 	}
 
-	c.cfg.Log.WithFields(log.Fields{
-		"prefix":                         "stripeauth.Client.Authorize",
+	logging.WithPrefix(c.cfg.Log, "stripeauth.Client.Authorize").WithFields(log.Fields{
 		"websocket_url":                  session.WebSocketURL,
 		"websocket_id":                   session.WebSocketID,
 		"websocket_authorized_feature":   session.WebSocketAuthorizedFeature,
@@ -0,0 +1,81 @@
+// Package suggest finds the closest match of a mistyped name, to suggest it
+// in error messages.
+package suggest
+
+import (
+	"strings"
+)
+
+// Closest returns the candidate closest to name, if it's close enough to be
+// a typo of it
+func Closest(name string, candidates []string) (string, bool) {
+	name = strings.ToLower(name)
+
+	best := ""
+	bestDistance := maxDistance(name) + 1
+
+	for _, candidate := range candidates {
+		distance := Distance(name, strings.ToLower(candidate))
+		if distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	return best, best != ""
+}
+
+// Distance returns the Levenshtein distance between a and b, counting a
+// transposition of two adjacent characters as a single edit
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between s[:i] and t[:j]
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+// maxDistance is the distance up to which a candidate is considered a typo,
+// which grows with the length of the name
+func maxDistance(name string) int {
+	if n := len(name) / 4; n > 2 {
+		return n
+	}
+
+	return 2
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
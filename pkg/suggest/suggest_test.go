@@ -0,0 +1,30 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	require.Equal(t, 0, Distance("listen", "listen"))
+	require.Equal(t, 1, Distance("lisen", "listen"))
+	require.Equal(t, 1, Distance("tial", "tail"))
+	require.Equal(t, 3, Distance("kitten", "sitting"))
+	require.Equal(t, 6, Distance("", "listen"))
+}
+
+func TestClosest(t *testing.T) {
+	events := []string{"payment_intent.created", "payment_intent.succeeded", "customer.created"}
+
+	match, ok := Closest("paymnet_intent.succeeded", events)
+	require.True(t, ok)
+	require.Equal(t, "payment_intent.succeeded", match)
+
+	match, ok = Closest("Customer.Creatd", events)
+	require.True(t, ok)
+	require.Equal(t, "customer.created", match)
+
+	_, ok = Closest("invoice.paid", events)
+	require.False(t, ok)
+}
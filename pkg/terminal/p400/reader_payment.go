@@ -91,7 +91,7 @@ func CompleteTestPayment(ctx context.Context, tsCtx TerminalSessionContext) (Ter
 // SummarizeQuickstartCompletion is the success text that is output once the quickstart flow is completed. It lists the Payment Intent Dashboard URL, and the Terminal readers Dashboard URL
 func SummarizeQuickstartCompletion(tsCtx TerminalSessionContext) error {
 	color := ansi.Color(os.Stdout)
-	successText := color.Green("✔ Test payment complete! Here are some example applications from Stripe to continue with your integration.")
+	successText := ansi.SuccessGlyph() + " " + color.Green("Test payment complete! Here are some example applications from Stripe to continue with your integration.").String()
 	exampleAppURL := color.Cyan("https://stripe.com/docs/terminal/example-applications")
 	paymentIntentURL := color.Cyan(fmt.Sprintf("https://dashboard.stripe.com/test/payments/%s", tsCtx.PaymentIntentID))
 	readerURL := color.Cyan(fmt.Sprintf("https://dashboard.stripe.com/test/terminal/locations/%s", tsCtx.LocationID))
@@ -104,7 +104,7 @@ func DiscoverReaders(ctx context.Context, tsCtx TerminalSessionContext) ([]Reade
 // StartNewRPCSession calls the Stripe API for a new RPC session token for interacting with a P400 reader
 // returns a session token when successful
 func StartNewRPCSession(tsCtx TerminalSessionContext) (string, error) {
-	httpclient := http.Client{}
+	httpclient := http.Client{Transport: stripe.HTTPTransport()}
 	parsedBaseURL, err := url.Parse(stripe.DefaultAPIBaseURL)
 
 	if err != nil {
@@ -64,7 +64,7 @@ func ReaderNewOrExistingPrompt() (string, error) {
 	options := ActivationTypeLabels
 	templates := &promptui.SelectTemplates{
 		Label:    "{{ . }} ",
-		Selected: ansi.Faint(fmt.Sprintf("✔ Selected %s: {{ . | bold }} ", "setup type")),
+		Selected: ansi.SuccessGlyph() + ansi.Faint(fmt.Sprintf(" Selected %s: {{ . | bold }} ", "setup type")),
 	}
 
 	_, selected, err := selectOptions(templates, "Is this reader new or already registered?", options)
@@ -85,7 +85,7 @@ func RegisteredReaderChoicePrompt(readerList []Reader, tsCtx TerminalSessionCont
 		Label:    "{{ .Label }} ({{ .Status }}) ",
 		Active:   "▸ {{ .Label | underline }} ({{ .Status }})",
 		Inactive: "{{ .Label }} ({{ .Status }})",
-		Selected: ansi.Faint(fmt.Sprintf("✔ Selected %s: {{ .Label | bold }} ", "reader")),
+		Selected: ansi.SuccessGlyph() + ansi.Faint(fmt.Sprintf(" Selected %s: {{ .Label | bold }} ", "reader")),
 	}
 
 	index, _, err := selectOptions(templates, "Select a reader:", readerList)
@@ -58,7 +58,7 @@ func QuickstartP400(ctx context.Context, cfg *config.Config) error {
 // SetTerminalSessionContext creates a data struct that contains the context of the user's current quickstart session
 // it returns a TerminalSessionContext interface that is passed into most of the P400 reader related functions in the quickstart flow
 func SetTerminalSessionContext(cfg *config.Config) p400.TerminalSessionContext {
-	apiKey, _ := cfg.Profile.GetAPIKey(false)
+	apiKey, _ := cfg.Profile.GetSecretKey(false)
 	posID := cfg.Profile.GetTerminalPOSDeviceID()
 
 	if posID == "" {
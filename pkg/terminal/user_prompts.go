@@ -22,7 +22,7 @@ func ReaderTypeSelectPrompt(readers []string) (string, error) {
 
 func selectOptions(template string, label string, options []string) (string, error) {
 	templates := &promptui.SelectTemplates{
-		Selected: ansi.Faint(fmt.Sprintf("✔ Selected %s: {{ . | bold }} ", template)),
+		Selected: ansi.SuccessGlyph() + ansi.Faint(fmt.Sprintf(" Selected %s: {{ . | bold }} ", template)),
 	}
 	prompt := promptui.Select{
 		Label:     label,
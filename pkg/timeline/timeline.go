@@ -0,0 +1,394 @@
+// Package timeline aggregates what happened to a customer, from its charges,
+// invoices, subscriptions and events, into a chronological list.
+package timeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/eventformat"
+	"github.com/stripe/stripe-cli/pkg/preview"
+	"github.com/stripe/stripe-cli/pkg/simulate"
+)
+
+// The levels of the entries
+const (
+	LevelError   = "error"
+	LevelInfo    = "info"
+	LevelSuccess = "success"
+	LevelWarning = "warning"
+)
+
+// ExpandAll expands all the entries when rendering
+const ExpandAll = "all"
+
+// maxPages is the number of pages of each list read, past which the oldest
+// entries are left out
+const maxPages = 5
+
+// ignoredEvents are the prefixes of the types of the events that are
+// already in the timeline as the objects they are about
+var ignoredEvents = []string{
+	"charge.",
+	"customer.created",
+	"customer.subscription.created",
+	"customer.subscription.deleted",
+	"invoice.",
+	"invoiceitem.",
+	"payment_intent.",
+}
+
+//
+// Public types
+//
+
+// Entry is something that happened to a customer
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	ID      string    `json:"id"`
+	Level   string    `json:"level"`
+	Summary string    `json:"summary"`
+	Details []string  `json:"details,omitempty"`
+}
+
+// Timeline is what happened to a customer, oldest first
+type Timeline struct {
+	Customer string  `json:"customer"`
+	Entries  []Entry `json:"entries"`
+
+	// Truncated tells whether the oldest entries of a list were left out
+	Truncated bool `json:"truncated"`
+}
+
+//
+// Public functions
+//
+
+// Build returns the timeline of customer. Events are only kept for 30 days
+// by the API, the objects are listed since their creation.
+func Build(ctx context.Context, client simulate.APIClient, customer string) (*Timeline, error) {
+	object, err := client.Request(ctx, http.MethodGet, "/v1/customers/"+customer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Timeline{Customer: customer}
+
+	t.Entries = append(t.Entries, Entry{
+		Time:    timestamp(object.Get("created")),
+		Kind:    "customer",
+		ID:      customer,
+		Level:   LevelInfo,
+		Summary: "Customer created",
+		Details: nonEmpty(object.Get("email").String(), object.Get("name").String()),
+	})
+
+	lists := []struct {
+		path   string
+		params []string
+		entry  func(gjson.Result) []Entry
+	}{
+		{"/v1/charges", []string{"customer=" + customer}, chargeEntries},
+		{"/v1/invoices", []string{"customer=" + customer}, invoiceEntries},
+		{"/v1/subscriptions", []string{"customer=" + customer, "status=all"}, subscriptionEntries},
+		{"/v1/events", nil, func(event gjson.Result) []Entry { return eventEntries(customer, event) }},
+	}
+
+	for _, list := range lists {
+		objects, truncated, err := listAll(ctx, client, list.path, list.params)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Truncated = t.Truncated || truncated
+
+		for _, object := range objects {
+			t.Entries = append(t.Entries, list.entry(object)...)
+		}
+	}
+
+	sort.SliceStable(t.Entries, func(i, j int) bool {
+		return t.Entries[i].Time.Before(t.Entries[j].Time)
+	})
+
+	return t, nil
+}
+
+// Render writes the timeline to w, with the details of the entries whose
+// IDs are in expand, or of all of them when it contains ExpandAll.
+func Render(w io.Writer, t *Timeline, expand []string) {
+	expanded := make(map[string]bool)
+	for _, id := range expand {
+		expanded[id] = true
+	}
+
+	color := ansi.Color(w)
+
+	for _, entry := range t.Entries {
+		fmt.Fprintf(w, "%s %s  %s %s\n",
+			glyph(entry.Level),
+			color.Faint(entry.Time.Local().Format("2006-01-02 15:04")),
+			entry.Summary,
+			color.Faint(entry.ID),
+		)
+
+		if len(entry.Details) == 0 {
+			continue
+		}
+
+		if expanded[ExpandAll] || expanded[entry.ID] {
+			for _, detail := range entry.Details {
+				fmt.Fprintf(w, "                      %s\n", detail)
+			}
+		}
+	}
+
+	if t.Truncated {
+		fmt.Fprintf(w, "\n%s Only the most recent %d objects of each kind are listed\n", ansi.WarningGlyph(), maxPages*100)
+	}
+
+	if !expanded[ExpandAll] {
+		fmt.Fprintf(w, "\n%s\n", color.Faint("Show the details of entries with --expand <id>, or --expand all"))
+	}
+}
+
+//
+// Private functions
+//
+
+func chargeEntries(charge gjson.Result) []Entry {
+	amount := preview.FormatAmount(charge.Get("amount").Int(), charge.Get("currency").String())
+
+	entry := Entry{
+		Time: timestamp(charge.Get("created")),
+		Kind: "charge",
+		ID:   charge.Get("id").String(),
+		Details: nonEmpty(
+			charge.Get("description").String(),
+			charge.Get("payment_method_details.type").String(),
+			charge.Get("receipt_url").String(),
+		),
+	}
+
+	switch charge.Get("status").String() {
+	case "succeeded":
+		entry.Level = LevelSuccess
+		entry.Summary = "Charge of " + amount + " succeeded"
+	case "failed":
+		entry.Level = LevelError
+		entry.Summary = "Charge of " + amount + " failed"
+		entry.Details = append(nonEmpty(charge.Get("failure_message").String(), charge.Get("outcome.seller_message").String()), entry.Details...)
+	default:
+		entry.Level = LevelInfo
+		entry.Summary = "Charge of " + amount + " pending"
+	}
+
+	if refunded := charge.Get("amount_refunded").Int(); refunded > 0 {
+		entry.Level = LevelWarning
+		entry.Summary += ", " + preview.FormatAmount(refunded, charge.Get("currency").String()) + " refunded"
+	}
+
+	if charge.Get("disputed").Bool() {
+		entry.Level = LevelError
+		entry.Summary += ", disputed"
+	}
+
+	return []Entry{entry}
+}
+
+func invoiceEntries(invoice gjson.Result) []Entry {
+	name := invoice.Get("number").String()
+	if name == "" {
+		name = "draft"
+	}
+
+	status := invoice.Get("status").String()
+	level := map[string]string{
+		"paid":          LevelSuccess,
+		"open":          LevelWarning,
+		"uncollectible": LevelError,
+	}[status]
+	if level == "" {
+		level = LevelInfo
+	}
+
+	return []Entry{{
+		Time:    timestamp(invoice.Get("created")),
+		Kind:    "invoice",
+		ID:      invoice.Get("id").String(),
+		Level:   level,
+		Summary: fmt.Sprintf("Invoice %s of %s %s", name, preview.FormatAmount(invoice.Get("total").Int(), invoice.Get("currency").String()), status),
+		Details: nonEmpty(
+			invoice.Get("billing_reason").String(),
+			invoice.Get("hosted_invoice_url").String(),
+		),
+	}}
+}
+
+func subscriptionEntries(subscription gjson.Result) []Entry {
+	id := subscription.Get("id").String()
+
+	var items []string
+	for _, item := range subscription.Get("items.data").Array() {
+		items = append(items, fmt.Sprintf("%d x %s", item.Get("quantity").Int(), item.Get("price.id").String()))
+	}
+
+	entries := []Entry{{
+		Time:    timestamp(subscription.Get("created")),
+		Kind:    "subscription",
+		ID:      id,
+		Level:   LevelInfo,
+		Summary: "Subscription created",
+		Details: items,
+	}}
+
+	if canceled := subscription.Get("canceled_at"); canceled.Int() > 0 {
+		entries = append(entries, Entry{
+			Time:    timestamp(canceled),
+			Kind:    "subscription",
+			ID:      id,
+			Level:   LevelWarning,
+			Summary: "Subscription canceled",
+			Details: nonEmpty(subscription.Get("cancellation_details.reason").String()),
+		})
+	}
+
+	return entries
+}
+
+// eventEntries returns the entry of an event about customer, or of a
+// subscription of customer
+func eventEntries(customer string, event gjson.Result) []Entry {
+	object := event.Get("data.object")
+	if object.Get("id").String() != customer && object.Get("customer").String() != customer {
+		return nil
+	}
+
+	eventType := event.Get("type").String()
+	for _, prefix := range ignoredEvents {
+		if strings.HasPrefix(eventType, prefix) {
+			return nil
+		}
+	}
+
+	entry := Entry{
+		Time:    timestamp(event.Get("created")),
+		Kind:    "event",
+		ID:      event.Get("id").String(),
+		Level:   LevelInfo,
+		Summary: eventType,
+	}
+
+	if strings.HasSuffix(eventType, ".deleted") {
+		entry.Level = LevelWarning
+	}
+
+	payload, _ := event.Value().(map[string]interface{})
+	changes := eventformat.Changes(payload)
+
+	for _, change := range changes {
+		entry.Details = append(entry.Details, fmt.Sprintf("%s: %s %s %s", change.Path, compact(change.Before), arrow(), compact(change.After)))
+	}
+
+	// Subscription changes are worth seeing without expanding
+	if strings.HasPrefix(eventType, "customer.subscription.") && len(changes) > 0 {
+		entry.Summary += " (" + strings.Join(changedPaths(changes), ", ") + ")"
+	}
+
+	return []Entry{entry}
+}
+
+// listAll lists the objects at path, up to maxPages pages
+func listAll(ctx context.Context, client simulate.APIClient, path string, params []string) ([]gjson.Result, bool, error) {
+	var objects []gjson.Result
+
+	startingAfter := ""
+
+	for page := 0; page < maxPages; page++ {
+		pageParams := append([]string{"limit=100"}, params...)
+		if startingAfter != "" {
+			pageParams = append(pageParams, "starting_after="+startingAfter)
+		}
+
+		list, err := client.Request(ctx, http.MethodGet, path, pageParams)
+		if err != nil {
+			return nil, false, err
+		}
+
+		data := list.Get("data").Array()
+		objects = append(objects, data...)
+
+		if !list.Get("has_more").Bool() || len(data) == 0 {
+			return objects, false, nil
+		}
+
+		startingAfter = data[len(data)-1].Get("id").String()
+	}
+
+	return objects, true, nil
+}
+
+func changedPaths(changes []eventformat.Change) []string {
+	paths := make([]string, len(changes))
+	for i, change := range changes {
+		paths[i] = change.Path
+	}
+
+	return paths
+}
+
+func timestamp(value gjson.Result) time.Time {
+	return time.Unix(value.Int(), 0)
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func compact(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "?"
+	}
+
+	return string(data)
+}
+
+func arrow() string {
+	if ansi.ASCIIOnly {
+		return "->"
+	}
+
+	return "→"
+}
+
+func glyph(level string) string {
+	switch level {
+	case LevelSuccess:
+		return ansi.SuccessGlyph()
+	case LevelWarning:
+		return ansi.WarningGlyph()
+	case LevelError:
+		return ansi.ErrorGlyph()
+	}
+
+	return ansi.InfoGlyph()
+}
@@ -0,0 +1,80 @@
+package timeline
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+type fakeClient struct {
+	responses map[string]string
+}
+
+func (c *fakeClient) Request(ctx context.Context, method, path string, params []string) (gjson.Result, error) {
+	if response, ok := c.responses[path]; ok {
+		return gjson.Parse(response), nil
+	}
+
+	return gjson.Parse(`{"object":"list","has_more":false,"data":[]}`), nil
+}
+
+func TestBuild(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"/v1/customers/cus_123": `{"id":"cus_123","created":1000,"email":"jenny.rosen@example.com"}`,
+		"/v1/charges": `{"object":"list","has_more":false,"data":[
+			{"id":"ch_2","created":4000,"amount":2000,"currency":"usd","status":"failed","failure_message":"Your card was declined."},
+			{"id":"ch_1","created":2000,"amount":2000,"currency":"usd","status":"succeeded","amount_refunded":500}
+		]}`,
+		"/v1/invoices": `{"object":"list","has_more":false,"data":[
+			{"id":"in_1","created":1900,"number":"ABC-0001","total":2000,"currency":"usd","status":"paid"}
+		]}`,
+		"/v1/subscriptions": `{"object":"list","has_more":false,"data":[
+			{"id":"sub_1","created":1800,"canceled_at":5000,"items":{"data":[{"quantity":1,"price":{"id":"price_1"}}]}}
+		]}`,
+		"/v1/events": `{"object":"list","has_more":false,"data":[
+			{"id":"evt_3","created":4500,"type":"customer.subscription.updated","data":{"object":{"id":"sub_1","customer":"cus_123","status":"past_due"},"previous_attributes":{"status":"active"}}},
+			{"id":"evt_2","created":4000,"type":"charge.failed","data":{"object":{"id":"ch_2","customer":"cus_123"}}},
+			{"id":"evt_1","created":3000,"type":"customer.subscription.updated","data":{"object":{"id":"sub_9","customer":"cus_456"},"previous_attributes":{"status":"active"}}}
+		]}`,
+	}}
+
+	tl, err := Build(context.Background(), client, "cus_123")
+	require.NoError(t, err)
+
+	var ids, levels []string
+	for _, entry := range tl.Entries {
+		ids = append(ids, entry.ID)
+		levels = append(levels, entry.Level)
+	}
+
+	require.Equal(t, []string{"cus_123", "sub_1", "in_1", "ch_1", "ch_2", "evt_3", "sub_1"}, ids)
+	require.Equal(t, []string{LevelInfo, LevelInfo, LevelSuccess, LevelWarning, LevelError, LevelInfo, LevelWarning}, levels)
+
+	require.Equal(t, "Charge of USD 20.00 succeeded, USD 5.00 refunded", tl.Entries[3].Summary)
+	require.Equal(t, "customer.subscription.updated (status)", tl.Entries[5].Summary)
+	require.Equal(t, []string{`status: "active" → "past_due"`}, tl.Entries[5].Details)
+	require.False(t, tl.Truncated)
+}
+
+func TestRender(t *testing.T) {
+	tl := &Timeline{Customer: "cus_123", Entries: []Entry{
+		{ID: "ch_1", Level: LevelError, Summary: "Charge of USD 20.00 failed", Details: []string{"Your card was declined."}},
+		{ID: "in_1", Level: LevelSuccess, Summary: "Invoice ABC-0001 of USD 20.00 paid", Details: []string{"subscription_cycle"}},
+	}}
+
+	var b bytes.Buffer
+	Render(&b, tl, []string{"ch_1"})
+
+	require.Contains(t, b.String(), "Charge of USD 20.00 failed ch_1")
+	require.Contains(t, b.String(), "Your card was declined.")
+	require.NotContains(t, b.String(), "subscription_cycle")
+
+	b.Reset()
+	Render(&b, tl, []string{ExpandAll})
+
+	require.Contains(t, b.String(), "subscription_cycle")
+	require.NotContains(t, b.String(), "--expand all")
+}
@@ -0,0 +1,370 @@
+// Package undo keeps a journal of the mutations made by the CLI, with the
+// request that reverts each of them when there is one.
+package undo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+// JournalFileName is the name of the journal file in the config folder
+const JournalFileName = "mutations.jsonl"
+
+// maxJournalSize is the number of mutations past which the oldest ones are
+// dropped from the journal
+const maxJournalSize = 100
+
+//
+// Public types
+//
+
+// Mutation is an API request made by the CLI that changed an object
+type Mutation struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Livemode bool      `json:"livemode"`
+	Method   string    `json:"method"`
+	Path     string    `json:"path"`
+	ObjectID string    `json:"object_id,omitempty"`
+
+	// StripeAccount is the connected account the request was made on
+	StripeAccount string `json:"stripe_account,omitempty"`
+
+	// Undo is the request that reverts the mutation, nil when it's
+	// irreversible
+	Undo *Request `json:"undo,omitempty"`
+
+	// Irreversible tells why the mutation can't be reverted
+	Irreversible string `json:"irreversible,omitempty"`
+}
+
+// Request is an API request reverting a mutation
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Params are the form-encoded parameters of the request
+	Params string `json:"params,omitempty"`
+}
+
+func (r *Request) String() string {
+	if r.Params == "" {
+		return fmt.Sprintf("%s %s", r.Method, r.Path)
+	}
+
+	return fmt.Sprintf("%s %s %s", r.Method, r.Path, r.Params)
+}
+
+//
+// Public functions
+//
+
+// Enable starts recording the mutations made by this process in the journal
+// at path, attributed to command.
+func Enable(path, command string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	journalPath = path
+	currentCommand = command
+}
+
+// Enabled returns whether the mutations are recorded.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return journalPath != ""
+}
+
+// Record adds a mutation made by this process to the journal. It does
+// nothing unless recording was enabled.
+func Record(m Mutation) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if journalPath == "" {
+		return nil
+	}
+
+	m.Command = currentCommand
+	m.Time = time.Now()
+
+	mutations, err := Load(journalPath)
+	if err != nil {
+		return err
+	}
+
+	mutations = append(mutations, m)
+	if len(mutations) > maxJournalSize {
+		mutations = mutations[len(mutations)-maxJournalSize:]
+	}
+
+	return Save(journalPath, mutations)
+}
+
+// Load reads the mutations of the journal at path, oldest first. A missing
+// journal has no mutations.
+func Load(path string) ([]Mutation, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mutations []Mutation
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var m Mutation
+		if err := json.Unmarshal(scanner.Bytes(), &m); err == nil {
+			mutations = append(mutations, m)
+		}
+	}
+
+	return mutations, scanner.Err()
+}
+
+// Save replaces the journal at path with mutations.
+func Save(path string, mutations []Mutation) error {
+	var b strings.Builder
+
+	for _, m := range mutations {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(b.String()), 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Clear deletes the journal at path.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Select returns the indices of the steps most recent reversible mutations,
+// most recent first, and of the irreversible mutations made after the last
+// of them.
+func Select(mutations []Mutation, steps int) ([]int, []int) {
+	var reversible, skipped []int
+
+	for i := len(mutations) - 1; i >= 0 && len(reversible) < steps; i-- {
+		if mutations[i].Undo == nil {
+			skipped = append(skipped, i)
+		} else {
+			reversible = append(reversible, i)
+		}
+	}
+
+	if len(reversible) == 0 {
+		// Nothing precedes the irreversible mutations
+		return nil, skipped
+	}
+
+	return reversible, skipped
+}
+
+// NeedsPrevious returns whether reverting a request requires the object it
+// changes as it was before the request, i.e. whether it's an update.
+func NeedsPrevious(docs *spec.ResourceDocs, method, path string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+
+	operation, ok := docs.MatchOperation(method, path)
+
+	return ok && isObjectPath(operation.Path) && hasOperation(docs, http.MethodGet, path)
+}
+
+// Plan returns the mutation made by a successful request, with the request
+// reverting it when there is one. params are the form-encoded parameters of
+// the request, response is its response, and previous is the object before
+// an update.
+func Plan(docs *spec.ResourceDocs, method, path, params string, response, previous map[string]interface{}) Mutation {
+	m := Mutation{Method: method, Path: path}
+	if id, ok := response["id"].(string); ok {
+		m.ObjectID = id
+	}
+
+	operation, ok := docs.MatchOperation(method, path)
+
+	switch {
+	case !ok:
+		m.Irreversible = "the API reference has no such operation"
+	case method == http.MethodDelete:
+		m.Irreversible = "deleted objects can't be restored"
+	case method != http.MethodPost:
+		m.Irreversible = fmt.Sprintf("%s requests can't be reverted", method)
+	case isObjectPath(operation.Path):
+		m.Undo, m.Irreversible = planUpdateUndo(path, params, previous)
+	case m.ObjectID != "" && hasOperation(docs, http.MethodDelete, path+"/"+m.ObjectID):
+		m.Undo = &Request{Method: http.MethodDelete, Path: path + "/" + m.ObjectID}
+	case isCollectionPath(docs, operation.Path):
+		m.Irreversible = fmt.Sprintf("the API can't delete %s objects", objectName(response))
+	default:
+		m.Irreversible = fmt.Sprintf("actions like %s can't be reverted", operation.Path[strings.LastIndex(operation.Path, "/")+1:])
+	}
+
+	return m
+}
+
+//
+// Private variables
+//
+
+var (
+	mu             sync.Mutex
+	journalPath    string
+	currentCommand string
+)
+
+//
+// Private functions
+//
+
+// planUpdateUndo returns the update restoring the values the parameters of
+// an update had before it
+func planUpdateUndo(path, params string, previous map[string]interface{}) (*Request, string) {
+	if previous == nil {
+		return nil, "the object couldn't be retrieved before the update"
+	}
+
+	values, err := url.ParseQuery(params)
+	if err != nil {
+		return nil, "the parameters of the update couldn't be read"
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "expand[]" && !strings.HasPrefix(key, "expand[") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	restored := url.Values{}
+
+	for _, key := range keys {
+		name, sub := splitParam(key)
+
+		value, ok := previous[name]
+		if sub != "" {
+			if value == nil {
+				// The whole object is unset
+				restored.Set(name, "")
+				continue
+			}
+
+			object, isObject := value.(map[string]interface{})
+			if !isObject || strings.Contains(sub, "[") {
+				return nil, fmt.Sprintf("the previous value of %s can't be restored", key)
+			}
+
+			value, ok = object[sub]
+		}
+
+		if !ok && sub == "" {
+			return nil, fmt.Sprintf("the previous value of %s is unknown", key)
+		}
+
+		formatted, restorable := formatValue(value)
+		if !restorable {
+			return nil, fmt.Sprintf("the previous value of %s can't be restored", key)
+		}
+
+		restored.Set(key, formatted)
+	}
+
+	return &Request{Method: http.MethodPost, Path: path, Params: restored.Encode()}, ""
+}
+
+// splitParam splits `metadata[order_id]` into `metadata` and `order_id`
+func splitParam(key string) (string, string) {
+	i := strings.Index(key, "[")
+	if i < 0 || !strings.HasSuffix(key, "]") {
+		return key, ""
+	}
+
+	return key[:i], key[i+1 : len(key)-1]
+}
+
+// formatValue formats a previous value as a form parameter, empty values
+// unsetting the parameter
+func formatValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case bool:
+		return fmt.Sprintf("%t", v), true
+	case float64:
+		return fmt.Sprintf("%v", v), true
+	case map[string]interface{}:
+		// Expanded objects are restored by ID
+		if id, ok := v["id"].(string); ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+func hasOperation(docs *spec.ResourceDocs, method, path string) bool {
+	_, ok := docs.MatchOperation(method, path)
+	return ok
+}
+
+// isObjectPath returns whether a path template is the path of an object,
+// e.g. `/v1/customers/{customer}`, rather than of a collection or an action
+func isObjectPath(template string) bool {
+	return strings.HasSuffix(template, "}")
+}
+
+// isCollectionPath returns whether a path template is the path of a
+// collection, e.g. `/v1/refunds` or `/v1/customers/{customer}/sources`,
+// rather than of an action
+func isCollectionPath(docs *spec.ResourceDocs, template string) bool {
+	return strings.Count(template, "/") == 2 || hasOperation(docs, http.MethodGet, template)
+}
+
+func objectName(response map[string]interface{}) string {
+	if object, ok := response["object"].(string); ok {
+		return object
+	}
+
+	return "these"
+}
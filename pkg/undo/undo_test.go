@@ -0,0 +1,110 @@
+package undo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/stripe/stripe-cli/pkg/spec"
+)
+
+func TestPlan(t *testing.T) {
+	docs, err := spec.LoadResourceDocs()
+	require.NoError(t, err)
+
+	m := Plan(docs, "POST", "/v1/customers", "email=jenny%40example.com", map[string]interface{}{"id": "cus_123", "object": "customer"}, nil)
+	require.Equal(t, &Request{Method: "DELETE", Path: "/v1/customers/cus_123"}, m.Undo)
+	require.Equal(t, "cus_123", m.ObjectID)
+
+	m = Plan(docs, "POST", "/v1/refunds", "charge=ch_123", map[string]interface{}{"id": "re_123", "object": "refund"}, nil)
+	require.Nil(t, m.Undo)
+	require.Equal(t, "the API can't delete refund objects", m.Irreversible)
+
+	m = Plan(docs, "POST", "/v1/payment_intents/pi_123/confirm", "", map[string]interface{}{"id": "pi_123"}, nil)
+	require.Nil(t, m.Undo)
+	require.Equal(t, "actions like confirm can't be reverted", m.Irreversible)
+
+	m = Plan(docs, "DELETE", "/v1/customers/cus_123", "", map[string]interface{}{"id": "cus_123", "deleted": true}, nil)
+	require.Nil(t, m.Undo)
+	require.Equal(t, "deleted objects can't be restored", m.Irreversible)
+
+	m = Plan(docs, "POST", "/v1/not_a_resource", "", map[string]interface{}{"id": "x_123"}, nil)
+	require.Nil(t, m.Undo)
+}
+
+func TestPlanUpdate(t *testing.T) {
+	docs, err := spec.LoadResourceDocs()
+	require.NoError(t, err)
+
+	require.True(t, NeedsPrevious(docs, "POST", "/v1/customers/cus_123"))
+	require.False(t, NeedsPrevious(docs, "POST", "/v1/customers"))
+
+	previous := map[string]interface{}{
+		"id":          "cus_123",
+		"email":       "old@example.com",
+		"description": nil,
+		"metadata":    map[string]interface{}{"order_id": "6735"},
+	}
+
+	m := Plan(docs, "POST", "/v1/customers/cus_123", "email=new%40example.com&description=VIP&metadata[order_id]=6736&metadata[tier]=gold&expand[]=default_source", map[string]interface{}{"id": "cus_123"}, previous)
+	require.Empty(t, m.Irreversible)
+	require.Equal(t, &Request{
+		Method: "POST",
+		Path:   "/v1/customers/cus_123",
+		Params: "description=&email=old%40example.com&metadata%5Border_id%5D=6735&metadata%5Btier%5D=",
+	}, m.Undo)
+
+	m = Plan(docs, "POST", "/v1/customers/cus_123", "source=tok_visa", map[string]interface{}{"id": "cus_123"}, previous)
+	require.Nil(t, m.Undo)
+	require.Equal(t, "the previous value of source is unknown", m.Irreversible)
+
+	m = Plan(docs, "POST", "/v1/customers/cus_123", "email=new%40example.com", map[string]interface{}{"id": "cus_123"}, nil)
+	require.Nil(t, m.Undo)
+}
+
+func TestSelect(t *testing.T) {
+	reversible := Mutation{Undo: &Request{Method: "DELETE", Path: "/v1/customers/cus_123"}}
+	irreversible := Mutation{Irreversible: "deleted objects can't be restored"}
+
+	mutations := []Mutation{reversible, reversible, irreversible, reversible, irreversible}
+
+	selected, skipped := Select(mutations, 2)
+	require.Equal(t, []int{3, 1}, selected)
+	require.Equal(t, []int{4, 2}, skipped)
+
+	selected, skipped = Select([]Mutation{irreversible}, 1)
+	require.Empty(t, selected)
+	require.Equal(t, []int{0}, skipped)
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+
+	mutations, err := Load(path)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+
+	require.NoError(t, Record(Mutation{Method: "POST"}))
+
+	Enable(path, "stripe customers create")
+	defer Enable("", "")
+
+	require.True(t, Enabled())
+
+	for i := 0; i < maxJournalSize+1; i++ {
+		require.NoError(t, Record(Mutation{Method: "POST", Path: "/v1/customers"}))
+	}
+
+	mutations, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, mutations, maxJournalSize)
+	require.Equal(t, "stripe customers create", mutations[0].Command)
+
+	require.NoError(t, Clear(path))
+	require.NoError(t, Clear(path))
+
+	mutations, err = Load(path)
+	require.NoError(t, err)
+	require.Empty(t, mutations)
+}
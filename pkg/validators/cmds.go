@@ -69,3 +69,27 @@ func MaximumNArgs(num int) cobra.PositionalArgs {
 		return nil
 	}
 }
+
+// MinimumNArgs is a validator for commands to print an error when the provided
+// args are fewer than the minimum amount
+func MinimumNArgs(num int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		argument := "positional argument"
+		if num > 1 {
+			argument = "positional arguments"
+		}
+
+		errorMessage := fmt.Sprintf(
+			"`%s` requires at least %d %s. See `%s --help` for supported flags and usage",
+			cmd.CommandPath(),
+			num,
+			argument,
+			cmd.CommandPath(),
+		)
+
+		if len(args) < num {
+			return errors.New(errorMessage)
+		}
+		return nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/stripe/stripe-cli/pkg/ansi"
+	"github.com/stripe/stripe-cli/pkg/offline"
 )
 
 // Version of the CLI.
@@ -18,16 +19,23 @@ import (
 // always show master.
 var Version = "master"
 
+// Commit is the git commit the CLI was built from, set by GoReleaser
+var Commit = "none"
+
+// Date is the date the CLI was built at, set by GoReleaser
+var Date = "unknown"
+
 // Template for the version string.
 var Template = fmt.Sprintf("stripe version %s\n", Version)
 
 // CheckLatestVersion makes a request to the GitHub API to pull the latest
-// release of the CLI
-func CheckLatestVersion() {
+// release of the CLI. The check is abandoned when ctx is canceled, and
+// skipped when the CLI is offline.
+func CheckLatestVersion(ctx context.Context) {
 	// master is the dev version, we don't want to check against that every time
-	if Version != "master" {
+	if Version != "master" && !ansi.Quiet && !offline.Enabled {
 		s := ansi.StartNewSpinner("Checking for new versions...", os.Stdout)
-		latest := getLatestVersion()
+		latest := getLatestVersion(ctx)
 
 		ansi.StopSpinner(s, "", os.Stdout)
 
@@ -41,9 +49,9 @@ func needsToUpgrade(version, latest string) bool {
 	return latest != "" && (strings.TrimPrefix(latest, "v") != strings.TrimPrefix(version, "v"))
 }
 
-func getLatestVersion() string {
+func getLatestVersion(ctx context.Context) string {
 	client := github.NewClient(nil)
-	rep, _, err := client.Repositories.GetLatestRelease(context.Background(), "stripe", "stripe-cli")
+	rep, _, err := client.Repositories.GetLatestRelease(ctx, "stripe", "stripe-cli")
 
 	l := log.StandardLogger()
 
@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff computes the waits between connection attempts. Waits grow
+// exponentially from min up to max, and are randomized so that clients
+// disconnected at the same time don't all reconnect at once.
+type backoff struct {
+	min    time.Duration
+	max    time.Duration
+	jitter float64
+
+	attempts int
+}
+
+// next returns the wait before the next connection attempt.
+func (b *backoff) next() time.Duration {
+	wait := b.min
+	for i := 0; i < b.attempts && wait < b.max; i++ {
+		wait *= 2
+	}
+
+	if wait > b.max {
+		wait = b.max
+	}
+
+	b.attempts++
+
+	// Randomize the wait in [wait*(1-jitter), wait*(1+jitter)]
+	delta := b.jitter * float64(wait)
+	wait = time.Duration(float64(wait) - delta + rand.Float64()*2*delta) // #nosec G404
+
+	return wait
+}
+
+// reset starts over from the minimum wait, e.g. once connected.
+func (b *backoff) reset() {
+	b.attempts = 0
+}
@@ -0,0 +1,32 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	b := &backoff{min: 100 * time.Millisecond, max: time.Second}
+
+	require.Equal(t, 100*time.Millisecond, b.next())
+	require.Equal(t, 200*time.Millisecond, b.next())
+	require.Equal(t, 400*time.Millisecond, b.next())
+	require.Equal(t, 800*time.Millisecond, b.next())
+	require.Equal(t, time.Second, b.next())
+	require.Equal(t, time.Second, b.next())
+
+	b.reset()
+	require.Equal(t, 100*time.Millisecond, b.next())
+}
+
+func TestBackoffJitter(t *testing.T) {
+	b := &backoff{min: time.Second, max: time.Second, jitter: 0.2}
+
+	for i := 0; i < 100; i++ {
+		wait := b.next()
+		require.GreaterOrEqual(t, wait, 800*time.Millisecond)
+		require.LessOrEqual(t, wait, 1200*time.Millisecond)
+	}
+}
@@ -15,6 +15,8 @@ import (
 	ws "github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/stripe/stripe-cli/pkg/cryptopolicy"
+	"github.com/stripe/stripe-cli/pkg/logging"
 	"github.com/stripe/stripe-cli/pkg/useragent"
 )
 
@@ -24,8 +26,16 @@ import (
 
 // Config contains the optional configuration parameters of a Client.
 type Config struct {
+	// Maximum wait between connection attempts. Failed attempts are retried
+	// with an exponential backoff starting at InitialConnectAttemptWait.
 	ConnectAttemptWait time.Duration
 
+	InitialConnectAttemptWait time.Duration
+
+	// How long the opening handshake of a connection can take. Ignored when
+	// Dialer is set.
+	ConnectTimeout time.Duration
+
 	Dialer *ws.Dialer
 
 	Log *log.Logger
@@ -35,6 +45,8 @@ type Config struct {
 
 	PingPeriod time.Duration
 
+	// How long to wait for a pong before closing the connection. Defaults
+	// to 5 ping periods when only PingPeriod is set.
 	PongWait time.Duration
 
 	// Interval at which the websocket client should reset the connection
@@ -46,6 +58,10 @@ type Config struct {
 	WriteWait time.Duration
 
 	EventHandler EventHandler
+
+	// OnReconnect is called when the connection is reestablished after it
+	// was lost, with the time it was down
+	OnReconnect func(downtime time.Duration)
 }
 
 // EventHandler handles an event.
@@ -81,6 +97,8 @@ type Client struct {
 
 	conn        *ws.Conn
 	done        chan struct{}
+	stopOnce    sync.Once
+	stopped     chan struct{}
 	isConnected bool
 
 	NotifyExpired chan struct{}
@@ -106,42 +124,78 @@ func (c *Client) Connected() <-chan struct{} {
 	return d
 }
 
+// Stopped returns a channel that's closed once Run has returned and the
+// connection has been closed.
+func (c *Client) Stopped() <-chan struct{} {
+	return c.stopped
+}
+
 // Run starts listening for incoming webhook requests from Stripe.
 func (c *Client) Run(ctx context.Context) {
+	defer close(c.stopped)
+
+	retries := &backoff{
+		min:    c.cfg.InitialConnectAttemptWait,
+		max:    c.cfg.ConnectAttemptWait,
+		jitter: connectAttemptJitter,
+	}
+
+	// disconnectedAt is when the connection was lost, zero if it wasn't
+	var disconnectedAt time.Time
+
 	for {
 		c.isConnected = false
-		c.cfg.Log.WithFields(log.Fields{
-			"prefix": "websocket.client.Run",
-		}).Debug("Attempting to connect to Stripe")
+		logging.WithPrefix(c.cfg.Log, "websocket.client.Run").Debug("Attempting to connect to Stripe")
 
+		// The websocket ID of the session is reused, so reconnecting
+		// doesn't require authorizing a new session until it expires.
 		var err error
 		err = c.connect(ctx)
 		for err != nil {
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.client.Run",
-			}).Debug("Failed to connect to Stripe. Retrying...")
-
 			if err == ErrUnknownID {
-				c.cfg.Log.WithFields(log.Fields{
-					"prefix": "websocket.client.Run",
-				}).Debug("Websocket session is expired.")
+				logging.WithPrefix(c.cfg.Log, "websocket.client.Run").Debug("Websocket session is expired.")
 				select {
 				case <-ctx.Done():
 					c.Stop()
 					return
-				case <-time.After(c.cfg.ConnectAttemptWait):
-					c.NotifyExpired <- struct{}{}
+				case <-c.done:
+					return
+				case c.NotifyExpired <- struct{}{}:
 					return
 				}
 			}
+
+			wait := retries.next()
+			logging.WithPrefix(c.cfg.Log, "websocket.client.Run").WithFields(log.Fields{
+				"wait": wait,
+			}).Debug("Failed to connect to Stripe. Retrying...")
+
 			select {
 			case <-ctx.Done():
 				c.Stop()
-			case <-time.After(c.cfg.ConnectAttemptWait):
+				return
+			case <-c.done:
+				return
+			case <-time.After(wait):
 			}
 			err = c.connect(ctx)
 		}
 
+		retries.reset()
+
+		if !disconnectedAt.IsZero() {
+			downtime := time.Since(disconnectedAt)
+			disconnectedAt = time.Time{}
+
+			logging.WithPrefix(c.cfg.Log, "websocket.client.Run").WithFields(log.Fields{
+				"downtime": downtime,
+			}).Debug("Reconnected to Stripe")
+
+			if c.cfg.OnReconnect != nil {
+				c.cfg.OnReconnect(downtime)
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			close(c.send)
@@ -153,15 +207,12 @@ func (c *Client) Run(ctx context.Context) {
 			c.Close(ws.CloseNormalClosure, "Connection Done")
 			return
 		case <-c.notifyClose:
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.client.Run",
-			}).Debug("Disconnected from Stripe")
-			c.Close(ws.CloseGoingAway, "Server closed the connection")
+			disconnectedAt = time.Now()
+			logging.WithPrefix(c.cfg.Log, "websocket.client.Run").Debug("Disconnected from Stripe")
+			c.close(ws.CloseGoingAway, "Server closed the connection", false)
 			c.wg.Wait()
 		case <-time.After(c.cfg.ReconnectInterval):
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.Client.Run",
-			}).Debug("Resetting the connection")
+			logging.WithPrefix(c.cfg.Log, "websocket.Client.Run").Debug("Resetting the connection")
 			c.Close(ws.CloseNormalClosure, "Resetting the connection")
 			c.wg.Wait()
 		}
@@ -171,6 +222,13 @@ func (c *Client) Run(ctx context.Context) {
 // Close executes a proper closure handshake then closes the connection
 // list of close codes: https://datatracker.ietf.org/doc/html/rfc6455#section-7.4
 func (c *Client) Close(closeCode int, text string) {
+	c.close(closeCode, text, true)
+}
+
+// close closes the connection. If waitForServer is false, it doesn't give the
+// server time to reply to the close frame, e.g. because the connection is
+// already lost.
+func (c *Client) close(closeCode int, text string, waitForServer bool) {
 	close(c.stopReadPump)
 	close(c.stopWritePump)
 	if c.conn != nil {
@@ -178,19 +236,22 @@ func (c *Client) Close(closeCode int, text string) {
 
 		err := c.conn.WriteControl(ws.CloseMessage, message, time.Now().Add(c.cfg.WriteWait))
 		if err != nil {
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.Client.Close",
-				"error":  err,
+			logging.WithPrefix(c.cfg.Log, "websocket.Client.Close").WithFields(log.Fields{
+				"error": err,
 			}).Debug("Error while trying to send close frame")
+		} else if waitForServer {
+			time.Sleep(c.cfg.CloseDelayPeriod)
 		}
-		time.Sleep(c.cfg.CloseDelayPeriod)
 		c.conn.Close()
 	}
 }
 
-// Stop stops listening for incoming webhook events.
+// Stop stops listening for incoming webhook events. It can be called more
+// than once, e.g. by the proxy and on cancellation.
 func (c *Client) Stop() {
-	close(c.done)
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
 }
 
 // SendMessage sends a message to Stripe through the websocket.
@@ -249,16 +310,14 @@ func (c *Client) connect(ctx context.Context) error {
 
 	url = url + "?websocket_feature=" + c.WebSocketAuthorizedFeature
 
-	c.cfg.Log.WithFields(log.Fields{
-		"prefix": "websocket.Client.connect",
-		"url":    url,
+	logging.WithPrefix(c.cfg.Log, "websocket.Client.connect").WithFields(log.Fields{
+		"url": url,
 	}).Debug("Dialing websocket")
 
 	conn, resp, err := c.cfg.Dialer.DialContext(ctx, url, header)
 	if err != nil {
 		message := readWSConnectErrorMessage(resp)
-		c.cfg.Log.WithFields(log.Fields{
-			"prefix":  "websocket.Client.connect",
+		logging.WithPrefix(c.cfg.Log, "websocket.Client.connect").WithFields(log.Fields{
 			"error":   err,
 			"message": message,
 		}).Debug("Websocket connection error")
@@ -280,9 +339,7 @@ func (c *Client) connect(ctx context.Context) error {
 
 	go c.writePump()
 
-	c.cfg.Log.WithFields(log.Fields{
-		"prefix": "websocket.client.connect",
-	}).Debug("Connected!")
+	logging.WithPrefix(c.cfg.Log, "websocket.client.connect").Debug("Connected!")
 
 	return err
 }
@@ -310,9 +367,7 @@ func (c *Client) readPump() {
 	}
 
 	c.conn.SetPongHandler(func(string) error {
-		c.cfg.Log.WithFields(log.Fields{
-			"prefix": "websocket.Client.readPump",
-		}).Debug("Received pong message")
+		logging.WithPrefix(c.cfg.Log, "websocket.Client.readPump").Debug("Received pong message")
 
 		err := c.conn.SetReadDeadline(time.Now().Add(c.cfg.PongWait))
 		if err != nil {
@@ -327,29 +382,19 @@ func (c *Client) readPump() {
 		if err != nil {
 			select {
 			case <-c.stopReadPump:
-				c.cfg.Log.WithFields(log.Fields{
-					"prefix": "websocket.Client.readPump",
-				}).Debug("stopReadPump")
+				logging.WithPrefix(c.cfg.Log, "websocket.Client.readPump").Debug("stopReadPump")
 			default:
 				switch {
 				case !ws.IsCloseError(err):
 					// read errors do not prevent websocket reconnects in the CLI so we should
 					// only display this on debug-level logging
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "websocket.Client.Close",
-					}).Debug("read error: ", err)
+					logging.WithPrefix(c.cfg.Log, "websocket.Client.Close").Debug("read error: ", err)
 				case ws.IsUnexpectedCloseError(err, ws.CloseNormalClosure):
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "websocket.Client.Close",
-					}).Error("close error: ", err)
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "stripecli.ADDITIONAL_INFO",
-					}).Error("If you run into issues, please re-run with `--log-level debug` and share the output with the Stripe team on GitHub.")
+					logging.WithPrefix(c.cfg.Log, "websocket.Client.Close").Error("close error: ", err)
+					logging.WithPrefix(c.cfg.Log, "stripecli.ADDITIONAL_INFO").Error("If you run into issues, please re-run with `--log-level debug` and share the output with the Stripe team on GitHub.")
 				default:
 					c.cfg.Log.Error("other error: ", err)
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "stripecli.ADDITIONAL_INFO",
-					}).Error("If you run into issues, please re-run with `--log-level debug` and share the output with the Stripe team on GitHub.")
+					logging.WithPrefix(c.cfg.Log, "stripecli.ADDITIONAL_INFO").Error("If you run into issues, please re-run with `--log-level debug` and share the output with the Stripe team on GitHub.")
 				}
 				c.notifyClose <- err
 			}
@@ -357,8 +402,7 @@ func (c *Client) readPump() {
 			return
 		}
 
-		c.cfg.Log.WithFields(log.Fields{
-			"prefix":  "websocket.Client.readPump",
+		logging.WithPrefix(c.cfg.Log, "websocket.Client.readPump").WithFields(log.Fields{
 			"message": string(data),
 		}).Debug("Incoming message")
 
@@ -396,9 +440,7 @@ func (c *Client) writePump() {
 			}
 
 			if !ok {
-				c.cfg.Log.WithFields(log.Fields{
-					"prefix": "websocket.Client.writePump",
-				}).Debug("Sending close message")
+				logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("Sending close message")
 
 				err = c.conn.WriteMessage(ws.CloseMessage, ws.FormatCloseMessage(ws.CloseNormalClosure, ""))
 				if err != nil {
@@ -408,9 +450,7 @@ func (c *Client) writePump() {
 				return
 			}
 
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.Client.writePump",
-			}).Debug("Sending text message")
+			logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("Sending text message")
 
 			err = c.conn.WriteJSON(outMsg)
 			if err != nil {
@@ -429,9 +469,7 @@ func (c *Client) writePump() {
 				c.cfg.Log.Debug("SetWriteDeadline error: ", err)
 			}
 
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.Client.writePump",
-			}).Debug("Sending ping message")
+			logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("Sending ping message")
 
 			if err = c.conn.WriteMessage(ws.PingMessage, nil); err != nil {
 				if ws.IsUnexpectedCloseError(err, ws.CloseNormalClosure) {
@@ -441,20 +479,14 @@ func (c *Client) writePump() {
 				// writing to notifyClose during a reset will cause a deadlock
 				select {
 				case c.notifyClose <- err:
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "websocket.Client.writePump",
-					}).Debug("Failed to send ping; closing connection")
+					logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("Failed to send ping; closing connection")
 				case <-c.stopWritePump:
-					c.cfg.Log.WithFields(log.Fields{
-						"prefix": "websocket.Client.writePump",
-					}).Debug("Failed to send ping; connection is resetting")
+					logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("Failed to send ping; connection is resetting")
 				}
 				return
 			}
 		case <-c.stopWritePump:
-			c.cfg.Log.WithFields(log.Fields{
-				"prefix": "websocket.Client.writePump",
-			}).Debug("stopWritePump")
+			logging.WithPrefix(c.cfg.Log, "websocket.Client.writePump").Debug("stopWritePump")
 
 			return
 		}
@@ -475,8 +507,19 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 		cfg.ConnectAttemptWait = defaultConnectAttemptWait
 	}
 
+	if cfg.InitialConnectAttemptWait == 0 || cfg.InitialConnectAttemptWait > cfg.ConnectAttemptWait {
+		cfg.InitialConnectAttemptWait = defaultInitialConnectAttemptWait
+		if cfg.InitialConnectAttemptWait > cfg.ConnectAttemptWait {
+			cfg.InitialConnectAttemptWait = cfg.ConnectAttemptWait
+		}
+	}
+
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+
 	if cfg.Dialer == nil {
-		cfg.Dialer = newWebSocketDialer(os.Getenv("STRIPE_CLI_UNIX_SOCKET"))
+		cfg.Dialer = newWebSocketDialer(os.Getenv("STRIPE_CLI_UNIX_SOCKET"), cfg.ConnectTimeout)
 	}
 
 	if cfg.Log == nil {
@@ -485,6 +528,9 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 
 	if cfg.PongWait == 0 {
 		cfg.PongWait = defaultPongWait
+		if cfg.PingPeriod != 0 {
+			cfg.PongWait = cfg.PingPeriod * 5
+		}
 	}
 
 	if cfg.PingPeriod == 0 {
@@ -513,6 +559,7 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 		WebSocketAuthorizedFeature: websocketAuthorizedFeature,
 		cfg:                        cfg,
 		done:                       make(chan struct{}),
+		stopped:                    make(chan struct{}),
 		send:                       make(chan *OutgoingMessage),
 		NotifyExpired:              make(chan struct{}),
 	}
@@ -525,6 +572,14 @@ func NewClient(url string, webSocketID string, websocketAuthorizedFeature string
 const (
 	defaultConnectAttemptWait = 10 * time.Second
 
+	defaultInitialConnectAttemptWait = 500 * time.Millisecond
+
+	// connectAttemptJitter is the fraction by which the waits between
+	// connection attempts are randomized
+	connectAttemptJitter = 0.2
+
+	defaultConnectTimeout = 10 * time.Second
+
 	defaultPongWait = 10 * time.Second
 
 	defaultReconnectInterval = 60 * time.Second
@@ -546,7 +601,7 @@ var nullEventHandler = EventHandlerFunc(func(IncomingMessage) {})
 // Private functions
 //
 
-func newWebSocketDialer(unixSocket string) *ws.Dialer {
+func newWebSocketDialer(unixSocket string, timeout time.Duration) *ws.Dialer {
 	var dialer *ws.Dialer
 
 	if unixSocket != "" {
@@ -554,15 +609,16 @@ func newWebSocketDialer(unixSocket string) *ws.Dialer {
 			return net.Dial("unix", unixSocket)
 		}
 		dialer = &ws.Dialer{
-			HandshakeTimeout: 10 * time.Second,
+			HandshakeTimeout: timeout,
 			NetDial:          dialFunc,
 			Subprotocols:     subprotocols[:],
 		}
 	} else {
 		dialer = &ws.Dialer{
-			HandshakeTimeout: 10 * time.Second,
+			HandshakeTimeout: timeout,
 			Proxy:            http.ProxyFromEnvironment,
 			Subprotocols:     subprotocols[:],
+			TLSClientConfig:  cryptopolicy.Current().TLSConfig(),
 		}
 	}
 
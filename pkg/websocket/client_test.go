@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -176,6 +177,57 @@ func TestClientExpiredError(t *testing.T) {
 	}
 }
 
+func TestClientReconnectsAfterConnectionLoss(t *testing.T) {
+	upgrader := ws.Upgrader{}
+	var connections int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		if n == 1 {
+			// Drop the first connection without a close handshake
+			c.UnderlyingConn().Close()
+			return
+		}
+
+		defer c.Close()
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	reconnected := make(chan time.Duration, 1)
+
+	client := NewClient(
+		url,
+		"websocket-random-id",
+		"webhook-payloads",
+		&Config{
+			OnReconnect: func(downtime time.Duration) {
+				reconnected <- downtime
+			},
+		},
+	)
+
+	go client.Run(context.Background())
+
+	defer client.Stop()
+
+	select {
+	case downtime := <-reconnected:
+		require.Less(t, downtime, time.Second)
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "Timed out waiting for the client to reconnect")
+	}
+}
+
 /* func TestClientWebhookReconnect(t *testing.T) {
 	log.SetLevel(log.DebugLevel)
 	wg := &sync.WaitGroup{}
@@ -228,3 +280,37 @@ func TestClientExpiredError(t *testing.T) {
 
 	wg.Wait()
 } */
+
+func TestClientStopWhileReconnecting(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http")
+
+	client := NewClient(
+		url,
+		"websocket-random-id",
+		"webhook-payloads",
+		&Config{
+			ConnectAttemptWait:        time.Minute,
+			InitialConnectAttemptWait: time.Minute,
+		},
+	)
+
+	go client.Run(context.Background())
+
+	// Give the first connection attempt the time to fail.
+	time.Sleep(100 * time.Millisecond)
+
+	client.Stop()
+	client.Stop()
+
+	select {
+	case <-client.Stopped():
+	case <-time.After(500 * time.Millisecond):
+		require.FailNow(t, "Timed out waiting for the client to stop")
+	}
+}
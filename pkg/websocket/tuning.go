@@ -0,0 +1,66 @@
+package websocket
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Tuning holds the settings of the connection to Stripe that users can
+// adjust, e.g. for corporate proxies closing connections that look idle.
+// Zero values keep the defaults of the client.
+type Tuning struct {
+	// PingPeriod is the time between two pings keeping the connection alive
+	PingPeriod time.Duration
+	// ReconnectMaxBackoff is the maximum wait between two connection
+	// attempts after the connection was lost
+	ReconnectMaxBackoff time.Duration
+	// ConnectTimeout is how long the opening handshake of a connection can
+	// take
+	ConnectTimeout time.Duration
+}
+
+// tuningSettings are the flags of the tuning settings, with the keys of the
+// config file setting their defaults
+var tuningSettings = []struct {
+	flag  string
+	key   string
+	usage string
+	value func(t *Tuning) *time.Duration
+}{
+	{"websocket-ping-interval", "websocket_ping_interval", "Time between two pings keeping the connection to Stripe alive, e.g. 5s behind proxies closing idle connections (default 2s)", func(t *Tuning) *time.Duration { return &t.PingPeriod }},
+	{"reconnect-max-backoff", "reconnect_max_backoff", "Maximum wait between two attempts to reconnect to Stripe (default 10s)", func(t *Tuning) *time.Duration { return &t.ReconnectMaxBackoff }},
+	{"connect-timeout", "connect_timeout", "How long connecting to Stripe can take before the attempt is retried (default 10s)", func(t *Tuning) *time.Duration { return &t.ConnectTimeout }},
+}
+
+// AddFlags adds the flags setting the tuning settings to a command
+func (t *Tuning) AddFlags(flags *pflag.FlagSet) {
+	for _, s := range tuningSettings {
+		flags.DurationVar(s.value(t), s.flag, 0, s.usage)
+	}
+}
+
+// Load fills the settings whose flags weren't set from the config file,
+// e.g. `websocket_ping_interval = "5s"`, and checks them.
+func (t *Tuning) Load(flags *pflag.FlagSet) error {
+	for _, s := range tuningSettings {
+		value := s.value(t)
+
+		if !flags.Changed(s.flag) && viper.IsSet(s.key) {
+			d, err := time.ParseDuration(viper.GetString(s.key))
+			if err != nil {
+				return fmt.Errorf("invalid %s in the config file: %w", s.key, err)
+			}
+
+			*value = d
+		}
+
+		if *value < 0 {
+			return fmt.Errorf("%s must be positive, got %s", s.flag, *value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTuningFlags(t *testing.T) {
+	var tuning Tuning
+	flags := pflag.NewFlagSet("listen", pflag.ContinueOnError)
+	tuning.AddFlags(flags)
+
+	err := flags.Parse([]string{"--websocket-ping-interval", "5s", "--reconnect-max-backoff", "1m", "--connect-timeout", "30s"})
+	require.NoError(t, err)
+	require.NoError(t, tuning.Load(flags))
+
+	require.Equal(t, Tuning{PingPeriod: 5 * time.Second, ReconnectMaxBackoff: time.Minute, ConnectTimeout: 30 * time.Second}, tuning)
+}
+
+func TestTuningConfigFile(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("websocket_ping_interval", "5s")
+	viper.Set("connect_timeout", "20s")
+
+	var tuning Tuning
+	flags := pflag.NewFlagSet("listen", pflag.ContinueOnError)
+	tuning.AddFlags(flags)
+
+	// Flags take precedence over the config file
+	err := flags.Parse([]string{"--connect-timeout", "30s"})
+	require.NoError(t, err)
+	require.NoError(t, tuning.Load(flags))
+
+	require.Equal(t, Tuning{PingPeriod: 5 * time.Second, ConnectTimeout: 30 * time.Second}, tuning)
+}
+
+func TestTuningInvalid(t *testing.T) {
+	defer viper.Reset()
+
+	var tuning Tuning
+	flags := pflag.NewFlagSet("listen", pflag.ContinueOnError)
+	tuning.AddFlags(flags)
+
+	err := flags.Parse([]string{"--websocket-ping-interval", "-5s"})
+	require.NoError(t, err)
+	require.EqualError(t, tuning.Load(flags), "websocket-ping-interval must be positive, got -5s")
+
+	viper.Set("reconnect_max_backoff", "soon")
+	tuning = Tuning{}
+	flags = pflag.NewFlagSet("listen", pflag.ContinueOnError)
+	tuning.AddFlags(flags)
+	require.Error(t, tuning.Load(flags))
+}
+
+func TestNewClientTuning(t *testing.T) {
+	c := NewClient("", "", "", nil)
+	require.Equal(t, 2*time.Second, c.cfg.PingPeriod)
+	require.Equal(t, 10*time.Second, c.cfg.PongWait)
+	require.Equal(t, 10*time.Second, c.cfg.Dialer.HandshakeTimeout)
+
+	c = NewClient("", "", "", &Config{PingPeriod: 5 * time.Second, ConnectTimeout: 30 * time.Second})
+	require.Equal(t, 25*time.Second, c.cfg.PongWait)
+	require.Equal(t, 30*time.Second, c.cfg.Dialer.HandshakeTimeout)
+}